@@ -0,0 +1,249 @@
+/*
+Chronokeep Desktop - Race Scoring Software
+Copyright (C) 2026 James Sentinella
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package database
+
+import (
+	"chronokeep/results/types"
+	"chronokeep/results/util"
+	_ "embed"
+	"encoding/csv"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ageGradeData holds the open standards and age factors used for age grading.
+//
+//go:embed agegrade_factors.csv
+var ageGradeData string
+
+// ageGradeStandard is the open standard, in seconds, and the factor for each age in the
+// table for a single distance.
+type ageGradeStandard struct {
+	meters   float64
+	standard float64
+	factors  []float64
+}
+
+type ageGradeTable struct {
+	ages      []int
+	standards map[string][]ageGradeStandard
+}
+
+var ageGrades = mustLoadAgeGrades(ageGradeData)
+
+func mustLoadAgeGrades(data string) ageGradeTable {
+	table, err := loadAgeGrades(data)
+	if err != nil {
+		panic(fmt.Sprintf("invalid age grading data: %v", err))
+	}
+	return table
+}
+
+// loadAgeGrades Parses age grading data.  The header lists the ages the factors are for and
+// each row has the gender, the distance in meters, the open standard and a factor per age.
+func loadAgeGrades(data string) (ageGradeTable, error) {
+	reader := csv.NewReader(strings.NewReader(data))
+	reader.Comment = '#'
+	rows, err := reader.ReadAll()
+	if err != nil {
+		return ageGradeTable{}, err
+	}
+	if len(rows) < 1 || len(rows[0]) < 4 {
+		return ageGradeTable{}, fmt.Errorf("no ages given")
+	}
+	table := ageGradeTable{
+		standards: make(map[string][]ageGradeStandard),
+	}
+	for _, col := range rows[0][3:] {
+		age, err := strconv.Atoi(col)
+		if err != nil {
+			return ageGradeTable{}, fmt.Errorf("invalid age %s: %v", col, err)
+		}
+		table.ages = append(table.ages, age)
+	}
+	for _, row := range rows[1:] {
+		std := ageGradeStandard{}
+		if std.meters, err = strconv.ParseFloat(row[1], 64); err != nil {
+			return ageGradeTable{}, fmt.Errorf("invalid distance %s: %v", row[1], err)
+		}
+		if std.standard, err = strconv.ParseFloat(row[2], 64); err != nil {
+			return ageGradeTable{}, fmt.Errorf("invalid standard %s: %v", row[2], err)
+		}
+		for _, col := range row[3:] {
+			factor, err := strconv.ParseFloat(col, 64)
+			if err != nil {
+				return ageGradeTable{}, fmt.Errorf("invalid factor %s: %v", col, err)
+			}
+			std.factors = append(std.factors, factor)
+		}
+		table.standards[row[0]] = append(table.standards[row[0]], std)
+	}
+	for _, stds := range table.standards {
+		sort.Slice(stds, func(i, j int) bool {
+			return stds[i].meters < stds[j].meters
+		})
+	}
+	return table, nil
+}
+
+// DistanceMeters Converts a distance in one of the distance units to meters.  Returns
+// false if the unit isn't known.
+func DistanceMeters(value float64, unit string) (float64, bool) {
+	switch unit {
+	case util.DISTANCE_TYPE_MILE:
+		return value * 1609.344, true
+	case util.DISTANCE_TYPE_METER:
+		return value, true
+	case util.DISTANCE_TYPE_KILOMETER:
+		return value * 1000, true
+	case util.DISTANCE_TYPE_YARD:
+		return value * 0.9144, true
+	case util.DISTANCE_TYPE_FEET:
+		return value * 0.3048, true
+	}
+	return 0, false
+}
+
+// ageGradeGender Returns the gender of the tables to use for a gender, or an empty string
+// if there are none.
+func ageGradeGender(gender string) string {
+	switch strings.ToLower(strings.TrimSpace(gender)) {
+	case "m", "male", "man":
+		return "M"
+	case "f", "female", "woman", "w":
+		return "F"
+	}
+	return ""
+}
+
+// factor Returns the factor for an age, interpolating between the ages in the table.
+func (t *ageGradeTable) factor(std *ageGradeStandard, age int) float64 {
+	ix := sort.SearchInts(t.ages, age)
+	if t.ages[ix] == age {
+		return std.factors[ix]
+	}
+	lo, hi := t.ages[ix-1], t.ages[ix]
+	frac := float64(age-lo) / float64(hi-lo)
+	return std.factors[ix-1] + frac*(std.factors[ix]-std.factors[ix-1])
+}
+
+// lookup Returns the age factor and open standard for a gender and age at a distance.
+// Distances between those in the table are interpolated on a log scale.  Distances within
+// one percent of either end of the table use the end.
+func (t *ageGradeTable) lookup(gender string, age int, meters float64) (float64, float64, bool) {
+	stds := t.standards[ageGradeGender(gender)]
+	if len(stds) < 1 || len(t.ages) < 1 || age < t.ages[0] || age > t.ages[len(t.ages)-1] {
+		return 0, 0, false
+	}
+	first, last := &stds[0], &stds[len(stds)-1]
+	if meters < first.meters*0.99 || meters > last.meters*1.01 {
+		return 0, 0, false
+	}
+	if meters <= first.meters {
+		return t.factor(first, age), first.standard, true
+	}
+	if meters >= last.meters {
+		return t.factor(last, age), last.standard, true
+	}
+	ix := sort.Search(len(stds), func(i int) bool {
+		return stds[i].meters >= meters
+	})
+	hi := &stds[ix]
+	if hi.meters == meters {
+		return t.factor(hi, age), hi.standard, true
+	}
+	lo := &stds[ix-1]
+	frac := (math.Log(meters) - math.Log(lo.meters)) / (math.Log(hi.meters) - math.Log(lo.meters))
+	loFactor, hiFactor := t.factor(lo, age), t.factor(hi, age)
+	factor := loFactor + frac*(hiFactor-loFactor)
+	standard := math.Exp(math.Log(lo.standard) + frac*(math.Log(hi.standard)-math.Log(lo.standard)))
+	return factor, standard, true
+}
+
+// AgeGrade Sets the age graded time and percentage of every finish in the results that has
+// an age, a gender with age grading tables and a distance with a known length.  The time is
+// the chip time if the ranking type is chip and gun time otherwise.
+func AgeGrade(results []types.Result, distances []types.Distance, rankingType string) {
+	lengths := make(map[string]float64)
+	for _, dist := range distances {
+		if meters, ok := DistanceMeters(dist.DistanceValue, dist.DistanceUnit); ok && meters > 0 {
+			lengths[dist.Name] = meters
+		}
+	}
+	for ix := range results {
+		res := &results[ix]
+		meters, ok := lengths[res.Distance]
+		if !ok || !isRecordFinish(res) {
+			continue
+		}
+		factor, standard, ok := ageGrades.lookup(res.Gender, res.Age, meters)
+		if !ok {
+			continue
+		}
+		seconds, milliseconds := recordTime(res, rankingType)
+		elapsed := float64(seconds) + float64(milliseconds)/1000
+		if elapsed <= 0 {
+			continue
+		}
+		graded := int64(math.Round(elapsed * factor * 1000))
+		res.AgeGradedSeconds = int(graded / 1000)
+		res.AgeGradedMilliseconds = int(graded % 1000)
+		res.AgeGradedPercentage = math.Round(standard/(elapsed*factor)*10000) / 100
+	}
+}
+
+// AgeGradedRankings Returns the age graded results ordered by distance and then by age graded
+// percentage with their age graded ranking set.  Results that weren't age graded are left out.
+func AgeGradedRankings(results []types.Result) []types.Result {
+	out := make([]types.Result, 0)
+	for _, res := range results {
+		if res.AgeGradedPercentage > 0 {
+			out = append(out, res)
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		one, two := &out[i], &out[j]
+		if one.Distance != two.Distance {
+			return one.Distance < two.Distance
+		}
+		if one.AgeGradedPercentage != two.AgeGradedPercentage {
+			return one.AgeGradedPercentage > two.AgeGradedPercentage
+		}
+		if one.AgeGradedSeconds != two.AgeGradedSeconds {
+			return one.AgeGradedSeconds < two.AgeGradedSeconds
+		}
+		if one.AgeGradedMilliseconds != two.AgeGradedMilliseconds {
+			return one.AgeGradedMilliseconds < two.AgeGradedMilliseconds
+		}
+		return one.Bib < two.Bib
+	})
+	place := 0
+	for ix := range out {
+		if ix == 0 || out[ix].Distance != out[ix-1].Distance {
+			place = 0
+		}
+		place++
+		out[ix].AgeGradedRanking = place
+	}
+	return out
+}
+
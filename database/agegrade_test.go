@@ -0,0 +1,181 @@
+/*
+Chronokeep Desktop - Race Scoring Software
+Copyright (C) 2026 James Sentinella
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package database
+
+import (
+	"chronokeep/results/types"
+	"chronokeep/results/util"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadAgeGrades(t *testing.T) {
+	table, err := loadAgeGrades(ageGradeData)
+	if assert.NoError(t, err) {
+		assert.Equal(t, 5, table.ages[0])
+		assert.Equal(t, 100, table.ages[len(table.ages)-1])
+		for _, gender := range []string{"M", "F"} {
+			stds := table.standards[gender]
+			if assert.NotEmpty(t, stds) {
+				for ix, std := range stds {
+					assert.Equal(t, len(table.ages), len(std.factors))
+					if ix > 0 {
+						assert.Less(t, stds[ix-1].meters, std.meters)
+					}
+				}
+			}
+		}
+	}
+	_, err = loadAgeGrades("gender,meters,standard,30\nM,5000,769,bad\n")
+	assert.Error(t, err)
+	_, err = loadAgeGrades("gender,meters,standard,thirty\n")
+	assert.Error(t, err)
+	_, err = loadAgeGrades("")
+	assert.Error(t, err)
+}
+
+func TestDistanceMeters(t *testing.T) {
+	meters, ok := DistanceMeters(1, util.DISTANCE_TYPE_MILE)
+	assert.True(t, ok)
+	assert.InDelta(t, 1609.344, meters, 0.0001)
+	meters, ok = DistanceMeters(10, util.DISTANCE_TYPE_KILOMETER)
+	assert.True(t, ok)
+	assert.InDelta(t, 10000, meters, 0.0001)
+	meters, ok = DistanceMeters(400, util.DISTANCE_TYPE_METER)
+	assert.True(t, ok)
+	assert.InDelta(t, 400, meters, 0.0001)
+	meters, ok = DistanceMeters(100, util.DISTANCE_TYPE_YARD)
+	assert.True(t, ok)
+	assert.InDelta(t, 91.44, meters, 0.0001)
+	meters, ok = DistanceMeters(100, util.DISTANCE_TYPE_FEET)
+	assert.True(t, ok)
+	assert.InDelta(t, 30.48, meters, 0.0001)
+	_, ok = DistanceMeters(5, "furlongs")
+	assert.False(t, ok)
+}
+
+func TestAgeGradeLookup(t *testing.T) {
+	// Ages in the table.
+	factor, standard, ok := ageGrades.lookup("M", 40, 10000)
+	if assert.True(t, ok) {
+		assert.InDelta(t, 0.944, factor, 0.00001)
+		assert.InDelta(t, 1584, standard, 0.00001)
+	}
+	// Ages between those in the table.
+	factor, _, ok = ageGrades.lookup("Man", 42, 10000)
+	if assert.True(t, ok) {
+		assert.InDelta(t, 0.9284, factor, 0.00001)
+	}
+	// Distances between those in the table.
+	factor, standard, ok = ageGrades.lookup("F", 50, 12000)
+	if assert.True(t, ok) {
+		lowFactor, lowStandard, _ := ageGrades.lookup("F", 50, 10000)
+		highFactor, highStandard, _ := ageGrades.lookup("F", 50, 15000)
+		assert.Less(t, highFactor, factor)
+		assert.Less(t, factor, lowFactor)
+		assert.Less(t, lowStandard, standard)
+		assert.Less(t, standard, highStandard)
+	}
+	// A 3.1 mile course is close enough to 5K.
+	meters, _ := DistanceMeters(3.1, util.DISTANCE_TYPE_MILE)
+	_, standard, ok = ageGrades.lookup("Woman", 30, meters)
+	if assert.True(t, ok) {
+		assert.InDelta(t, 866, standard, 0.00001)
+	}
+	// Distances, ages and genders without standards.
+	_, _, ok = ageGrades.lookup("M", 40, 1609.344)
+	assert.False(t, ok)
+	_, _, ok = ageGrades.lookup("M", 40, 100000)
+	assert.False(t, ok)
+	_, _, ok = ageGrades.lookup("M", 0, 10000)
+	assert.False(t, ok)
+	_, _, ok = ageGrades.lookup("M", 101, 10000)
+	assert.False(t, ok)
+	_, _, ok = ageGrades.lookup("Non-Binary", 40, 10000)
+	assert.False(t, ok)
+}
+
+func ageGradeTestResults() []types.Result {
+	return []types.Result{
+		{Bib: "1", Age: 40, Gender: "M", Distance: "10K", Location: "Finish", Seconds: 2010, ChipSeconds: 2000, Finish: true},
+		{Bib: "2", Age: 25, Gender: "F", Distance: "10K", Location: "Finish", Seconds: 2200, ChipSeconds: 2195, ChipMilliseconds: 500, Finish: true},
+		{Bib: "3", Age: 60, Gender: "F", Distance: "10K", Location: "Finish", Seconds: 2800, ChipSeconds: 2790, Finish: true},
+		{Bib: "4", Age: 40, Gender: "M", Distance: "10K", Location: "Finish", Seconds: 1900, ChipSeconds: 1900, Finish: true, Status: types.ResultStatusDQ},
+		{Bib: "5", Age: 40, Gender: "M", Distance: "10K", Location: "5K", Seconds: 1000, ChipSeconds: 1000},
+		{Bib: "6", Age: 40, Gender: "Non-Binary", Distance: "10K", Location: "Finish", Seconds: 2100, ChipSeconds: 2100, Finish: true},
+		{Bib: "7", Age: 40, Gender: "M", Distance: "Fun Run", Location: "Finish", Seconds: 900, ChipSeconds: 900, Finish: true},
+		{Bib: "8", Age: 35, Gender: "F", Distance: "Half Marathon", Location: "Finish", Seconds: 5400, ChipSeconds: 5400, Finish: true},
+	}
+}
+
+func TestAgeGrade(t *testing.T) {
+	distances := []types.Distance{
+		{Name: "10K", DistanceValue: 10, DistanceUnit: util.DISTANCE_TYPE_KILOMETER},
+		{Name: "Fun Run"},
+		{Name: "Half Marathon", DistanceValue: 13.1, DistanceUnit: util.DISTANCE_TYPE_MILE},
+	}
+	results := ageGradeTestResults()
+	AgeGrade(results, distances, util.RANKING_TYPE_CHIP)
+	assert.Equal(t, 1888, results[0].AgeGradedSeconds)
+	assert.Equal(t, 0, results[0].AgeGradedMilliseconds)
+	assert.Equal(t, 83.9, results[0].AgeGradedPercentage)
+	// Open age factors are one.
+	assert.Equal(t, 2195, results[1].AgeGradedSeconds)
+	assert.Equal(t, 500, results[1].AgeGradedMilliseconds)
+	assert.Equal(t, 79.89, results[1].AgeGradedPercentage)
+	assert.Less(t, results[2].AgeGradedSeconds, results[2].ChipSeconds)
+	assert.Less(t, 0.0, results[7].AgeGradedPercentage)
+	// DQ, non finish, no standards and no distance length.
+	for _, ix := range []int{3, 4, 5, 6} {
+		assert.Equal(t, 0, results[ix].AgeGradedSeconds)
+		assert.Equal(t, 0.0, results[ix].AgeGradedPercentage)
+	}
+	// Gun time.
+	results = ageGradeTestResults()
+	AgeGrade(results, distances, util.RANKING_TYPE_GUN)
+	assert.Equal(t, 1897, results[0].AgeGradedSeconds)
+	assert.Equal(t, 440, results[0].AgeGradedMilliseconds)
+}
+
+func TestAgeGradedRankings(t *testing.T) {
+	distances := []types.Distance{
+		{Name: "10K", DistanceValue: 10, DistanceUnit: util.DISTANCE_TYPE_KILOMETER},
+		{Name: "Half Marathon", DistanceValue: 13.1, DistanceUnit: util.DISTANCE_TYPE_MILE},
+	}
+	results := ageGradeTestResults()
+	AgeGrade(results, distances, util.RANKING_TYPE_CHIP)
+	ranked := AgeGradedRankings(results)
+	if assert.Equal(t, 4, len(ranked)) {
+		assert.Equal(t, "10K", ranked[0].Distance)
+		assert.Equal(t, 1, ranked[0].AgeGradedRanking)
+		assert.Equal(t, 2, ranked[1].AgeGradedRanking)
+		assert.Equal(t, 3, ranked[2].AgeGradedRanking)
+		assert.GreaterOrEqual(t, ranked[0].AgeGradedPercentage, ranked[1].AgeGradedPercentage)
+		assert.GreaterOrEqual(t, ranked[1].AgeGradedPercentage, ranked[2].AgeGradedPercentage)
+		assert.Equal(t, "Half Marathon", ranked[3].Distance)
+		assert.Equal(t, "8", ranked[3].Bib)
+		assert.Equal(t, 1, ranked[3].AgeGradedRanking)
+	}
+	// The results passed in aren't changed.
+	for _, res := range results {
+		assert.Equal(t, 0, res.AgeGradedRanking)
+	}
+}
+
@@ -0,0 +1,61 @@
+/*
+Chronokeep Desktop - Race Scoring Software
+Copyright (C) 2026 James Sentinella
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package database
+
+import (
+	"chronokeep/results/types"
+	"sort"
+)
+
+// MatchAthleteEntries Returns the entries that belong to an athlete.  Entries claimed for the athlete
+// always belong to them, while other entries only do if they're from an event of the athlete's account
+// and the participant has the same birthdate as the athlete.  Birthdates of participants from other
+// accounts are never compared.  An event year and bib is only listed once, and the entries are ordered
+// from most recent to oldest.
+func MatchAthleteEntries(athlete types.Athlete, entries []types.AthleteEntry) []types.AthleteEntry {
+	type entryKey struct {
+		eventYearID int64
+		bib         string
+	}
+	birthdate := types.NormalizeBirthdate(athlete.Birthdate)
+	found := make(map[entryKey]int)
+	output := make([]types.AthleteEntry, 0)
+	for _, entry := range entries {
+		if !entry.Claimed && (birthdate == "" || entry.AccountIdentifier != athlete.AccountIdentifier ||
+			types.NormalizeBirthdate(entry.Birthdate) != birthdate) {
+			continue
+		}
+		key := entryKey{eventYearID: entry.EventYearIdentifier, bib: entry.Bib}
+		if ix, ok := found[key]; ok {
+			output[ix].Anonymous = output[ix].Anonymous || entry.Anonymous
+			output[ix].Claimed = output[ix].Claimed || entry.Claimed
+			continue
+		}
+		found[key] = len(output)
+		output = append(output, entry)
+	}
+	sort.SliceStable(output, func(i, j int) bool {
+		if !output[i].DateTime.Equal(output[j].DateTime) {
+			return output[i].DateTime.After(output[j].DateTime)
+		}
+		return output[i].Slug < output[j].Slug
+	})
+	return output
+}
+
@@ -0,0 +1,70 @@
+/*
+Chronokeep Desktop - Race Scoring Software
+Copyright (C) 2026 James Sentinella
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package database
+
+import (
+	"chronokeep/results/types"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatchAthleteEntries(t *testing.T) {
+	athlete := types.Athlete{
+		AccountIdentifier: 1,
+		Slug:              "john-smith",
+		First:             "John",
+		Last:              "Smith",
+		Birthdate:         "1990-04-05",
+	}
+	older := time.Date(2020, 6, 1, 9, 0, 0, 0, time.UTC)
+	newer := time.Date(2021, 6, 1, 9, 0, 0, 0, time.UTC)
+	entries := []types.AthleteEntry{
+		{EventYearIdentifier: 1, Slug: "event1", Year: "2020", DateTime: older, Bib: "10", Birthdate: "4/5/1990", AccountIdentifier: 1},
+		{EventYearIdentifier: 2, Slug: "event1", Year: "2021", DateTime: newer, Bib: "12", Birthdate: "1990/04/05", AccountIdentifier: 1},
+		// Another person with the same name.
+		{EventYearIdentifier: 2, Slug: "event1", Year: "2021", DateTime: newer, Bib: "13", Birthdate: "1/1/1970", AccountIdentifier: 1},
+		// Entries without a birthdate only count when claimed.
+		{EventYearIdentifier: 3, Slug: "event2", Year: "2021", DateTime: newer, Bib: "5", AccountIdentifier: 1},
+		{EventYearIdentifier: 4, Slug: "event0", Year: "2021", DateTime: newer, Bib: "7", Claimed: true, AccountIdentifier: 2},
+		// The same birthdate in an event of another account is never matched.
+		{EventYearIdentifier: 5, Slug: "event3", Year: "2021", DateTime: newer, Bib: "8", Birthdate: "4/5/1990", AccountIdentifier: 2},
+		// A claimed bib that was also matched by birthdate.
+		{EventYearIdentifier: 1, Slug: "event1", Year: "2020", DateTime: older, Bib: "10", Claimed: true, AccountIdentifier: 1},
+	}
+	output := MatchAthleteEntries(athlete, entries)
+	if assert.Equal(t, 3, len(output)) {
+		assert.Equal(t, "event0", output[0].Slug)
+		assert.Equal(t, "7", output[0].Bib)
+		assert.Equal(t, "event1", output[1].Slug)
+		assert.Equal(t, "12", output[1].Bib)
+		assert.Equal(t, "2020", output[2].Year)
+		assert.Equal(t, "10", output[2].Bib)
+		assert.True(t, output[2].Claimed)
+	}
+	// Athletes without a birthdate only get claimed entries.
+	athlete.Birthdate = ""
+	output = MatchAthleteEntries(athlete, entries)
+	if assert.Equal(t, 2, len(output)) {
+		assert.Equal(t, "7", output[0].Bib)
+		assert.Equal(t, "10", output[1].Bib)
+	}
+}
+
@@ -0,0 +1,313 @@
+/*
+Chronokeep Desktop - Race Scoring Software
+Copyright (C) 2026 James Sentinella
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package database
+
+import (
+	"chronokeep/results/types"
+	"encoding/json"
+	"fmt"
+)
+
+// auditKey identifies the person or result an audit entry is for.  People are identified by
+// their alternate id and results by their person along with location and occurence.
+type auditKey struct {
+	kind      string
+	personID  string
+	location  string
+	occurence int
+}
+
+// AuditScope Limits the people and results compared for the audit history to the ones a change
+// can touch.  Everyone in the event year is included when All is set and everyone in a distance
+// when Distance is set, otherwise people are included by their alternate id or bib.
+type AuditScope struct {
+	All          bool
+	Distance     string
+	AlternateIds []string
+	Bibs         []string
+}
+
+// PeopleAuditScope Returns the scope of a change to the people given.
+func PeopleAuditScope(people []types.Person) AuditScope {
+	output := AuditScope{}
+	for _, person := range people {
+		output.AlternateIds = append(output.AlternateIds, person.AlternateId)
+	}
+	return output
+}
+
+// ResultsAuditScope Returns the scope of a change to the results given.  Results are added to
+// the person with their alternate id or bib so both are included.
+func ResultsAuditScope(results []types.Result) AuditScope {
+	output := AuditScope{}
+	for _, res := range results {
+		if res.PersonId != "" {
+			output.AlternateIds = append(output.AlternateIds, res.PersonId)
+		}
+		output.Bibs = append(output.Bibs, res.Bib)
+	}
+	return output
+}
+
+// ChangesAuditScope Returns the scope of reverting the changes given.
+func ChangesAuditScope(changes []types.AuditEntry) AuditScope {
+	output := AuditScope{}
+	for _, change := range changes {
+		if change.Before != nil {
+			output.AlternateIds = append(output.AlternateIds, change.Before.PersonId)
+		}
+		if change.After != nil {
+			output.AlternateIds = append(output.AlternateIds, change.After.PersonId)
+		}
+	}
+	return output
+}
+
+// Parts Splits an audit scope into scopes with at most size alternate ids or bibs each so they
+// can be retrieved without going over the number of parameters a query can have.  People may be
+// in more than one part when they're included by both their alternate id and their bib.
+func (s AuditScope) Parts(size int) []AuditScope {
+	if s.All || s.Distance != "" {
+		return []AuditScope{s}
+	}
+	output := make([]AuditScope, 0)
+	for start := 0; start < len(s.AlternateIds); start += size {
+		output = append(output, AuditScope{AlternateIds: s.AlternateIds[start:min(start+size, len(s.AlternateIds))]})
+	}
+	for start := 0; start < len(s.Bibs); start += size {
+		output = append(output, AuditScope{Bibs: s.Bibs[start:min(start+size, len(s.Bibs))]})
+	}
+	return output
+}
+
+// auditKeyOf Returns the key of the person or result an audit entry is for.
+func auditKeyOf(entry *types.AuditEntry) auditKey {
+	key := auditKey{
+		kind:      entry.Type,
+		location:  entry.Location,
+		occurence: entry.Occurence,
+	}
+	if entry.After != nil {
+		key.personID = entry.After.PersonId
+	} else if entry.Before != nil {
+		key.personID = entry.Before.PersonId
+	}
+	return key
+}
+
+// AuditPerson Returns the values of the person a result belongs to as they're kept in the
+// audit history.
+func AuditPerson(res types.Result) types.Result {
+	return types.Result{
+		PersonId:  res.PersonId,
+		Bib:       res.Bib,
+		First:     res.First,
+		Last:      res.Last,
+		Age:       res.Age,
+		Gender:    res.Gender,
+		AgeGroup:  res.AgeGroup,
+		Distance:  res.Distance,
+		Anonymous: res.Anonymous,
+		Division:  res.Division,
+	}
+}
+
+// AuditResult Returns the values of a result as they're kept in the audit history.  Rankings
+// and other values calculated by the server are left out.
+func AuditResult(res types.Result) types.Result {
+	output := AuditPerson(res)
+	output.Seconds = res.Seconds
+	output.Milliseconds = res.Milliseconds
+	output.ChipSeconds = res.ChipSeconds
+	output.ChipMilliseconds = res.ChipMilliseconds
+	output.Segment = res.Segment
+	output.Location = res.Location
+	output.Occurence = res.Occurence
+	output.Finish = res.Finish
+	output.Type = res.Type
+	output.LocalTime = res.LocalTime
+	output.Status = res.Status
+	output.StatusReason = res.StatusReason
+	return output
+}
+
+// AuditValue Returns the value stored for one side of an audit entry.  Nil values are stored as
+// an empty string.
+func AuditValue(res *types.Result) (string, error) {
+	if res == nil {
+		return "", nil
+	}
+	value, err := json.Marshal(res)
+	if err != nil {
+		return "", fmt.Errorf("error encoding audit value: %v", err)
+	}
+	return string(value), nil
+}
+
+// ParseAuditValue Returns the result stored for one side of an audit entry.
+func ParseAuditValue(value string) (*types.Result, error) {
+	if value == "" {
+		return nil, nil
+	}
+	var output types.Result
+	if err := json.Unmarshal([]byte(value), &output); err != nil {
+		return nil, fmt.Errorf("error decoding audit value: %v", err)
+	}
+	return &output, nil
+}
+
+// auditEntry Returns an audit entry for a change between two values of a person or result.
+func auditEntry(kind string, before, after *types.Result) types.AuditEntry {
+	entry := types.AuditEntry{
+		Type:   kind,
+		Action: types.AuditActionUpdate,
+		Before: before,
+		After:  after,
+	}
+	value := after
+	switch {
+	case before == nil:
+		entry.Action = types.AuditActionInsert
+	case after == nil:
+		entry.Action = types.AuditActionDelete
+		value = before
+	}
+	entry.Bib = value.Bib
+	if kind == types.AuditTypeResult {
+		entry.Location = value.Location
+		entry.Occurence = value.Occurence
+	}
+	return entry
+}
+
+// AuditChanges Compares the people and results of an event year from before and after a change
+// and returns an audit entry for each person or result that was inserted, updated or deleted.
+// People are given with only their person values.  Entries for people being inserted or updated
+// come before their results and entries for deleted people come after their results.  People
+// and results given more than once are only compared once.
+func AuditChanges(beforePeople, beforeResults, afterPeople, afterResults []types.Result) []types.AuditEntry {
+	output := make([]types.AuditEntry, 0)
+	people := make(map[string]types.Result)
+	for _, person := range beforePeople {
+		people[person.PersonId] = AuditPerson(person)
+	}
+	results := make(map[auditKey]types.Result)
+	for _, res := range beforeResults {
+		results[auditKey{kind: types.AuditTypeResult, personID: res.PersonId, location: res.Location, occurence: res.Occurence}] = AuditResult(res)
+	}
+	seenPeople := make(map[string]bool)
+	for _, person := range afterPeople {
+		after := AuditPerson(person)
+		if seenPeople[after.PersonId] {
+			continue
+		}
+		seenPeople[after.PersonId] = true
+		before, ok := people[after.PersonId]
+		if !ok {
+			output = append(output, auditEntry(types.AuditTypePerson, nil, &after))
+		} else if before != after {
+			output = append(output, auditEntry(types.AuditTypePerson, &before, &after))
+		}
+	}
+	seenResults := make(map[auditKey]bool)
+	for _, res := range afterResults {
+		after := AuditResult(res)
+		key := auditKey{kind: types.AuditTypeResult, personID: after.PersonId, location: after.Location, occurence: after.Occurence}
+		if seenResults[key] {
+			continue
+		}
+		seenResults[key] = true
+		before, ok := results[key]
+		if !ok {
+			output = append(output, auditEntry(types.AuditTypeResult, nil, &after))
+		} else if before != after {
+			output = append(output, auditEntry(types.AuditTypeResult, &before, &after))
+		}
+	}
+	for _, res := range beforeResults {
+		key := auditKey{kind: types.AuditTypeResult, personID: res.PersonId, location: res.Location, occurence: res.Occurence}
+		if !seenResults[key] {
+			seenResults[key] = true
+			before := results[key]
+			output = append(output, auditEntry(types.AuditTypeResult, &before, nil))
+		}
+	}
+	for _, person := range beforePeople {
+		if !seenPeople[person.PersonId] {
+			seenPeople[person.PersonId] = true
+			before := people[person.PersonId]
+			output = append(output, auditEntry(types.AuditTypePerson, &before, nil))
+		}
+	}
+	return output
+}
+
+// RevertChanges Returns the changes needed to return the people and results changed after an
+// audit entry to their values as of that entry.  Entries must be in the order they were made.
+// When single is true only the person or result the entry is for is reverted, otherwise everything
+// is.  An audit id of 0 reverts every change when single is false.  Returns false if the audit
+// entry isn't found.
+func RevertChanges(entries []types.AuditEntry, auditID int64, single bool) ([]types.AuditEntry, bool) {
+	var only *auditKey
+	found := auditID == 0 && !single
+	for ix := range entries {
+		if entries[ix].Identifier == auditID {
+			key := auditKeyOf(&entries[ix])
+			only = &key
+			found = true
+			break
+		}
+	}
+	if !found {
+		return nil, false
+	}
+	if !single {
+		only = nil
+	}
+	// The value as of the entry is the value before the first change made after it, and the
+	// current value is the value after the last change.
+	targets := make(map[auditKey]*types.Result)
+	current := make(map[auditKey]*types.Result)
+	order := make([]auditKey, 0)
+	for ix := range entries {
+		entry := &entries[ix]
+		if entry.Identifier <= auditID {
+			continue
+		}
+		key := auditKeyOf(entry)
+		if only != nil && key != *only {
+			continue
+		}
+		if _, ok := targets[key]; !ok {
+			targets[key] = entry.Before
+			order = append(order, key)
+		}
+		current[key] = entry.After
+	}
+	output := make([]types.AuditEntry, 0)
+	for _, key := range order {
+		before, after := current[key], targets[key]
+		if (before == nil && after == nil) || (before != nil && after != nil && *before == *after) {
+			continue
+		}
+		output = append(output, auditEntry(key.kind, before, after))
+	}
+	return output, true
+}
+
@@ -0,0 +1,228 @@
+/*
+Chronokeep Desktop - Race Scoring Software
+Copyright (C) 2026 James Sentinella
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package database
+
+import (
+	"chronokeep/results/types"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	auditTestPeople = []types.Result{
+		{
+			PersonId: "100",
+			Bib:      "100",
+			First:    "John",
+			Last:     "Smith",
+			Age:      24,
+			Gender:   "Man",
+			AgeGroup: "20-29",
+			Distance: "5 Mile",
+			Division: "Open",
+			Ranking:  1,
+		},
+		{
+			PersonId: "200",
+			Bib:      "200",
+			First:    "Jane",
+			Last:     "Doe",
+			Age:      31,
+			Gender:   "Woman",
+			AgeGroup: "30-39",
+			Distance: "5 Mile",
+			Division: "Open",
+		},
+	}
+	auditTestResults = []types.Result{
+		{
+			PersonId:  "100",
+			Bib:       "100",
+			First:     "John",
+			Last:      "Smith",
+			Age:       24,
+			Gender:    "Man",
+			AgeGroup:  "20-29",
+			Distance:  "5 Mile",
+			Division:  "Open",
+			Seconds:   1800,
+			Location:  "Finish",
+			Occurence: 1,
+			Finish:    true,
+			Ranking:   1,
+		},
+	}
+)
+
+func TestAuditChanges(t *testing.T) {
+	// Nothing changed.
+	changes := AuditChanges(auditTestPeople, auditTestResults, auditTestPeople, auditTestResults)
+	assert.Empty(t, changes)
+	// Inserts, people first.
+	changes = AuditChanges(nil, nil, auditTestPeople, auditTestResults)
+	if assert.Equal(t, 3, len(changes)) {
+		assert.Equal(t, types.AuditTypePerson, changes[0].Type)
+		assert.Equal(t, types.AuditActionInsert, changes[0].Action)
+		assert.Equal(t, "100", changes[0].Bib)
+		assert.Nil(t, changes[0].Before)
+		assert.Equal(t, "", changes[0].After.Location)
+		assert.Equal(t, 0, changes[0].After.Ranking)
+		assert.Equal(t, types.AuditTypePerson, changes[1].Type)
+		assert.Equal(t, "200", changes[1].Bib)
+		assert.Equal(t, types.AuditTypeResult, changes[2].Type)
+		assert.Equal(t, types.AuditActionInsert, changes[2].Action)
+		assert.Equal(t, "Finish", changes[2].Location)
+		assert.Equal(t, 1, changes[2].Occurence)
+		assert.Equal(t, 1800, changes[2].After.Seconds)
+		assert.Equal(t, 0, changes[2].After.Ranking)
+	}
+	// Rankings changing isn't a change.
+	ranked := append([]types.Result{}, auditTestResults...)
+	ranked[0].Ranking = 5
+	assert.Empty(t, AuditChanges(auditTestPeople, auditTestResults, auditTestPeople, ranked))
+	// Updates.
+	updated := append([]types.Result{}, auditTestResults...)
+	updated[0].Seconds = 1750
+	changes = AuditChanges(auditTestPeople, auditTestResults, auditTestPeople, updated)
+	if assert.Equal(t, 1, len(changes)) {
+		assert.Equal(t, types.AuditActionUpdate, changes[0].Action)
+		assert.Equal(t, 1800, changes[0].Before.Seconds)
+		assert.Equal(t, 1750, changes[0].After.Seconds)
+	}
+	// Deletes, results first.
+	changes = AuditChanges(auditTestPeople, auditTestResults, auditTestPeople[1:], nil)
+	if assert.Equal(t, 2, len(changes)) {
+		assert.Equal(t, types.AuditTypeResult, changes[0].Type)
+		assert.Equal(t, types.AuditActionDelete, changes[0].Action)
+		assert.Equal(t, "100", changes[0].Bib)
+		assert.Nil(t, changes[0].After)
+		assert.Equal(t, types.AuditTypePerson, changes[1].Type)
+		assert.Equal(t, types.AuditActionDelete, changes[1].Action)
+		assert.Equal(t, "100", changes[1].Bib)
+	}
+	// People and results retrieved more than once.
+	twice := append(append([]types.Result{}, auditTestPeople...), auditTestPeople...)
+	twiceResults := append(append([]types.Result{}, updated...), updated...)
+	changes = AuditChanges(twice, append(auditTestResults, auditTestResults...), twice, twiceResults)
+	assert.Equal(t, 1, len(changes))
+	changes = AuditChanges(twice, append(auditTestResults, auditTestResults...), nil, nil)
+	assert.Equal(t, 3, len(changes))
+}
+
+func TestAuditScopeParts(t *testing.T) {
+	assert.Empty(t, AuditScope{}.Parts(2))
+	all := AuditScope{All: true}
+	assert.Equal(t, []AuditScope{all}, all.Parts(2))
+	distance := AuditScope{Distance: "5K"}
+	assert.Equal(t, []AuditScope{distance}, distance.Parts(2))
+	scope := ResultsAuditScope([]types.Result{
+		{PersonId: "1", Bib: "100"},
+		{PersonId: "2", Bib: "200"},
+		{PersonId: "3", Bib: "300"},
+		{Bib: "400"},
+	})
+	assert.Equal(t, []AuditScope{
+		{AlternateIds: []string{"1", "2"}},
+		{AlternateIds: []string{"3"}},
+		{Bibs: []string{"100", "200"}},
+		{Bibs: []string{"300", "400"}},
+	}, scope.Parts(2))
+	scope = PeopleAuditScope([]types.Person{{AlternateId: "1"}, {AlternateId: "2"}})
+	assert.Equal(t, []AuditScope{{AlternateIds: []string{"1", "2"}}}, scope.Parts(2))
+}
+
+func TestAuditValue(t *testing.T) {
+	value, err := AuditValue(nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "", value)
+	res, err := ParseAuditValue(value)
+	assert.NoError(t, err)
+	assert.Nil(t, res)
+	orig := AuditResult(auditTestResults[0])
+	value, err = AuditValue(&orig)
+	assert.NoError(t, err)
+	res, err = ParseAuditValue(value)
+	assert.NoError(t, err)
+	if assert.NotNil(t, res) {
+		assert.Equal(t, orig, *res)
+	}
+	_, err = ParseAuditValue("{")
+	assert.Error(t, err)
+}
+
+// auditTestHistory Returns the entries recorded when both people and their result are added, the
+// result is updated twice, and then the second person is removed.
+func auditTestHistory() []types.AuditEntry {
+	entries := AuditChanges(nil, nil, auditTestPeople, auditTestResults)
+	first := append([]types.Result{}, auditTestResults...)
+	first[0].Seconds = 1750
+	entries = append(entries, AuditChanges(auditTestPeople, auditTestResults, auditTestPeople, first)...)
+	second := append([]types.Result{}, first...)
+	second[0].Seconds = 1700
+	entries = append(entries, AuditChanges(auditTestPeople, first, auditTestPeople, second)...)
+	entries = append(entries, AuditChanges(auditTestPeople, second, auditTestPeople[:1], second)...)
+	for ix := range entries {
+		entries[ix].Identifier = int64(ix + 1)
+	}
+	return entries
+}
+
+func TestRevertChanges(t *testing.T) {
+	entries := auditTestHistory()
+	assert.Equal(t, 6, len(entries))
+	// Unknown entry.
+	_, found := RevertChanges(entries, 100, false)
+	assert.False(t, found)
+	_, found = RevertChanges(entries, 0, true)
+	assert.False(t, found)
+	// Back to after the first update, which brings back the removed person.
+	changes, found := RevertChanges(entries, 4, false)
+	assert.True(t, found)
+	if assert.Equal(t, 2, len(changes)) {
+		assert.Equal(t, types.AuditTypeResult, changes[0].Type)
+		assert.Equal(t, types.AuditActionUpdate, changes[0].Action)
+		assert.Equal(t, 1700, changes[0].Before.Seconds)
+		assert.Equal(t, 1750, changes[0].After.Seconds)
+		assert.Equal(t, types.AuditTypePerson, changes[1].Type)
+		assert.Equal(t, types.AuditActionInsert, changes[1].Action)
+		assert.Equal(t, "200", changes[1].Bib)
+	}
+	// Only the result.
+	changes, found = RevertChanges(entries, 4, true)
+	assert.True(t, found)
+	if assert.Equal(t, 1, len(changes)) {
+		assert.Equal(t, types.AuditTypeResult, changes[0].Type)
+		assert.Equal(t, 1750, changes[0].After.Seconds)
+	}
+	// Back to before anything was recorded.
+	changes, found = RevertChanges(entries, 0, false)
+	assert.True(t, found)
+	if assert.Equal(t, 2, len(changes)) {
+		assert.Equal(t, types.AuditActionDelete, changes[0].Action)
+		assert.Equal(t, "100", changes[0].Bib)
+		assert.Equal(t, types.AuditActionDelete, changes[1].Action)
+		assert.Equal(t, types.AuditTypeResult, changes[1].Type)
+	}
+	// The latest entry has nothing to revert.
+	changes, found = RevertChanges(entries, 6, false)
+	assert.True(t, found)
+	assert.Empty(t, changes)
+}
+
@@ -0,0 +1,140 @@
+/*
+Chronokeep Desktop - Race Scoring Software
+Copyright (C) 2026 James Sentinella
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package database
+
+import (
+	"chronokeep/results/types"
+	"fmt"
+	"sort"
+)
+
+// Award rules used for event years that haven't been given any.
+const (
+	DefaultOverallPlaces  = 3
+	DefaultAgeGroupPlaces = 3
+)
+
+// DefaultAwardRules Returns the award rules used for an event year without any.
+func DefaultAwardRules() types.AwardRules {
+	return types.AwardRules{
+		OverallPlaces:  DefaultOverallPlaces,
+		AgeGroupPlaces: DefaultAgeGroupPlaces,
+		MastersAges:    make([]int, 0),
+	}
+}
+
+// awardGroup is a set of finishers in the same distance competing for the same awards.
+type awardGroup struct {
+	category string
+	name     string
+	places   int
+	eligible []*types.Result
+}
+
+// CalculateAwards Works out who wins each award using the results of an event year.  Finishers
+// are ordered the same way they are ranked and awards are given out overall first, then masters,
+// then age groups and then divisions.  Unless the rules allow double dipping a finisher that has
+// already won an award is skipped and the next finisher in the group wins it instead.  The awards
+// are ordered by distance, then by category, then by the group in the order its first finisher
+// crossed the line and then by place.
+func CalculateAwards(results []types.Result, rules types.AwardRules, rankingType string) []types.Award {
+	finishers := make([]*types.Result, 0)
+	for ix := range results {
+		if isRecordFinish(&results[ix]) {
+			finishers = append(finishers, &results[ix])
+		}
+	}
+	sort.SliceStable(finishers, func(i, j int) bool {
+		if finishers[i].Distance != finishers[j].Distance {
+			return finishers[i].Distance < finishers[j].Distance
+		}
+		return rankedBefore(finishers[i], finishers[j], rankingType)
+	})
+	output := make([]types.Award, 0)
+	for start := 0; start < len(finishers); {
+		end := start
+		for end < len(finishers) && finishers[end].Distance == finishers[start].Distance {
+			end++
+		}
+		output = append(output, distanceAwards(finishers[start:end], rules)...)
+		start = end
+	}
+	return output
+}
+
+// distanceAwards Gives out the awards for the finishers of a single distance.
+func distanceAwards(finishers []*types.Result, rules types.AwardRules) []types.Award {
+	groups := make([]*awardGroup, 0)
+	lookup := make(map[string]*awardGroup)
+	add := func(category, name string, places int, res *types.Result) {
+		if places < 1 {
+			return
+		}
+		key := category + "\x00" + name
+		group, ok := lookup[key]
+		if !ok {
+			group = &awardGroup{category: category, name: name, places: places}
+			lookup[key] = group
+			groups = append(groups, group)
+		}
+		group.eligible = append(group.eligible, res)
+	}
+	for _, res := range finishers {
+		add(types.AwardOverall, res.Gender, rules.OverallPlaces, res)
+	}
+	for _, age := range rules.MastersAges {
+		for _, res := range finishers {
+			if res.Age >= age {
+				add(types.AwardMasters, fmt.Sprintf("%s %d+", res.Gender, age), rules.MastersPlaces, res)
+			}
+		}
+	}
+	for _, res := range finishers {
+		add(types.AwardAgeGroup, res.Gender+" "+res.AgeGroup, rules.AgeGroupPlaces, res)
+	}
+	for _, res := range finishers {
+		if res.Division != "" {
+			add(types.AwardDivision, res.Division, rules.DivisionPlaces, res)
+		}
+	}
+	output := make([]types.Award, 0)
+	awarded := make(map[*types.Result]bool)
+	for _, group := range groups {
+		place := 0
+		for _, res := range group.eligible {
+			if place >= group.places {
+				break
+			}
+			if awarded[res] && !rules.DoubleDip {
+				continue
+			}
+			place++
+			awarded[res] = true
+			output = append(output, types.Award{
+				Distance: res.Distance,
+				Category: group.category,
+				Group:    group.name,
+				Place:    place,
+				Result:   *res,
+			})
+		}
+	}
+	return output
+}
+
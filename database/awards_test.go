@@ -0,0 +1,120 @@
+/*
+Chronokeep Desktop - Race Scoring Software
+Copyright (C) 2026 James Sentinella
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package database
+
+import (
+	"chronokeep/results/types"
+	"chronokeep/results/util"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func awardTestResult(bib, gender, ageGroup string, age, seconds int) types.Result {
+	return types.Result{
+		Bib:         bib,
+		First:       "First" + bib,
+		Last:        "Last" + bib,
+		Age:         age,
+		Gender:      gender,
+		AgeGroup:    ageGroup,
+		Distance:    "5K",
+		Location:    "Finish",
+		Occurence:   1,
+		Seconds:     seconds,
+		ChipSeconds: seconds,
+		Finish:      true,
+	}
+}
+
+// awardBibs Returns the bibs that won the awards of a category and group in place order.
+func awardBibs(awards []types.Award, category, group string) []string {
+	output := make([]string, 0)
+	for _, award := range awards {
+		if award.Category == category && award.Group == group {
+			output = append(output, award.Result.Bib)
+		}
+	}
+	return output
+}
+
+func TestCalculateAwards(t *testing.T) {
+	results := []types.Result{
+		awardTestResult("1", "Man", "30-39", 35, 100),
+		awardTestResult("2", "Man", "40-49", 42, 110),
+		awardTestResult("3", "Woman", "30-39", 31, 120),
+		awardTestResult("4", "Man", "40-49", 45, 130),
+		awardTestResult("5", "Man", "30-39", 33, 140),
+		awardTestResult("6", "Woman", "50-59", 52, 150),
+		awardTestResult("7", "Man", "50-59", 55, 160),
+		awardTestResult("8", "Man", "40-49", 48, 170),
+	}
+	dnf := awardTestResult("9", "Man", "40-49", 41, 90)
+	dnf.Status = types.ResultStatusDNF
+	results = append(results, dnf)
+	results[7].Division = "Clydesdale"
+	results[4].Division = "Clydesdale"
+	// Default rules give three overall and three per age group without double dipping.
+	out := CalculateAwards(results, DefaultAwardRules(), util.RANKING_TYPE_GUN)
+	assert.Equal(t, []string{"1", "2", "4"}, awardBibs(out, types.AwardOverall, "Man"))
+	assert.Equal(t, []string{"3", "6"}, awardBibs(out, types.AwardOverall, "Woman"))
+	assert.Equal(t, []string{"5"}, awardBibs(out, types.AwardAgeGroup, "Man 30-39"))
+	assert.Equal(t, []string{"8"}, awardBibs(out, types.AwardAgeGroup, "Man 40-49"))
+	assert.Equal(t, []string{"7"}, awardBibs(out, types.AwardAgeGroup, "Man 50-59"))
+	assert.Equal(t, 0, len(awardBibs(out, types.AwardAgeGroup, "Woman 30-39")))
+	assert.Equal(t, 0, len(awardBibs(out, types.AwardDivision, "Clydesdale")))
+	if assert.Equal(t, 8, len(out)) {
+		assert.Equal(t, types.AwardOverall, out[0].Category)
+		assert.Equal(t, 1, out[0].Place)
+		assert.Equal(t, "5K", out[0].Distance)
+		// Places are within the group, not the ranking of the result.
+		assert.Equal(t, types.AwardAgeGroup, out[5].Category)
+		assert.Equal(t, 1, out[5].Place)
+	}
+	// Masters are awarded before age groups and divisions.
+	rules := types.AwardRules{
+		OverallPlaces:  1,
+		AgeGroupPlaces: 1,
+		DivisionPlaces: 2,
+		MastersAges:    []int{40, 50},
+		MastersPlaces:  1,
+	}
+	out = CalculateAwards(results, rules, util.RANKING_TYPE_GUN)
+	assert.Equal(t, []string{"1"}, awardBibs(out, types.AwardOverall, "Man"))
+	assert.Equal(t, []string{"2"}, awardBibs(out, types.AwardMasters, "Man 40+"))
+	assert.Equal(t, []string{"7"}, awardBibs(out, types.AwardMasters, "Man 50+"))
+	assert.Equal(t, []string{"6"}, awardBibs(out, types.AwardMasters, "Woman 40+"))
+	assert.Equal(t, 0, len(awardBibs(out, types.AwardMasters, "Woman 50+")))
+	assert.Equal(t, []string{"5"}, awardBibs(out, types.AwardAgeGroup, "Man 30-39"))
+	assert.Equal(t, []string{"4"}, awardBibs(out, types.AwardAgeGroup, "Man 40-49"))
+	assert.Equal(t, []string{"8"}, awardBibs(out, types.AwardDivision, "Clydesdale"))
+	// Double dipping lets finishers win in every category they place in.
+	rules.DoubleDip = true
+	out = CalculateAwards(results, rules, util.RANKING_TYPE_GUN)
+	assert.Equal(t, []string{"1"}, awardBibs(out, types.AwardOverall, "Man"))
+	assert.Equal(t, []string{"2"}, awardBibs(out, types.AwardMasters, "Man 40+"))
+	assert.Equal(t, []string{"7"}, awardBibs(out, types.AwardMasters, "Man 50+"))
+	assert.Equal(t, []string{"1"}, awardBibs(out, types.AwardAgeGroup, "Man 30-39"))
+	assert.Equal(t, []string{"2"}, awardBibs(out, types.AwardAgeGroup, "Man 40-49"))
+	assert.Equal(t, []string{"5", "8"}, awardBibs(out, types.AwardDivision, "Clydesdale"))
+	// No places means no awards.
+	out = CalculateAwards(results, types.AwardRules{}, util.RANKING_TYPE_GUN)
+	assert.Equal(t, 0, len(out))
+}
+
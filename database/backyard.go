@@ -0,0 +1,234 @@
+/*
+Chronokeep Desktop - Race Scoring Software
+Copyright (C) 2026 James Sentinella
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package database
+
+import (
+	"chronokeep/results/types"
+	"sort"
+	"time"
+)
+
+// BackyardYard is the length of a yard in a backyard ultra.  A new yard starts every hour and
+// runners have until the next one starts to finish it.
+const BackyardYard = time.Hour
+
+// backyardRunner holds the standing of a runner along with the results it came from.
+type backyardRunner struct {
+	standing types.BackyardStanding
+	results  []int
+	valid    map[int]bool
+}
+
+// backyardRunners Works out the yards completed by each runner in a backyard ultra, grouped by distance.
+// Only finishes count as yards.  They're counted as the yard given by their occurence and a yard is
+// completed when it's finished before the cutoff.  Runners are dropped in the first yard they miss the
+// cutoff for, stop in or don't have a finish for, or the yard after their last one once its cutoff has
+// passed based on the elapsed time given.
+func backyardRunners(results []types.Result, elapsed time.Duration) map[string][]*backyardRunner {
+	byBib := make(map[string]*backyardRunner)
+	order := make([]string, 0)
+	for ix, res := range results {
+		key := res.Distance + "\x00" + res.Bib
+		runner, ok := byBib[key]
+		if !ok {
+			runner = &backyardRunner{
+				standing: types.BackyardStanding{
+					Bib:      res.Bib,
+					Distance: res.Distance,
+					Yards:    make([]types.BackyardYard, 0),
+				},
+				valid: make(map[int]bool),
+			}
+			byBib[key] = runner
+			order = append(order, key)
+		}
+		runner.results = append(runner.results, ix)
+	}
+	output := make(map[string][]*backyardRunner)
+	for _, key := range order {
+		runner := byBib[key]
+		sort.SliceStable(runner.results, func(i, j int) bool {
+			return results[runner.results[i]].Occurence < results[runner.results[j]].Occurence
+		})
+		anonymous := false
+		for _, ix := range runner.results {
+			res := &results[ix]
+			anonymous = anonymous || res.Anonymous
+			runner.standing.First = res.First
+			runner.standing.Last = res.Last
+			runner.standing.Gender = res.Gender
+			runner.standing.AgeGroup = res.AgeGroup
+			if res.IsDNF() || res.IsDNS() || res.IsDQ() {
+				if runner.standing.DroppedIn == 0 {
+					runner.standing.DroppedIn = runner.standing.YardsCompleted + 1
+				}
+				continue
+			}
+			if !res.Finish || res.Occurence <= runner.standing.YardsCompleted || runner.standing.DroppedIn != 0 {
+				continue
+			}
+			// Yards have to be finished in order, so a yard without a finish is where they dropped.
+			if res.Occurence != runner.standing.YardsCompleted+1 {
+				runner.standing.DroppedIn = runner.standing.YardsCompleted + 1
+				continue
+			}
+			finished := time.Duration(res.Seconds)*time.Second + time.Duration(res.Milliseconds)*time.Millisecond
+			yardTime := finished - time.Duration(res.Occurence-1)*BackyardYard
+			yard := types.BackyardYard{
+				Yard:         res.Occurence,
+				Seconds:      int(yardTime / time.Second),
+				Milliseconds: int(yardTime % time.Second / time.Millisecond),
+				Completed:    yardTime >= 0 && yardTime <= BackyardYard,
+			}
+			runner.standing.Yards = append(runner.standing.Yards, yard)
+			if !yard.Completed {
+				runner.standing.DroppedIn = res.Occurence
+				continue
+			}
+			runner.standing.YardsCompleted = res.Occurence
+			runner.valid[ix] = true
+		}
+		if runner.standing.DroppedIn == 0 && elapsed >= time.Duration(runner.standing.YardsCompleted+1)*BackyardYard {
+			runner.standing.DroppedIn = runner.standing.YardsCompleted + 1
+		}
+		if anonymous {
+			runner.standing.First = ""
+			runner.standing.Last = ""
+		}
+		output[runner.standing.Distance] = append(output[runner.standing.Distance], runner)
+	}
+	for _, runners := range output {
+		sort.SliceStable(runners, func(i, j int) bool {
+			return runners[i].standing.YardsCompleted > runners[j].standing.YardsCompleted
+		})
+		// Runners who completed the same number of yards share a place.
+		for ix, runner := range runners {
+			runner.standing.Ranking = ix + 1
+			if ix > 0 && runners[ix-1].standing.YardsCompleted == runner.standing.YardsCompleted {
+				runner.standing.Ranking = runners[ix-1].standing.Ranking
+			}
+			runner.standing.Status = types.BackyardStatusRunning
+			if runner.standing.DroppedIn != 0 {
+				runner.standing.Status = types.BackyardStatusDropped
+			}
+		}
+		// The winner is the only runner to complete a yard after everyone else has dropped.
+		if len(runners) < 2 || runners[1].standing.DroppedIn == 0 ||
+			runners[0].standing.YardsCompleted == runners[1].standing.YardsCompleted {
+			continue
+		}
+		dropped := true
+		for _, runner := range runners[1:] {
+			dropped = dropped && runner.standing.DroppedIn != 0
+		}
+		if !dropped {
+			continue
+		}
+		runners[0].standing.Status = types.BackyardStatusWinner
+		runners[0].standing.DroppedIn = 0
+		// The assist is the last runner to drop, if they were the only one to drop in that yard.
+		if len(runners) == 2 || runners[1].standing.YardsCompleted != runners[2].standing.YardsCompleted {
+			runners[1].standing.Status = types.BackyardStatusAssist
+		}
+	}
+	return output
+}
+
+// CalculateBackyardStandings Returns the standings of a backyard ultra for each distance, ordered by
+// the yards completed.  Runners who completed the same number of yards share a place.  Elapsed is
+// the time since the start and is used to drop runners who haven't finished a yard before its cutoff.
+// Names are left out for anonymous runners.
+func CalculateBackyardStandings(results []types.Result, elapsed time.Duration) map[string][]types.BackyardStanding {
+	output := make(map[string][]types.BackyardStanding)
+	for distance, runners := range backyardRunners(results, elapsed) {
+		standings := make([]types.BackyardStanding, 0, len(runners))
+		for _, runner := range runners {
+			standings = append(standings, runner.standing)
+		}
+		output[distance] = standings
+	}
+	return output
+}
+
+// CalculateBackyardRankings Calculates the rankings for the results of a backyard ultra.  Each yard a
+// runner completed is given the place of the runner based on the yards they completed, with ties
+// sharing a place, while results for yards finished after the cutoff are left unranked.  The returned
+// slice is in the same order as the results passed in.
+func CalculateBackyardRankings(results []types.Result) []types.Result {
+	out := make([]types.Result, len(results))
+	copy(out, results)
+	for ix := range out {
+		out[ix].Ranking = Unranked
+		out[ix].GenderRanking = Unranked
+		out[ix].AgeRanking = Unranked
+		out[ix].DivisionRanking = Unranked
+	}
+	for _, runners := range backyardRunners(results, 0) {
+		genders := newTiedRanks()
+		ageGroups := newTiedRanks()
+		divisions := newTiedRanks()
+		for _, runner := range runners {
+			standing := &runner.standing
+			yards := standing.YardsCompleted
+			gender := genders.rank(standing.Gender, yards)
+			ageGroup := ageGroups.rank(standing.Gender+"\x00"+standing.AgeGroup, yards)
+			division := Unranked
+			if name := results[runner.results[len(runner.results)-1]].Division; name != "" {
+				division = divisions.rank(name, yards)
+			}
+			for _, ix := range runner.results {
+				if !runner.valid[ix] {
+					continue
+				}
+				out[ix].Ranking = standing.Ranking
+				out[ix].GenderRanking = gender
+				out[ix].AgeRanking = ageGroup
+				out[ix].DivisionRanking = division
+			}
+		}
+	}
+	return out
+}
+
+// tiedRanks Hands out places within groups to runners ordered by the yards they completed, where runners
+// who completed the same number of yards share a place.
+type tiedRanks struct {
+	count map[string]int
+	place map[string]int
+	yards map[string]int
+}
+
+func newTiedRanks() *tiedRanks {
+	return &tiedRanks{
+		count: make(map[string]int),
+		place: make(map[string]int),
+		yards: make(map[string]int),
+	}
+}
+
+// rank Returns the place of the next runner in a group.
+func (t *tiedRanks) rank(group string, yards int) int {
+	t.count[group]++
+	if prev, ok := t.yards[group]; !ok || prev != yards {
+		t.place[group] = t.count[group]
+		t.yards[group] = yards
+	}
+	return t.place[group]
+}
+
@@ -0,0 +1,189 @@
+/*
+Chronokeep Desktop - Race Scoring Software
+Copyright (C) 2026 James Sentinella
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package database
+
+import (
+	"chronokeep/results/types"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// backyardTestYards Returns a result for each yard a runner finished, taking yardSeconds for each.
+func backyardTestYards(bib, gender string, yards, yardSeconds int) []types.Result {
+	output := make([]types.Result, 0, yards)
+	for yard := 1; yard <= yards; yard++ {
+		output = append(output, types.Result{
+			PersonId:  bib,
+			Bib:       bib,
+			First:     "Runner",
+			Last:      bib,
+			Gender:    gender,
+			AgeGroup:  "30-39",
+			Distance:  "Backyard",
+			Division:  "Open",
+			Seconds:   (yard-1)*3600 + yardSeconds,
+			Location:  "Start/Finish",
+			Occurence: yard,
+			Finish:    true,
+		})
+	}
+	return output
+}
+
+func TestCalculateBackyardStandings(t *testing.T) {
+	results := backyardTestYards("A", "Woman", 5, 3000)
+	results = append(results, backyardTestYards("B", "Man", 5, 3400)...)
+	// The fifth yard of B was finished after the cutoff.
+	results[len(results)-1].Seconds = 4*3600 + 3700
+	results = append(results, backyardTestYards("C", "Man", 2, 2900)...)
+	results = append(results, backyardTestYards("D", "Woman", 2, 3100)...)
+	stopped := backyardTestYards("D", "Woman", 1, 0)[0]
+	stopped.Occurence = 3
+	stopped.Status = types.ResultStatusDNF
+	results = append(results, stopped)
+	results[0].Anonymous = true
+	// Everyone has dropped but the winner.
+	standings := CalculateBackyardStandings(results, time.Hour*6)
+	if assert.Equal(t, 1, len(standings)) && assert.Equal(t, 4, len(standings["Backyard"])) {
+		output := standings["Backyard"]
+		assert.Equal(t, "A", output[0].Bib)
+		assert.Equal(t, "", output[0].First)
+		assert.Equal(t, "", output[0].Last)
+		assert.Equal(t, 1, output[0].Ranking)
+		assert.Equal(t, 5, output[0].YardsCompleted)
+		assert.Equal(t, 0, output[0].DroppedIn)
+		assert.Equal(t, types.BackyardStatusWinner, output[0].Status)
+		if assert.Equal(t, 5, len(output[0].Yards)) {
+			assert.Equal(t, types.BackyardYard{Yard: 3, Seconds: 3000, Completed: true}, output[0].Yards[2])
+		}
+		assert.Equal(t, "B", output[1].Bib)
+		assert.Equal(t, "Runner", output[1].First)
+		assert.Equal(t, 2, output[1].Ranking)
+		assert.Equal(t, 4, output[1].YardsCompleted)
+		assert.Equal(t, 5, output[1].DroppedIn)
+		assert.Equal(t, types.BackyardStatusAssist, output[1].Status)
+		if assert.Equal(t, 5, len(output[1].Yards)) {
+			assert.Equal(t, types.BackyardYard{Yard: 5, Seconds: 3700, Completed: false}, output[1].Yards[4])
+		}
+		for _, standing := range output[2:] {
+			assert.Equal(t, 3, standing.Ranking)
+			assert.Equal(t, 2, standing.YardsCompleted)
+			assert.Equal(t, 3, standing.DroppedIn)
+			assert.Equal(t, types.BackyardStatusDropped, standing.Status)
+		}
+	}
+	// C could still finish the third yard so there's no winner yet.
+	standings = CalculateBackyardStandings(results, time.Hour*2+time.Minute*30)
+	if assert.Equal(t, 4, len(standings["Backyard"])) {
+		output := standings["Backyard"]
+		assert.Equal(t, types.BackyardStatusRunning, output[0].Status)
+		assert.Equal(t, types.BackyardStatusDropped, output[1].Status)
+		assert.Equal(t, "C", output[2].Bib)
+		assert.Equal(t, 0, output[2].DroppedIn)
+		assert.Equal(t, types.BackyardStatusRunning, output[2].Status)
+		assert.Equal(t, "D", output[3].Bib)
+		assert.Equal(t, types.BackyardStatusDropped, output[3].Status)
+	}
+	// Nobody wins if the last runners all drop in the same yard.
+	results = backyardTestYards("A", "Woman", 3, 3000)
+	results = append(results, backyardTestYards("B", "Man", 3, 3000)...)
+	results = append(results, backyardTestYards("C", "Man", 1, 3000)...)
+	standings = CalculateBackyardStandings(results, time.Hour*10)
+	if assert.Equal(t, 3, len(standings["Backyard"])) {
+		for _, standing := range standings["Backyard"] {
+			assert.Equal(t, types.BackyardStatusDropped, standing.Status)
+		}
+		assert.Equal(t, 1, standings["Backyard"][0].Ranking)
+		assert.Equal(t, 1, standings["Backyard"][1].Ranking)
+		assert.Equal(t, 3, standings["Backyard"][2].Ranking)
+	}
+	// There's no assist when more than one runner drops in the yard before the winner's last.
+	results = append(results, backyardTestYards("A", "Woman", 4, 3000)[3])
+	results = append(results, backyardTestYards("C", "Man", 3, 3000)[1:]...)
+	standings = CalculateBackyardStandings(results, time.Hour*10)
+	if assert.Equal(t, 3, len(standings["Backyard"])) {
+		assert.Equal(t, types.BackyardStatusWinner, standings["Backyard"][0].Status)
+		assert.Equal(t, types.BackyardStatusDropped, standings["Backyard"][1].Status)
+		assert.Equal(t, types.BackyardStatusDropped, standings["Backyard"][2].Status)
+	}
+	// Yards without a finish aren't skipped over and reads at other locations aren't yards.
+	results = backyardTestYards("A", "Woman", 3, 3000)
+	results = append(results[:1], results[2])
+	midLoop := backyardTestYards("B", "Man", 2, 1500)
+	midLoop[1].Location = "Turnaround"
+	midLoop[1].Finish = false
+	results = append(results, midLoop...)
+	standings = CalculateBackyardStandings(results, time.Hour*10)
+	if assert.Equal(t, 2, len(standings["Backyard"])) {
+		for _, standing := range standings["Backyard"] {
+			assert.Equal(t, 1, standing.YardsCompleted)
+			assert.Equal(t, 2, standing.DroppedIn)
+			assert.Equal(t, 1, len(standing.Yards))
+		}
+	}
+	ranked := CalculateBackyardRankings(results)
+	if assert.Equal(t, len(results), len(ranked)) {
+		assert.Equal(t, 1, ranked[0].Ranking)
+		assert.Equal(t, Unranked, ranked[1].Ranking)
+		assert.Equal(t, 1, ranked[2].Ranking)
+		assert.Equal(t, Unranked, ranked[3].Ranking)
+	}
+}
+
+func TestCalculateBackyardRankings(t *testing.T) {
+	results := backyardTestYards("A", "Woman", 3, 3000)
+	results = append(results, backyardTestYards("B", "Man", 3, 3400)...)
+	results[len(results)-1].Seconds = 2*3600 + 3700
+	results = append(results, backyardTestYards("C", "Man", 2, 2900)...)
+	results = append(results, backyardTestYards("D", "Woman", 2, 3100)...)
+	ranked := CalculateBackyardRankings(results)
+	if assert.Equal(t, len(results), len(ranked)) {
+		for ix, res := range ranked {
+			switch {
+			case res.Bib == "A":
+				assert.Equal(t, 1, res.Ranking)
+				assert.Equal(t, 1, res.GenderRanking)
+				assert.Equal(t, 1, res.AgeRanking)
+				assert.Equal(t, 1, res.DivisionRanking)
+			case res.Bib == "B" && res.Occurence == 3:
+				// Finished after the cutoff.
+				assert.Equal(t, Unranked, res.Ranking)
+				assert.Equal(t, Unranked, res.GenderRanking)
+			case res.Bib == "B":
+				assert.Equal(t, 2, res.Ranking)
+				assert.Equal(t, 1, res.GenderRanking)
+				assert.Equal(t, 2, res.DivisionRanking)
+			case res.Bib == "C":
+				assert.Equal(t, 2, res.Ranking)
+				assert.Equal(t, 1, res.GenderRanking)
+				assert.Equal(t, 2, res.DivisionRanking)
+			case res.Bib == "D":
+				assert.Equal(t, 2, res.Ranking)
+				assert.Equal(t, 2, res.GenderRanking)
+				assert.Equal(t, 2, res.AgeRanking)
+			}
+			// Everything else is left alone and in the same order.
+			assert.Equal(t, results[ix].Bib, res.Bib)
+			assert.Equal(t, results[ix].Seconds, res.Seconds)
+		}
+	}
+}
+
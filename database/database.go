@@ -28,7 +28,7 @@ const (
 	MaxOpenConnections    = 20
 	MaxIdleConnections    = 20
 	MaxConnectionLifetime = time.Minute * 5
-	CurrentVersion        = 19
+	CurrentVersion        = 33
 	MaxLoginAttempts      = 4
 )
 
@@ -88,10 +88,17 @@ type Database interface {
 	GetAllDistanceResults(eventYearID int64, distance string, limit, page int) ([]types.Result, error)
 	GetFinishResults(eventYearID int64, distance string, limit, page int) ([]types.Result, error)
 	GetBibResults(eventYearID int64, bib string) ([]types.Result, error)
-	DeleteResults(eventYearID int64, results []types.Result) (int64, error)
-	DeleteDistanceResults(eventYearId int64, distance string) (int64, error)
-	DeleteEventResults(eventYearID int64) (int64, error)
-	AddResults(eventYearID int64, results []types.Result) ([]types.Result, error)
+	GetUpdatedResults(eventYearID int64, distance string, updatedAfter int64) ([]types.Result, error)
+	GetLocationResults(eventYearID int64, distance, location, segment string, occurence int) ([]types.Result, error)
+	GetDeletedResults(eventYearID int64, distance string, deletedAfter int64) ([]types.Result, error)
+	DeleteResults(eventYearID int64, results []types.Result, actor *types.AuditActor) (int64, error)
+	DeleteDistanceResults(eventYearId int64, distance string, actor *types.AuditActor) (int64, error)
+	DeleteEventResults(eventYearID int64, actor *types.AuditActor) (int64, error)
+	AddResults(eventYearID int64, results []types.Result, actor *types.AuditActor) ([]types.Result, error)
+	UpdateRankings(eventYearID int64) (int64, error)
+	// Audit Functions
+	GetResultAudit(eventYearID int64, bib string) ([]types.AuditEntry, error)
+	RevertAudit(eventYearID int64, changes []types.AuditEntry, actor *types.AuditActor) (int64, error)
 	// Multi-Get Functions
 	GetAccountAndEvent(slug string) (*types.MultiGet, error)
 	GetAccountEventAndYear(slug, year string) (*types.MultiGet, error)
@@ -100,10 +107,10 @@ type Database interface {
 	// Person Functions
 	GetPerson(slug, year, bib string) (*types.Person, error)
 	GetPeople(slug, year string) ([]types.Person, error)
-	AddPerson(eventYearID int64, person types.Person) (*types.Person, error)
-	AddPeople(eventYearID int64, people []types.Person) ([]types.Person, error)
-	DeletePeople(eventYearID int64, alternateIds []string) (int64, error)
-	UpdatePerson(eventYearID int64, person types.Person) (*types.Person, error)
+	AddPerson(eventYearID int64, person types.Person, actor *types.AuditActor) (*types.Person, error)
+	AddPeople(eventYearID int64, people []types.Person, actor *types.AuditActor) ([]types.Person, error)
+	DeletePeople(eventYearID int64, alternateIds []string, actor *types.AuditActor) (int64, error)
+	UpdatePerson(eventYearID int64, person types.Person, actor *types.AuditActor) (*types.Person, error)
 	// Registration Functions
 	AddParticipants(eventYearID int64, participant []types.Participant) ([]types.Participant, error)
 	GetParticipants(eventYearID int64, limit, page int, updatedAfter *int64) ([]types.Participant, error)
@@ -137,6 +144,41 @@ type Database interface {
 	GetDistance(eventYearID int64, distance_name string) (*types.Distance, error)
 	GetDistances(eventYearID int64) ([]types.Distance, error)
 	DeleteDistances(eventYearID int64) (int64, error)
+	// Record functions
+	GetRecords(eventID int64) ([]types.Record, error)
+	SetRecords(eventYearID int64, records []types.Record) ([]types.Record, error)
+	// Team functions
+	AddTeams(eventYearID int64, teams []types.Team) ([]types.Team, error)
+	GetTeams(eventYearID int64) ([]types.Team, error)
+	DeleteTeams(eventYearID int64, names []string) (int64, error)
+	SetTeamScoring(eventYearID int64, scoring []types.TeamScoring) ([]types.TeamScoring, error)
+	GetTeamScoring(eventYearID int64) ([]types.TeamScoring, error)
+	// Publish functions
+	GetPublishSettings(eventYearID int64) (*types.PublishSettings, error)
+	SetPublishSettings(eventYearID int64, settings types.PublishSettings) (*types.PublishSettings, error)
+	// Award functions
+	GetAwardRules(eventYearID int64) (*types.AwardRules, error)
+	SetAwardRules(eventYearID int64, rules types.AwardRules) (*types.AwardRules, error)
+	// Wave functions
+	GetWaves(eventYearID int64) ([]types.Wave, error)
+	SetWaves(eventYearID int64, waves []types.Wave) ([]types.Wave, error)
+	// Handicap functions
+	GetHandicapScoring(eventYearID int64) (*types.HandicapScoring, error)
+	SetHandicapScoring(eventYearID int64, scoring types.HandicapScoring) (*types.HandicapScoring, error)
+	// Statistics functions
+	GetDistanceStatistics(eventYearID int64, distance, rankingType string, bucketSeconds int) ([]types.DistanceStatistics, error)
+	GetYearStatistics(eventID int64) ([]types.YearStatistics, error)
+	// Series functions
+	GetSeries(slug string) (*types.Series, error)
+	AddSeries(series types.Series) (*types.Series, error)
+	DeleteSeries(series types.Series) error
+	// Athlete functions
+	GetAthlete(slug string) (*types.Athlete, error)
+	AddAthlete(athlete types.Athlete) (*types.Athlete, error)
+	DeleteAthlete(athlete types.Athlete) error
+	AddAthleteLink(athleteID, eventYearID int64, bib string) error
+	DeleteAthleteLink(athleteID, eventYearID int64, bib string) (int64, error)
+	GetAthleteEntries(athlete types.Athlete) ([]types.AthleteEntry, error)
 	// Close the database.
 	Close()
 }
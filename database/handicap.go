@@ -0,0 +1,126 @@
+/*
+Chronokeep Desktop - Race Scoring Software
+Copyright (C) 2026 James Sentinella
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package database
+
+import (
+	"chronokeep/results/types"
+	"cmp"
+	"sort"
+)
+
+// handicapKey Identifies the finish of a bib in a distance.
+type handicapKey struct {
+	distance string
+	bib      string
+}
+
+// handicapFinish holds the times a finish is ranked by in a handicap race, in milliseconds.
+type handicapFinish struct {
+	key     handicapKey
+	clock   int64
+	actual  int64
+	place   int
+	ranking int
+}
+
+// ScoreHandicaps Sets the handicap values of the results using the finishes in the field, which
+// should be every finish in the event year.  Everyone starts their handicap after the gun so the
+// handicap place is the order of their gun times, which is the order they crossed the line.  Their
+// actual time is their gun time less their handicap and they're also ranked by that, unless their
+// handicap is longer than their gun time.  Both are ranked within each distance and only finishes
+// are given them.
+func ScoreHandicaps(results []types.Result, field []types.Result, handicaps []types.Handicap) {
+	offsets := make(map[string]int64)
+	for _, h := range handicaps {
+		offsets[h.Bib] = int64(h.Seconds)*1000 + int64(h.Milliseconds)
+	}
+	finishes := make([]*handicapFinish, 0)
+	byKey := make(map[handicapKey]*handicapFinish)
+	for ix := range field {
+		res := &field[ix]
+		key := handicapKey{distance: res.Distance, bib: res.Bib}
+		if !isRecordFinish(res) || byKey[key] != nil {
+			continue
+		}
+		clock := int64(res.Seconds)*1000 + int64(res.Milliseconds)
+		finish := &handicapFinish{
+			key:    key,
+			clock:  clock,
+			actual: clock - offsets[res.Bib],
+		}
+		finishes = append(finishes, finish)
+		byKey[key] = finish
+	}
+	placeHandicapFinishes(finishes,
+		func(finish *handicapFinish) int64 { return finish.clock },
+		func(finish *handicapFinish, place int) { finish.place = place },
+	)
+	timed := make([]*handicapFinish, 0, len(finishes))
+	for _, finish := range finishes {
+		if finish.actual >= 0 {
+			timed = append(timed, finish)
+		}
+	}
+	placeHandicapFinishes(timed,
+		func(finish *handicapFinish) int64 { return finish.actual },
+		func(finish *handicapFinish, place int) { finish.ranking = place },
+	)
+	for ix := range results {
+		res := &results[ix]
+		offset := offsets[res.Bib]
+		res.HandicapSeconds = int(offset / 1000)
+		res.HandicapMilliseconds = int(offset % 1000)
+		if !isRecordFinish(res) {
+			continue
+		}
+		finish, ok := byKey[handicapKey{distance: res.Distance, bib: res.Bib}]
+		if !ok {
+			continue
+		}
+		res.HandicapRanking = finish.place
+		if finish.actual >= 0 {
+			res.ActualSeconds = int(finish.actual / 1000)
+			res.ActualMilliseconds = int(finish.actual % 1000)
+			res.ActualRanking = finish.ranking
+		}
+	}
+}
+
+// placeHandicapFinishes Places the finishes within each distance in the order of the time given,
+// with ties going to whoever crossed the line first.
+func placeHandicapFinishes(finishes []*handicapFinish, time func(finish *handicapFinish) int64, set func(finish *handicapFinish, place int)) {
+	sort.SliceStable(finishes, func(i, j int) bool {
+		one, two := finishes[i], finishes[j]
+		return cmp.Or(
+			cmp.Compare(one.key.distance, two.key.distance),
+			cmp.Compare(time(one), time(two)),
+			cmp.Compare(one.clock, two.clock),
+			cmp.Compare(one.key.bib, two.key.bib),
+		) < 0
+	})
+	place := 0
+	for ix, finish := range finishes {
+		if ix == 0 || finish.key.distance != finishes[ix-1].key.distance {
+			place = 0
+		}
+		place++
+		set(finish, place)
+	}
+}
+
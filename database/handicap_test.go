@@ -0,0 +1,80 @@
+/*
+Chronokeep Desktop - Race Scoring Software
+Copyright (C) 2026 James Sentinella
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package database
+
+import (
+	"chronokeep/results/types"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScoreHandicaps(t *testing.T) {
+	field := []types.Result{
+		// Scratch runner, starts with the gun.
+		{Bib: "1", Distance: "5K", Seconds: 1200, Finish: true},
+		// Starts five minutes after the gun and crosses the line first.
+		{Bib: "2", Distance: "5K", Seconds: 1150, Milliseconds: 500, Finish: true},
+		// Starts ten minutes after the gun.
+		{Bib: "3", Distance: "5K", Seconds: 1250, Finish: true},
+		// Handicap longer than their gun time, so they can't be given an actual time.
+		{Bib: "4", Distance: "5K", Seconds: 100, Finish: true},
+		// Not a finish.
+		{Bib: "5", Distance: "5K", Seconds: 600, Finish: false},
+		// Didn't finish.
+		{Bib: "6", Distance: "5K", Seconds: 1100, Finish: true, Status: types.ResultStatusDNF},
+		// Other distances are ranked separately.
+		{Bib: "7", Distance: "10K", Seconds: 2400, Finish: true},
+	}
+	handicaps := []types.Handicap{
+		{Bib: "2", Seconds: 300},
+		{Bib: "3", Seconds: 600, Milliseconds: 250},
+		{Bib: "4", Seconds: 200},
+		{Bib: "5", Seconds: 60},
+	}
+	results := make([]types.Result, len(field))
+	copy(results, field)
+	ScoreHandicaps(results, field, handicaps)
+	type expected struct {
+		handicap, handicapMs, place, actual, actualMs, ranking int
+	}
+	for ix, exp := range []expected{
+		{0, 0, 3, 1200, 0, 3},
+		{300, 0, 2, 850, 500, 2},
+		{600, 250, 4, 649, 750, 1},
+		{200, 0, 1, 0, 0, 0},
+		{60, 0, 0, 0, 0, 0},
+		{0, 0, 0, 0, 0, 0},
+		{0, 0, 1, 2400, 0, 1},
+	} {
+		res := results[ix]
+		assert.Equal(t, exp.handicap, res.HandicapSeconds, res.Bib)
+		assert.Equal(t, exp.handicapMs, res.HandicapMilliseconds, res.Bib)
+		assert.Equal(t, exp.place, res.HandicapRanking, res.Bib)
+		assert.Equal(t, exp.actual, res.ActualSeconds, res.Bib)
+		assert.Equal(t, exp.actualMs, res.ActualMilliseconds, res.Bib)
+		assert.Equal(t, exp.ranking, res.ActualRanking, res.Bib)
+	}
+	// Results that are only part of the field are still ranked against all of it.
+	single := []types.Result{field[2]}
+	ScoreHandicaps(single, field, handicaps)
+	assert.Equal(t, 4, single[0].HandicapRanking)
+	assert.Equal(t, 1, single[0].ActualRanking)
+}
+
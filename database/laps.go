@@ -0,0 +1,298 @@
+/*
+Chronokeep Desktop - Race Scoring Software
+Copyright (C) 2026 James Sentinella
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package database
+
+import (
+	"chronokeep/results/types"
+	"math"
+	"sort"
+)
+
+// lapCourse holds the length of a lap of a distance in a timed event.  When the distance has
+// segments only their locations count as laps, each with the length of its segment, otherwise
+// every location counts as a lap the length of the distance.
+type lapCourse struct {
+	unit      string
+	meters    float64
+	locations map[string]float64
+}
+
+// newLapCourses Works out the lap length of each distance from the distance itself and its segments.
+// Distances are given in the unit of the distance, or of the first segment if the distance doesn't
+// have a length.
+func newLapCourses(distances []types.Distance, segments []types.Segment) map[string]*lapCourse {
+	courses := make(map[string]*lapCourse)
+	for _, dist := range distances {
+		course := &lapCourse{}
+		if meters, ok := DistanceMeters(dist.DistanceValue, DistanceUnit(dist.DistanceUnit)); ok {
+			course.unit = DistanceUnit(dist.DistanceUnit)
+			course.meters = meters
+		}
+		courses[dist.Name] = course
+	}
+	for _, seg := range segments {
+		course, ok := courses[seg.DistanceName]
+		if !ok {
+			course = &lapCourse{}
+			courses[seg.DistanceName] = course
+		}
+		meters, ok := DistanceMeters(seg.DistanceValue, DistanceUnit(seg.DistanceUnit))
+		if !ok {
+			continue
+		}
+		if course.locations == nil {
+			course.locations = make(map[string]float64)
+		}
+		if _, ok := course.locations[seg.Location]; !ok {
+			course.locations[seg.Location] = meters
+		}
+		if course.unit == "" {
+			course.unit = DistanceUnit(seg.DistanceUnit)
+		}
+	}
+	return courses
+}
+
+// lap Returns the length of a lap finished at a location in meters, or false if the location
+// isn't a lap of the course.
+func (c *lapCourse) lap(location string) (float64, bool) {
+	if c == nil {
+		return 0, true
+	}
+	if c.locations == nil {
+		return c.meters, true
+	}
+	meters, ok := c.locations[location]
+	return meters, ok
+}
+
+// value Returns a length in meters in the unit of the course, rounded to the nearest thousandth.
+func (c *lapCourse) value(meters float64) float64 {
+	if c == nil {
+		return 0
+	}
+	unitMeters, ok := DistanceMeters(1, c.unit)
+	if !ok {
+		return 0
+	}
+	return math.Round(meters/unitMeters*1000) / 1000
+}
+
+// lapRunner holds the standing of a runner in a timed event along with the results it came from.
+type lapRunner struct {
+	standing types.LapStanding
+	results  []int
+	laps     map[int]bool
+	ranked   bool
+	elapsed  int64
+}
+
+// lapRunners Works out the laps completed by each runner in a timed event, grouped by distance and
+// ordered by their standing.  Laps are the results past the start at a lap location of the course,
+// in the order they were finished.  Runners are ranked by the distance covered, then by the number
+// of laps and then by the time their last lap was finished at.  DNS and DQ runners, along with those
+// who haven't completed a lap, are left unranked.
+func lapRunners(results []types.Result, distances []types.Distance, segments []types.Segment, rankingType string) map[string][]*lapRunner {
+	courses := newLapCourses(distances, segments)
+	byBib := make(map[string]*lapRunner)
+	order := make([]string, 0)
+	for ix, res := range results {
+		key := res.Distance + "\x00" + res.Bib
+		runner, ok := byBib[key]
+		if !ok {
+			runner = &lapRunner{
+				standing: types.LapStanding{
+					Bib:      res.Bib,
+					Distance: res.Distance,
+					LapTimes: make([]types.Lap, 0),
+				},
+				laps:   make(map[int]bool),
+				ranked: true,
+			}
+			byBib[key] = runner
+			order = append(order, key)
+		}
+		runner.results = append(runner.results, ix)
+	}
+	output := make(map[string][]*lapRunner)
+	for _, key := range order {
+		runner := byBib[key]
+		course := courses[runner.standing.Distance]
+		if course != nil {
+			runner.standing.DistanceUnit = course.unit
+		}
+		sort.SliceStable(runner.results, func(i, j int) bool {
+			one, two := &results[runner.results[i]], &results[runner.results[j]]
+			oneSeconds, oneMilliseconds := recordTime(one, rankingType)
+			twoSeconds, twoMilliseconds := recordTime(two, rankingType)
+			oneMillis := int64(oneSeconds)*1000 + int64(oneMilliseconds)
+			twoMillis := int64(twoSeconds)*1000 + int64(twoMilliseconds)
+			if oneMillis != twoMillis {
+				return oneMillis < twoMillis
+			}
+			return one.Occurence < two.Occurence
+		})
+		anonymous := false
+		meters := 0.0
+		best := int64(-1)
+		for _, ix := range runner.results {
+			res := &results[ix]
+			anonymous = anonymous || res.Anonymous
+			runner.standing.First = res.First
+			runner.standing.Last = res.Last
+			runner.standing.Gender = res.Gender
+			runner.standing.AgeGroup = res.AgeGroup
+			runner.standing.Division = res.Division
+			if res.IsDNS() || res.IsDQ() {
+				runner.ranked = false
+			}
+			if !res.IsRanked() || res.Occurence < 1 {
+				continue
+			}
+			lapMeters, ok := course.lap(res.Location)
+			if !ok {
+				continue
+			}
+			seconds, milliseconds := recordTime(res, rankingType)
+			elapsed := int64(seconds)*1000 + int64(milliseconds)
+			lapTime := elapsed - runner.elapsed
+			runner.elapsed = elapsed
+			meters += lapMeters
+			runner.standing.Laps++
+			runner.standing.LapTimes = append(runner.standing.LapTimes, types.Lap{
+				Lap:                 runner.standing.Laps,
+				Location:            res.Location,
+				DistanceValue:       course.value(lapMeters),
+				Seconds:             int(lapTime / 1000),
+				Milliseconds:        int(lapTime % 1000),
+				ElapsedSeconds:      seconds,
+				ElapsedMilliseconds: milliseconds,
+			})
+			if best < 0 || lapTime < best {
+				best = lapTime
+			}
+			runner.laps[ix] = true
+		}
+		if laps := int64(runner.standing.Laps); laps > 0 {
+			runner.standing.DistanceValue = course.value(meters)
+			runner.standing.Seconds = int(runner.elapsed / 1000)
+			runner.standing.Milliseconds = int(runner.elapsed % 1000)
+			last := runner.standing.LapTimes[laps-1]
+			runner.standing.LastLapSeconds = last.Seconds
+			runner.standing.LastLapMilliseconds = last.Milliseconds
+			runner.standing.BestLapSeconds = int(best / 1000)
+			runner.standing.BestLapMilliseconds = int(best % 1000)
+			average := runner.elapsed / laps
+			runner.standing.AverageLapSeconds = int(average / 1000)
+			runner.standing.AverageLapMilliseconds = int(average % 1000)
+		} else {
+			runner.ranked = false
+		}
+		if anonymous {
+			runner.standing.First = ""
+			runner.standing.Last = ""
+		}
+		output[runner.standing.Distance] = append(output[runner.standing.Distance], runner)
+	}
+	for _, runners := range output {
+		sort.SliceStable(runners, func(i, j int) bool {
+			one, two := runners[i], runners[j]
+			if one.ranked != two.ranked {
+				return one.ranked
+			}
+			if one.standing.DistanceValue != two.standing.DistanceValue {
+				return one.standing.DistanceValue > two.standing.DistanceValue
+			}
+			if one.standing.Laps != two.standing.Laps {
+				return one.standing.Laps > two.standing.Laps
+			}
+			if one.elapsed != two.elapsed {
+				return one.elapsed < two.elapsed
+			}
+			return one.standing.Bib < two.standing.Bib
+		})
+		genders := make(map[string]int)
+		ageGroups := make(map[subGroup]int)
+		divisions := make(map[string]int)
+		for ix, runner := range runners {
+			standing := &runner.standing
+			if !runner.ranked {
+				standing.Ranking = Unranked
+				standing.GenderRanking = Unranked
+				standing.AgeRanking = Unranked
+				standing.DivisionRanking = Unranked
+				continue
+			}
+			standing.Ranking = ix + 1
+			genders[standing.Gender]++
+			standing.GenderRanking = genders[standing.Gender]
+			ag := subGroup{gender: standing.Gender, ageGroup: standing.AgeGroup}
+			ageGroups[ag]++
+			standing.AgeRanking = ageGroups[ag]
+			standing.DivisionRanking = Unranked
+			if standing.Division != "" {
+				divisions[standing.Division]++
+				standing.DivisionRanking = divisions[standing.Division]
+			}
+		}
+	}
+	return output
+}
+
+// CalculateLapStandings Returns the standings of a timed event for each distance, ordered by the
+// distance covered and then the time the last lap was finished at.  Lap lengths come from the
+// segments of the distance when it has any and from the distance itself otherwise.  Names are left
+// out for anonymous runners.
+func CalculateLapStandings(results []types.Result, distances []types.Distance, segments []types.Segment, rankingType string) map[string][]types.LapStanding {
+	output := make(map[string][]types.LapStanding)
+	for distance, runners := range lapRunners(results, distances, segments, rankingType) {
+		standings := make([]types.LapStanding, 0, len(runners))
+		for _, runner := range runners {
+			standings = append(standings, runner.standing)
+		}
+		output[distance] = standings
+	}
+	return output
+}
+
+// CalculateLapRankings Calculates the rankings for the results of a timed event.  Each lap a runner
+// completed is given the places of the runner in the standings, while every other result is left
+// unranked.  The returned slice is in the same order as the results passed in.
+func CalculateLapRankings(results []types.Result, distances []types.Distance, segments []types.Segment, rankingType string) []types.Result {
+	out := make([]types.Result, len(results))
+	copy(out, results)
+	for ix := range out {
+		out[ix].Ranking = Unranked
+		out[ix].GenderRanking = Unranked
+		out[ix].AgeRanking = Unranked
+		out[ix].DivisionRanking = Unranked
+	}
+	for _, runners := range lapRunners(results, distances, segments, rankingType) {
+		for _, runner := range runners {
+			for ix := range runner.laps {
+				out[ix].Ranking = runner.standing.Ranking
+				out[ix].GenderRanking = runner.standing.GenderRanking
+				out[ix].AgeRanking = runner.standing.AgeRanking
+				out[ix].DivisionRanking = runner.standing.DivisionRanking
+			}
+		}
+	}
+	return out
+}
+
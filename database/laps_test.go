@@ -0,0 +1,183 @@
+/*
+Chronokeep Desktop - Race Scoring Software
+Copyright (C) 2026 James Sentinella
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package database
+
+import (
+	"chronokeep/results/types"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// lapTestLaps Returns a result for each lap a runner finished at a location, taking lapSeconds for each.
+func lapTestLaps(bib, gender, location string, laps, lapSeconds int) []types.Result {
+	output := make([]types.Result, 0, laps)
+	for lap := 1; lap <= laps; lap++ {
+		output = append(output, types.Result{
+			PersonId:    bib,
+			Bib:         bib,
+			First:       "Runner",
+			Last:        bib,
+			Gender:      gender,
+			AgeGroup:    "30-39",
+			Distance:    "12 Hour",
+			Division:    "Open",
+			Seconds:     lap * lapSeconds,
+			ChipSeconds: lap * lapSeconds,
+			Location:    location,
+			Occurence:   lap,
+		})
+	}
+	return output
+}
+
+var lapTestDistances = []types.Distance{
+	{
+		Name:          "12 Hour",
+		Certification: "None",
+		DistanceValue: 1,
+		DistanceUnit:  "miles",
+	},
+}
+
+func TestCalculateLapStandings(t *testing.T) {
+	results := lapTestLaps("A", "Woman", "Start/Finish", 4, 1000)
+	// A took longer on their second lap.
+	for ix := 1; ix < len(results); ix++ {
+		results[ix].Seconds += 200
+		results[ix].ChipSeconds += 200
+	}
+	results = append(results, lapTestLaps("B", "Man", "Start/Finish", 4, 900)...)
+	results = append(results, lapTestLaps("C", "Man", "Start/Finish", 5, 800)...)
+	results = append(results, lapTestLaps("D", "Woman", "Start/Finish", 2, 700)...)
+	dq := lapTestLaps("D", "Woman", "Start/Finish", 3, 700)[2]
+	dq.Status = types.ResultStatusDQ
+	results = append(results, dq)
+	start := lapTestLaps("E", "Woman", "Start/Finish", 1, 0)[0]
+	start.Occurence = 0
+	results = append(results, start)
+	results[0].Anonymous = true
+	standings := CalculateLapStandings(results, lapTestDistances, nil, "gun")
+	if assert.Equal(t, 1, len(standings)) && assert.Equal(t, 5, len(standings["12 Hour"])) {
+		output := standings["12 Hour"]
+		assert.Equal(t, "C", output[0].Bib)
+		assert.Equal(t, 1, output[0].Ranking)
+		assert.Equal(t, 1, output[0].GenderRanking)
+		assert.Equal(t, 5, output[0].Laps)
+		assert.Equal(t, 5.0, output[0].DistanceValue)
+		assert.Equal(t, "miles", output[0].DistanceUnit)
+		assert.Equal(t, 4000, output[0].Seconds)
+		assert.Equal(t, "B", output[1].Bib)
+		assert.Equal(t, 2, output[1].Ranking)
+		assert.Equal(t, 2, output[1].GenderRanking)
+		assert.Equal(t, 4.0, output[1].DistanceValue)
+		assert.Equal(t, "A", output[2].Bib)
+		assert.Equal(t, "", output[2].First)
+		assert.Equal(t, "", output[2].Last)
+		assert.Equal(t, 3, output[2].Ranking)
+		assert.Equal(t, 1, output[2].GenderRanking)
+		assert.Equal(t, 1, output[2].AgeRanking)
+		assert.Equal(t, 3, output[2].DivisionRanking)
+		assert.Equal(t, 4, output[2].Laps)
+		assert.Equal(t, 4200, output[2].Seconds)
+		assert.Equal(t, 1000, output[2].LastLapSeconds)
+		assert.Equal(t, 1000, output[2].BestLapSeconds)
+		assert.Equal(t, 1050, output[2].AverageLapSeconds)
+		if assert.Equal(t, 4, len(output[2].LapTimes)) {
+			assert.Equal(t, types.Lap{
+				Lap:            2,
+				Location:       "Start/Finish",
+				DistanceValue:  1,
+				Seconds:        1200,
+				ElapsedSeconds: 2200,
+			}, output[2].LapTimes[1])
+		}
+		// DQ runners and runners without a lap are left unranked.
+		assert.Equal(t, -1, output[3].Ranking)
+		assert.Equal(t, -1, output[4].Ranking)
+		assert.Equal(t, 0, output[4].Laps)
+	}
+}
+
+func TestCalculateLapStandingsSegments(t *testing.T) {
+	// Laps through the long loop count for more than laps through the short loop.
+	segments := []types.Segment{
+		{
+			Location:      "Long Loop",
+			DistanceName:  "12 Hour",
+			Name:          "Long Loop",
+			DistanceValue: 5,
+			DistanceUnit:  "kilometers",
+		},
+		{
+			Location:      "Short Loop",
+			DistanceName:  "12 Hour",
+			Name:          "Short Loop",
+			DistanceValue: 1000,
+			DistanceUnit:  "meters",
+		},
+	}
+	results := lapTestLaps("A", "Woman", "Long Loop", 2, 1500)
+	results = append(results, lapTestLaps("B", "Woman", "Short Loop", 6, 300)...)
+	short := lapTestLaps("A", "Woman", "Short Loop", 3, 0)[2]
+	short.Seconds = 3300
+	results = append(results, short)
+	// Reads at other locations aren't laps.
+	results = append(results, lapTestLaps("B", "Woman", "Aid", 10, 100)...)
+	standings := CalculateLapStandings(results, nil, segments, "gun")
+	if assert.Equal(t, 2, len(standings["12 Hour"])) {
+		output := standings["12 Hour"]
+		assert.Equal(t, "A", output[0].Bib)
+		assert.Equal(t, 3, output[0].Laps)
+		assert.Equal(t, 11.0, output[0].DistanceValue)
+		assert.Equal(t, "kilometers", output[0].DistanceUnit)
+		assert.Equal(t, 3300, output[0].Seconds)
+		assert.Equal(t, 300, output[0].LastLapSeconds)
+		assert.Equal(t, "B", output[1].Bib)
+		assert.Equal(t, 6, output[1].Laps)
+		assert.Equal(t, 6.0, output[1].DistanceValue)
+	}
+}
+
+func TestCalculateLapRankings(t *testing.T) {
+	results := lapTestLaps("A", "Woman", "Start/Finish", 3, 1000)
+	results = append(results, lapTestLaps("B", "Woman", "Start/Finish", 3, 900)...)
+	start := lapTestLaps("B", "Woman", "Start/Finish", 1, 0)[0]
+	start.Occurence = 0
+	results = append(results, start)
+	// Chip times are used when ranking by chip time.
+	for ix := range 3 {
+		results[ix].ChipSeconds -= 500
+	}
+	ranked := CalculateLapRankings(results, lapTestDistances, nil, "chip")
+	if assert.Equal(t, len(results), len(ranked)) {
+		for ix := range 3 {
+			assert.Equal(t, 1, ranked[ix].Ranking)
+			assert.Equal(t, 1, ranked[ix].GenderRanking)
+			assert.Equal(t, 1, ranked[ix].AgeRanking)
+			assert.Equal(t, 1, ranked[ix].DivisionRanking)
+			assert.Equal(t, 2, ranked[ix+3].Ranking)
+		}
+		assert.Equal(t, -1, ranked[6].Ranking)
+	}
+	ranked = CalculateLapRankings(results, lapTestDistances, nil, "gun")
+	assert.Equal(t, 2, ranked[0].Ranking)
+	assert.Equal(t, 1, ranked[3].Ranking)
+}
+
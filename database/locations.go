@@ -0,0 +1,81 @@
+/*
+Chronokeep Desktop - Race Scoring Software
+Copyright (C) 2026 James Sentinella
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package database
+
+import (
+	"chronokeep/results/types"
+	"chronokeep/results/util"
+	"sort"
+)
+
+// RankArrivals Returns the results at a timing location in the order people arrived there along with
+// how far behind the first arrival they were.  Results that didn't finish, didn't start or were
+// disqualified are listed last and left unranked.
+func RankArrivals(results []types.Result) []types.LocationResult {
+	sorted := make([]types.Result, len(results))
+	copy(sorted, results)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].IsRanked() != sorted[j].IsRanked() {
+			return sorted[i].IsRanked()
+		}
+		return rankedBefore(&sorted[i], &sorted[j], util.RANKING_TYPE_GUN)
+	})
+	output := make([]types.LocationResult, 0, len(sorted))
+	var first int64
+	for ix, res := range sorted {
+		out := types.LocationResult{
+			Result:  res,
+			Arrival: Unranked,
+		}
+		if res.IsRanked() {
+			millis := int64(res.Seconds)*1000 + int64(res.Milliseconds)
+			if ix == 0 {
+				first = millis
+			}
+			out.Arrival = ix + 1
+			out.BehindSeconds, out.BehindMilliseconds = int((millis-first)/1000), int((millis-first)%1000)
+		}
+		output = append(output, out)
+	}
+	return output
+}
+
+// ExpectedArrivals Returns the number of participants still expected at a timing location.  These are
+// the participants in the distance, or in any distance if none is given, who haven't been seen at the
+// location and whose last results are neither a finish nor a DNF, DNS or DQ.
+func ExpectedArrivals(participants []types.Participant, arrived, last []types.Result, distance string) int {
+	done := make(map[string]bool)
+	for _, res := range arrived {
+		done[res.Bib] = true
+	}
+	for _, res := range last {
+		if isRecordFinish(&res) || res.IsDNF() || res.IsDNS() || res.IsDQ() {
+			done[res.Bib] = true
+		}
+	}
+	count := 0
+	for _, part := range participants {
+		if part.Bib == "" || (distance != "" && part.Distance != distance) || done[part.Bib] {
+			continue
+		}
+		count++
+	}
+	return count
+}
+
@@ -0,0 +1,86 @@
+/*
+Chronokeep Desktop - Race Scoring Software
+Copyright (C) 2026 James Sentinella
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package database
+
+import (
+	"chronokeep/results/types"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRankArrivals(t *testing.T) {
+	results := []types.Result{
+		splitTestResult("1", "Aid 1", "", 1, 1210, false),
+		splitTestResult("2", "Aid 1", "", 1, 1200, false),
+		splitTestResult("3", "Aid 1", "", 1, 1100, false),
+		splitTestResult("4", "Aid 1", "", 1, 1300, false),
+	}
+	results[0].Milliseconds = 500
+	// Arrival uses gun time.
+	results[1].ChipSeconds = 1300
+	results[2].Status = types.ResultStatusDNF
+	output := RankArrivals(results)
+	if assert.Equal(t, 4, len(output)) {
+		assert.Equal(t, "2", output[0].Bib)
+		assert.Equal(t, 1, output[0].Arrival)
+		assert.Equal(t, 0, output[0].BehindSeconds)
+		assert.Equal(t, "1", output[1].Bib)
+		assert.Equal(t, 2, output[1].Arrival)
+		assert.Equal(t, 10, output[1].BehindSeconds)
+		assert.Equal(t, 500, output[1].BehindMilliseconds)
+		assert.Equal(t, "4", output[2].Bib)
+		assert.Equal(t, 3, output[2].Arrival)
+		assert.Equal(t, 100, output[2].BehindSeconds)
+		assert.Equal(t, "3", output[3].Bib)
+		assert.Equal(t, Unranked, output[3].Arrival)
+		assert.Equal(t, 0, output[3].BehindSeconds)
+	}
+	assert.Equal(t, 0, len(RankArrivals([]types.Result{})))
+}
+
+func TestExpectedArrivals(t *testing.T) {
+	participants := []types.Participant{
+		{Bib: "1", Distance: "10K"},
+		{Bib: "2", Distance: "10K"},
+		{Bib: "3", Distance: "10K"},
+		{Bib: "4", Distance: "10K"},
+		{Bib: "5", Distance: "10K"},
+		{Bib: "6", Distance: "5K"},
+		{Bib: "", Distance: "10K"},
+	}
+	arrived := []types.Result{
+		splitTestResult("1", "Aid 1", "", 1, 1200, false),
+	}
+	dnf := splitTestResult("2", "Start/Finish", "", 1, 600, false)
+	dnf.Status = types.ResultStatusDNF
+	last := []types.Result{
+		arrived[0],
+		dnf,
+		// Finished without being seen at the location.
+		splitTestResult("3", "Start/Finish", "", 2, 2500, true),
+		// Still on the course.
+		splitTestResult("4", "Start/Finish", "", 1, 650, false),
+	}
+	assert.Equal(t, 2, ExpectedArrivals(participants, arrived, last, "10K"))
+	assert.Equal(t, 3, ExpectedArrivals(participants, arrived, last, ""))
+	assert.Equal(t, 1, ExpectedArrivals(participants, arrived, last, "5K"))
+	assert.Equal(t, 0, ExpectedArrivals(participants, arrived, last, "Marathon"))
+}
+
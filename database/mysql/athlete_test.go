@@ -0,0 +1,242 @@
+/*
+Chronokeep Desktop - Race Scoring Software
+Copyright (C) 2026 James Sentinella
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package mysql
+
+import (
+	"chronokeep/results/types"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddAthlete(t *testing.T) {
+	db, finalize, err := setupTests(t)
+	if err != nil {
+		t.Fatalf("Error setting up tests. %v", err)
+	}
+	defer finalize(t)
+	setupSeriesTests()
+	account, _ := db.AddAccount(accounts[0])
+	athlete := types.Athlete{
+		AccountIdentifier: account.Identifier,
+		Slug:              "john-smith",
+		First:             "John",
+		Last:              "Smith",
+		Birthdate:         "1990-04-05",
+	}
+	out, err := db.AddAthlete(athlete)
+	if assert.NoError(t, err) {
+		assert.True(t, athlete.Equals(out))
+		assert.NotEqual(t, int64(0), out.Identifier)
+		assert.Equal(t, account.Identifier, out.AccountIdentifier)
+	}
+	// Adding an athlete with the same slug updates it.
+	athlete.Birthdate = "1990-04-06"
+	athlete.Last = "Smithe"
+	updated, err := db.AddAthlete(athlete)
+	if assert.NoError(t, err) {
+		assert.True(t, athlete.Equals(updated))
+		assert.Equal(t, out.Identifier, updated.Identifier)
+	}
+}
+
+func TestGetAthlete(t *testing.T) {
+	db, finalize, err := setupTests(t)
+	if err != nil {
+		t.Fatalf("Error setting up tests. %v", err)
+	}
+	defer finalize(t)
+	setupSeriesTests()
+	account, _ := db.AddAccount(accounts[0])
+	athlete, err := db.AddAthlete(types.Athlete{
+		AccountIdentifier: account.Identifier,
+		Slug:              "john-smith",
+		First:             "John",
+		Last:              "Smith",
+	})
+	if err != nil {
+		t.Fatalf("Error adding athlete: %v", err)
+	}
+	out, err := db.GetAthlete("john-smith")
+	if assert.NoError(t, err) {
+		assert.True(t, athlete.Equals(out))
+	}
+	out, err = db.GetAthlete("jane-smith")
+	if assert.NoError(t, err) {
+		assert.Nil(t, out)
+	}
+}
+
+func TestDeleteAthlete(t *testing.T) {
+	db, finalize, err := setupTests(t)
+	if err != nil {
+		t.Fatalf("Error setting up tests. %v", err)
+	}
+	defer finalize(t)
+	setupSeriesTests()
+	account, eventYears := setupSeriesEventYears(t, db)
+	athlete, err := db.AddAthlete(types.Athlete{
+		AccountIdentifier: account.Identifier,
+		Slug:              "john-smith",
+		First:             "John",
+		Last:              "Smith",
+	})
+	if err != nil {
+		t.Fatalf("Error adding athlete: %v", err)
+	}
+	err = db.AddAthleteLink(athlete.Identifier, eventYears[0].Identifier, "10")
+	if err != nil {
+		t.Fatalf("Error adding athlete link: %v", err)
+	}
+	err = db.DeleteAthlete(*athlete)
+	assert.NoError(t, err)
+	out, err := db.GetAthlete("john-smith")
+	if assert.NoError(t, err) {
+		assert.Nil(t, out)
+	}
+	// The links of a deleted athlete are removed with them.
+	count, err := db.DeleteAthleteLink(athlete.Identifier, eventYears[0].Identifier, "10")
+	if assert.NoError(t, err) {
+		assert.Equal(t, int64(0), count)
+	}
+}
+
+func TestAthleteLinks(t *testing.T) {
+	db, finalize, err := setupTests(t)
+	if err != nil {
+		t.Fatalf("Error setting up tests. %v", err)
+	}
+	defer finalize(t)
+	setupSeriesTests()
+	account, eventYears := setupSeriesEventYears(t, db)
+	athlete, err := db.AddAthlete(types.Athlete{
+		AccountIdentifier: account.Identifier,
+		Slug:              "john-smith",
+		First:             "John",
+		Last:              "Smith",
+	})
+	if err != nil {
+		t.Fatalf("Error adding athlete: %v", err)
+	}
+	assert.NoError(t, db.AddAthleteLink(athlete.Identifier, eventYears[0].Identifier, "10"))
+	// Adding the same link twice is ignored.
+	assert.NoError(t, db.AddAthleteLink(athlete.Identifier, eventYears[0].Identifier, "10"))
+	assert.NoError(t, db.AddAthleteLink(athlete.Identifier, eventYears[1].Identifier, "25"))
+	entries, err := db.GetAthleteEntries(*athlete)
+	if assert.NoError(t, err) {
+		assert.Equal(t, 2, len(entries))
+		for _, entry := range entries {
+			assert.True(t, entry.Claimed)
+		}
+	}
+	count, err := db.DeleteAthleteLink(athlete.Identifier, eventYears[0].Identifier, "10")
+	if assert.NoError(t, err) {
+		assert.Equal(t, int64(1), count)
+	}
+	entries, err = db.GetAthleteEntries(*athlete)
+	if assert.NoError(t, err) {
+		if assert.Equal(t, 1, len(entries)) {
+			assert.Equal(t, eventYears[1].Identifier, entries[0].EventYearIdentifier)
+			assert.Equal(t, "25", entries[0].Bib)
+			assert.Equal(t, "event2", entries[0].Slug)
+			assert.Equal(t, "2021", entries[0].Year)
+		}
+	}
+}
+
+func TestGetAthleteEntries(t *testing.T) {
+	db, finalize, err := setupTests(t)
+	if err != nil {
+		t.Fatalf("Error setting up tests. %v", err)
+	}
+	defer finalize(t)
+	setupSeriesTests()
+	account, eventYears := setupSeriesEventYears(t, db)
+	athlete, err := db.AddAthlete(types.Athlete{
+		AccountIdentifier: account.Identifier,
+		Slug:              "john-smith",
+		First:             "John",
+		Last:              "Smith",
+		Birthdate:         "1990-04-05",
+	})
+	if err != nil {
+		t.Fatalf("Error adding athlete: %v", err)
+	}
+	_, err = db.AddParticipants(eventYears[0].Identifier, []types.Participant{
+		{AlternateId: "1", Bib: "10", First: "JOHN", Last: "smith", Birthdate: "4/5/1990", Gender: "M", AgeGroup: "30-39", Distance: "10K"},
+		{AlternateId: "2", Bib: "11", First: "Jane", Last: "Smith", Birthdate: "1990/04/05", Gender: "F", AgeGroup: "30-39", Distance: "10K"},
+	})
+	if err != nil {
+		t.Fatalf("Error adding participants: %v", err)
+	}
+	_, err = db.AddParticipants(eventYears[1].Identifier, []types.Participant{
+		{AlternateId: "1", Bib: "20", First: "John", Last: "Smith", Birthdate: "1/1/1970", Gender: "M", AgeGroup: "50-59", Distance: "10K", Anonymous: true},
+	})
+	if err != nil {
+		t.Fatalf("Error adding participants: %v", err)
+	}
+	entries, err := db.GetAthleteEntries(*athlete)
+	if assert.NoError(t, err) {
+		assert.Equal(t, 2, len(entries))
+		for _, entry := range entries {
+			assert.False(t, entry.Claimed)
+			assert.Equal(t, account.Identifier, entry.AccountIdentifier)
+			switch entry.Bib {
+			case "10":
+				assert.Equal(t, eventYears[0].Identifier, entry.EventYearIdentifier)
+				assert.Equal(t, "4/5/1990", entry.Birthdate)
+				assert.False(t, entry.Anonymous)
+			case "20":
+				assert.Equal(t, eventYears[1].Identifier, entry.EventYearIdentifier)
+				assert.Equal(t, "1/1/1970", entry.Birthdate)
+				assert.True(t, entry.Anonymous)
+			default:
+				t.Errorf("Unexpected entry found: %+v", entry)
+			}
+		}
+	}
+	// Participants are only matched by name for athletes of the account that owns the event.
+	other, _ := db.AddAccount(accounts[1])
+	otherAthlete, err := db.AddAthlete(types.Athlete{
+		AccountIdentifier: other.Identifier,
+		Slug:              "john-smith-2",
+		First:             "John",
+		Last:              "Smith",
+		Birthdate:         "1990-04-05",
+	})
+	if err != nil {
+		t.Fatalf("Error adding athlete: %v", err)
+	}
+	entries, err = db.GetAthleteEntries(*otherAthlete)
+	if assert.NoError(t, err) {
+		assert.Equal(t, 0, len(entries))
+	}
+	// Deleted event years are left out.
+	err = db.DeleteEventYear(eventYears[1])
+	if err != nil {
+		t.Fatalf("Error deleting event year: %v", err)
+	}
+	entries, err = db.GetAthleteEntries(*athlete)
+	if assert.NoError(t, err) {
+		if assert.Equal(t, 1, len(entries)) {
+			assert.Equal(t, "10", entries[0].Bib)
+		}
+	}
+}
+
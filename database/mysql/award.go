@@ -0,0 +1,97 @@
+/*
+Chronokeep Desktop - Race Scoring Software
+Copyright (C) 2026 James Sentinella
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package mysql
+
+import (
+	"chronokeep/results/types"
+	"context"
+	"fmt"
+	"time"
+)
+
+// GetAwardRules Gets the award rules of an event year.  Returns nil if none have been set.
+func (m *MySQL) GetAwardRules(eventYearID int64) (*types.AwardRules, error) {
+	db, err := m.GetDB()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancelfunc := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancelfunc()
+	res, err := db.QueryContext(
+		ctx,
+		"SELECT overall_places, age_group_places, division_places, masters_ages, masters_places, double_dip "+
+			"FROM award_rules WHERE event_year_id=?;",
+		eventYearID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving award rules: %v", err)
+	}
+	defer res.Close()
+	if res.Next() {
+		var rules types.AwardRules
+		var mastersAges string
+		err := res.Scan(
+			&rules.OverallPlaces,
+			&rules.AgeGroupPlaces,
+			&rules.DivisionPlaces,
+			&mastersAges,
+			&rules.MastersPlaces,
+			&rules.DoubleDip,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("error getting award rules: %v", err)
+		}
+		if err = rules.DecodeMastersAges(mastersAges); err != nil {
+			return nil, err
+		}
+		return &rules, nil
+	}
+	return nil, nil
+}
+
+// SetAwardRules Sets the award rules of an event year, replacing any it already had.
+func (m *MySQL) SetAwardRules(eventYearID int64, rules types.AwardRules) (*types.AwardRules, error) {
+	db, err := m.GetDB()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancelfunc := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancelfunc()
+	_, err = db.ExecContext(
+		ctx,
+		"INSERT INTO award_rules(event_year_id, overall_places, age_group_places, division_places, masters_ages, masters_places, double_dip) "+
+			"VALUES (?,?,?,?,?,?,?) "+
+			"ON DUPLICATE KEY UPDATE overall_places=VALUES(overall_places), age_group_places=VALUES(age_group_places), division_places=VALUES(division_places), masters_ages=VALUES(masters_ages), masters_places=VALUES(masters_places), double_dip=VALUES(double_dip);",
+		eventYearID,
+		rules.OverallPlaces,
+		rules.AgeGroupPlaces,
+		rules.DivisionPlaces,
+		rules.EncodeMastersAges(),
+		rules.MastersPlaces,
+		rules.DoubleDip,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error setting award rules: %v", err)
+	}
+	if rules.MastersAges == nil {
+		rules.MastersAges = make([]int, 0)
+	}
+	return &rules, nil
+}
+
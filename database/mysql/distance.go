@@ -41,10 +41,14 @@ func (m *MySQL) AddDistances(eventYearID int64, distances []types.Distance) ([]t
 		"INSERT INTO distances("+
 			"event_year_id, "+
 			"distance_name, "+
-			"certification"+
-			") VALUES (?,?,?) "+
+			"certification, "+
+			"distance_value, "+
+			"distance_unit"+
+			") VALUES (?,?,?,?,?) "+
 			"ON DUPLICATE KEY UPDATE "+
-			"certification=VALUES(certification)"+
+			"certification=VALUES(certification), "+
+			"distance_value=VALUES(distance_value), "+
+			"distance_unit=VALUES(distance_unit)"+
 			";",
 	)
 	if err != nil {
@@ -56,6 +60,8 @@ func (m *MySQL) AddDistances(eventYearID int64, distances []types.Distance) ([]t
 			eventYearID,
 			dist.Name,
 			dist.Certification,
+			dist.DistanceValue,
+			dist.DistanceUnit,
 		)
 		if err != nil {
 			tx.Rollback()
@@ -72,6 +78,8 @@ func (m *MySQL) AddDistances(eventYearID int64, distances []types.Distance) ([]t
 		output = append(output, types.Distance{
 			Name:          dist.Name,
 			Certification: dist.Certification,
+			DistanceValue: dist.DistanceValue,
+			DistanceUnit:  dist.DistanceUnit,
 		})
 	}
 	return output, nil
@@ -86,7 +94,7 @@ func (m *MySQL) GetDistance(eventYearID int64, dist_name string) (*types.Distanc
 	defer cancelfunc()
 	res, err := db.QueryContext(
 		ctx,
-		"SELECT distance_id, distance_name, certification "+
+		"SELECT distance_id, distance_name, certification, distance_value, distance_unit "+
 			"FROM distances WHERE event_year_id=? AND distance_name=?;",
 		eventYearID,
 		dist_name,
@@ -101,6 +109,8 @@ func (m *MySQL) GetDistance(eventYearID int64, dist_name string) (*types.Distanc
 			&dist.Identifier,
 			&dist.Name,
 			&dist.Certification,
+			&dist.DistanceValue,
+			&dist.DistanceUnit,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("error getting distance: %v", err)
@@ -119,7 +129,7 @@ func (m *MySQL) GetDistances(eventYearID int64) ([]types.Distance, error) {
 	defer cancelfunc()
 	res, err := db.QueryContext(
 		ctx,
-		"SELECT distance_id, distance_name, certification "+
+		"SELECT distance_id, distance_name, certification, distance_value, distance_unit "+
 			"FROM distances WHERE event_year_id=?;",
 		eventYearID,
 	)
@@ -134,6 +144,8 @@ func (m *MySQL) GetDistances(eventYearID int64) ([]types.Distance, error) {
 			&dist.Identifier,
 			&dist.Name,
 			&dist.Certification,
+			&dist.DistanceValue,
+			&dist.DistanceUnit,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("error getting distance: %v", err)
@@ -45,6 +45,8 @@ func setupDistanceTests() {
 		{
 			Name:          "Marathon",
 			Certification: "USATF Certification #WA555221",
+			DistanceValue: 26.2,
+			DistanceUnit:  "miles",
 		},
 		{
 			Name:          "Half Marathon",
@@ -53,6 +55,8 @@ func setupDistanceTests() {
 		{
 			Name:          "10k",
 			Certification: "USATF Certification #WA555121",
+			DistanceValue: 10,
+			DistanceUnit:  "kilometers",
 		},
 	}
 }
@@ -92,6 +96,8 @@ func TestAddDistance(t *testing.T) {
 			for _, inner := range d {
 				if outer.Name == inner.Name {
 					assert.Equal(t, outer.Certification, inner.Certification)
+					assert.Equal(t, outer.DistanceValue, inner.DistanceValue)
+					assert.Equal(t, outer.DistanceUnit, inner.DistanceUnit)
 					assert.Equal(t, outer.Name, inner.Name)
 					found = true
 				}
@@ -107,6 +113,8 @@ func TestAddDistance(t *testing.T) {
 			for _, inner := range d {
 				if outer.Name == inner.Name {
 					assert.Equal(t, outer.Certification, inner.Certification)
+					assert.Equal(t, outer.DistanceValue, inner.DistanceValue)
+					assert.Equal(t, outer.DistanceUnit, inner.DistanceUnit)
 					assert.Equal(t, outer.Name, inner.Name)
 					found = true
 				}
@@ -130,6 +138,8 @@ func TestAddDistance(t *testing.T) {
 			for _, inner := range d {
 				if outer.Name == inner.Name {
 					assert.Equal(t, outer.Certification, inner.Certification)
+					assert.Equal(t, outer.DistanceValue, inner.DistanceValue)
+					assert.Equal(t, outer.DistanceUnit, inner.DistanceUnit)
 					assert.Equal(t, outer.Name, inner.Name)
 					found = true
 				}
@@ -145,6 +155,8 @@ func TestAddDistance(t *testing.T) {
 			for _, inner := range d {
 				if outer.Name == inner.Name {
 					assert.Equal(t, outer.Certification, inner.Certification)
+					assert.Equal(t, outer.DistanceValue, inner.DistanceValue)
+					assert.Equal(t, outer.DistanceUnit, inner.DistanceUnit)
 					assert.Equal(t, outer.Name, inner.Name)
 					found = true
 				}
@@ -231,6 +243,8 @@ func TestGetDistances(t *testing.T) {
 			for _, inner := range d {
 				if outer.Name == inner.Name {
 					assert.Equal(t, outer.Certification, inner.Certification)
+					assert.Equal(t, outer.DistanceValue, inner.DistanceValue)
+					assert.Equal(t, outer.DistanceUnit, inner.DistanceUnit)
 					assert.Equal(t, outer.Name, inner.Name)
 					found = true
 				}
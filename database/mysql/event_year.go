@@ -71,7 +71,7 @@ func (m *MySQL) GetEventYear(event_slug, year string) (*types.EventYear, error)
 	defer cancelfunc()
 	res, err := db.QueryContext(
 		ctx,
-		"SELECT event_year_id, event_id, year, date_time, live, days_allowed, ranking_type FROM event_year NATURAL JOIN event WHERE slug=? AND year=? AND year_deleted=FALSE;",
+		"SELECT event_year_id, event_id, year, date_time, live, days_allowed, ranking_type, year_status FROM event_year NATURAL JOIN event WHERE slug=? AND year=? AND year_deleted=FALSE;",
 		event_slug,
 		year,
 	)
@@ -89,6 +89,7 @@ func (m *MySQL) GetEventYear(event_slug, year string) (*types.EventYear, error)
 			&outEventYear.Live,
 			&outEventYear.DaysAllowed,
 			&outEventYear.RankingType,
+			&outEventYear.Status,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("error getting event year: %v", err)
@@ -109,7 +110,7 @@ func (m *MySQL) GetEventYears(event_slug string) ([]types.EventYear, error) {
 	defer cancelfunc()
 	res, err := db.QueryContext(
 		ctx,
-		"SELECT event_year_id, event_id, year, date_time, live, days_allowed, ranking_type FROM event_year NATURAL JOIN event WHERE slug=? AND year_deleted=FALSE;",
+		"SELECT event_year_id, event_id, year, date_time, live, days_allowed, ranking_type, year_status FROM event_year NATURAL JOIN event WHERE slug=? AND year_deleted=FALSE;",
 		event_slug,
 	)
 	if err != nil {
@@ -127,6 +128,7 @@ func (m *MySQL) GetEventYears(event_slug string) ([]types.EventYear, error) {
 			&year.Live,
 			&year.DaysAllowed,
 			&year.RankingType,
+			&year.Status,
 		)
 		if err != nil {
 			return nil, nil
@@ -146,7 +148,7 @@ func (m *MySQL) GetAllEventYears() ([]types.EventYear, error) {
 	defer cancelfunc()
 	res, err := db.QueryContext(
 		ctx,
-		"SELECT event_year_id, event_id, year, date_time, live, days_allowed, ranking_type FROM event_year NATURAL JOIN event WHERE year_deleted=FALSE;",
+		"SELECT event_year_id, event_id, year, date_time, live, days_allowed, ranking_type, year_status FROM event_year NATURAL JOIN event WHERE year_deleted=FALSE;",
 	)
 	if err != nil {
 		return nil, fmt.Errorf("error retrieving event years: %v", err)
@@ -163,6 +165,7 @@ func (m *MySQL) GetAllEventYears() ([]types.EventYear, error) {
 			&year.Live,
 			&year.DaysAllowed,
 			&year.RankingType,
+			&year.Status,
 		)
 		if err != nil {
 			return nil, nil
@@ -216,13 +219,14 @@ func (m *MySQL) AddEventYear(year types.EventYear) (*types.EventYear, error) {
 	defer cancelfunc()
 	res, err := db.ExecContext(
 		ctx,
-		"INSERT INTO event_year(event_id, year, date_time, live, days_allowed, ranking_type) VALUES (?, ?, ?, ?, ?, ?);",
+		"INSERT INTO event_year(event_id, year, date_time, live, days_allowed, ranking_type, year_status) VALUES (?, ?, ?, ?, ?, ?, ?);",
 		year.EventIdentifier,
 		year.Year,
 		year.DateTime,
 		year.Live,
 		year.DaysAllowed,
 		year.RankingType,
+		year.GetStatus(),
 	)
 	if err != nil {
 		return nil, fmt.Errorf("unable to add event year: %v", err)
@@ -239,6 +243,7 @@ func (m *MySQL) AddEventYear(year types.EventYear) (*types.EventYear, error) {
 		Live:            year.Live,
 		DaysAllowed:     year.DaysAllowed,
 		RankingType:     year.RankingType,
+		Status:          year.GetStatus(),
 	}, nil
 }
 
@@ -272,11 +277,12 @@ func (m *MySQL) UpdateEventYear(year types.EventYear) error {
 	defer cancelfunc()
 	_, err = db.ExecContext(
 		ctx,
-		"UPDATE event_year SET date_time=?, live=?, days_allowed=?, ranking_type=? WHERE event_year_id=?",
+		"UPDATE event_year SET date_time=?, live=?, days_allowed=?, ranking_type=?, year_status=? WHERE event_year_id=?",
 		year.DateTime,
 		year.Live,
 		year.DaysAllowed,
 		year.RankingType,
+		year.GetStatus(),
 		year.Identifier,
 	)
 	if err != nil {
@@ -0,0 +1,139 @@
+/*
+Chronokeep Desktop - Race Scoring Software
+Copyright (C) 2026 James Sentinella
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package mysql
+
+import (
+	"chronokeep/results/types"
+	"context"
+	"fmt"
+	"time"
+)
+
+// GetHandicapScoring Gets the handicap scoring of an event year.  Returns nil if it hasn't been set.
+func (m *MySQL) GetHandicapScoring(eventYearID int64) (*types.HandicapScoring, error) {
+	db, err := m.GetDB()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancelfunc := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancelfunc()
+	res, err := db.QueryContext(
+		ctx,
+		"SELECT enabled FROM handicap_scoring WHERE event_year_id=?;",
+		eventYearID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving handicap scoring: %v", err)
+	}
+	defer res.Close()
+	if !res.Next() {
+		return nil, nil
+	}
+	scoring := types.HandicapScoring{
+		Handicaps: make([]types.Handicap, 0),
+	}
+	err = res.Scan(&scoring.Enabled)
+	if err != nil {
+		return nil, fmt.Errorf("error getting handicap scoring: %v", err)
+	}
+	res.Close()
+	res, err = db.QueryContext(
+		ctx,
+		"SELECT bib, seconds, milliseconds FROM handicaps WHERE event_year_id=? "+
+			"ORDER BY seconds ASC, milliseconds ASC, bib ASC;",
+		eventYearID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving handicaps: %v", err)
+	}
+	defer res.Close()
+	for res.Next() {
+		var handicap types.Handicap
+		err := res.Scan(
+			&handicap.Bib,
+			&handicap.Seconds,
+			&handicap.Milliseconds,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("error getting handicap: %v", err)
+		}
+		scoring.Handicaps = append(scoring.Handicaps, handicap)
+	}
+	return &scoring, nil
+}
+
+// SetHandicapScoring Replaces the handicap scoring of an event year.
+func (m *MySQL) SetHandicapScoring(eventYearID int64, scoring types.HandicapScoring) (*types.HandicapScoring, error) {
+	db, err := m.GetDB()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancelfunc := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancelfunc()
+	tx, err := db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("unable to start transaction: %v", err)
+	}
+	_, err = tx.ExecContext(
+		ctx,
+		"INSERT INTO handicap_scoring(event_year_id, enabled) VALUES (?,?) "+
+			"ON DUPLICATE KEY UPDATE enabled=VALUES(enabled);",
+		eventYearID,
+		scoring.Enabled,
+	)
+	if err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("error adding handicap scoring to database: %v", err)
+	}
+	_, err = tx.ExecContext(
+		ctx,
+		"DELETE FROM handicaps WHERE event_year_id=?;",
+		eventYearID,
+	)
+	if err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("error deleting old handicaps: %v", err)
+	}
+	seen := make(map[string]bool)
+	for _, handicap := range scoring.Handicaps {
+		if seen[handicap.Bib] {
+			continue
+		}
+		seen[handicap.Bib] = true
+		_, err = tx.ExecContext(
+			ctx,
+			"INSERT INTO handicaps(event_year_id, bib, seconds, milliseconds) VALUES (?,?,?,?);",
+			eventYearID,
+			handicap.Bib,
+			handicap.Seconds,
+			handicap.Milliseconds,
+		)
+		if err != nil {
+			tx.Rollback()
+			return nil, fmt.Errorf("error adding handicap to database: %v", err)
+		}
+	}
+	err = tx.Commit()
+	if err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("error committing transaction: %v", err)
+	}
+	return m.GetHandicapScoring(eventYearID)
+}
+
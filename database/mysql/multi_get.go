@@ -90,7 +90,7 @@ func (m *MySQL) GetAccountEventAndYear(slug, year string) (*types.MultiGet, erro
 			"SELECT "+
 				"account_id, account_name, account_email, account_type, account_locked, "+
 				"event_id, event_name, slug, website, image, contact_email, access_restricted, event_type, "+
-				"event_year_id, year, date_time, live, days_allowed, cert_name, ranking_type "+
+				"event_year_id, year, date_time, live, days_allowed, cert_name, ranking_type, year_status "+
 				"FROM account NATURAL JOIN event NATURAL JOIN event_year y INNER JOIN "+
 				"(SELECT event_id AS e_id, MAX(date_time) AS d_time FROM event_year WHERE year_deleted=FALSE GROUP BY e_id) AS g ON g.e_id=y.event_id AND g.d_time=y.date_time "+
 				"WHERE account_deleted=FALSE AND event_deleted=FALSE AND year_deleted=FALSE AND slug=?",
@@ -102,7 +102,7 @@ func (m *MySQL) GetAccountEventAndYear(slug, year string) (*types.MultiGet, erro
 			"SELECT "+
 				"account_id, account_name, account_email, account_type, account_locked, "+
 				"event_id, event_name, slug, website, image, contact_email, access_restricted, event_type, "+
-				"event_year_id, year, date_time, live, days_allowed, cert_name, ranking_type "+
+				"event_year_id, year, date_time, live, days_allowed, cert_name, ranking_type, year_status "+
 				"FROM account NATURAL JOIN event NATURAL JOIN event_year WHERE account_deleted=FALSE AND event_deleted=FALSE AND year_deleted=FALSE AND slug=? AND year=?",
 			slug,
 			year,
@@ -138,6 +138,7 @@ func (m *MySQL) GetAccountEventAndYear(slug, year string) (*types.MultiGet, erro
 			&outVal.EventYear.DaysAllowed,
 			&outVal.Event.CertificateName,
 			&outVal.EventYear.RankingType,
+			&outVal.EventYear.Status,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("error getting values for account and event: %v", err)
@@ -164,7 +165,7 @@ func (m *MySQL) GetEventAndYear(slug, year string) (*types.MultiGet, error) {
 			ctx,
 			"SELECT "+
 				"account_id, event_id, event_name, slug, website, image, contact_email, access_restricted, event_type, "+
-				"event_year_id, year, date_time, live, days_allowed, cert_name, ranking_type "+
+				"event_year_id, year, date_time, live, days_allowed, cert_name, ranking_type, year_status "+
 				"FROM event NATURAL JOIN event_year y INNER JOIN "+
 				"(SELECT event_id AS e_id, MAX(date_time) AS d_time FROM event_year WHERE year_deleted=FALSE GROUP BY e_id) AS g ON g.e_id=y.event_id AND g.d_time=y.date_time "+
 				" WHERE event_deleted=FALSE AND year_deleted=FALSE AND slug=?",
@@ -189,7 +190,7 @@ func (m *MySQL) GetEventAndYear(slug, year string) (*types.MultiGet, error) {
 			ctx,
 			"SELECT "+
 				"account_id, event_id, event_name, slug, website, image, contact_email, access_restricted, event_type, "+
-				"event_year_id, year, date_time, live, days_allowed, cert_name, ranking_type "+
+				"event_year_id, year, date_time, live, days_allowed, cert_name, ranking_type, year_status "+
 				"FROM event NATURAL JOIN event_year WHERE event_deleted=FALSE AND year_deleted=FALSE AND slug=? AND year=?",
 			slug,
 			year,
@@ -242,6 +243,7 @@ func (m *MySQL) GetEventAndYear(slug, year string) (*types.MultiGet, error) {
 			&outVal.EventYear.DaysAllowed,
 			&outVal.Event.CertificateName,
 			&outVal.EventYear.RankingType,
+			&outVal.EventYear.Status,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("error getting values for account and event: %v", err)
@@ -264,7 +266,7 @@ func (m *MySQL) GetKeyAndAccount(key string) (*types.MultiKey, error) {
 		ctx,
 		"SELECT "+
 			"account_id, account_name, account_email, account_type, account_locked, "+
-			"key_value, key_type, allowed_hosts, valid_until "+
+			"key_name, key_value, key_type, allowed_hosts, valid_until "+
 			"FROM account NATURAL JOIN api_key WHERE account_deleted=FALSE AND key_deleted=FALSE AND key_value=?",
 		key,
 	)
@@ -282,6 +284,7 @@ func (m *MySQL) GetKeyAndAccount(key string) (*types.MultiKey, error) {
 			&outVal.Account.Email,
 			&outVal.Account.Type,
 			&outVal.Account.Locked,
+			&outVal.Key.Name,
 			&outVal.Key.Value,
 			&outVal.Key.Type,
 			&outVal.Key.AllowedHosts,
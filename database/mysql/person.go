@@ -19,6 +19,7 @@ along with this program.  If not, see <https://www.gnu.org/licenses/>.
 package mysql
 
 import (
+	"chronokeep/results/database"
 	"chronokeep/results/types"
 	"context"
 	"fmt"
@@ -111,7 +112,7 @@ func (m *MySQL) GetPeople(slug, year string) ([]types.Person, error) {
 	return output, nil
 }
 
-func (m *MySQL) AddPerson(eventYearID int64, person types.Person) (*types.Person, error) {
+func (m *MySQL) AddPerson(eventYearID int64, person types.Person, actor *types.AuditActor) (*types.Person, error) {
 	db, err := m.GetDB()
 	if err != nil {
 		return nil, err
@@ -122,6 +123,12 @@ func (m *MySQL) AddPerson(eventYearID int64, person types.Person) (*types.Person
 	if err != nil {
 		return nil, fmt.Errorf("unable to start transaction: %v", err)
 	}
+	scope := database.AuditScope{AlternateIds: []string{person.AlternateId}}
+	auditPeople, auditResults, err := getAuditState(ctx, tx, eventYearID, scope)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
 	_, err = tx.ExecContext(
 		ctx,
 		"INSERT INTO person("+
@@ -191,6 +198,11 @@ func (m *MySQL) AddPerson(eventYearID int64, person types.Person) (*types.Person
 		tx.Rollback()
 		return nil, fmt.Errorf("person not found after add: %v", err)
 	}
+	err = addAuditEntries(ctx, tx, eventYearID, scope, actor, auditPeople, auditResults)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
 	err = tx.Commit()
 	if err != nil {
 		tx.Rollback()
@@ -199,7 +211,7 @@ func (m *MySQL) AddPerson(eventYearID int64, person types.Person) (*types.Person
 	return &output, nil
 }
 
-func (m *MySQL) AddPeople(eventYearID int64, people []types.Person) ([]types.Person, error) {
+func (m *MySQL) AddPeople(eventYearID int64, people []types.Person, actor *types.AuditActor) ([]types.Person, error) {
 	db, err := m.GetDB()
 	if err != nil {
 		return nil, err
@@ -238,6 +250,12 @@ func (m *MySQL) AddPeople(eventYearID int64, people []types.Person) ([]types.Per
 	if err != nil {
 		return nil, fmt.Errorf("error preparing statement for adding people: %v", err)
 	}
+	scope := database.PeopleAuditScope(people)
+	auditPeople, auditResults, err := getAuditState(ctx, tx, eventYearID, scope)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
 	for _, person := range people {
 		_, err = personstmt.ExecContext(
 			ctx,
@@ -300,6 +318,11 @@ func (m *MySQL) AddPeople(eventYearID int64, people []types.Person) ([]types.Per
 			return nil, fmt.Errorf("person not found after add: %v", err)
 		}
 	}
+	err = addAuditEntries(ctx, tx, eventYearID, scope, actor, auditPeople, auditResults)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
 	err = tx.Commit()
 	if err != nil {
 		tx.Rollback()
@@ -308,7 +331,7 @@ func (m *MySQL) AddPeople(eventYearID int64, people []types.Person) ([]types.Per
 	return output, nil
 }
 
-func (m *MySQL) DeletePeople(eventYearId int64, alternateIds []string) (int64, error) {
+func (m *MySQL) DeletePeople(eventYearId int64, alternateIds []string, actor *types.AuditActor) (int64, error) {
 	db, err := m.GetDB()
 	if err != nil {
 		return 0, err
@@ -319,6 +342,12 @@ func (m *MySQL) DeletePeople(eventYearId int64, alternateIds []string) (int64, e
 	}
 	ctx, cancelfunc := context.WithTimeout(context.Background(), time.Second*5)
 	defer cancelfunc()
+	scope := database.AuditScope{AlternateIds: alternateIds}
+	auditPeople, auditResults, err := getAuditState(ctx, tx, eventYearId, scope)
+	if err != nil {
+		tx.Rollback()
+		return 0, err
+	}
 	var count int64
 	if len(alternateIds) > 0 {
 		count = 0
@@ -351,6 +380,11 @@ func (m *MySQL) DeletePeople(eventYearId int64, alternateIds []string) (int64, e
 			return 0, fmt.Errorf("error fetching rows affected from person deletion: %v", err)
 		}
 	}
+	err = addAuditEntries(ctx, tx, eventYearId, scope, actor, auditPeople, auditResults)
+	if err != nil {
+		tx.Rollback()
+		return 0, err
+	}
 	err = tx.Commit()
 	if err != nil {
 		tx.Rollback()
@@ -359,7 +393,7 @@ func (m *MySQL) DeletePeople(eventYearId int64, alternateIds []string) (int64, e
 	return count, nil
 }
 
-func (m *MySQL) UpdatePerson(eventYearID int64, person types.Person) (*types.Person, error) {
+func (m *MySQL) UpdatePerson(eventYearID int64, person types.Person, actor *types.AuditActor) (*types.Person, error) {
 	db, err := m.GetDB()
 	if err != nil {
 		return nil, err
@@ -370,6 +404,12 @@ func (m *MySQL) UpdatePerson(eventYearID int64, person types.Person) (*types.Per
 	if err != nil {
 		return nil, fmt.Errorf("unable to start transaction: %v", err)
 	}
+	scope := database.AuditScope{AlternateIds: []string{person.AlternateId}}
+	auditPeople, auditResults, err := getAuditState(ctx, tx, eventYearID, scope)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
 	_, err = tx.ExecContext(
 		ctx,
 		"UPDATE person SET "+
@@ -427,6 +467,11 @@ func (m *MySQL) UpdatePerson(eventYearID int64, person types.Person) (*types.Per
 		tx.Rollback()
 		return nil, fmt.Errorf("person not found after add: %v", err)
 	}
+	err = addAuditEntries(ctx, tx, eventYearID, scope, actor, auditPeople, auditResults)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
 	err = tx.Commit()
 	if err != nil {
 		tx.Rollback()
@@ -127,7 +127,7 @@ func TestGetPerson(t *testing.T) {
 	if person != nil {
 		t.Errorf("Found someone when no one should exist: %v", person)
 	}
-	db.AddPeople(eventYear.Identifier, people)
+	db.AddPeople(eventYear.Identifier, people, nil)
 	for _, p := range people {
 		person, err = db.GetPerson(event.Slug, eventYear.Year, p.Bib)
 		if err != nil {
@@ -185,7 +185,7 @@ func TestGetPeople(t *testing.T) {
 	if assert.NoError(t, err) {
 		assert.Equal(t, 0, len(iPeople))
 	}
-	db.AddPeople(eventYear.Identifier, people)
+	db.AddPeople(eventYear.Identifier, people, nil)
 	iPeople, err = db.GetPeople(event.Slug, eventYear.Year)
 	if assert.NoError(t, err) {
 		assert.Equal(t, len(people), len(iPeople))
@@ -234,7 +234,7 @@ func TestAddPerson(t *testing.T) {
 		RankingType:     "chip",
 	}
 	eventYear, _ = db.AddEventYear(*eventYear)
-	person, err := db.AddPerson(eventYear.Identifier, people[0])
+	person, err := db.AddPerson(eventYear.Identifier, people[0], nil)
 	if assert.NoError(t, err) {
 		assert.True(t, people[0].Equals(person))
 		assert.Equal(t, people[0].Age, person.Age)
@@ -270,7 +270,7 @@ func TestAddPerson(t *testing.T) {
 	temp.Last = "Test"
 	temp.Distance = "12 Mile Fun"
 	temp.Gender = "U"
-	person, err = db.AddPerson(eventYear.Identifier, temp)
+	person, err = db.AddPerson(eventYear.Identifier, temp, nil)
 	if assert.NoError(t, err) {
 		assert.True(t, temp.Equals(person))
 		assert.Equal(t, temp.Age, person.Age)
@@ -292,7 +292,7 @@ func TestAddPerson(t *testing.T) {
 	temp.Distance = "12 Mile Fun"
 	temp.Gender = "NB"
 	temp.Anonymous = true
-	person, err = db.AddPerson(eventYear.Identifier, temp)
+	person, err = db.AddPerson(eventYear.Identifier, temp, nil)
 	if assert.NoError(t, err) && assert.NotNil(t, person) {
 		assert.True(t, temp.Equals(person))
 		assert.Equal(t, temp.Age, person.Age)
@@ -334,7 +334,7 @@ func TestAddPeople(t *testing.T) {
 	if assert.NoError(t, err) {
 		assert.Equal(t, 0, len(p))
 	}
-	p, err = db.AddPeople(eventYear.Identifier, people)
+	p, err = db.AddPeople(eventYear.Identifier, people, nil)
 	if assert.NoError(t, err) {
 		assert.Equal(t, len(people), len(p))
 		for _, outer := range people {
@@ -376,7 +376,7 @@ func TestAddPeople(t *testing.T) {
 			Anonymous:   true,
 		})
 	}
-	p, err = db.AddPeople(eventYear.Identifier, upd)
+	p, err = db.AddPeople(eventYear.Identifier, upd, nil)
 	if assert.NoError(t, err) {
 		assert.Equal(t, len(people), len(p))
 		for _, outer := range people {
@@ -427,20 +427,20 @@ func TestDeletePeople(t *testing.T) {
 	if assert.NoError(t, err) {
 		assert.Equal(t, 0, len(p))
 	}
-	_, err = db.AddPeople(eventYear.Identifier, people)
+	_, err = db.AddPeople(eventYear.Identifier, people, nil)
 	assert.NoError(t, err)
 	p, err = db.GetPeople(event.Slug, eventYear.Year)
 	if assert.NoError(t, err) {
 		assert.Equal(t, len(people), len(p))
 	}
-	count, err := db.DeletePeople(eventYear.Identifier, nil)
+	count, err := db.DeletePeople(eventYear.Identifier, nil, nil)
 	assert.NoError(t, err)
 	p, err = db.GetPeople(event.Slug, eventYear.Year)
 	if assert.NoError(t, err) {
 		assert.Equal(t, 0, len(p))
 	}
 	assert.Equal(t, count, int64(len(people)))
-	_, err = db.AddPeople(eventYear.Identifier, people)
+	_, err = db.AddPeople(eventYear.Identifier, people, nil)
 	assert.NoError(t, err)
 	p, err = db.GetPeople(event.Slug, eventYear.Year)
 	if assert.NoError(t, err) {
@@ -450,7 +450,7 @@ func TestDeletePeople(t *testing.T) {
 		people[0].AlternateId,
 		people[1].AlternateId,
 	}
-	count, err = db.DeletePeople(eventYear.Identifier, toDelete)
+	count, err = db.DeletePeople(eventYear.Identifier, toDelete, nil)
 	assert.NoError(t, err)
 	assert.Equal(t, count, int64(len(toDelete)))
 	p, err = db.GetPeople(event.Slug, eventYear.Year)
@@ -487,15 +487,15 @@ func TestBadDatabasePerson(t *testing.T) {
 	if err == nil {
 		t.Fatalf("Expected error getting people")
 	}
-	_, err = db.AddPerson(0, types.Person{})
+	_, err = db.AddPerson(0, types.Person{}, nil)
 	if err == nil {
 		t.Fatalf("Expected error adding person")
 	}
-	_, err = db.AddPeople(0, nil)
+	_, err = db.AddPeople(0, nil, nil)
 	if err == nil {
 		t.Fatalf("Expected error adding people")
 	}
-	_, err = db.DeletePeople(0, nil)
+	_, err = db.DeletePeople(0, nil, nil)
 	if err == nil {
 		t.Fatalf("Expected error deleting people")
 	}
@@ -511,15 +511,15 @@ func TestNoDatabasePerson(t *testing.T) {
 	if err == nil {
 		t.Fatalf("Expected error getting people")
 	}
-	_, err = db.AddPerson(0, types.Person{})
+	_, err = db.AddPerson(0, types.Person{}, nil)
 	if err == nil {
 		t.Fatalf("Expected error adding person")
 	}
-	_, err = db.AddPeople(0, nil)
+	_, err = db.AddPeople(0, nil, nil)
 	if err == nil {
 		t.Fatalf("Expected error adding people")
 	}
-	_, err = db.DeletePeople(0, nil)
+	_, err = db.DeletePeople(0, nil, nil)
 	if err == nil {
 		t.Fatalf("Expected error deleting people")
 	}
@@ -548,7 +548,7 @@ func TestUpdatePerson(t *testing.T) {
 		RankingType:     "chip",
 	}
 	eventYear, _ = db.AddEventYear(*eventYear)
-	person, _ := db.AddPerson(eventYear.Identifier, people[0])
+	person, _ := db.AddPerson(eventYear.Identifier, people[0], nil)
 	assert.NotNil(t, person)
 	id := person.Identifier
 	// test update
@@ -561,7 +561,7 @@ func TestUpdatePerson(t *testing.T) {
 	temp.Distance = "12 Mile Fun"
 	temp.Gender = "U"
 	temp.Anonymous = !people[0].Anonymous
-	person, err = db.UpdatePerson(eventYear.Identifier, temp)
+	person, err = db.UpdatePerson(eventYear.Identifier, temp, nil)
 	if assert.NoError(t, err) {
 		assert.True(t, temp.Equals(person))
 		assert.Equal(t, temp.Age, person.Age)
@@ -578,7 +578,7 @@ func TestUpdatePerson(t *testing.T) {
 	// test invalid update
 	temp = people[1]
 	temp.Bib = "newbib"
-	person, err = db.UpdatePerson(eventYear.Identifier, temp)
+	person, err = db.UpdatePerson(eventYear.Identifier, temp, nil)
 	assert.Error(t, err)
 	assert.Nil(t, person)
 }
@@ -19,7 +19,9 @@ along with this program.  If not, see <https://www.gnu.org/licenses/>.
 package mysql
 
 import (
+	"chronokeep/results/database"
 	"chronokeep/results/types"
+	"chronokeep/results/util"
 	"context"
 	"database/sql"
 	"fmt"
@@ -34,6 +36,61 @@ const (
 	Last
 )
 
+const (
+	// Results that didn't finish, didn't start or were disqualified are listed after everyone else.
+	resultOrder = "CASE result_status WHEN 'dnf' THEN 1 WHEN 'dq' THEN 2 WHEN 'dns' THEN 3 ELSE 0 END ASC, seconds ASC"
+	// The columns scanned by scanResults.
+	resultColumns = "bib, first, last, age, gender, age_group, distance, seconds, milliseconds, " +
+		"chip_seconds, chip_milliseconds, segment, location, occurence, ranking, age_ranking, " +
+		"gender_ranking, finish, result_type, anonymous, alternate_id, local_time, division, " +
+		"division_ranking, result_status, status_reason"
+	// A bib that didn't finish, didn't start or was disqualified shows that as their last result.
+	lastResultKey = "(CASE WHEN result_status IN ('dnf', 'dns', 'dq') THEN 1 ELSE 0 END)*100000000+seconds"
+)
+
+// scanResults Reads every result from rows selecting resultColumns.
+func scanResults(res *sql.Rows) ([]types.Result, error) {
+	var outResults []types.Result
+	for res.Next() {
+		var result types.Result
+		var anonymous int
+		err := res.Scan(
+			&result.Bib,
+			&result.First,
+			&result.Last,
+			&result.Age,
+			&result.Gender,
+			&result.AgeGroup,
+			&result.Distance,
+			&result.Seconds,
+			&result.Milliseconds,
+			&result.ChipSeconds,
+			&result.ChipMilliseconds,
+			&result.Segment,
+			&result.Location,
+			&result.Occurence,
+			&result.Ranking,
+			&result.AgeRanking,
+			&result.GenderRanking,
+			&result.Finish,
+			&result.Type,
+			&anonymous,
+			&result.PersonId,
+			&result.LocalTime,
+			&result.Division,
+			&result.DivisionRanking,
+			&result.Status,
+			&result.StatusReason,
+		)
+		result.Anonymous = anonymous != 0
+		if err != nil {
+			return nil, fmt.Errorf("error getting result: %v", err)
+		}
+		outResults = append(outResults, result)
+	}
+	return outResults, nil
+}
+
 func (m *MySQL) getResultsInternal(eventYearID int64, bib *string, rtype ResultType, distance string, limit, page int) ([]types.Result, error) {
 	db, err := m.GetDB()
 	if err != nil {
@@ -48,11 +105,8 @@ func (m *MySQL) getResultsInternal(eventYearID int64, bib *string, rtype ResultT
 		if limit > 0 {
 			res, err = db.QueryContext(
 				ctx,
-				"SELECT bib, first, last, age, gender, age_group, distance, seconds, milliseconds, "+
-					"chip_seconds, chip_milliseconds, segment, location, occurence, ranking, age_ranking, "+
-					"gender_ranking, finish, result_type, anonymous, alternate_id, local_time, division, "+
-					"division_ranking FROM result NATURAL JOIN person "+
-					"WHERE event_year_id=? AND bib=? ORDER BY seconds ASC LIMIT ? OFFSET ?;",
+				"SELECT "+resultColumns+" FROM result NATURAL JOIN person "+
+					"WHERE event_year_id=? AND bib=? ORDER BY "+resultOrder+" LIMIT ? OFFSET ?;",
 				eventYearID,
 				bib,
 				limit,
@@ -61,11 +115,8 @@ func (m *MySQL) getResultsInternal(eventYearID int64, bib *string, rtype ResultT
 		} else {
 			res, err = db.QueryContext(
 				ctx,
-				"SELECT bib, first, last, age, gender, age_group, distance, seconds, milliseconds, "+
-					"chip_seconds, chip_milliseconds, segment, location, occurence, ranking, age_ranking, "+
-					"gender_ranking, finish, result_type, anonymous, alternate_id, local_time, division, "+
-					"division_ranking FROM result NATURAL JOIN person "+
-					"WHERE event_year_id=? AND bib=? ORDER BY seconds ASC;",
+				"SELECT "+resultColumns+" FROM result NATURAL JOIN person "+
+					"WHERE event_year_id=? AND bib=? ORDER BY "+resultOrder+";",
 				eventYearID,
 				bib,
 			)
@@ -75,11 +126,8 @@ func (m *MySQL) getResultsInternal(eventYearID int64, bib *string, rtype ResultT
 			if limit > 0 {
 				res, err = db.QueryContext(
 					ctx,
-					"SELECT bib, first, last, age, gender, age_group, distance, seconds, milliseconds, "+
-						"chip_seconds, chip_milliseconds, segment, location, occurence, ranking, age_ranking, "+
-						"gender_ranking, finish, result_type, anonymous, alternate_id, local_time, division, "+
-						"division_ranking FROM result NATURAL JOIN person "+
-						"WHERE finish=TRUE AND event_year_id=? AND distance=? ORDER BY seconds ASC LIMIT ? OFFSET ?;",
+					"SELECT "+resultColumns+" FROM result NATURAL JOIN person "+
+						"WHERE finish=TRUE AND event_year_id=? AND distance=? ORDER BY "+resultOrder+" LIMIT ? OFFSET ?;",
 					eventYearID,
 					distance,
 					limit,
@@ -88,11 +136,8 @@ func (m *MySQL) getResultsInternal(eventYearID int64, bib *string, rtype ResultT
 			} else {
 				res, err = db.QueryContext(
 					ctx,
-					"SELECT bib, first, last, age, gender, age_group, distance, seconds, milliseconds, "+
-						"chip_seconds, chip_milliseconds, segment, location, occurence, ranking, age_ranking, "+
-						"gender_ranking, finish, result_type, anonymous, alternate_id, local_time, division, "+
-						"division_ranking FROM result NATURAL JOIN person "+
-						"WHERE finish=TRUE AND event_year_id=? AND distance=? ORDER BY seconds ASC;",
+					"SELECT "+resultColumns+" FROM result NATURAL JOIN person "+
+						"WHERE finish=TRUE AND event_year_id=? AND distance=? ORDER BY "+resultOrder+";",
 					eventYearID,
 					distance,
 				)
@@ -101,11 +146,8 @@ func (m *MySQL) getResultsInternal(eventYearID int64, bib *string, rtype ResultT
 			if limit > 0 {
 				res, err = db.QueryContext(
 					ctx,
-					"SELECT bib, first, last, age, gender, age_group, distance, seconds, milliseconds, "+
-						"chip_seconds, chip_milliseconds, segment, location, occurence, ranking, age_ranking, "+
-						"gender_ranking, finish, result_type, anonymous, alternate_id, local_time, division, "+
-						"division_ranking FROM result NATURAL JOIN person "+
-						"WHERE event_year_id=? AND distance=? ORDER BY seconds ASC LIMIT ? OFFSET ?;",
+					"SELECT "+resultColumns+" FROM result NATURAL JOIN person "+
+						"WHERE event_year_id=? AND distance=? ORDER BY "+resultOrder+" LIMIT ? OFFSET ?;",
 					eventYearID,
 					distance,
 					limit,
@@ -114,11 +156,8 @@ func (m *MySQL) getResultsInternal(eventYearID int64, bib *string, rtype ResultT
 			} else {
 				res, err = db.QueryContext(
 					ctx,
-					"SELECT bib, first, last, age, gender, age_group, distance, seconds, milliseconds, "+
-						"chip_seconds, chip_milliseconds, segment, location, occurence, ranking, age_ranking, "+
-						"gender_ranking, finish, result_type, anonymous, alternate_id, local_time, division, "+
-						"division_ranking FROM result NATURAL JOIN person "+
-						"WHERE event_year_id=? AND distance=? ORDER BY seconds ASC;",
+					"SELECT "+resultColumns+" FROM result NATURAL JOIN person "+
+						"WHERE event_year_id=? AND distance=? ORDER BY "+resultOrder+";",
 					eventYearID,
 					distance,
 				)
@@ -127,15 +166,12 @@ func (m *MySQL) getResultsInternal(eventYearID int64, bib *string, rtype ResultT
 			if limit > 0 {
 				res, err = db.QueryContext(
 					ctx,
-					"SELECT bib, first, last, age, gender, age_group, distance, seconds, milliseconds, "+
-						"chip_seconds, chip_milliseconds, segment, location, occurence, ranking, age_ranking, "+
-						"gender_ranking, finish, result_type, anonymous, alternate_id, local_time, division, "+
-						"division_ranking FROM result r NATURAL JOIN person p "+
-						"JOIN (SELECT bib AS mx_bib, event_year_id AS mx_event_year_id, MAX(seconds) as mx_seconds "+
+					"SELECT "+resultColumns+" FROM result r NATURAL JOIN person p "+
+						"JOIN (SELECT bib AS mx_bib, event_year_id AS mx_event_year_id, MAX("+lastResultKey+") as mx_key "+
 						"FROM result NATURAL JOIN person GROUP BY bib, event_year_id, segment) b "+
 						"ON b.mx_bib=p.bib AND b.mx_event_year_id=p.event_year_id "+
-						"AND b.mx_seconds=r.seconds "+
-						"WHERE event_year_id=? AND distance=? ORDER BY seconds ASC LIMIT ? OFFSET ?;",
+						"AND b.mx_key="+lastResultKey+" "+
+						"WHERE event_year_id=? AND distance=? ORDER BY "+resultOrder+" LIMIT ? OFFSET ?;",
 					eventYearID,
 					distance,
 					limit,
@@ -144,15 +180,12 @@ func (m *MySQL) getResultsInternal(eventYearID int64, bib *string, rtype ResultT
 			} else {
 				res, err = db.QueryContext(
 					ctx,
-					"SELECT bib, first, last, age, gender, age_group, distance, seconds, milliseconds, "+
-						"chip_seconds, chip_milliseconds, segment, location, occurence, ranking, age_ranking, "+
-						"gender_ranking, finish, result_type, anonymous, alternate_id, local_time, division, "+
-						"division_ranking FROM result r NATURAL JOIN person p "+
-						"JOIN (SELECT bib AS mx_bib, event_year_id AS mx_event_year_id, MAX(seconds) as mx_seconds "+
+					"SELECT "+resultColumns+" FROM result r NATURAL JOIN person p "+
+						"JOIN (SELECT bib AS mx_bib, event_year_id AS mx_event_year_id, MAX("+lastResultKey+") as mx_key "+
 						"FROM result NATURAL JOIN person GROUP BY bib, event_year_id, segment) b "+
 						"ON b.mx_bib=p.bib AND b.mx_event_year_id=p.event_year_id "+
-						"AND b.mx_seconds=r.seconds "+
-						"WHERE event_year_id=? AND distance=? ORDER BY seconds ASC;",
+						"AND b.mx_key="+lastResultKey+" "+
+						"WHERE event_year_id=? AND distance=? ORDER BY "+resultOrder+";",
 					eventYearID,
 					distance,
 				)
@@ -162,11 +195,8 @@ func (m *MySQL) getResultsInternal(eventYearID int64, bib *string, rtype ResultT
 		if limit > 0 {
 			res, err = db.QueryContext(
 				ctx,
-				"SELECT bib, first, last, age, gender, age_group, distance, seconds, milliseconds, "+
-					"chip_seconds, chip_milliseconds, segment, location, occurence, ranking, age_ranking, "+
-					"gender_ranking, finish, result_type, anonymous, alternate_id, local_time, division, "+
-					"division_ranking FROM result NATURAL JOIN person "+
-					"WHERE event_year_id=? ORDER BY seconds ASC LIMIT ? OFFSET ?;",
+				"SELECT "+resultColumns+" FROM result NATURAL JOIN person "+
+					"WHERE event_year_id=? ORDER BY "+resultOrder+" LIMIT ? OFFSET ?;",
 				eventYearID,
 				limit,
 				page*limit,
@@ -174,11 +204,8 @@ func (m *MySQL) getResultsInternal(eventYearID int64, bib *string, rtype ResultT
 		} else {
 			res, err = db.QueryContext(
 				ctx,
-				"SELECT bib, first, last, age, gender, age_group, distance, seconds, milliseconds, "+
-					"chip_seconds, chip_milliseconds, segment, location, occurence, ranking, age_ranking, "+
-					"gender_ranking, finish, result_type, anonymous, alternate_id, local_time, division, "+
-					"division_ranking FROM result NATURAL JOIN person "+
-					"WHERE event_year_id=? ORDER BY seconds ASC;",
+				"SELECT "+resultColumns+" FROM result NATURAL JOIN person "+
+					"WHERE event_year_id=? ORDER BY "+resultOrder+";",
 				eventYearID,
 			)
 		}
@@ -186,11 +213,8 @@ func (m *MySQL) getResultsInternal(eventYearID int64, bib *string, rtype ResultT
 		if limit > 0 {
 			res, err = db.QueryContext(
 				ctx,
-				"SELECT bib, first, last, age, gender, age_group, distance, seconds, milliseconds, "+
-					"chip_seconds, chip_milliseconds, segment, location, occurence, ranking, age_ranking, "+
-					"gender_ranking, finish, result_type, anonymous, alternate_id, local_time, division, "+
-					"division_ranking FROM result NATURAL JOIN person "+
-					"WHERE finish=TRUE AND event_year_id=? ORDER BY seconds ASC LIMIT ? OFFSET ?;",
+				"SELECT "+resultColumns+" FROM result NATURAL JOIN person "+
+					"WHERE finish=TRUE AND event_year_id=? ORDER BY "+resultOrder+" LIMIT ? OFFSET ?;",
 				eventYearID,
 				limit,
 				page*limit,
@@ -198,11 +222,8 @@ func (m *MySQL) getResultsInternal(eventYearID int64, bib *string, rtype ResultT
 		} else {
 			res, err = db.QueryContext(
 				ctx,
-				"SELECT bib, first, last, age, gender, age_group, distance, seconds, milliseconds, "+
-					"chip_seconds, chip_milliseconds, segment, location, occurence, ranking, age_ranking, "+
-					"gender_ranking, finish, result_type, anonymous, alternate_id, local_time, division, "+
-					"division_ranking FROM result NATURAL JOIN person "+
-					"WHERE finish=TRUE AND event_year_id=? ORDER BY seconds ASC;",
+				"SELECT "+resultColumns+" FROM result NATURAL JOIN person "+
+					"WHERE finish=TRUE AND event_year_id=? ORDER BY "+resultOrder+";",
 				eventYearID,
 			)
 		}
@@ -210,15 +231,12 @@ func (m *MySQL) getResultsInternal(eventYearID int64, bib *string, rtype ResultT
 		if limit > 0 {
 			res, err = db.QueryContext(
 				ctx,
-				"SELECT bib, first, last, age, gender, age_group, distance, seconds, milliseconds, "+
-					"chip_seconds, chip_milliseconds, segment, location, occurence, ranking, age_ranking, "+
-					"gender_ranking, finish, result_type, anonymous, alternate_id, local_time, division, "+
-					"division_ranking FROM result r NATURAL JOIN person p "+
-					"JOIN (SELECT bib AS mx_bib, event_year_id AS mx_event_year_id, MAX(seconds) as mx_seconds "+
+				"SELECT "+resultColumns+" FROM result r NATURAL JOIN person p "+
+					"JOIN (SELECT bib AS mx_bib, event_year_id AS mx_event_year_id, MAX("+lastResultKey+") as mx_key "+
 					"FROM result NATURAL JOIN person GROUP BY bib, event_year_id, segment) b "+
 					"ON b.mx_bib=p.bib AND b.mx_event_year_id=p.event_year_id "+
-					"AND b.mx_seconds=r.seconds "+
-					"WHERE event_year_id=? ORDER BY seconds ASC LIMIT ? OFFSET ?;",
+					"AND b.mx_key="+lastResultKey+" "+
+					"WHERE event_year_id=? ORDER BY "+resultOrder+" LIMIT ? OFFSET ?;",
 				eventYearID,
 				limit,
 				page*limit,
@@ -226,15 +244,12 @@ func (m *MySQL) getResultsInternal(eventYearID int64, bib *string, rtype ResultT
 		} else {
 			res, err = db.QueryContext(
 				ctx,
-				"SELECT bib, first, last, age, gender, age_group, distance, seconds, milliseconds, "+
-					"chip_seconds, chip_milliseconds, segment, location, occurence, ranking, age_ranking, "+
-					"gender_ranking, finish, result_type, anonymous, alternate_id, local_time, division, "+
-					"division_ranking FROM result r NATURAL JOIN person p "+
-					"JOIN (SELECT bib AS mx_bib, event_year_id AS mx_event_year_id, MAX(seconds) as mx_seconds "+
+				"SELECT "+resultColumns+" FROM result r NATURAL JOIN person p "+
+					"JOIN (SELECT bib AS mx_bib, event_year_id AS mx_event_year_id, MAX("+lastResultKey+") as mx_key "+
 					"FROM result NATURAL JOIN person GROUP BY bib, event_year_id, segment) b "+
 					"ON b.mx_bib=p.bib AND b.mx_event_year_id=p.event_year_id "+
-					"AND b.mx_seconds=r.seconds "+
-					"WHERE event_year_id=? ORDER BY seconds ASC;",
+					"AND b.mx_key="+lastResultKey+" "+
+					"WHERE event_year_id=? ORDER BY "+resultOrder+";",
 				eventYearID,
 			)
 		}
@@ -243,43 +258,7 @@ func (m *MySQL) getResultsInternal(eventYearID int64, bib *string, rtype ResultT
 		return nil, fmt.Errorf("error retrieving results: %v", err)
 	}
 	defer res.Close()
-	var outResults []types.Result
-	for res.Next() {
-		var result types.Result
-		var anonymous int
-		err := res.Scan(
-			&result.Bib,
-			&result.First,
-			&result.Last,
-			&result.Age,
-			&result.Gender,
-			&result.AgeGroup,
-			&result.Distance,
-			&result.Seconds,
-			&result.Milliseconds,
-			&result.ChipSeconds,
-			&result.ChipMilliseconds,
-			&result.Segment,
-			&result.Location,
-			&result.Occurence,
-			&result.Ranking,
-			&result.AgeRanking,
-			&result.GenderRanking,
-			&result.Finish,
-			&result.Type,
-			&anonymous,
-			&result.PersonId,
-			&result.LocalTime,
-			&result.Division,
-			&result.DivisionRanking,
-		)
-		result.Anonymous = anonymous != 0
-		if err != nil {
-			return nil, fmt.Errorf("error getting result: %v", err)
-		}
-		outResults = append(outResults, result)
-	}
-	return outResults, nil
+	return scanResults(res)
 }
 
 // GetResults Gets results for an event year.
@@ -312,8 +291,143 @@ func (m *MySQL) GetBibResults(eventYearID int64, bib string) ([]types.Result, er
 	return m.getResultsInternal(eventYearID, &bib, All, "", 0, 0)
 }
 
+// GetUpdatedResults Gets all results for an event year (or just a distance) that have been added
+// or changed since updatedAfter, a unix timestamp in seconds.
+func (m *MySQL) GetUpdatedResults(eventYearID int64, distance string, updatedAfter int64) ([]types.Result, error) {
+	db, err := m.GetDB()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancelfunc := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancelfunc()
+	var res *sql.Rows
+	if distance != "" {
+		res, err = db.QueryContext(
+			ctx,
+			"SELECT "+resultColumns+" FROM result NATURAL JOIN person "+
+				"WHERE event_year_id=? AND distance=? AND result_updated_at>=FROM_UNIXTIME(?) ORDER BY "+resultOrder+";",
+			eventYearID,
+			distance,
+			updatedAfter,
+		)
+	} else {
+		res, err = db.QueryContext(
+			ctx,
+			"SELECT "+resultColumns+" FROM result NATURAL JOIN person "+
+				"WHERE event_year_id=? AND result_updated_at>=FROM_UNIXTIME(?) ORDER BY "+resultOrder+";",
+			eventYearID,
+			updatedAfter,
+		)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving updated results: %v", err)
+	}
+	defer res.Close()
+	return scanResults(res)
+}
+
+// GetLocationResults Gets the results for an event year (or just a distance) at a single timing
+// location.  The location is either the name of a segment or a location and the occurence of it,
+// and the results are ordered by arrival.
+func (m *MySQL) GetLocationResults(eventYearID int64, distance, location, segment string, occurence int) ([]types.Result, error) {
+	db, err := m.GetDB()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancelfunc := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancelfunc()
+	var res *sql.Rows
+	if distance != "" {
+		res, err = db.QueryContext(
+			ctx,
+			"SELECT "+resultColumns+" FROM result NATURAL JOIN person "+
+				"WHERE event_year_id=? AND distance=? AND ((segment<>'' AND segment=?) OR (location=? AND occurence=?)) "+
+				"ORDER BY "+resultOrder+", milliseconds ASC;",
+			eventYearID,
+			distance,
+			segment,
+			location,
+			occurence,
+		)
+	} else {
+		res, err = db.QueryContext(
+			ctx,
+			"SELECT "+resultColumns+" FROM result NATURAL JOIN person "+
+				"WHERE event_year_id=? AND ((segment<>'' AND segment=?) OR (location=? AND occurence=?)) "+
+				"ORDER BY "+resultOrder+", milliseconds ASC;",
+			eventYearID,
+			segment,
+			location,
+			occurence,
+		)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving location results: %v", err)
+	}
+	defer res.Close()
+	outResults, err := scanResults(res)
+	if outResults == nil && err == nil {
+		outResults = make([]types.Result, 0)
+	}
+	return outResults, err
+}
+
+// GetDeletedResults Gets the results for an event year (or just a distance) that have been deleted
+// since deletedAfter, a unix timestamp in seconds.  Only the bib, distance, location and occurence
+// of each result are returned.  Results that have been added again since are not included.
+func (m *MySQL) GetDeletedResults(eventYearID int64, distance string, deletedAfter int64) ([]types.Result, error) {
+	db, err := m.GetDB()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancelfunc := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancelfunc()
+	var res *sql.Rows
+	if distance != "" {
+		res, err = db.QueryContext(
+			ctx,
+			"SELECT bib, distance, location, occurence FROM deleted_result d "+
+				"WHERE event_year_id=? AND distance=? AND deleted_at>=? AND NOT EXISTS ("+
+				"SELECT * FROM result r NATURAL JOIN person p WHERE p.event_year_id=d.event_year_id "+
+				"AND p.bib=d.bib AND r.location=d.location AND r.occurence=d.occurence);",
+			eventYearID,
+			distance,
+			deletedAfter,
+		)
+	} else {
+		res, err = db.QueryContext(
+			ctx,
+			"SELECT bib, distance, location, occurence FROM deleted_result d "+
+				"WHERE event_year_id=? AND deleted_at>=? AND NOT EXISTS ("+
+				"SELECT * FROM result r NATURAL JOIN person p WHERE p.event_year_id=d.event_year_id "+
+				"AND p.bib=d.bib AND r.location=d.location AND r.occurence=d.occurence);",
+			eventYearID,
+			deletedAfter,
+		)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving deleted results: %v", err)
+	}
+	defer res.Close()
+	var outResults []types.Result
+	for res.Next() {
+		var result types.Result
+		err := res.Scan(
+			&result.Bib,
+			&result.Distance,
+			&result.Location,
+			&result.Occurence,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("error getting deleted result: %v", err)
+		}
+		outResults = append(outResults, result)
+	}
+	return outResults, nil
+}
+
 // DeleteResults Deletes results from the database.
-func (m *MySQL) DeleteResults(eventYearID int64, results []types.Result) (int64, error) {
+func (m *MySQL) DeleteResults(eventYearID int64, results []types.Result, actor *types.AuditActor) (int64, error) {
 	db, err := m.GetDB()
 	if err != nil {
 		return 0, err
@@ -324,6 +438,17 @@ func (m *MySQL) DeleteResults(eventYearID int64, results []types.Result) (int64,
 	if err != nil {
 		return 0, fmt.Errorf("unable to begin transaction to delete results: %v", err)
 	}
+	deletedStmt, err := tx.PrepareContext(
+		ctx,
+		"INSERT INTO deleted_result(event_year_id, bib, distance, location, occurence, deleted_at) "+
+			"SELECT event_year_id, bib, distance, location, occurence, ? FROM result NATURAL JOIN person "+
+			"WHERE event_year_id=? AND bib=? AND location=? AND occurence=? "+
+			"ON DUPLICATE KEY UPDATE distance=VALUES(distance), deleted_at=VALUES(deleted_at);",
+	)
+	if err != nil {
+		return 0, fmt.Errorf("unable to get prepared statement for recording result deletion: %v", err)
+	}
+	defer deletedStmt.Close()
 	stmt, err := tx.PrepareContext(
 		ctx,
 		"DELETE r FROM result AS r WHERE location=? AND occurence=? AND EXISTS (SELECT * FROM person AS p WHERE event_year_id=? AND bib=? AND p.person_id=r.person_id);",
@@ -332,8 +457,27 @@ func (m *MySQL) DeleteResults(eventYearID int64, results []types.Result) (int64,
 		return 0, fmt.Errorf("unable to get prepared statement for result deletion: %v", err)
 	}
 	defer stmt.Close()
+	scope := database.ResultsAuditScope(results)
+	auditPeople, auditResults, err := getAuditState(ctx, tx, eventYearID, scope)
+	if err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+	deletedAt := time.Now().Unix()
 	for _, result := range results {
-		_, err := stmt.ExecContext(
+		_, err := deletedStmt.ExecContext(
+			ctx,
+			deletedAt,
+			eventYearID,
+			result.Bib,
+			result.Location,
+			result.Occurence,
+		)
+		if err != nil {
+			tx.Rollback()
+			return 0, fmt.Errorf("error recording result deletion: %v", err)
+		}
+		_, err = stmt.ExecContext(
 			ctx,
 			result.Location,
 			result.Occurence,
@@ -345,6 +489,11 @@ func (m *MySQL) DeleteResults(eventYearID int64, results []types.Result) (int64,
 			return 0, fmt.Errorf("error executing prepared delete statement: %v", err)
 		}
 	}
+	err = addAuditEntries(ctx, tx, eventYearID, scope, actor, auditPeople, auditResults)
+	if err != nil {
+		tx.Rollback()
+		return 0, err
+	}
 	err = tx.Commit()
 	if err != nil {
 		tx.Rollback()
@@ -354,7 +503,7 @@ func (m *MySQL) DeleteResults(eventYearID int64, results []types.Result) (int64,
 }
 
 // DeleteDistanceResults Deletes results for an Event Distance from the database.
-func (m *MySQL) DeleteDistanceResults(eventYearID int64, distance string) (int64, error) {
+func (m *MySQL) DeleteDistanceResults(eventYearID int64, distance string, actor *types.AuditActor) (int64, error) {
 	db, err := m.GetDB()
 	if err != nil {
 		return 0, err
@@ -365,6 +514,26 @@ func (m *MySQL) DeleteDistanceResults(eventYearID int64, distance string) (int64
 	if err != nil {
 		return 0, fmt.Errorf("unable to start transaction: %v", err)
 	}
+	scope := database.AuditScope{Distance: distance}
+	auditPeople, auditResults, err := getAuditState(ctx, tx, eventYearID, scope)
+	if err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+	_, err = tx.ExecContext(
+		ctx,
+		"INSERT INTO deleted_result(event_year_id, bib, distance, location, occurence, deleted_at) "+
+			"SELECT event_year_id, bib, distance, location, occurence, ? FROM result NATURAL JOIN person "+
+			"WHERE event_year_id=? AND distance=? "+
+			"ON DUPLICATE KEY UPDATE distance=VALUES(distance), deleted_at=VALUES(deleted_at);",
+		time.Now().Unix(),
+		eventYearID,
+		distance,
+	)
+	if err != nil {
+		tx.Rollback()
+		return 0, fmt.Errorf("unable to record deleted results for event year & distance: %v", err)
+	}
 	res, err := tx.ExecContext(
 		ctx,
 		"DELETE r FROM result AS r WHERE EXISTS (SELECT * FROM person AS p WHERE p.event_year_id=? AND p.distance=? AND p.person_id=r.person_id);",
@@ -390,6 +559,11 @@ func (m *MySQL) DeleteDistanceResults(eventYearID int64, distance string) (int64
 		tx.Rollback()
 		return 0, fmt.Errorf("unable to delete persons for event year & distance: %v", err)
 	}
+	err = addAuditEntries(ctx, tx, eventYearID, scope, actor, auditPeople, auditResults)
+	if err != nil {
+		tx.Rollback()
+		return 0, err
+	}
 	err = tx.Commit()
 	if err != nil {
 		return 0, fmt.Errorf("unable to commit transaction: %v", err)
@@ -398,7 +572,7 @@ func (m *MySQL) DeleteDistanceResults(eventYearID int64, distance string) (int64
 }
 
 // DeleteEventResults Deletes results for an event year.
-func (m *MySQL) DeleteEventResults(eventYearID int64) (int64, error) {
+func (m *MySQL) DeleteEventResults(eventYearID int64, actor *types.AuditActor) (int64, error) {
 	db, err := m.GetDB()
 	if err != nil {
 		return 0, err
@@ -409,6 +583,25 @@ func (m *MySQL) DeleteEventResults(eventYearID int64) (int64, error) {
 	if err != nil {
 		return 0, fmt.Errorf("unable to start transaction: %v", err)
 	}
+	scope := database.AuditScope{All: true}
+	auditPeople, auditResults, err := getAuditState(ctx, tx, eventYearID, scope)
+	if err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+	_, err = tx.ExecContext(
+		ctx,
+		"INSERT INTO deleted_result(event_year_id, bib, distance, location, occurence, deleted_at) "+
+			"SELECT event_year_id, bib, distance, location, occurence, ? FROM result NATURAL JOIN person "+
+			"WHERE event_year_id=? "+
+			"ON DUPLICATE KEY UPDATE distance=VALUES(distance), deleted_at=VALUES(deleted_at);",
+		time.Now().Unix(),
+		eventYearID,
+	)
+	if err != nil {
+		tx.Rollback()
+		return 0, fmt.Errorf("unable to record deleted results for event year: %v", err)
+	}
 	res, err := tx.ExecContext(
 		ctx,
 		"DELETE r FROM result AS r WHERE EXISTS (SELECT * FROM person AS p WHERE p.event_year_id=? AND p.person_id=r.person_id);",
@@ -432,6 +625,11 @@ func (m *MySQL) DeleteEventResults(eventYearID int64) (int64, error) {
 		tx.Rollback()
 		return 0, fmt.Errorf("unable to delete persons for event year: %v", err)
 	}
+	err = addAuditEntries(ctx, tx, eventYearID, scope, actor, auditPeople, auditResults)
+	if err != nil {
+		tx.Rollback()
+		return 0, err
+	}
 	err = tx.Commit()
 	if err != nil {
 		tx.Rollback()
@@ -441,7 +639,7 @@ func (m *MySQL) DeleteEventResults(eventYearID int64) (int64, error) {
 }
 
 // AddResults Adds results to the database. (Also updates.)
-func (m *MySQL) AddResults(eventYearID int64, results []types.Result) ([]types.Result, error) {
+func (m *MySQL) AddResults(eventYearID int64, results []types.Result, actor *types.AuditActor) ([]types.Result, error) {
 	db, err := m.GetDB()
 	if err != nil {
 		return nil, err
@@ -502,9 +700,11 @@ func (m *MySQL) AddResults(eventYearID int64, results []types.Result) ([]types.R
 			"finish, "+
 			"result_type, "+
 			"local_time, "+
-			"division_ranking"+
+			"division_ranking, "+
+			"result_status, "+
+			"status_reason"+
 			") "+
-			" VALUES (?,?,?,?,?,?,?,?,?,?,?,?,?,?,?) "+
+			" VALUES (?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?,?) "+
 			"ON DUPLICATE KEY UPDATE "+
 			"seconds=VALUES(seconds), "+
 			"milliseconds=VALUES(milliseconds), "+
@@ -517,13 +717,21 @@ func (m *MySQL) AddResults(eventYearID int64, results []types.Result) ([]types.R
 			"finish=VALUES(finish), "+
 			"result_type=VALUES(result_type), "+
 			"local_time=VALUES(local_time), "+
-			"division_ranking=VALUES(division_ranking)"+
+			"division_ranking=VALUES(division_ranking), "+
+			"result_status=VALUES(result_status), "+
+			"status_reason=VALUES(status_reason)"+
 			";",
 	)
 	if err != nil {
 		return nil, fmt.Errorf("unable to prepare statement for result add: %v", err)
 	}
 	defer stmt.Close()
+	scope := database.ResultsAuditScope(results)
+	auditPeople, auditResults, err := getAuditState(ctx, tx, eventYearID, scope)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
 	// Get person ids from the database.
 	bibMap := make(map[string]int64)
 	res, err := tx.QueryContext(
@@ -594,6 +802,8 @@ func (m *MySQL) AddResults(eventYearID int64, results []types.Result) ([]types.R
 			result.Type,
 			result.LocalTime,
 			result.DivisionRanking,
+			result.Status,
+			result.StatusReason,
 		)
 		if err != nil {
 			tx.Rollback()
@@ -601,6 +811,11 @@ func (m *MySQL) AddResults(eventYearID int64, results []types.Result) ([]types.R
 		}
 		outResults = append(outResults, result)
 	}
+	err = addAuditEntries(ctx, tx, eventYearID, scope, actor, auditPeople, auditResults)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
 	err = tx.Commit()
 	if err != nil {
 		tx.Rollback()
@@ -609,3 +824,133 @@ func (m *MySQL) AddResults(eventYearID int64, results []types.Result) ([]types.R
 	return outResults, nil
 }
 
+
+// UpdateRankings Recalculates the rankings for every result in an event year and stores
+// any that changed.  Returns the number of results updated.
+func (m *MySQL) UpdateRankings(eventYearID int64) (int64, error) {
+	db, err := m.GetDB()
+	if err != nil {
+		return 0, err
+	}
+	ctx, cancelfunc := context.WithTimeout(context.Background(), time.Second*15)
+	defer cancelfunc()
+	tx, err := db.Begin()
+	if err != nil {
+		return 0, fmt.Errorf("unable to start transaction: %v", err)
+	}
+	var rankingType, eventType string
+	err = tx.QueryRowContext(
+		ctx,
+		"SELECT y.ranking_type, e.event_type FROM event_year y JOIN event e ON y.event_id=e.event_id WHERE y.event_year_id=?;",
+		eventYearID,
+	).Scan(&rankingType, &eventType)
+	if err != nil {
+		tx.Rollback()
+		return 0, fmt.Errorf("error retrieving ranking type: %v", err)
+	}
+	res, err := tx.QueryContext(
+		ctx,
+		"SELECT person_id, bib, gender, age_group, distance, division, seconds, milliseconds, "+
+			"chip_seconds, chip_milliseconds, location, occurence, finish, result_type, result_status, ranking, age_ranking, "+
+			"gender_ranking, division_ranking FROM result NATURAL JOIN person WHERE event_year_id=?;",
+		eventYearID,
+	)
+	if err != nil {
+		tx.Rollback()
+		return 0, fmt.Errorf("error retrieving results to rank: %v", err)
+	}
+	var ids []int64
+	var results []types.Result
+	for res.Next() {
+		var id int64
+		var result types.Result
+		err := res.Scan(
+			&id,
+			&result.Bib,
+			&result.Gender,
+			&result.AgeGroup,
+			&result.Distance,
+			&result.Division,
+			&result.Seconds,
+			&result.Milliseconds,
+			&result.ChipSeconds,
+			&result.ChipMilliseconds,
+			&result.Location,
+			&result.Occurence,
+			&result.Finish,
+			&result.Type,
+			&result.Status,
+			&result.Ranking,
+			&result.AgeRanking,
+			&result.GenderRanking,
+			&result.DivisionRanking,
+		)
+		if err != nil {
+			res.Close()
+			tx.Rollback()
+			return 0, fmt.Errorf("error getting result to rank: %v", err)
+		}
+		ids = append(ids, id)
+		results = append(results, result)
+	}
+	res.Close()
+	stmt, err := tx.PrepareContext(
+		ctx,
+		"UPDATE result SET ranking=?, age_ranking=?, gender_ranking=?, division_ranking=? "+
+			"WHERE person_id=? AND location=? AND occurence=?;",
+	)
+	if err != nil {
+		tx.Rollback()
+		return 0, fmt.Errorf("unable to prepare statement for ranking update: %v", err)
+	}
+	defer stmt.Close()
+	var count int64
+	// Backyard ultras are ranked by the yards completed and timed events by the distance
+	// covered instead of by time.
+	var ranked []types.Result
+	switch eventType {
+	case util.EVENT_TYPE_BACKYARDULTRA:
+		ranked = database.CalculateBackyardRankings(results)
+	case util.EVENT_TYPE_TIME:
+		distances, err := m.GetDistances(eventYearID)
+		if err != nil {
+			tx.Rollback()
+			return 0, fmt.Errorf("error retrieving distances to rank: %v", err)
+		}
+		segments, err := m.GetSegments(eventYearID)
+		if err != nil {
+			tx.Rollback()
+			return 0, fmt.Errorf("error retrieving segments to rank: %v", err)
+		}
+		ranked = database.CalculateLapRankings(results, distances, segments, rankingType)
+	default:
+		ranked = database.CalculateRankings(results, rankingType)
+	}
+	for ix := range ranked {
+		if !database.RankingsChanged(&ranked[ix], &results[ix]) {
+			continue
+		}
+		_, err = stmt.ExecContext(
+			ctx,
+			ranked[ix].Ranking,
+			ranked[ix].AgeRanking,
+			ranked[ix].GenderRanking,
+			ranked[ix].DivisionRanking,
+			ids[ix],
+			ranked[ix].Location,
+			ranked[ix].Occurence,
+		)
+		if err != nil {
+			tx.Rollback()
+			return 0, fmt.Errorf("error updating result ranking: %v", err)
+		}
+		count++
+	}
+	err = tx.Commit()
+	if err != nil {
+		tx.Rollback()
+		return 0, fmt.Errorf("unable to commit transaction: %v", err)
+	}
+	return count, nil
+}
+
@@ -0,0 +1,204 @@
+/*
+Chronokeep Desktop - Race Scoring Software
+Copyright (C) 2026 James Sentinella
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package mysql
+
+import (
+	"chronokeep/results/types"
+	"context"
+	"fmt"
+	"time"
+)
+
+// GetSeries Gets a series and its events ordered by the date of each event year.
+func (m *MySQL) GetSeries(slug string) (*types.Series, error) {
+	db, err := m.GetDB()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancelfunc := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancelfunc()
+	res, err := db.QueryContext(
+		ctx,
+		"SELECT series_id, account_id, series_slug, series_name, points, best_of, minimum_races, tie_breakers, identity_rule "+
+			"FROM series WHERE series_slug=?;",
+		slug,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving series: %v", err)
+	}
+	defer res.Close()
+	if !res.Next() {
+		return nil, nil
+	}
+	var outSeries types.Series
+	var points, tieBreakers string
+	err = res.Scan(
+		&outSeries.Identifier,
+		&outSeries.AccountIdentifier,
+		&outSeries.Slug,
+		&outSeries.Name,
+		&points,
+		&outSeries.BestOf,
+		&outSeries.MinimumRaces,
+		&tieBreakers,
+		&outSeries.Identity,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error getting series: %v", err)
+	}
+	if err = outSeries.DecodeRules(points, tieBreakers); err != nil {
+		return nil, err
+	}
+	res.Close()
+	res, err = db.QueryContext(
+		ctx,
+		"SELECT s.event_year_id, e.slug, y.year, s.distance FROM series_events s JOIN event_year y ON s.event_year_id=y.event_year_id "+
+			"JOIN event e ON y.event_id=e.event_id WHERE s.series_id=? ORDER BY y.date_time, e.slug;",
+		outSeries.Identifier,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving series events: %v", err)
+	}
+	defer res.Close()
+	outSeries.Events = make([]types.SeriesEvent, 0)
+	for res.Next() {
+		var event types.SeriesEvent
+		err := res.Scan(
+			&event.EventYearIdentifier,
+			&event.Slug,
+			&event.Year,
+			&event.Distance,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("error getting series event: %v", err)
+		}
+		outSeries.Events = append(outSeries.Events, event)
+	}
+	return &outSeries, nil
+}
+
+// AddSeries Adds a series or updates the one with the same slug.  The events of the series are
+// replaced with the ones given, which must have their event year identifiers set.
+func (m *MySQL) AddSeries(series types.Series) (*types.Series, error) {
+	db, err := m.GetDB()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancelfunc := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancelfunc()
+	tx, err := db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("unable to start transaction: %v", err)
+	}
+	points, tieBreakers := series.EncodeRules()
+	_, err = tx.ExecContext(
+		ctx,
+		"INSERT INTO series(account_id, series_slug, series_name, points, best_of, minimum_races, tie_breakers, identity_rule) "+
+			"VALUES (?,?,?,?,?,?,?,?) ON DUPLICATE KEY UPDATE series_name=VALUES(series_name), points=VALUES(points), "+
+			"best_of=VALUES(best_of), minimum_races=VALUES(minimum_races), tie_breakers=VALUES(tie_breakers), identity_rule=VALUES(identity_rule);",
+		series.AccountIdentifier,
+		series.Slug,
+		series.Name,
+		points,
+		series.BestOf,
+		series.MinimumRaces,
+		tieBreakers,
+		series.Identity,
+	)
+	if err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("error adding series to database: %v", err)
+	}
+	err = tx.QueryRowContext(
+		ctx,
+		"SELECT series_id FROM series WHERE series_slug=?;",
+		series.Slug,
+	).Scan(&series.Identifier)
+	if err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("error getting series id: %v", err)
+	}
+	_, err = tx.ExecContext(
+		ctx,
+		"DELETE FROM series_events WHERE series_id=?;",
+		series.Identifier,
+	)
+	if err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("error deleting old series events: %v", err)
+	}
+	for _, event := range series.Events {
+		_, err = tx.ExecContext(
+			ctx,
+			"INSERT INTO series_events(series_id, event_year_id, distance) VALUES (?,?,?);",
+			series.Identifier,
+			event.EventYearIdentifier,
+			event.Distance,
+		)
+		if err != nil {
+			tx.Rollback()
+			return nil, fmt.Errorf("error adding series event to database: %v", err)
+		}
+	}
+	err = tx.Commit()
+	if err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("error committing transaction: %v", err)
+	}
+	return &series, nil
+}
+
+// DeleteSeries Deletes a series and its events.  The event years in the series are not affected.
+func (m *MySQL) DeleteSeries(series types.Series) error {
+	db, err := m.GetDB()
+	if err != nil {
+		return err
+	}
+	ctx, cancelfunc := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancelfunc()
+	tx, err := db.Begin()
+	if err != nil {
+		return fmt.Errorf("unable to start transaction: %v", err)
+	}
+	_, err = tx.ExecContext(
+		ctx,
+		"DELETE FROM series_events WHERE series_id=?;",
+		series.Identifier,
+	)
+	if err != nil {
+		tx.Rollback()
+		return fmt.Errorf("error deleting series events: %v", err)
+	}
+	_, err = tx.ExecContext(
+		ctx,
+		"DELETE FROM series WHERE series_id=?;",
+		series.Identifier,
+	)
+	if err != nil {
+		tx.Rollback()
+		return fmt.Errorf("error deleting series: %v", err)
+	}
+	err = tx.Commit()
+	if err != nil {
+		tx.Rollback()
+		return fmt.Errorf("error committing transaction: %v", err)
+	}
+	return nil
+}
+
@@ -0,0 +1,226 @@
+/*
+Chronokeep Desktop - Race Scoring Software
+Copyright (C) 2026 James Sentinella
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package mysql
+
+import (
+	"chronokeep/results/database"
+	"chronokeep/results/types"
+	"chronokeep/results/util"
+	"context"
+	"fmt"
+	"time"
+)
+
+const (
+	// statStatus is the status of a finish line result, falling back on the result type for
+	// results uploaded before statuses were stored.
+	statStatus = "CASE WHEN result_status<>'' THEN result_status " +
+		"WHEN result_type IN (3,30) THEN 'dnf' WHEN result_type IN (2,20) THEN 'dns' ELSE 'finished' END"
+	statGunTime  = "(seconds*1000+milliseconds)"
+	statChipTime = "(chip_seconds*1000+chip_milliseconds)"
+)
+
+// GetDistanceStatistics Gets the statistics for every distance of an event year, or just one
+// distance if given.  Times are chip times if the ranking type is chip and gun times otherwise
+// and the histogram buckets are bucketSeconds long.
+func (m *MySQL) GetDistanceStatistics(eventYearID int64, distance, rankingType string, bucketSeconds int) ([]types.DistanceStatistics, error) {
+	db, err := m.GetDB()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancelfunc := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancelfunc()
+	timeCol, secondsCol := statGunTime, "seconds"
+	if rankingType == util.RANKING_TYPE_CHIP {
+		timeCol, secondsCol = statChipTime, "chip_seconds"
+	}
+	where := "finish=TRUE AND event_year_id=?"
+	args := []any{eventYearID}
+	if distance != "" {
+		where += " AND distance=?"
+		args = append(args, distance)
+	}
+	res, err := db.QueryContext(
+		ctx,
+		"SELECT distance, "+
+			"SUM(CASE WHEN "+statStatus+"<>'dns' THEN 1 ELSE 0 END), "+
+			"SUM(CASE WHEN "+statStatus+"='finished' THEN 1 ELSE 0 END), "+
+			"SUM(CASE WHEN "+statStatus+"='dnf' THEN 1 ELSE 0 END), "+
+			"SUM(CASE WHEN "+statStatus+"='dns' THEN 1 ELSE 0 END), "+
+			"SUM(CASE WHEN "+statStatus+"='dq' THEN 1 ELSE 0 END), "+
+			"COALESCE(MIN(CASE WHEN "+statStatus+"='finished' THEN "+timeCol+" END), 0), "+
+			"COALESCE(MAX(CASE WHEN "+statStatus+"='finished' THEN "+timeCol+" END), 0), "+
+			"COALESCE(AVG(CASE WHEN "+statStatus+"='finished' THEN "+timeCol+" END), 0) "+
+			"FROM result NATURAL JOIN person WHERE "+where+" GROUP BY distance ORDER BY distance;",
+		args...,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving distance statistics: %v", err)
+	}
+	defer res.Close()
+	output := make([]types.DistanceStatistics, 0)
+	lookup := make(map[string]int)
+	for res.Next() {
+		var stats types.DistanceStatistics
+		var fastest, slowest int64
+		var mean float64
+		err := res.Scan(
+			&stats.Distance,
+			&stats.Starters,
+			&stats.Finishers,
+			&stats.DNF,
+			&stats.DNS,
+			&stats.DQ,
+			&fastest,
+			&slowest,
+			&mean,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("error getting distance statistics: %v", err)
+		}
+		stats.DNFRate = database.DNFRate(stats.DNF, stats.Starters)
+		stats.Fastest = types.NewStatTime(fastest)
+		stats.Slowest = types.NewStatTime(slowest)
+		stats.Mean = types.NewStatTime(int64(mean + 0.5))
+		stats.Genders = make(map[string]int)
+		stats.Histogram = make([]types.HistogramBucket, 0)
+		lookup[stats.Distance] = len(output)
+		output = append(output, stats)
+	}
+	res.Close()
+	res, err = db.QueryContext(
+		ctx,
+		"SELECT distance, gender, COUNT(*) FROM result NATURAL JOIN person WHERE "+where+
+			" AND "+statStatus+"='finished' GROUP BY distance, gender;",
+		args...,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving gender statistics: %v", err)
+	}
+	defer res.Close()
+	for res.Next() {
+		var dist, gender string
+		var count int
+		if err := res.Scan(&dist, &gender, &count); err != nil {
+			return nil, fmt.Errorf("error getting gender statistics: %v", err)
+		}
+		if ix, ok := lookup[dist]; ok {
+			output[ix].Genders[gender] = count
+		}
+	}
+	res.Close()
+	res, err = db.QueryContext(
+		ctx,
+		"SELECT distance, "+secondsCol+" DIV ? AS bucket, COUNT(*) FROM result NATURAL JOIN person WHERE "+where+
+			" AND "+statStatus+"='finished' GROUP BY distance, bucket ORDER BY distance, bucket;",
+		append([]any{bucketSeconds}, args...)...,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving finish time histogram: %v", err)
+	}
+	defer res.Close()
+	for res.Next() {
+		var dist string
+		var bucket types.HistogramBucket
+		if err := res.Scan(&dist, &bucket.Start, &bucket.Count); err != nil {
+			return nil, fmt.Errorf("error getting finish time histogram: %v", err)
+		}
+		bucket.Start *= bucketSeconds
+		if ix, ok := lookup[dist]; ok {
+			output[ix].Histogram = append(output[ix].Histogram, bucket)
+		}
+	}
+	res.Close()
+	// The median is found by skipping to the middle finisher so only one or two rows are read.
+	for ix := range output {
+		stats := &output[ix]
+		stats.Histogram = database.FillHistogram(stats.Histogram, bucketSeconds)
+		if stats.Finishers < 1 {
+			continue
+		}
+		res, err = db.QueryContext(
+			ctx,
+			"SELECT "+timeCol+" AS finish_time FROM result NATURAL JOIN person WHERE finish=TRUE AND event_year_id=? AND distance=? AND "+
+				statStatus+"='finished' ORDER BY finish_time LIMIT ? OFFSET ?;",
+			eventYearID,
+			stats.Distance,
+			2-stats.Finishers%2,
+			(stats.Finishers-1)/2,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("error retrieving median time: %v", err)
+		}
+		var total, count int64
+		for res.Next() {
+			var t int64
+			if err := res.Scan(&t); err != nil {
+				res.Close()
+				return nil, fmt.Errorf("error getting median time: %v", err)
+			}
+			total += t
+			count++
+		}
+		res.Close()
+		if count > 0 {
+			stats.Median = types.NewStatTime((total + count/2) / count)
+		}
+	}
+	return output, nil
+}
+
+// GetYearStatistics Gets the participation numbers for every year of an event in date order.
+func (m *MySQL) GetYearStatistics(eventID int64) ([]types.YearStatistics, error) {
+	db, err := m.GetDB()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancelfunc := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancelfunc()
+	res, err := db.QueryContext(
+		ctx,
+		"SELECT y.year, "+
+			"(SELECT COUNT(*) FROM participant p WHERE p.event_year_id=y.event_year_id), "+
+			"(SELECT COUNT(*) FROM result r JOIN person e ON r.person_id=e.person_id WHERE e.event_year_id=y.event_year_id "+
+			"AND r.finish=TRUE AND "+statStatus+"<>'dns'), "+
+			"(SELECT COUNT(*) FROM result r JOIN person e ON r.person_id=e.person_id WHERE e.event_year_id=y.event_year_id "+
+			"AND r.finish=TRUE AND "+statStatus+"='finished') "+
+			"FROM event_year y WHERE y.event_id=? AND y.year_deleted=FALSE ORDER BY y.date_time;",
+		eventID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving year statistics: %v", err)
+	}
+	defer res.Close()
+	output := make([]types.YearStatistics, 0)
+	for res.Next() {
+		var stats types.YearStatistics
+		err := res.Scan(
+			&stats.Year,
+			&stats.Registered,
+			&stats.Starters,
+			&stats.Finishers,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("error getting year statistics: %v", err)
+		}
+		output = append(output, stats)
+	}
+	return output, nil
+}
+
@@ -0,0 +1,141 @@
+/*
+Chronokeep Desktop - Race Scoring Software
+Copyright (C) 2026 James Sentinella
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package mysql
+
+import (
+	"chronokeep/results/types"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func statisticsTestResult(bib, gender, distance string, seconds int, status string) types.Result {
+	return types.Result{
+		PersonId:         bib,
+		Bib:              bib,
+		First:            "First" + bib,
+		Last:             "Last" + bib,
+		Age:              30,
+		Gender:           gender,
+		AgeGroup:         "30-39",
+		Distance:         distance,
+		Seconds:          seconds,
+		ChipSeconds:      seconds - 10,
+		ChipMilliseconds: 0,
+		Location:         "Finish",
+		Occurence:        1,
+		Finish:           true,
+		Status:           status,
+	}
+}
+
+func TestStatistics(t *testing.T) {
+	db, finalize, err := setupTests(t)
+	if err != nil {
+		t.Fatalf("Error setting up tests. %v", err)
+	}
+	defer finalize(t)
+	setupTeamTests()
+	eventYear := setupTeamEventYear(t, db)
+	_, err = db.AddEventYear(types.EventYear{
+		EventIdentifier: eventYear.EventIdentifier,
+		Year:            "2022",
+		DateTime:        time.Date(2022, 04, 20, 9, 0, 0, 0, time.Local),
+		DaysAllowed:     1,
+		RankingType:     "chip",
+	})
+	if err != nil {
+		t.Fatalf("Error adding event year: %v", err)
+	}
+	stats, err := db.GetDistanceStatistics(eventYear.Identifier, "", eventYear.RankingType, 300)
+	if assert.NoError(t, err) {
+		assert.Equal(t, 0, len(stats))
+	}
+	legacy := statisticsTestResult("8", "Man", "5K", 0, "")
+	legacy.Type = types.ResultTypeDNF
+	split := statisticsTestResult("9", "Man", "5K", 500, types.ResultStatusInProgress)
+	split.Finish = false
+	split.Location = "Split"
+	_, err = db.AddResults(eventYear.Identifier, []types.Result{
+		statisticsTestResult("1", "Man", "5K", 1000, types.ResultStatusFinished),
+		statisticsTestResult("2", "Woman", "5K", 1100, types.ResultStatusFinished),
+		statisticsTestResult("3", "Man", "5K", 1250, types.ResultStatusFinished),
+		statisticsTestResult("4", "Woman", "5K", 1900, types.ResultStatusFinished),
+		statisticsTestResult("5", "Man", "5K", 0, types.ResultStatusDNF),
+		statisticsTestResult("6", "Man", "5K", 0, types.ResultStatusDNS),
+		statisticsTestResult("7", "Man", "5K", 0, types.ResultStatusDQ),
+		legacy,
+		split,
+		statisticsTestResult("10", "Man", "10K", 3000, types.ResultStatusFinished),
+	}, nil)
+	if err != nil {
+		t.Fatalf("Error adding results: %v", err)
+	}
+	_, err = db.AddParticipants(eventYear.Identifier, []types.Participant{
+		{AlternateId: "1", Bib: "1", First: "First1", Last: "Last1", Birthdate: "1990/01/01", Gender: "Man", Distance: "5K"},
+		{AlternateId: "2", Bib: "2", First: "First2", Last: "Last2", Birthdate: "1990/01/01", Gender: "Woman", Distance: "5K"},
+	})
+	if err != nil {
+		t.Fatalf("Error adding participants: %v", err)
+	}
+	stats, err = db.GetDistanceStatistics(eventYear.Identifier, "", eventYear.RankingType, 300)
+	if assert.NoError(t, err) && assert.Equal(t, 2, len(stats)) {
+		assert.Equal(t, "10K", stats[0].Distance)
+		assert.Equal(t, 1, stats[0].Finishers)
+		assert.Equal(t, types.StatTime{Seconds: 2990}, stats[0].Median)
+		assert.Equal(t, []types.HistogramBucket{{Start: 2700, Count: 1}}, stats[0].Histogram)
+		five := stats[1]
+		assert.Equal(t, "5K", five.Distance)
+		assert.Equal(t, 7, five.Starters)
+		assert.Equal(t, 4, five.Finishers)
+		assert.Equal(t, 2, five.DNF)
+		assert.Equal(t, 1, five.DNS)
+		assert.Equal(t, 1, five.DQ)
+		assert.InDelta(t, 2.0/7.0, five.DNFRate, 0.0001)
+		assert.Equal(t, map[string]int{"Man": 2, "Woman": 2}, five.Genders)
+		// Chip times are used because the year is ranked on chip time.
+		assert.Equal(t, types.StatTime{Seconds: 990}, five.Fastest)
+		assert.Equal(t, types.StatTime{Seconds: 1890}, five.Slowest)
+		assert.Equal(t, types.StatTime{Seconds: 1302, Milliseconds: 500}, five.Mean)
+		assert.Equal(t, types.StatTime{Seconds: 1165}, five.Median)
+		assert.Equal(t, []types.HistogramBucket{
+			{Start: 900, Count: 2},
+			{Start: 1200, Count: 1},
+			{Start: 1500, Count: 0},
+			{Start: 1800, Count: 1},
+		}, five.Histogram)
+	}
+	stats, err = db.GetDistanceStatistics(eventYear.Identifier, "5K", "gun", 600)
+	if assert.NoError(t, err) && assert.Equal(t, 1, len(stats)) {
+		assert.Equal(t, types.StatTime{Seconds: 1000}, stats[0].Fastest)
+		assert.Equal(t, types.StatTime{Seconds: 1175}, stats[0].Median)
+		assert.Equal(t, []types.HistogramBucket{
+			{Start: 600, Count: 2},
+			{Start: 1200, Count: 1},
+			{Start: 1800, Count: 1},
+		}, stats[0].Histogram)
+	}
+	years, err := db.GetYearStatistics(eventYear.EventIdentifier)
+	if assert.NoError(t, err) && assert.Equal(t, 2, len(years)) {
+		assert.Equal(t, types.YearStatistics{Year: "2021", Registered: 2, Starters: 8, Finishers: 5}, years[0])
+		assert.Equal(t, types.YearStatistics{Year: "2022"}, years[1])
+	}
+}
+
@@ -0,0 +1,317 @@
+/*
+Chronokeep Desktop - Race Scoring Software
+Copyright (C) 2026 James Sentinella
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package mysql
+
+import (
+	"chronokeep/results/types"
+	"context"
+	"fmt"
+	"time"
+)
+
+// AddTeams Adds teams to an event year or updates the ones with the same name.  The members
+// of each team are replaced with the ones given.
+func (m *MySQL) AddTeams(eventYearID int64, teams []types.Team) ([]types.Team, error) {
+	db, err := m.GetDB()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancelfunc := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancelfunc()
+	tx, err := db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("unable to start transaction: %v", err)
+	}
+	output := make([]types.Team, 0)
+	for _, team := range teams {
+		_, err = tx.ExecContext(
+			ctx,
+			"INSERT INTO teams(event_year_id, team_name, distance) VALUES (?,?,?) "+
+				"ON DUPLICATE KEY UPDATE distance=VALUES(distance);",
+			eventYearID,
+			team.Name,
+			team.Distance,
+		)
+		if err != nil {
+			tx.Rollback()
+			return nil, fmt.Errorf("error adding team to database: %v", err)
+		}
+		err = tx.QueryRowContext(
+			ctx,
+			"SELECT team_id FROM teams WHERE event_year_id=? AND team_name=?;",
+			eventYearID,
+			team.Name,
+		).Scan(&team.Identifier)
+		if err != nil {
+			tx.Rollback()
+			return nil, fmt.Errorf("error getting team id: %v", err)
+		}
+		_, err = tx.ExecContext(
+			ctx,
+			"DELETE FROM team_members WHERE team_id=?;",
+			team.Identifier,
+		)
+		if err != nil {
+			tx.Rollback()
+			return nil, fmt.Errorf("error deleting old team members: %v", err)
+		}
+		for _, member := range team.Members {
+			_, err = tx.ExecContext(
+				ctx,
+				"INSERT INTO team_members(team_id, bib, person_id) VALUES (?,?,?);",
+				team.Identifier,
+				member.Bib,
+				member.PersonId,
+			)
+			if err != nil {
+				tx.Rollback()
+				return nil, fmt.Errorf("error adding team member to database: %v", err)
+			}
+		}
+		output = append(output, team)
+	}
+	err = tx.Commit()
+	if err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("error committing transaction: %v", err)
+	}
+	return output, nil
+}
+
+// GetTeams Gets the teams of an event year along with their members.
+func (m *MySQL) GetTeams(eventYearID int64) ([]types.Team, error) {
+	db, err := m.GetDB()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancelfunc := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancelfunc()
+	res, err := db.QueryContext(
+		ctx,
+		"SELECT team_id, team_name, distance FROM teams WHERE event_year_id=? ORDER BY team_name;",
+		eventYearID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving teams: %v", err)
+	}
+	defer res.Close()
+	output := make([]types.Team, 0)
+	teamIndex := make(map[int64]int)
+	for res.Next() {
+		team := types.Team{
+			Members: make([]types.TeamMember, 0),
+		}
+		err := res.Scan(
+			&team.Identifier,
+			&team.Name,
+			&team.Distance,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("error getting team: %v", err)
+		}
+		teamIndex[team.Identifier] = len(output)
+		output = append(output, team)
+	}
+	res.Close()
+	res, err = db.QueryContext(
+		ctx,
+		"SELECT team_id, bib, person_id FROM team_members NATURAL JOIN teams WHERE event_year_id=?;",
+		eventYearID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving team members: %v", err)
+	}
+	defer res.Close()
+	for res.Next() {
+		var teamID int64
+		var member types.TeamMember
+		err := res.Scan(
+			&teamID,
+			&member.Bib,
+			&member.PersonId,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("error getting team member: %v", err)
+		}
+		if ix, ok := teamIndex[teamID]; ok {
+			output[ix].Members = append(output[ix].Members, member)
+		}
+	}
+	return output, nil
+}
+
+// DeleteTeams Deletes the teams with the given names from an event year, or every team in the
+// event year if no names are given.
+func (m *MySQL) DeleteTeams(eventYearID int64, names []string) (int64, error) {
+	db, err := m.GetDB()
+	if err != nil {
+		return 0, err
+	}
+	ctx, cancelfunc := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancelfunc()
+	tx, err := db.Begin()
+	if err != nil {
+		return 0, fmt.Errorf("unable to start transaction: %v", err)
+	}
+	if len(names) < 1 {
+		_, err = tx.ExecContext(
+			ctx,
+			"DELETE m FROM team_members m JOIN teams t ON t.team_id=m.team_id WHERE t.event_year_id=?;",
+			eventYearID,
+		)
+		if err != nil {
+			tx.Rollback()
+			return 0, fmt.Errorf("error deleting team members: %v", err)
+		}
+		res, err := tx.ExecContext(
+			ctx,
+			"DELETE FROM teams WHERE event_year_id=?;",
+			eventYearID,
+		)
+		if err != nil {
+			tx.Rollback()
+			return 0, fmt.Errorf("error deleting teams: %v", err)
+		}
+		count, err := res.RowsAffected()
+		if err != nil {
+			tx.Rollback()
+			return 0, fmt.Errorf("error fetching rows affected from team deletion: %v", err)
+		}
+		err = tx.Commit()
+		if err != nil {
+			tx.Rollback()
+			return 0, fmt.Errorf("error committing transaction: %v", err)
+		}
+		return count, nil
+	}
+	var count int64
+	for _, name := range names {
+		_, err = tx.ExecContext(
+			ctx,
+			"DELETE m FROM team_members m JOIN teams t ON t.team_id=m.team_id WHERE t.event_year_id=? AND t.team_name=?;",
+			eventYearID,
+			name,
+		)
+		if err != nil {
+			tx.Rollback()
+			return 0, fmt.Errorf("error deleting team members: %v", err)
+		}
+		res, err := tx.ExecContext(
+			ctx,
+			"DELETE FROM teams WHERE event_year_id=? AND team_name=?;",
+			eventYearID,
+			name,
+		)
+		if err != nil {
+			tx.Rollback()
+			return 0, fmt.Errorf("error deleting team: %v", err)
+		}
+		affected, err := res.RowsAffected()
+		if err != nil {
+			tx.Rollback()
+			return 0, fmt.Errorf("error fetching rows affected from team deletion: %v", err)
+		}
+		count += affected
+	}
+	err = tx.Commit()
+	if err != nil {
+		tx.Rollback()
+		return 0, fmt.Errorf("error committing transaction: %v", err)
+	}
+	return count, nil
+}
+
+// SetTeamScoring Replaces the team scoring rules for an event year.
+func (m *MySQL) SetTeamScoring(eventYearID int64, scoring []types.TeamScoring) ([]types.TeamScoring, error) {
+	db, err := m.GetDB()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancelfunc := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancelfunc()
+	tx, err := db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("unable to start transaction: %v", err)
+	}
+	_, err = tx.ExecContext(
+		ctx,
+		"DELETE FROM team_scoring WHERE event_year_id=?;",
+		eventYearID,
+	)
+	if err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("error deleting old team scoring: %v", err)
+	}
+	for _, rules := range scoring {
+		_, err = tx.ExecContext(
+			ctx,
+			"INSERT INTO team_scoring(event_year_id, distance, scorers, displacers, method) VALUES (?,?,?,?,?);",
+			eventYearID,
+			rules.Distance,
+			rules.Scorers,
+			rules.Displacers,
+			rules.Method,
+		)
+		if err != nil {
+			tx.Rollback()
+			return nil, fmt.Errorf("error adding team scoring to database: %v", err)
+		}
+	}
+	err = tx.Commit()
+	if err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("error committing transaction: %v", err)
+	}
+	return scoring, nil
+}
+
+// GetTeamScoring Gets the team scoring rules for each distance of an event year.
+func (m *MySQL) GetTeamScoring(eventYearID int64) ([]types.TeamScoring, error) {
+	db, err := m.GetDB()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancelfunc := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancelfunc()
+	res, err := db.QueryContext(
+		ctx,
+		"SELECT distance, scorers, displacers, method FROM team_scoring WHERE event_year_id=?;",
+		eventYearID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving team scoring: %v", err)
+	}
+	defer res.Close()
+	output := make([]types.TeamScoring, 0)
+	for res.Next() {
+		var rules types.TeamScoring
+		err := res.Scan(
+			&rules.Distance,
+			&rules.Scorers,
+			&rules.Displacers,
+			&rules.Method,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("error getting team scoring: %v", err)
+		}
+		output = append(output, rules)
+	}
+	return output, nil
+}
+
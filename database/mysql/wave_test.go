@@ -0,0 +1,86 @@
+/*
+Chronokeep Desktop - Race Scoring Software
+Copyright (C) 2026 James Sentinella
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package mysql
+
+import (
+	"chronokeep/results/types"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWaves(t *testing.T) {
+	db, finalize, err := setupTests(t)
+	if err != nil {
+		t.Fatalf("Error setting up tests. %v", err)
+	}
+	defer finalize(t)
+	setupTeamTests()
+	eventYear := setupTeamEventYear(t, db)
+	waves, err := db.GetWaves(eventYear.Identifier)
+	if assert.NoError(t, err) {
+		assert.Equal(t, 0, len(waves))
+	}
+	start := time.Date(2024, 5, 4, 8, 0, 0, 0, time.UTC)
+	set := []types.Wave{
+		{
+			Distance:  "5K",
+			Name:      "Wave 2",
+			StartTime: start.Add(10 * time.Minute),
+			BibRanges: []types.BibRange{{First: 100, Last: 199}},
+			Bibs:      []string{"5", "A1"},
+		},
+		{
+			Distance:  "5K",
+			Name:      "Wave 1",
+			StartTime: start,
+			BibRanges: []types.BibRange{{First: 1, Last: 99}, {First: 200, Last: 299}},
+			Bibs:      []string{},
+		},
+		{
+			Distance:  "10K",
+			Name:      "Elite",
+			StartTime: start.Add(1500 * time.Millisecond),
+			BibRanges: []types.BibRange{},
+			Bibs:      []string{"1000"},
+		},
+	}
+	expected := []types.Wave{set[2], set[1], set[0]}
+	waves, err = db.SetWaves(eventYear.Identifier, set)
+	if assert.NoError(t, err) {
+		assert.Equal(t, expected, waves)
+	}
+	waves, err = db.GetWaves(eventYear.Identifier)
+	if assert.NoError(t, err) {
+		assert.Equal(t, expected, waves)
+	}
+	// Setting them again replaces the old waves.
+	set = []types.Wave{set[0]}
+	set[0].StartTime = start.Add(11 * time.Minute)
+	waves, err = db.SetWaves(eventYear.Identifier, set)
+	if assert.NoError(t, err) {
+		assert.Equal(t, set, waves)
+	}
+	waves, err = db.SetWaves(eventYear.Identifier, nil)
+	if assert.NoError(t, err) {
+		assert.Equal(t, 0, len(waves))
+	}
+}
+
@@ -0,0 +1,221 @@
+/*
+Chronokeep Desktop - Race Scoring Software
+Copyright (C) 2026 James Sentinella
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package postgres
+
+import (
+	"chronokeep/results/types"
+	"context"
+	"fmt"
+	"time"
+)
+
+// GetAthlete Gets an athlete by slug.
+func (p *Postgres) GetAthlete(slug string) (*types.Athlete, error) {
+	db, err := p.GetDB()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancelfunc := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancelfunc()
+	res, err := db.Query(
+		ctx,
+		"SELECT athlete_id, account_id, athlete_slug, first, last, birthdate FROM athletes WHERE athlete_slug=$1;",
+		slug,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving athlete: %v", err)
+	}
+	defer res.Close()
+	if res.Next() {
+		var outAthlete types.Athlete
+		err := res.Scan(
+			&outAthlete.Identifier,
+			&outAthlete.AccountIdentifier,
+			&outAthlete.Slug,
+			&outAthlete.First,
+			&outAthlete.Last,
+			&outAthlete.Birthdate,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("error getting athlete: %v", err)
+		}
+		return &outAthlete, nil
+	}
+	return nil, nil
+}
+
+// AddAthlete Adds an athlete or updates the one with the same slug.
+func (p *Postgres) AddAthlete(athlete types.Athlete) (*types.Athlete, error) {
+	db, err := p.GetDB()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancelfunc := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancelfunc()
+	_, err = db.Exec(
+		ctx,
+		"INSERT INTO athletes(account_id, athlete_slug, first, last, birthdate) VALUES ($1,$2,$3,$4,$5) "+
+			"ON CONFLICT (athlete_slug) DO UPDATE SET first=excluded.first, last=excluded.last, birthdate=excluded.birthdate;",
+		athlete.AccountIdentifier,
+		athlete.Slug,
+		athlete.First,
+		athlete.Last,
+		athlete.Birthdate,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error adding athlete to database: %v", err)
+	}
+	return p.GetAthlete(athlete.Slug)
+}
+
+// DeleteAthlete Deletes an athlete and the bibs claimed for them.
+func (p *Postgres) DeleteAthlete(athlete types.Athlete) error {
+	db, err := p.GetDB()
+	if err != nil {
+		return err
+	}
+	ctx, cancelfunc := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancelfunc()
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("unable to start transaction: %v", err)
+	}
+	_, err = tx.Exec(
+		ctx,
+		"DELETE FROM athlete_links WHERE athlete_id=$1;",
+		athlete.Identifier,
+	)
+	if err != nil {
+		tx.Rollback(ctx)
+		return fmt.Errorf("error deleting athlete links: %v", err)
+	}
+	_, err = tx.Exec(
+		ctx,
+		"DELETE FROM athletes WHERE athlete_id=$1;",
+		athlete.Identifier,
+	)
+	if err != nil {
+		tx.Rollback(ctx)
+		return fmt.Errorf("error deleting athlete: %v", err)
+	}
+	err = tx.Commit(ctx)
+	if err != nil {
+		tx.Rollback(ctx)
+		return fmt.Errorf("error committing transaction: %v", err)
+	}
+	return nil
+}
+
+// AddAthleteLink Claims the bib of an event year for an athlete.
+func (p *Postgres) AddAthleteLink(athleteID, eventYearID int64, bib string) error {
+	db, err := p.GetDB()
+	if err != nil {
+		return err
+	}
+	ctx, cancelfunc := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancelfunc()
+	_, err = db.Exec(
+		ctx,
+		"INSERT INTO athlete_links(athlete_id, event_year_id, bib) VALUES ($1,$2,$3) ON CONFLICT (athlete_id, event_year_id, bib) DO NOTHING;",
+		athleteID,
+		eventYearID,
+		bib,
+	)
+	if err != nil {
+		return fmt.Errorf("error adding athlete link: %v", err)
+	}
+	return nil
+}
+
+// DeleteAthleteLink Removes the claim of an athlete on the bib of an event year.
+func (p *Postgres) DeleteAthleteLink(athleteID, eventYearID int64, bib string) (int64, error) {
+	db, err := p.GetDB()
+	if err != nil {
+		return 0, err
+	}
+	ctx, cancelfunc := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancelfunc()
+	res, err := db.Exec(
+		ctx,
+		"DELETE FROM athlete_links WHERE athlete_id=$1 AND event_year_id=$2 AND bib=$3;",
+		athleteID,
+		eventYearID,
+		bib,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("error deleting athlete link: %v", err)
+	}
+	return res.RowsAffected(), nil
+}
+
+// GetAthleteEntries Gets the event years an athlete may have taken part in.  These are the entries of
+// participants with the same name as the athlete in events of the athlete's account along with the bibs
+// claimed for the athlete.  Deleted events and event years are left out.
+func (p *Postgres) GetAthleteEntries(athlete types.Athlete) ([]types.AthleteEntry, error) {
+	db, err := p.GetDB()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancelfunc := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancelfunc()
+	res, err := db.Query(
+		ctx,
+		"SELECT y.event_year_id, e.account_id, e.access_restricted, p.birthdate, p.anonymous, 0, e.slug, e.event_name, y.year, y.date_time, p.bib "+
+			"FROM participant p JOIN event_year y ON p.event_year_id=y.event_year_id JOIN event e ON y.event_id=e.event_id "+
+			"WHERE LOWER(p.first)=LOWER($1) AND LOWER(p.last)=LOWER($2) AND e.account_id=$3 AND y.year_deleted=FALSE AND e.event_deleted=FALSE "+
+			"UNION ALL "+
+			"SELECT y.event_year_id, e.account_id, e.access_restricted, '', 0, 1, e.slug, e.event_name, y.year, y.date_time, l.bib "+
+			"FROM athlete_links l JOIN event_year y ON l.event_year_id=y.event_year_id JOIN event e ON y.event_id=e.event_id "+
+			"WHERE l.athlete_id=$4 AND y.year_deleted=FALSE AND e.event_deleted=FALSE;",
+		athlete.First,
+		athlete.Last,
+		athlete.AccountIdentifier,
+		athlete.Identifier,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving athlete entries: %v", err)
+	}
+	defer res.Close()
+	output := make([]types.AthleteEntry, 0)
+	for res.Next() {
+		var entry types.AthleteEntry
+		var anonymous, claimed int
+		err := res.Scan(
+			&entry.EventYearIdentifier,
+			&entry.AccountIdentifier,
+			&entry.AccessRestricted,
+			&entry.Birthdate,
+			&anonymous,
+			&claimed,
+			&entry.Slug,
+			&entry.Name,
+			&entry.Year,
+			&entry.DateTime,
+			&entry.Bib,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("error getting athlete entry: %v", err)
+		}
+		entry.Anonymous = anonymous != 0
+		entry.Claimed = claimed != 0
+		output = append(output, entry)
+	}
+	return output, nil
+}
+
@@ -0,0 +1,150 @@
+/*
+Chronokeep Desktop - Race Scoring Software
+Copyright (C) 2026 James Sentinella
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package postgres
+
+import (
+	"chronokeep/results/types"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResultAudit(t *testing.T) {
+	db, finalize, err := setupTests(t)
+	if err != nil {
+		t.Fatalf("setup error: %v", err)
+	}
+	defer finalize(t)
+	setupResultTests()
+	account, err := db.AddAccount(accounts[0])
+	if err != nil {
+		t.Fatalf("Error adding account: %v", err)
+	}
+	event := &types.Event{
+		AccountIdentifier: account.Identifier,
+		Name:              "Event 1",
+		Slug:              "event1",
+	}
+	event, _ = db.AddEvent(*event)
+	eventYear := &types.EventYear{
+		EventIdentifier: event.Identifier,
+		Year:            "2021",
+		DateTime:        time.Date(2021, 04, 20, 9, 0, 0, 0, time.Local),
+		Live:            false,
+		DaysAllowed:     1,
+		RankingType:     "chip",
+	}
+	eventYear, _ = db.AddEventYear(*eventYear)
+	actor := &types.AuditActor{
+		Account: account.Email,
+		KeyName: "timer",
+		KeyType: "write",
+	}
+	_, err = db.AddResults(eventYear.Identifier, results[0:2], actor)
+	if err != nil {
+		t.Fatalf("Error adding results: %v", err)
+	}
+	history, err := db.GetResultAudit(eventYear.Identifier, results[0].Bib)
+	if assert.NoError(t, err) && assert.Equal(t, 2, len(history)) {
+		assert.Equal(t, types.AuditTypePerson, history[0].Type)
+		assert.Equal(t, types.AuditActionInsert, history[0].Action)
+		assert.Equal(t, types.AuditTypeResult, history[1].Type)
+		assert.Equal(t, types.AuditActionInsert, history[1].Action)
+		assert.Equal(t, results[0].Location, history[1].Location)
+		assert.Equal(t, results[0].Occurence, history[1].Occurence)
+		assert.Equal(t, account.Email, history[1].Account)
+		assert.Equal(t, "timer", history[1].KeyName)
+		assert.Equal(t, "write", history[1].KeyType)
+		assert.Nil(t, history[1].Before)
+		if assert.NotNil(t, history[1].After) {
+			assert.Equal(t, results[0].Seconds, history[1].After.Seconds)
+		}
+		assert.NotZero(t, history[1].ChangedAt)
+	}
+	// Rankings being updated aren't recorded.
+	_, err = db.UpdateRankings(eventYear.Identifier)
+	assert.NoError(t, err)
+	// An update records the old and new values.
+	updated := results[0]
+	updated.Seconds = 300
+	_, err = db.AddResults(eventYear.Identifier, []types.Result{updated}, nil)
+	if err != nil {
+		t.Fatalf("Error updating results: %v", err)
+	}
+	updates, err := db.GetResultAudit(eventYear.Identifier, results[0].Bib)
+	if assert.NoError(t, err) && assert.Equal(t, 3, len(updates)) {
+		assert.Equal(t, types.AuditActionUpdate, updates[2].Action)
+		assert.Equal(t, results[0].Seconds, updates[2].Before.Seconds)
+		assert.Equal(t, 300, updates[2].After.Seconds)
+		assert.Equal(t, "", updates[2].Account)
+	}
+	// Deletes keep the old value.
+	_, err = db.DeleteResults(eventYear.Identifier, results[1:2], actor)
+	if err != nil {
+		t.Fatalf("Error deleting results: %v", err)
+	}
+	history, err = db.GetResultAudit(eventYear.Identifier, results[1].Bib)
+	if assert.NoError(t, err) && assert.Equal(t, 3, len(history)) {
+		assert.Equal(t, types.AuditActionDelete, history[2].Action)
+		assert.Nil(t, history[2].After)
+		assert.Equal(t, results[1].Seconds, history[2].Before.Seconds)
+	}
+	all, err := db.GetResultAudit(eventYear.Identifier, "")
+	assert.NoError(t, err)
+	assert.Equal(t, 6, len(all))
+	// Revert a single result to its first value.
+	changes := []types.AuditEntry{{
+		Type:   types.AuditTypeResult,
+		Action: types.AuditActionUpdate,
+		Before: updates[2].After,
+		After:  updates[1].After,
+	}}
+	count, err := db.RevertAudit(eventYear.Identifier, changes, actor)
+	if assert.NoError(t, err) {
+		assert.Equal(t, int64(1), count)
+	}
+	// Bring back the deleted result.
+	changes = []types.AuditEntry{{
+		Type:   types.AuditTypeResult,
+		Action: types.AuditActionInsert,
+		After:  history[2].Before,
+	}}
+	_, err = db.RevertAudit(eventYear.Identifier, changes, actor)
+	assert.NoError(t, err)
+	res, _ := db.GetResults(eventYear.Identifier, 0, 0)
+	if assert.Equal(t, 2, len(res)) {
+		for _, r := range res {
+			switch r.Bib {
+			case results[0].Bib:
+				assert.Equal(t, results[0].Seconds, r.Seconds)
+			case results[1].Bib:
+				assert.Equal(t, results[1].Seconds, r.Seconds)
+			}
+		}
+	}
+	all, err = db.GetResultAudit(eventYear.Identifier, "")
+	assert.NoError(t, err)
+	assert.Equal(t, 8, len(all))
+	// Other event years are kept separate.
+	all, err = db.GetResultAudit(eventYear.Identifier+100, "")
+	assert.NoError(t, err)
+	assert.Empty(t, all)
+}
+
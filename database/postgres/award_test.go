@@ -0,0 +1,68 @@
+/*
+Chronokeep Desktop - Race Scoring Software
+Copyright (C) 2026 James Sentinella
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package postgres
+
+import (
+	"chronokeep/results/types"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAwardRules(t *testing.T) {
+	db, finalize, err := setupTests(t)
+	if err != nil {
+		t.Fatalf("Error setting up tests. %v", err)
+	}
+	defer finalize(t)
+	setupTeamTests()
+	eventYear := setupTeamEventYear(t, db)
+	rules, err := db.GetAwardRules(eventYear.Identifier)
+	if assert.NoError(t, err) {
+		assert.Nil(t, rules)
+	}
+	set := types.AwardRules{
+		OverallPlaces:  3,
+		AgeGroupPlaces: 2,
+		DivisionPlaces: 1,
+		MastersAges:    []int{40, 50},
+		MastersPlaces:  1,
+		DoubleDip:      true,
+	}
+	rules, err = db.SetAwardRules(eventYear.Identifier, set)
+	if assert.NoError(t, err) {
+		assert.Equal(t, set, *rules)
+	}
+	rules, err = db.GetAwardRules(eventYear.Identifier)
+	if assert.NoError(t, err) && assert.NotNil(t, rules) {
+		assert.Equal(t, set, *rules)
+	}
+	// Setting them again replaces the old rules.
+	set = types.AwardRules{
+		OverallPlaces: 5,
+		MastersAges:   []int{},
+	}
+	_, err = db.SetAwardRules(eventYear.Identifier, set)
+	assert.NoError(t, err)
+	rules, err = db.GetAwardRules(eventYear.Identifier)
+	if assert.NoError(t, err) && assert.NotNil(t, rules) {
+		assert.Equal(t, set, *rules)
+	}
+}
+
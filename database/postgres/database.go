@@ -165,6 +165,22 @@ func (p *Postgres) dropTables() error {
 	_, err = db.Exec(
 		ctx,
 		"DROP TABLE "+
+			"handicaps, "+
+			"handicap_scoring, "+
+			"waves, "+
+			"award_rules, "+
+			"hidden_distances, "+
+			"publish_settings, "+
+			"result_audit, "+
+			"athlete_links, "+
+			"athletes, "+
+			"series_events, "+
+			"series, "+
+			"team_scoring, "+
+			"team_members, "+
+			"teams, "+
+			"records, "+
+			"deleted_result, "+
 			"distances, "+
 			"sms_subscriptions, "+
 			"linked_accounts, "+
@@ -294,6 +310,7 @@ func (p *Postgres) createTables() error {
 				"live BOOL DEFAULT FALSE, " +
 				"days_allowed INT NOT NULL DEFAULT 1, " +
 				"ranking_type VARCHAR(20) DEFAULT 'gun', " +
+				"year_status VARCHAR(20) NOT NULL DEFAULT 'draft', " +
 				"year_created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP, " +
 				"year_updated_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP," +
 				"year_deleted BOOL DEFAULT FALSE, " +
@@ -340,6 +357,8 @@ func (p *Postgres) createTables() error {
 				"gender_ranking INT DEFAULT -1, " +
 				"finish BOOL DEFAULT TRUE, " +
 				"result_type INT DEFAULT 0, " +
+				"result_status VARCHAR(20) NOT NULL DEFAULT '', " +
+				"status_reason VARCHAR(500) NOT NULL DEFAULT '', " +
 				"local_time VARCHAR(100) NOT NULL DEFAULT '', " +
 				"division_ranking INT NOT NULL DEFAULT -1, " +
 				"result_created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP, " +
@@ -438,6 +457,8 @@ func (p *Postgres) createTables() error {
 				"event_year_id BIGINT NOT NULL, " +
 				"distance_name VARCHAR NOT NULL, " +
 				"certification VARCHAR NOT NULL, " +
+				"distance_value DOUBLE PRECISION NOT NULL DEFAULT 0, " +
+				"distance_unit VARCHAR NOT NULL DEFAULT '', " +
 				"CONSTRAINT unique_distance UNIQUE (event_year_id, distance_name), " +
 				"FOREIGN KEY (event_year_id) REFERENCES event_year(event_year_id), " +
 				"PRIMARY KEY (distance_id)" +
@@ -467,6 +488,224 @@ func (p *Postgres) createTables() error {
 				"FOREIGN KEY (event_year_id) REFERENCES event_year(event_year_id)" +
 				");",
 		},
+		// DELETED RESULTS TABLE
+		{
+			name: "CreateDeletedResultTable",
+			query: "CREATE TABLE IF NOT EXISTS deleted_result(" +
+				"event_year_id BIGINT NOT NULL, " +
+				"bib VARCHAR(100) NOT NULL, " +
+				"distance VARCHAR(200) NOT NULL, " +
+				"location VARCHAR(500) NOT NULL, " +
+				"occurence INT NOT NULL, " +
+				"deleted_at BIGINT NOT NULL DEFAULT 0, " +
+				"CONSTRAINT one_deleted_result UNIQUE (event_year_id, bib, location, occurence), " +
+				"FOREIGN KEY (event_year_id) REFERENCES event_year(event_year_id)" +
+				");",
+		},
+		// RECORDS TABLE
+		{
+			name: "CreateRecordsTable",
+			query: "CREATE TABLE IF NOT EXISTS records(" +
+				"event_year_id BIGINT NOT NULL, " +
+				"distance VARCHAR(200) NOT NULL, " +
+				"category VARCHAR(20) NOT NULL, " +
+				"gender VARCHAR(50) NOT NULL, " +
+				"age_group VARCHAR(200) NOT NULL, " +
+				"bib VARCHAR(100) NOT NULL, " +
+				"first VARCHAR(100) NOT NULL, " +
+				"last VARCHAR(100) NOT NULL, " +
+				"seconds INT NOT NULL, " +
+				"milliseconds INT NOT NULL, " +
+				"CONSTRAINT one_record UNIQUE (event_year_id, distance, category, gender, age_group), " +
+				"FOREIGN KEY (event_year_id) REFERENCES event_year(event_year_id)" +
+				");",
+		},
+		// TEAMS TABLE
+		{
+			name: "CreateTeamsTable",
+			query: "CREATE TABLE IF NOT EXISTS teams(" +
+				"team_id BIGSERIAL NOT NULL, " +
+				"event_year_id BIGINT NOT NULL, " +
+				"team_name VARCHAR NOT NULL, " +
+				"distance VARCHAR NOT NULL, " +
+				"CONSTRAINT unique_team UNIQUE (event_year_id, team_name), " +
+				"FOREIGN KEY (event_year_id) REFERENCES event_year(event_year_id), " +
+				"PRIMARY KEY (team_id)" +
+				");",
+		},
+		// TEAM MEMBERS TABLE
+		{
+			name: "CreateTeamMembersTable",
+			query: "CREATE TABLE IF NOT EXISTS team_members(" +
+				"team_id BIGINT NOT NULL, " +
+				"bib VARCHAR NOT NULL, " +
+				"person_id VARCHAR NOT NULL, " +
+				"FOREIGN KEY (team_id) REFERENCES teams(team_id)" +
+				");",
+		},
+		// TEAM SCORING TABLE
+		{
+			name: "CreateTeamScoringTable",
+			query: "CREATE TABLE IF NOT EXISTS team_scoring(" +
+				"event_year_id BIGINT NOT NULL, " +
+				"distance VARCHAR NOT NULL, " +
+				"scorers INT NOT NULL, " +
+				"displacers INT NOT NULL, " +
+				"method VARCHAR NOT NULL, " +
+				"CONSTRAINT one_team_scoring UNIQUE (event_year_id, distance), " +
+				"FOREIGN KEY (event_year_id) REFERENCES event_year(event_year_id)" +
+				");",
+		},
+		// SERIES TABLE
+		{
+			name: "CreateSeriesTable",
+			query: "CREATE TABLE IF NOT EXISTS series(" +
+				"series_id BIGSERIAL NOT NULL, " +
+				"account_id BIGINT NOT NULL, " +
+				"series_slug VARCHAR NOT NULL, " +
+				"series_name VARCHAR NOT NULL, " +
+				"points VARCHAR NOT NULL, " +
+				"best_of INT NOT NULL DEFAULT 0, " +
+				"minimum_races INT NOT NULL DEFAULT 0, " +
+				"tie_breakers VARCHAR NOT NULL, " +
+				"identity_rule VARCHAR NOT NULL, " +
+				"CONSTRAINT unique_series UNIQUE (series_slug), " +
+				"FOREIGN KEY (account_id) REFERENCES account(account_id), " +
+				"PRIMARY KEY (series_id)" +
+				");",
+		},
+		// SERIES EVENTS TABLE
+		{
+			name: "CreateSeriesEventsTable",
+			query: "CREATE TABLE IF NOT EXISTS series_events(" +
+				"series_id BIGINT NOT NULL, " +
+				"event_year_id BIGINT NOT NULL, " +
+				"distance VARCHAR NOT NULL, " +
+				"CONSTRAINT unique_series_event UNIQUE (series_id, event_year_id), " +
+				"FOREIGN KEY (series_id) REFERENCES series(series_id), " +
+				"FOREIGN KEY (event_year_id) REFERENCES event_year(event_year_id)" +
+				");",
+		},
+		// ATHLETES TABLE
+		{
+			name: "CreateAthletesTable",
+			query: "CREATE TABLE IF NOT EXISTS athletes(" +
+				"athlete_id BIGSERIAL NOT NULL, " +
+				"account_id BIGINT NOT NULL, " +
+				"athlete_slug VARCHAR NOT NULL, " +
+				"first VARCHAR NOT NULL, " +
+				"last VARCHAR NOT NULL, " +
+				"birthdate VARCHAR NOT NULL, " +
+				"CONSTRAINT unique_athlete UNIQUE (athlete_slug), " +
+				"FOREIGN KEY (account_id) REFERENCES account(account_id), " +
+				"PRIMARY KEY (athlete_id)" +
+				");",
+		},
+		// ATHLETE LINKS TABLE
+		{
+			name: "CreateAthleteLinksTable",
+			query: "CREATE TABLE IF NOT EXISTS athlete_links(" +
+				"athlete_id BIGINT NOT NULL, " +
+				"event_year_id BIGINT NOT NULL, " +
+				"bib VARCHAR NOT NULL, " +
+				"CONSTRAINT unique_athlete_link UNIQUE (athlete_id, event_year_id, bib), " +
+				"FOREIGN KEY (athlete_id) REFERENCES athletes(athlete_id), " +
+				"FOREIGN KEY (event_year_id) REFERENCES event_year(event_year_id)" +
+				");",
+		},
+		// RESULT AUDIT TABLE
+		{
+			name: "CreateResultAuditTable",
+			query: "CREATE TABLE IF NOT EXISTS result_audit(" +
+				"audit_id BIGSERIAL NOT NULL, " +
+				"event_year_id BIGINT NOT NULL, " +
+				"audit_type VARCHAR NOT NULL, " +
+				"action VARCHAR NOT NULL, " +
+				"bib VARCHAR NOT NULL, " +
+				"location VARCHAR NOT NULL, " +
+				"occurence INT NOT NULL, " +
+				"account_email VARCHAR NOT NULL, " +
+				"key_name VARCHAR NOT NULL, " +
+				"key_type VARCHAR NOT NULL, " +
+				"before_value TEXT NOT NULL, " +
+				"after_value TEXT NOT NULL, " +
+				"changed_at BIGINT NOT NULL, " +
+				"FOREIGN KEY (event_year_id) REFERENCES event_year(event_year_id), " +
+				"PRIMARY KEY (audit_id)" +
+				");",
+		},
+		// PUBLISH SETTINGS TABLE
+		{
+			name: "CreatePublishSettingsTable",
+			query: "CREATE TABLE IF NOT EXISTS publish_settings(" +
+				"event_year_id BIGINT NOT NULL, " +
+				"publish_at BIGINT NOT NULL DEFAULT 0, " +
+				"owner_only BOOL DEFAULT FALSE, " +
+				"CONSTRAINT one_publish_settings UNIQUE (event_year_id), " +
+				"FOREIGN KEY (event_year_id) REFERENCES event_year(event_year_id)" +
+				");",
+		},
+		// HIDDEN DISTANCES TABLE
+		{
+			name: "CreateHiddenDistancesTable",
+			query: "CREATE TABLE IF NOT EXISTS hidden_distances(" +
+				"event_year_id BIGINT NOT NULL, " +
+				"distance VARCHAR NOT NULL, " +
+				"CONSTRAINT one_hidden_distance UNIQUE (event_year_id, distance), " +
+				"FOREIGN KEY (event_year_id) REFERENCES event_year(event_year_id)" +
+				");",
+		},
+		// AWARD RULES TABLE
+		{
+			name: "CreateAwardRulesTable",
+			query: "CREATE TABLE IF NOT EXISTS award_rules(" +
+				"event_year_id BIGINT NOT NULL, " +
+				"overall_places INT NOT NULL DEFAULT 0, " +
+				"age_group_places INT NOT NULL DEFAULT 0, " +
+				"division_places INT NOT NULL DEFAULT 0, " +
+				"masters_ages VARCHAR NOT NULL, " +
+				"masters_places INT NOT NULL DEFAULT 0, " +
+				"double_dip BOOL DEFAULT FALSE, " +
+				"CONSTRAINT one_award_rules UNIQUE (event_year_id), " +
+				"FOREIGN KEY (event_year_id) REFERENCES event_year(event_year_id)" +
+				");",
+		},
+		// WAVES TABLE
+		{
+			name: "CreateWavesTable",
+			query: "CREATE TABLE IF NOT EXISTS waves(" +
+				"event_year_id BIGINT NOT NULL, " +
+				"distance VARCHAR NOT NULL, " +
+				"name VARCHAR NOT NULL, " +
+				"start_time BIGINT NOT NULL DEFAULT 0, " +
+				"bib_ranges TEXT NOT NULL, " +
+				"bibs TEXT NOT NULL, " +
+				"CONSTRAINT one_wave UNIQUE (event_year_id, distance, name), " +
+				"FOREIGN KEY (event_year_id) REFERENCES event_year(event_year_id)" +
+				");",
+		},
+		// HANDICAP SCORING TABLE
+		{
+			name: "CreateHandicapScoringTable",
+			query: "CREATE TABLE IF NOT EXISTS handicap_scoring(" +
+				"event_year_id BIGINT NOT NULL, " +
+				"enabled BOOL DEFAULT FALSE, " +
+				"CONSTRAINT one_handicap_scoring UNIQUE (event_year_id), " +
+				"FOREIGN KEY (event_year_id) REFERENCES event_year(event_year_id)" +
+				");",
+		},
+		// HANDICAPS TABLE
+		{
+			name: "CreateHandicapsTable",
+			query: "CREATE TABLE IF NOT EXISTS handicaps(" +
+				"event_year_id BIGINT NOT NULL, " +
+				"bib VARCHAR NOT NULL, " +
+				"seconds INT NOT NULL DEFAULT 0, " +
+				"milliseconds INT NOT NULL DEFAULT 0, " +
+				"CONSTRAINT one_handicap UNIQUE (event_year_id, bib), " +
+				"FOREIGN KEY (event_year_id) REFERENCES event_year(event_year_id)" +
+				");",
+		},
 		// UPDATE ACCOUNT FUNC
 		{
 			name: "UpdateAccountFunc",
@@ -522,6 +761,17 @@ func (p *Postgres) createTables() error {
 				"END;" +
 				"$$ language 'plpgsql';",
 		},
+		// UPDATE PERSON FUNC
+		{
+			name: "UpdatePersonResultFunc",
+			query: "CREATE OR REPLACE FUNCTION person_result_timestamp_column() " +
+				"RETURNS TRIGGER AS $$ " +
+				"BEGIN " +
+				"UPDATE result SET result_updated_at = now() WHERE person_id = NEW.person_id;" +
+				"RETURN NEW;" +
+				"END;" +
+				"$$ language 'plpgsql';",
+		},
 		// TRIGGERS FOR UPDATING UPDATED_AT timestamps
 		{
 			name: "AccountTableTrigger",
@@ -548,6 +798,14 @@ func (p *Postgres) createTables() error {
 			query: "DROP TRIGGER IF EXISTS update_result_timestamp ON result; " +
 				"CREATE TRIGGER update_result_timestamp BEFORE UPDATE ON result FOR EACH ROW EXECUTE PROCEDURE result_timestamp_column();",
 		},
+		{
+			name: "PersonTableTrigger",
+			query: "DROP TRIGGER IF EXISTS update_person_result_timestamp ON person; " +
+				"CREATE TRIGGER update_person_result_timestamp AFTER UPDATE ON person FOR EACH ROW " +
+				"WHEN ((OLD.bib, OLD.first, OLD.last, OLD.age, OLD.gender, OLD.age_group, OLD.distance, OLD.anonymous, OLD.division) " +
+				"IS DISTINCT FROM (NEW.bib, NEW.first, NEW.last, NEW.age, NEW.gender, NEW.age_group, NEW.distance, NEW.anonymous, NEW.division)) " +
+				"EXECUTE PROCEDURE person_result_timestamp_column();",
+		},
 	}
 
 	if p.db == nil {
@@ -1138,6 +1396,476 @@ func (p *Postgres) updateTables(oldVersion, newVersion int) error {
 			}
 		}
 	}
+	if oldVersion < 20 && newVersion >= 20 {
+		log.Info("Updating to database version 20.")
+		queries := []myQuery{
+			{
+				name: "CreateDeletedResultTable",
+				query: "CREATE TABLE IF NOT EXISTS deleted_result(" +
+					"event_year_id BIGINT NOT NULL, " +
+					"bib VARCHAR(100) NOT NULL, " +
+					"distance VARCHAR(200) NOT NULL, " +
+					"location VARCHAR(500) NOT NULL, " +
+					"occurence INT NOT NULL, " +
+					"deleted_at BIGINT NOT NULL DEFAULT 0, " +
+					"CONSTRAINT one_deleted_result UNIQUE (event_year_id, bib, location, occurence), " +
+					"FOREIGN KEY (event_year_id) REFERENCES event_year(event_year_id)" +
+					");",
+			},
+		}
+		for _, q := range queries {
+			_, err := tx.Exec(
+				ctx,
+				q.query,
+			)
+			if err != nil {
+				tx.Rollback(ctx)
+				return fmt.Errorf("error updating from version %d to %d in query %s: %v", oldVersion, newVersion, q.name, err)
+			}
+		}
+	}
+	if oldVersion < 21 && newVersion >= 21 {
+		log.Info("Updating to database version 21.")
+		queries := []myQuery{
+			{
+				name:  "AddResultStatus",
+				query: "ALTER TABLE result ADD COLUMN result_status VARCHAR(20) NOT NULL DEFAULT '';",
+			},
+			{
+				name:  "AddResultStatusReason",
+				query: "ALTER TABLE result ADD COLUMN status_reason VARCHAR(500) NOT NULL DEFAULT '';",
+			},
+			{
+				name:  "SetDNFStatus",
+				query: "UPDATE result SET result_status='dnf', seconds=CASE WHEN seconds=1000000 THEN 0 ELSE seconds END WHERE result_type IN (3, 30);",
+			},
+			{
+				name:  "SetDNSStatus",
+				query: "UPDATE result SET result_status='dns' WHERE result_type IN (2, 20);",
+			},
+			{
+				name:  "SetFinishedStatus",
+				query: "UPDATE result SET result_status='finished' WHERE result_status='' AND finish=TRUE;",
+			},
+			{
+				name:  "SetInProgressStatus",
+				query: "UPDATE result SET result_status='in_progress' WHERE result_status='';",
+			},
+		}
+		for _, q := range queries {
+			_, err := tx.Exec(
+				ctx,
+				q.query,
+			)
+			if err != nil {
+				tx.Rollback(ctx)
+				return fmt.Errorf("error updating from version %d to %d in query %s: %v", oldVersion, newVersion, q.name, err)
+			}
+		}
+	}
+	if oldVersion < 22 && newVersion >= 22 {
+		log.Info("Updating to database version 22.")
+		queries := []myQuery{
+			{
+				name: "CreateRecordsTable",
+				query: "CREATE TABLE IF NOT EXISTS records(" +
+					"event_year_id BIGINT NOT NULL, " +
+					"distance VARCHAR(200) NOT NULL, " +
+					"category VARCHAR(20) NOT NULL, " +
+					"gender VARCHAR(50) NOT NULL, " +
+					"age_group VARCHAR(200) NOT NULL, " +
+					"bib VARCHAR(100) NOT NULL, " +
+					"first VARCHAR(100) NOT NULL, " +
+					"last VARCHAR(100) NOT NULL, " +
+					"seconds INT NOT NULL, " +
+					"milliseconds INT NOT NULL, " +
+					"CONSTRAINT one_record UNIQUE (event_year_id, distance, category, gender, age_group), " +
+					"FOREIGN KEY (event_year_id) REFERENCES event_year(event_year_id)" +
+					");",
+			},
+		}
+		for _, q := range queries {
+			_, err := tx.Exec(
+				ctx,
+				q.query,
+			)
+			if err != nil {
+				tx.Rollback(ctx)
+				return fmt.Errorf("error updating from version %d to %d in query %s: %v", oldVersion, newVersion, q.name, err)
+			}
+		}
+	}
+	if oldVersion < 23 && newVersion >= 23 {
+		log.Info("Updating to database version 23.")
+		queries := []myQuery{
+			{
+				name:  "AddDistanceValue",
+				query: "ALTER TABLE distances ADD COLUMN distance_value DOUBLE PRECISION NOT NULL DEFAULT 0;",
+			},
+			{
+				name:  "AddDistanceUnit",
+				query: "ALTER TABLE distances ADD COLUMN distance_unit VARCHAR NOT NULL DEFAULT '';",
+			},
+		}
+		for _, q := range queries {
+			_, err := tx.Exec(
+				ctx,
+				q.query,
+			)
+			if err != nil {
+				tx.Rollback(ctx)
+				return fmt.Errorf("error updating from version %d to %d in query %s: %v", oldVersion, newVersion, q.name, err)
+			}
+		}
+	}
+	if oldVersion < 24 && newVersion >= 24 {
+		log.Info("Updating to database version 24.")
+		queries := []myQuery{
+			{
+				name: "CreateTeamsTable",
+				query: "CREATE TABLE IF NOT EXISTS teams(" +
+					"team_id BIGSERIAL NOT NULL, " +
+					"event_year_id BIGINT NOT NULL, " +
+					"team_name VARCHAR NOT NULL, " +
+					"distance VARCHAR NOT NULL, " +
+					"CONSTRAINT unique_team UNIQUE (event_year_id, team_name), " +
+					"FOREIGN KEY (event_year_id) REFERENCES event_year(event_year_id), " +
+					"PRIMARY KEY (team_id)" +
+					");",
+			},
+			{
+				name: "CreateTeamMembersTable",
+				query: "CREATE TABLE IF NOT EXISTS team_members(" +
+					"team_id BIGINT NOT NULL, " +
+					"bib VARCHAR NOT NULL, " +
+					"person_id VARCHAR NOT NULL, " +
+					"FOREIGN KEY (team_id) REFERENCES teams(team_id)" +
+					");",
+			},
+			{
+				name: "CreateTeamScoringTable",
+				query: "CREATE TABLE IF NOT EXISTS team_scoring(" +
+					"event_year_id BIGINT NOT NULL, " +
+					"distance VARCHAR NOT NULL, " +
+					"scorers INT NOT NULL, " +
+					"displacers INT NOT NULL, " +
+					"method VARCHAR NOT NULL, " +
+					"CONSTRAINT one_team_scoring UNIQUE (event_year_id, distance), " +
+					"FOREIGN KEY (event_year_id) REFERENCES event_year(event_year_id)" +
+					");",
+			},
+		}
+		for _, q := range queries {
+			_, err := tx.Exec(
+				ctx,
+				q.query,
+			)
+			if err != nil {
+				tx.Rollback(ctx)
+				return fmt.Errorf("error updating from version %d to %d in query %s: %v", oldVersion, newVersion, q.name, err)
+			}
+		}
+	}
+	if oldVersion < 25 && newVersion >= 25 {
+		log.Info("Updating to database version 25.")
+		queries := []myQuery{
+			{
+				name: "CreateSeriesTable",
+				query: "CREATE TABLE IF NOT EXISTS series(" +
+					"series_id BIGSERIAL NOT NULL, " +
+					"account_id BIGINT NOT NULL, " +
+					"series_slug VARCHAR NOT NULL, " +
+					"series_name VARCHAR NOT NULL, " +
+					"points VARCHAR NOT NULL, " +
+					"best_of INT NOT NULL DEFAULT 0, " +
+					"minimum_races INT NOT NULL DEFAULT 0, " +
+					"tie_breakers VARCHAR NOT NULL, " +
+					"identity_rule VARCHAR NOT NULL, " +
+					"CONSTRAINT unique_series UNIQUE (series_slug), " +
+					"FOREIGN KEY (account_id) REFERENCES account(account_id), " +
+					"PRIMARY KEY (series_id)" +
+					");",
+			},
+			{
+				name: "CreateSeriesEventsTable",
+				query: "CREATE TABLE IF NOT EXISTS series_events(" +
+					"series_id BIGINT NOT NULL, " +
+					"event_year_id BIGINT NOT NULL, " +
+					"distance VARCHAR NOT NULL, " +
+					"CONSTRAINT unique_series_event UNIQUE (series_id, event_year_id), " +
+					"FOREIGN KEY (series_id) REFERENCES series(series_id), " +
+					"FOREIGN KEY (event_year_id) REFERENCES event_year(event_year_id)" +
+					");",
+			},
+		}
+		for _, q := range queries {
+			_, err := tx.Exec(
+				ctx,
+				q.query,
+			)
+			if err != nil {
+				tx.Rollback(ctx)
+				return fmt.Errorf("error updating from version %d to %d in query %s: %v", oldVersion, newVersion, q.name, err)
+			}
+		}
+	}
+	if oldVersion < 26 && newVersion >= 26 {
+		log.Info("Updating to database version 26.")
+		queries := []myQuery{
+			{
+				name: "CreateAthletesTable",
+				query: "CREATE TABLE IF NOT EXISTS athletes(" +
+					"athlete_id BIGSERIAL NOT NULL, " +
+					"account_id BIGINT NOT NULL, " +
+					"athlete_slug VARCHAR NOT NULL, " +
+					"first VARCHAR NOT NULL, " +
+					"last VARCHAR NOT NULL, " +
+					"birthdate VARCHAR NOT NULL, " +
+					"CONSTRAINT unique_athlete UNIQUE (athlete_slug), " +
+					"FOREIGN KEY (account_id) REFERENCES account(account_id), " +
+					"PRIMARY KEY (athlete_id)" +
+					");",
+			},
+			{
+				name: "CreateAthleteLinksTable",
+				query: "CREATE TABLE IF NOT EXISTS athlete_links(" +
+					"athlete_id BIGINT NOT NULL, " +
+					"event_year_id BIGINT NOT NULL, " +
+					"bib VARCHAR NOT NULL, " +
+					"CONSTRAINT unique_athlete_link UNIQUE (athlete_id, event_year_id, bib), " +
+					"FOREIGN KEY (athlete_id) REFERENCES athletes(athlete_id), " +
+					"FOREIGN KEY (event_year_id) REFERENCES event_year(event_year_id)" +
+					");",
+			},
+		}
+		for _, q := range queries {
+			_, err := tx.Exec(
+				ctx,
+				q.query,
+			)
+			if err != nil {
+				tx.Rollback(ctx)
+				return fmt.Errorf("error updating from version %d to %d in query %s: %v", oldVersion, newVersion, q.name, err)
+			}
+		}
+	}
+	if oldVersion < 27 && newVersion >= 27 {
+		log.Info("Updating to database version 27.")
+		queries := []myQuery{
+			{
+				name: "CreateResultAuditTable",
+				query: "CREATE TABLE IF NOT EXISTS result_audit(" +
+					"audit_id BIGSERIAL NOT NULL, " +
+					"event_year_id BIGINT NOT NULL, " +
+					"audit_type VARCHAR NOT NULL, " +
+					"action VARCHAR NOT NULL, " +
+					"bib VARCHAR NOT NULL, " +
+					"location VARCHAR NOT NULL, " +
+					"occurence INT NOT NULL, " +
+					"account_email VARCHAR NOT NULL, " +
+					"key_name VARCHAR NOT NULL, " +
+					"key_type VARCHAR NOT NULL, " +
+					"before_value TEXT NOT NULL, " +
+					"after_value TEXT NOT NULL, " +
+					"changed_at BIGINT NOT NULL, " +
+					"FOREIGN KEY (event_year_id) REFERENCES event_year(event_year_id), " +
+					"PRIMARY KEY (audit_id)" +
+					");",
+			},
+		}
+		for _, q := range queries {
+			_, err := tx.Exec(
+				ctx,
+				q.query,
+			)
+			if err != nil {
+				tx.Rollback(ctx)
+				return fmt.Errorf("error updating from version %d to %d in query %s: %v", oldVersion, newVersion, q.name, err)
+			}
+		}
+	}
+	if oldVersion < 28 && newVersion >= 28 {
+		log.Info("Updating to database version 28.")
+		queries := []myQuery{
+			{
+				name:  "AddEventYearStatus",
+				query: "ALTER TABLE event_year ADD COLUMN year_status VARCHAR(20) NOT NULL DEFAULT 'draft';",
+			},
+			{
+				name:  "SetLiveEventYearStatus",
+				query: "UPDATE event_year SET year_status='live' WHERE live=TRUE;",
+			},
+		}
+		for _, q := range queries {
+			_, err := tx.Exec(
+				ctx,
+				q.query,
+			)
+			if err != nil {
+				tx.Rollback(ctx)
+				return fmt.Errorf("error updating from version %d to %d in query %s: %v", oldVersion, newVersion, q.name, err)
+			}
+		}
+	}
+	if oldVersion < 29 && newVersion >= 29 {
+		log.Info("Updating to database version 29.")
+		queries := []myQuery{
+			{
+				name: "CreatePublishSettingsTable",
+				query: "CREATE TABLE IF NOT EXISTS publish_settings(" +
+					"event_year_id BIGINT NOT NULL, " +
+					"publish_at BIGINT NOT NULL DEFAULT 0, " +
+					"owner_only BOOL DEFAULT FALSE, " +
+					"CONSTRAINT one_publish_settings UNIQUE (event_year_id), " +
+					"FOREIGN KEY (event_year_id) REFERENCES event_year(event_year_id)" +
+					");",
+			},
+			{
+				name: "CreateHiddenDistancesTable",
+				query: "CREATE TABLE IF NOT EXISTS hidden_distances(" +
+					"event_year_id BIGINT NOT NULL, " +
+					"distance VARCHAR NOT NULL, " +
+					"CONSTRAINT one_hidden_distance UNIQUE (event_year_id, distance), " +
+					"FOREIGN KEY (event_year_id) REFERENCES event_year(event_year_id)" +
+					");",
+			},
+		}
+		for _, q := range queries {
+			_, err := tx.Exec(
+				ctx,
+				q.query,
+			)
+			if err != nil {
+				tx.Rollback(ctx)
+				return fmt.Errorf("error updating from version %d to %d in query %s: %v", oldVersion, newVersion, q.name, err)
+			}
+		}
+	}
+	if oldVersion < 30 && newVersion >= 30 {
+		log.Info("Updating to database version 30.")
+		queries := []myQuery{
+			{
+				name: "CreateAwardRulesTable",
+				query: "CREATE TABLE IF NOT EXISTS award_rules(" +
+					"event_year_id BIGINT NOT NULL, " +
+					"overall_places INT NOT NULL DEFAULT 0, " +
+					"age_group_places INT NOT NULL DEFAULT 0, " +
+					"division_places INT NOT NULL DEFAULT 0, " +
+					"masters_ages VARCHAR NOT NULL, " +
+					"masters_places INT NOT NULL DEFAULT 0, " +
+					"double_dip BOOL DEFAULT FALSE, " +
+					"CONSTRAINT one_award_rules UNIQUE (event_year_id), " +
+					"FOREIGN KEY (event_year_id) REFERENCES event_year(event_year_id)" +
+					");",
+			},
+		}
+		for _, q := range queries {
+			_, err := tx.Exec(
+				ctx,
+				q.query,
+			)
+			if err != nil {
+				tx.Rollback(ctx)
+				return fmt.Errorf("error updating from version %d to %d in query %s: %v", oldVersion, newVersion, q.name, err)
+			}
+		}
+	}
+	if oldVersion < 31 && newVersion >= 31 {
+		log.Info("Updating to database version 31.")
+		queries := []myQuery{
+			{
+				name: "CreateWavesTable",
+				query: "CREATE TABLE IF NOT EXISTS waves(" +
+					"event_year_id BIGINT NOT NULL, " +
+					"distance VARCHAR NOT NULL, " +
+					"name VARCHAR NOT NULL, " +
+					"start_time BIGINT NOT NULL DEFAULT 0, " +
+					"bib_ranges TEXT NOT NULL, " +
+					"bibs TEXT NOT NULL, " +
+					"CONSTRAINT one_wave UNIQUE (event_year_id, distance, name), " +
+					"FOREIGN KEY (event_year_id) REFERENCES event_year(event_year_id)" +
+					");",
+			},
+		}
+		for _, q := range queries {
+			_, err := tx.Exec(
+				ctx,
+				q.query,
+			)
+			if err != nil {
+				tx.Rollback(ctx)
+				return fmt.Errorf("error updating from version %d to %d in query %s: %v", oldVersion, newVersion, q.name, err)
+			}
+		}
+	}
+	if oldVersion < 32 && newVersion >= 32 {
+		log.Info("Updating to database version 32.")
+		queries := []myQuery{
+			{
+				name: "CreateHandicapScoringTable",
+				query: "CREATE TABLE IF NOT EXISTS handicap_scoring(" +
+					"event_year_id BIGINT NOT NULL, " +
+					"enabled BOOL DEFAULT FALSE, " +
+					"CONSTRAINT one_handicap_scoring UNIQUE (event_year_id), " +
+					"FOREIGN KEY (event_year_id) REFERENCES event_year(event_year_id)" +
+					");",
+			},
+			{
+				name: "CreateHandicapsTable",
+				query: "CREATE TABLE IF NOT EXISTS handicaps(" +
+					"event_year_id BIGINT NOT NULL, " +
+					"bib VARCHAR NOT NULL, " +
+					"seconds INT NOT NULL DEFAULT 0, " +
+					"milliseconds INT NOT NULL DEFAULT 0, " +
+					"CONSTRAINT one_handicap UNIQUE (event_year_id, bib), " +
+					"FOREIGN KEY (event_year_id) REFERENCES event_year(event_year_id)" +
+					");",
+			},
+		}
+		for _, q := range queries {
+			_, err := tx.Exec(
+				ctx,
+				q.query,
+			)
+			if err != nil {
+				tx.Rollback(ctx)
+				return fmt.Errorf("error updating from version %d to %d in query %s: %v", oldVersion, newVersion, q.name, err)
+			}
+		}
+	}
+	if oldVersion < 33 && newVersion >= 33 {
+		log.Info("Updating to database version 33.")
+		queries := []myQuery{
+			{
+				name: "UpdatePersonResultFunc",
+				query: "CREATE OR REPLACE FUNCTION person_result_timestamp_column() " +
+					"RETURNS TRIGGER AS $$ " +
+					"BEGIN " +
+					"UPDATE result SET result_updated_at = now() WHERE person_id = NEW.person_id;" +
+					"RETURN NEW;" +
+					"END;" +
+					"$$ language 'plpgsql';",
+			},
+			{
+				name: "PersonTableTrigger",
+				query: "DROP TRIGGER IF EXISTS update_person_result_timestamp ON person; " +
+					"CREATE TRIGGER update_person_result_timestamp AFTER UPDATE ON person FOR EACH ROW " +
+					"WHEN ((OLD.bib, OLD.first, OLD.last, OLD.age, OLD.gender, OLD.age_group, OLD.distance, OLD.anonymous, OLD.division) " +
+					"IS DISTINCT FROM (NEW.bib, NEW.first, NEW.last, NEW.age, NEW.gender, NEW.age_group, NEW.distance, NEW.anonymous, NEW.division)) " +
+					"EXECUTE PROCEDURE person_result_timestamp_column();",
+			},
+		}
+		for _, q := range queries {
+			_, err := tx.Exec(
+				ctx,
+				q.query,
+			)
+			if err != nil {
+				tx.Rollback(ctx)
+				return fmt.Errorf("error updating from version %d to %d in query %s: %v", oldVersion, newVersion, q.name, err)
+			}
+		}
+	}
 	_, err = tx.Exec(
 		ctx,
 		"UPDATE settings SET value=$1 WHERE name='version';",
@@ -783,6 +783,132 @@ func TestUpgrade(t *testing.T) {
 	if version != 19 {
 		t.Fatalf("Version set to '%v' expected '19'.", version)
 	}
+	// Verify version 20
+	err = db.updateTables(version, 20)
+	if err != nil {
+		t.Fatalf("error updating database from %d to %d: %v", version, 20, err)
+	}
+	version = db.checkVersion()
+	if version != 20 {
+		t.Fatalf("Version set to '%v' expected '20'.", version)
+	}
+	// Verify version 21
+	err = db.updateTables(version, 21)
+	if err != nil {
+		t.Fatalf("error updating database from %d to %d: %v", version, 21, err)
+	}
+	version = db.checkVersion()
+	if version != 21 {
+		t.Fatalf("Version set to '%v' expected '21'.", version)
+	}
+	// Verify version 22
+	err = db.updateTables(version, 22)
+	if err != nil {
+		t.Fatalf("error updating database from %d to %d: %v", version, 22, err)
+	}
+	version = db.checkVersion()
+	if version != 22 {
+		t.Fatalf("Version set to '%v' expected '22'.", version)
+	}
+	// Verify version 23
+	err = db.updateTables(version, 23)
+	if err != nil {
+		t.Fatalf("error updating database from %d to %d: %v", version, 23, err)
+	}
+	version = db.checkVersion()
+	if version != 23 {
+		t.Fatalf("Version set to '%v' expected '23'.", version)
+	}
+	// Verify version 24
+	err = db.updateTables(version, 24)
+	if err != nil {
+		t.Fatalf("error updating database from %d to %d: %v", version, 24, err)
+	}
+	version = db.checkVersion()
+	if version != 24 {
+		t.Fatalf("Version set to '%v' expected '24'.", version)
+	}
+	// Verify version 25
+	err = db.updateTables(version, 25)
+	if err != nil {
+		t.Fatalf("error updating database from %d to %d: %v", version, 25, err)
+	}
+	version = db.checkVersion()
+	if version != 25 {
+		t.Fatalf("Version set to '%v' expected '25'.", version)
+	}
+	// Verify version 26
+	err = db.updateTables(version, 26)
+	if err != nil {
+		t.Fatalf("error updating database from %d to %d: %v", version, 26, err)
+	}
+	version = db.checkVersion()
+	if version != 26 {
+		t.Fatalf("Version set to '%v' expected '26'.", version)
+	}
+	// Verify version 27
+	err = db.updateTables(version, 27)
+	if err != nil {
+		t.Fatalf("error updating database from %d to %d: %v", version, 27, err)
+	}
+	version = db.checkVersion()
+	if version != 27 {
+		t.Fatalf("Version set to '%v' expected '27'.", version)
+	}
+	// Verify version 28
+	err = db.updateTables(version, 28)
+	if err != nil {
+		t.Fatalf("error updating database from %d to %d: %v", version, 28, err)
+	}
+	version = db.checkVersion()
+	if version != 28 {
+		t.Fatalf("Version set to '%v' expected '28'.", version)
+	}
+	// Verify version 29
+	err = db.updateTables(version, 29)
+	if err != nil {
+		t.Fatalf("error updating database from %d to %d: %v", version, 29, err)
+	}
+	version = db.checkVersion()
+	if version != 29 {
+		t.Fatalf("Version set to '%v' expected '29'.", version)
+	}
+	// Verify version 30
+	err = db.updateTables(version, 30)
+	if err != nil {
+		t.Fatalf("error updating database from %d to %d: %v", version, 30, err)
+	}
+	version = db.checkVersion()
+	if version != 30 {
+		t.Fatalf("Version set to '%v' expected '30'.", version)
+	}
+	// Verify version 31
+	err = db.updateTables(version, 31)
+	if err != nil {
+		t.Fatalf("error updating database from %d to %d: %v", version, 31, err)
+	}
+	version = db.checkVersion()
+	if version != 31 {
+		t.Fatalf("Version set to '%v' expected '31'.", version)
+	}
+	// Verify version 32
+	err = db.updateTables(version, 32)
+	if err != nil {
+		t.Fatalf("error updating database from %d to %d: %v", version, 32, err)
+	}
+	version = db.checkVersion()
+	if version != 32 {
+		t.Fatalf("Version set to '%v' expected '32'.", version)
+	}
+	// Verify version 33
+	err = db.updateTables(version, 33)
+	if err != nil {
+		t.Fatalf("error updating database from %d to %d: %v", version, 33, err)
+	}
+	version = db.checkVersion()
+	if version != 33 {
+		t.Fatalf("Version set to '%v' expected '33'.", version)
+	}
 	// Check for error on drop tables as well. Because we can.
 	err = db.dropTables()
 	if err != nil {
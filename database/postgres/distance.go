@@ -42,14 +42,20 @@ func (p *Postgres) AddDistances(eventYearID int64, distances []types.Distance) (
 			"INSERT INTO distances("+
 				"event_year_id, "+
 				"distance_name, "+
-				"certification"+
-				") VALUES ($1,$2,$3) "+
+				"certification, "+
+				"distance_value, "+
+				"distance_unit"+
+				") VALUES ($1,$2,$3,$4,$5) "+
 				"ON CONFLICT (event_year_id, distance_name) DO UPDATE SET "+
-				"certification=$3"+
+				"certification=$3, "+
+				"distance_value=$4, "+
+				"distance_unit=$5"+
 				";",
 			eventYearID,
 			dist.Name,
 			dist.Certification,
+			dist.DistanceValue,
+			dist.DistanceUnit,
 		)
 		if err != nil {
 			tx.Rollback(ctx)
@@ -66,6 +72,8 @@ func (p *Postgres) AddDistances(eventYearID int64, distances []types.Distance) (
 		output = append(output, types.Distance{
 			Name:          dist.Name,
 			Certification: dist.Certification,
+			DistanceValue: dist.DistanceValue,
+			DistanceUnit:  dist.DistanceUnit,
 		})
 	}
 	return output, nil
@@ -80,7 +88,7 @@ func (p *Postgres) GetDistance(eventYearID int64, dist_name string) (*types.Dist
 	defer cancelfunc()
 	res, err := db.Query(
 		ctx,
-		"SELECT distance_id, distance_name, certification "+
+		"SELECT distance_id, distance_name, certification, distance_value, distance_unit "+
 			"FROM distances WHERE event_year_id=$1 AND distance_name=$2;",
 		eventYearID,
 		dist_name,
@@ -95,6 +103,8 @@ func (p *Postgres) GetDistance(eventYearID int64, dist_name string) (*types.Dist
 			&dist.Identifier,
 			&dist.Name,
 			&dist.Certification,
+			&dist.DistanceValue,
+			&dist.DistanceUnit,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("error getting distance: %v", err)
@@ -113,7 +123,7 @@ func (p *Postgres) GetDistances(eventYearID int64) ([]types.Distance, error) {
 	defer cancelfunc()
 	res, err := db.Query(
 		ctx,
-		"SELECT distance_id, distance_name, certification "+
+		"SELECT distance_id, distance_name, certification, distance_value, distance_unit "+
 			"FROM distances WHERE event_year_id=$1;",
 		eventYearID,
 	)
@@ -128,6 +138,8 @@ func (p *Postgres) GetDistances(eventYearID int64) ([]types.Distance, error) {
 			&dist.Identifier,
 			&dist.Name,
 			&dist.Certification,
+			&dist.DistanceValue,
+			&dist.DistanceUnit,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("error getting distance: %v", err)
@@ -264,6 +264,132 @@ func (p *Postgres) RealDeleteEvent(event types.Event) error {
 		tx.Rollback(ctx)
 		return fmt.Errorf("error deleting event people: %v", err)
 	}
+	_, err = tx.Exec(
+		ctx,
+		"DELETE FROM deleted_result d WHERE EXISTS (SELECT * FROM event_year y WHERE d.event_year_id=y.event_year_id AND y.event_id=$1);",
+		event.Identifier,
+	)
+	if err != nil {
+		tx.Rollback(ctx)
+		return fmt.Errorf("error deleting event deleted results: %v", err)
+	}
+	_, err = tx.Exec(
+		ctx,
+		"DELETE FROM records r WHERE EXISTS (SELECT * FROM event_year y WHERE r.event_year_id=y.event_year_id AND y.event_id=$1);",
+		event.Identifier,
+	)
+	if err != nil {
+		tx.Rollback(ctx)
+		return fmt.Errorf("error deleting event records: %v", err)
+	}
+	_, err = tx.Exec(
+		ctx,
+		"DELETE FROM team_members m WHERE EXISTS (SELECT * FROM teams t NATURAL JOIN event_year y WHERE m.team_id=t.team_id AND y.event_id=$1);",
+		event.Identifier,
+	)
+	if err != nil {
+		tx.Rollback(ctx)
+		return fmt.Errorf("error deleting event team members: %v", err)
+	}
+	_, err = tx.Exec(
+		ctx,
+		"DELETE FROM teams t WHERE EXISTS (SELECT * FROM event_year y WHERE t.event_year_id=y.event_year_id AND y.event_id=$1);",
+		event.Identifier,
+	)
+	if err != nil {
+		tx.Rollback(ctx)
+		return fmt.Errorf("error deleting event teams: %v", err)
+	}
+	_, err = tx.Exec(
+		ctx,
+		"DELETE FROM team_scoring s WHERE EXISTS (SELECT * FROM event_year y WHERE s.event_year_id=y.event_year_id AND y.event_id=$1);",
+		event.Identifier,
+	)
+	if err != nil {
+		tx.Rollback(ctx)
+		return fmt.Errorf("error deleting event team scoring: %v", err)
+	}
+	_, err = tx.Exec(
+		ctx,
+		"DELETE FROM series_events s WHERE EXISTS (SELECT * FROM event_year y WHERE s.event_year_id=y.event_year_id AND y.event_id=$1);",
+		event.Identifier,
+	)
+	if err != nil {
+		tx.Rollback(ctx)
+		return fmt.Errorf("error deleting event series events: %v", err)
+	}
+	_, err = tx.Exec(
+		ctx,
+		"DELETE FROM athlete_links l WHERE EXISTS (SELECT * FROM event_year y WHERE l.event_year_id=y.event_year_id AND y.event_id=$1);",
+		event.Identifier,
+	)
+	if err != nil {
+		tx.Rollback(ctx)
+		return fmt.Errorf("error deleting event athlete links: %v", err)
+	}
+	_, err = tx.Exec(
+		ctx,
+		"DELETE FROM result_audit a WHERE EXISTS (SELECT * FROM event_year y WHERE a.event_year_id=y.event_year_id AND y.event_id=$1);",
+		event.Identifier,
+	)
+	if err != nil {
+		tx.Rollback(ctx)
+		return fmt.Errorf("error deleting event result audit: %v", err)
+	}
+	_, err = tx.Exec(
+		ctx,
+		"DELETE FROM publish_settings s WHERE EXISTS (SELECT * FROM event_year y WHERE s.event_year_id=y.event_year_id AND y.event_id=$1);",
+		event.Identifier,
+	)
+	if err != nil {
+		tx.Rollback(ctx)
+		return fmt.Errorf("error deleting event publish settings: %v", err)
+	}
+	_, err = tx.Exec(
+		ctx,
+		"DELETE FROM hidden_distances h WHERE EXISTS (SELECT * FROM event_year y WHERE h.event_year_id=y.event_year_id AND y.event_id=$1);",
+		event.Identifier,
+	)
+	if err != nil {
+		tx.Rollback(ctx)
+		return fmt.Errorf("error deleting event hidden distances: %v", err)
+	}
+	_, err = tx.Exec(
+		ctx,
+		"DELETE FROM award_rules r WHERE EXISTS (SELECT * FROM event_year y WHERE r.event_year_id=y.event_year_id AND y.event_id=$1);",
+		event.Identifier,
+	)
+	if err != nil {
+		tx.Rollback(ctx)
+		return fmt.Errorf("error deleting event award rules: %v", err)
+	}
+	_, err = tx.Exec(
+		ctx,
+		"DELETE FROM waves w WHERE EXISTS (SELECT * FROM event_year y WHERE w.event_year_id=y.event_year_id AND y.event_id=$1);",
+		event.Identifier,
+	)
+	if err != nil {
+		tx.Rollback(ctx)
+		return fmt.Errorf("error deleting event waves: %v", err)
+	}
+	_, err = tx.Exec(
+		ctx,
+		"DELETE FROM handicap_scoring s WHERE EXISTS (SELECT * FROM event_year y WHERE s.event_year_id=y.event_year_id AND y.event_id=$1);",
+		event.Identifier,
+	)
+	if err != nil {
+		tx.Rollback(ctx)
+		return fmt.Errorf("error deleting event handicap scoring: %v", err)
+	}
+	_, err = tx.Exec(
+		ctx,
+		"DELETE FROM handicaps h WHERE EXISTS (SELECT * FROM event_year y WHERE h.event_year_id=y.event_year_id AND y.event_id=$1);",
+		event.Identifier,
+	)
+	if err != nil {
+		tx.Rollback(ctx)
+		return fmt.Errorf("error deleting event handicaps: %v", err)
+	}
 	_, err = tx.Exec(
 		ctx,
 		"DELETE FROM event_year WHERE event_id=$1;",
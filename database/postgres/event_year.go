@@ -36,7 +36,7 @@ func (p *Postgres) GetEventYear(event_slug, year string) (*types.EventYear, erro
 	defer cancelfunc()
 	res, err := db.Query(
 		ctx,
-		"SELECT event_year_id, event_id, year, date_time, live, days_allowed, ranking_type FROM event_year NATURAL JOIN event WHERE slug=$1 AND year=$2 AND year_deleted=FALSE;",
+		"SELECT event_year_id, event_id, year, date_time, live, days_allowed, ranking_type, year_status FROM event_year NATURAL JOIN event WHERE slug=$1 AND year=$2 AND year_deleted=FALSE;",
 		event_slug,
 		year,
 	)
@@ -54,6 +54,7 @@ func (p *Postgres) GetEventYear(event_slug, year string) (*types.EventYear, erro
 			&outEventYear.Live,
 			&outEventYear.DaysAllowed,
 			&outEventYear.RankingType,
+			&outEventYear.Status,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("error getting event year: %v", err)
@@ -110,7 +111,7 @@ func (p *Postgres) GetEventYears(event_slug string) ([]types.EventYear, error) {
 	defer cancelfunc()
 	res, err := db.Query(
 		ctx,
-		"SELECT event_year_id, event_id, year, date_time, live, days_allowed, ranking_type FROM event_year NATURAL JOIN event WHERE slug=$1 AND year_deleted=FALSE;",
+		"SELECT event_year_id, event_id, year, date_time, live, days_allowed, ranking_type, year_status FROM event_year NATURAL JOIN event WHERE slug=$1 AND year_deleted=FALSE;",
 		event_slug,
 	)
 	if err != nil {
@@ -128,6 +129,7 @@ func (p *Postgres) GetEventYears(event_slug string) ([]types.EventYear, error) {
 			&year.Live,
 			&year.DaysAllowed,
 			&year.RankingType,
+			&year.Status,
 		)
 		if err != nil {
 			return nil, nil
@@ -147,7 +149,7 @@ func (p *Postgres) GetAllEventYears() ([]types.EventYear, error) {
 	defer cancelfunc()
 	res, err := db.Query(
 		ctx,
-		"SELECT event_year_id, event_id, year, date_time, live, days_allowed, ranking_type FROM event_year NATURAL JOIN event WHERE year_deleted=FALSE;",
+		"SELECT event_year_id, event_id, year, date_time, live, days_allowed, ranking_type, year_status FROM event_year NATURAL JOIN event WHERE year_deleted=FALSE;",
 	)
 	if err != nil {
 		return nil, fmt.Errorf("error retrieving event years: %v", err)
@@ -164,6 +166,7 @@ func (p *Postgres) GetAllEventYears() ([]types.EventYear, error) {
 			&year.Live,
 			&year.DaysAllowed,
 			&year.RankingType,
+			&year.Status,
 		)
 		if err != nil {
 			return nil, nil
@@ -184,13 +187,14 @@ func (p *Postgres) AddEventYear(year types.EventYear) (*types.EventYear, error)
 	var id int64
 	err = db.QueryRow(
 		ctx,
-		"INSERT INTO event_year(event_id, year, date_time, live, days_allowed, ranking_type) VALUES ($1, $2, $3, $4, $5, $6) RETURNING (event_year_id);",
+		"INSERT INTO event_year(event_id, year, date_time, live, days_allowed, ranking_type, year_status) VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING (event_year_id);",
 		year.EventIdentifier,
 		year.Year,
 		year.DateTime,
 		year.Live,
 		year.DaysAllowed,
 		year.RankingType,
+		year.GetStatus(),
 	).Scan(&id)
 	if err != nil {
 		return nil, fmt.Errorf("unable to add event year: %v", err)
@@ -206,6 +210,7 @@ func (p *Postgres) AddEventYear(year types.EventYear) (*types.EventYear, error)
 		Live:            year.Live,
 		DaysAllowed:     year.DaysAllowed,
 		RankingType:     year.RankingType,
+		Status:          year.GetStatus(),
 	}, nil
 }
 
@@ -273,12 +278,13 @@ func (p *Postgres) UpdateEventYear(year types.EventYear) error {
 	defer cancelfunc()
 	_, err = db.Exec(
 		ctx,
-		"UPDATE event_year SET date_time=$1, live=$2, days_allowed=$4, ranking_type=$5 WHERE event_year_id=$3",
+		"UPDATE event_year SET date_time=$1, live=$2, days_allowed=$4, ranking_type=$5, year_status=$6 WHERE event_year_id=$3",
 		year.DateTime,
 		year.Live,
 		year.Identifier,
 		year.DaysAllowed,
 		year.RankingType,
+		year.GetStatus(),
 	)
 	if err != nil {
 		return fmt.Errorf("error updating event year: %v", err)
@@ -19,6 +19,7 @@ along with this program.  If not, see <https://www.gnu.org/licenses/>.
 package postgres
 
 import (
+	"chronokeep/results/database"
 	"chronokeep/results/types"
 	"context"
 	"fmt"
@@ -111,7 +112,7 @@ func (p *Postgres) GetPeople(slug, year string) ([]types.Person, error) {
 	return output, nil
 }
 
-func (p *Postgres) AddPerson(eventYearID int64, person types.Person) (*types.Person, error) {
+func (p *Postgres) AddPerson(eventYearID int64, person types.Person, actor *types.AuditActor) (*types.Person, error) {
 	db, err := p.GetDB()
 	if err != nil {
 		return nil, err
@@ -122,6 +123,12 @@ func (p *Postgres) AddPerson(eventYearID int64, person types.Person) (*types.Per
 	if err != nil {
 		return nil, fmt.Errorf("unable to start transaction: %v", err)
 	}
+	scope := database.AuditScope{AlternateIds: []string{person.AlternateId}}
+	auditPeople, auditResults, err := getAuditState(ctx, tx, eventYearID, scope)
+	if err != nil {
+		tx.Rollback(ctx)
+		return nil, err
+	}
 	output := types.Person{
 		Bib:         person.Bib,
 		First:       person.First,
@@ -172,6 +179,11 @@ func (p *Postgres) AddPerson(eventYearID int64, person types.Person) (*types.Per
 		tx.Rollback(ctx)
 		return nil, fmt.Errorf("error adding person to database: %v", err)
 	}
+	err = addAuditEntries(ctx, tx, eventYearID, scope, actor, auditPeople, auditResults)
+	if err != nil {
+		tx.Rollback(ctx)
+		return nil, err
+	}
 	err = tx.Commit(ctx)
 	if err != nil {
 		tx.Rollback(ctx)
@@ -180,7 +192,7 @@ func (p *Postgres) AddPerson(eventYearID int64, person types.Person) (*types.Per
 	return &output, nil
 }
 
-func (p *Postgres) AddPeople(eventYearID int64, people []types.Person) ([]types.Person, error) {
+func (p *Postgres) AddPeople(eventYearID int64, people []types.Person, actor *types.AuditActor) ([]types.Person, error) {
 	db, err := p.GetDB()
 	if err != nil {
 		return nil, err
@@ -192,6 +204,12 @@ func (p *Postgres) AddPeople(eventYearID int64, people []types.Person) ([]types.
 		return nil, fmt.Errorf("unable to start transaction: %v", err)
 	}
 	output := make([]types.Person, 0)
+	scope := database.PeopleAuditScope(people)
+	auditPeople, auditResults, err := getAuditState(ctx, tx, eventYearID, scope)
+	if err != nil {
+		tx.Rollback(ctx)
+		return nil, err
+	}
 	for _, person := range people {
 		var id int64
 		err = tx.QueryRow(
@@ -246,6 +264,11 @@ func (p *Postgres) AddPeople(eventYearID int64, people []types.Person) ([]types.
 			AlternateId: person.AlternateId,
 		})
 	}
+	err = addAuditEntries(ctx, tx, eventYearID, scope, actor, auditPeople, auditResults)
+	if err != nil {
+		tx.Rollback(ctx)
+		return nil, err
+	}
 	err = tx.Commit(ctx)
 	if err != nil {
 		tx.Rollback(ctx)
@@ -254,7 +277,7 @@ func (p *Postgres) AddPeople(eventYearID int64, people []types.Person) ([]types.
 	return output, nil
 }
 
-func (p *Postgres) DeletePeople(eventYearId int64, alternateIds []string) (int64, error) {
+func (p *Postgres) DeletePeople(eventYearId int64, alternateIds []string, actor *types.AuditActor) (int64, error) {
 	db, err := p.GetDB()
 	if err != nil {
 		return 0, err
@@ -265,6 +288,12 @@ func (p *Postgres) DeletePeople(eventYearId int64, alternateIds []string) (int64
 	if err != nil {
 		return 0, fmt.Errorf("error starting transaction: %v", err)
 	}
+	scope := database.AuditScope{AlternateIds: alternateIds}
+	auditPeople, auditResults, err := getAuditState(ctx, tx, eventYearId, scope)
+	if err != nil {
+		tx.Rollback(ctx)
+		return 0, err
+	}
 	var count int64
 	if len(alternateIds) > 0 {
 		count = 0
@@ -293,6 +322,11 @@ func (p *Postgres) DeletePeople(eventYearId int64, alternateIds []string) (int64
 		}
 		count = res.RowsAffected()
 	}
+	err = addAuditEntries(ctx, tx, eventYearId, scope, actor, auditPeople, auditResults)
+	if err != nil {
+		tx.Rollback(ctx)
+		return 0, err
+	}
 	err = tx.Commit(ctx)
 	if err != nil {
 		tx.Rollback(ctx)
@@ -301,7 +335,7 @@ func (p *Postgres) DeletePeople(eventYearId int64, alternateIds []string) (int64
 	return count, nil
 }
 
-func (p *Postgres) UpdatePerson(eventYearID int64, person types.Person) (*types.Person, error) {
+func (p *Postgres) UpdatePerson(eventYearID int64, person types.Person, actor *types.AuditActor) (*types.Person, error) {
 	db, err := p.GetDB()
 	if err != nil {
 		return nil, err
@@ -312,6 +346,12 @@ func (p *Postgres) UpdatePerson(eventYearID int64, person types.Person) (*types.
 	if err != nil {
 		return nil, fmt.Errorf("unable to start transaction: %v", err)
 	}
+	scope := database.AuditScope{AlternateIds: []string{person.AlternateId}}
+	auditPeople, auditResults, err := getAuditState(ctx, tx, eventYearID, scope)
+	if err != nil {
+		tx.Rollback(ctx)
+		return nil, err
+	}
 	output := types.Person{
 		Bib:         person.Bib,
 		First:       person.First,
@@ -350,6 +390,11 @@ func (p *Postgres) UpdatePerson(eventYearID int64, person types.Person) (*types.
 		tx.Rollback(ctx)
 		return nil, fmt.Errorf("error adding person to database: %v", err)
 	}
+	err = addAuditEntries(ctx, tx, eventYearID, scope, actor, auditPeople, auditResults)
+	if err != nil {
+		tx.Rollback(ctx)
+		return nil, err
+	}
 	err = tx.Commit(ctx)
 	if err != nil {
 		tx.Rollback(ctx)
@@ -0,0 +1,211 @@
+/*
+Chronokeep Desktop - Race Scoring Software
+Copyright (C) 2026 James Sentinella
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package postgres
+
+import (
+	"chronokeep/results/types"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	records []types.Record
+)
+
+func setupRecordTests() {
+	if len(accounts) < 1 {
+		accounts = []types.Account{
+			{
+				Name:     "John Smith",
+				Email:    "j@test.com",
+				Type:     "admin",
+				Password: testHashPassword("password"),
+			},
+		}
+	}
+	records = []types.Record{
+		{
+			Distance:     "5K",
+			Category:     types.RecordCourse,
+			Bib:          "100",
+			First:        "John",
+			Last:         "Smith",
+			Seconds:      1100,
+			Milliseconds: 250,
+		},
+		{
+			Distance:     "5K",
+			Category:     types.RecordGender,
+			Gender:       "M",
+			Bib:          "100",
+			First:        "John",
+			Last:         "Smith",
+			Seconds:      1100,
+			Milliseconds: 250,
+		},
+		{
+			Distance:     "5K",
+			Category:     types.RecordAgeGroup,
+			Gender:       "M",
+			AgeGroup:     "20-29",
+			Bib:          "100",
+			First:        "John",
+			Last:         "Smith",
+			Seconds:      1100,
+			Milliseconds: 250,
+		},
+	}
+}
+
+func TestSetRecords(t *testing.T) {
+	db, finalize, err := setupTests(t)
+	if err != nil {
+		t.Fatalf("Error setting up tests. %v", err)
+	}
+	defer finalize(t)
+	setupRecordTests()
+	account, _ := db.AddAccount(accounts[0])
+	event := &types.Event{
+		AccountIdentifier: account.Identifier,
+		Name:              "Event 1",
+		Slug:              "event1",
+	}
+	event, _ = db.AddEvent(*event)
+	eventYear := &types.EventYear{
+		EventIdentifier: event.Identifier,
+		Year:            "2021",
+		DateTime:        time.Date(2021, 04, 20, 9, 0, 0, 0, time.Local),
+		Live:            false,
+		DaysAllowed:     1,
+		RankingType:     "chip",
+	}
+	eventYear, _ = db.AddEventYear(*eventYear)
+	r, err := db.SetRecords(eventYear.Identifier, records)
+	if assert.NoError(t, err) {
+		assert.Equal(t, len(records), len(r))
+	}
+	r, err = db.GetRecords(event.Identifier)
+	if assert.NoError(t, err) {
+		assert.Equal(t, len(records), len(r))
+		for _, outer := range records {
+			outer.Year = eventYear.Year
+			found := false
+			for _, inner := range r {
+				if outer.Equals(&inner) {
+					found = true
+				}
+			}
+			assert.True(t, found)
+		}
+	}
+	// Setting records replaces the old ones.
+	_, err = db.SetRecords(eventYear.Identifier, records[:1])
+	assert.NoError(t, err)
+	r, err = db.GetRecords(event.Identifier)
+	if assert.NoError(t, err) {
+		assert.Equal(t, 1, len(r))
+	}
+	_, err = db.SetRecords(eventYear.Identifier, nil)
+	assert.NoError(t, err)
+	r, err = db.GetRecords(event.Identifier)
+	if assert.NoError(t, err) {
+		assert.Equal(t, 0, len(r))
+	}
+}
+
+func TestGetRecords(t *testing.T) {
+	db, finalize, err := setupTests(t)
+	if err != nil {
+		t.Fatalf("Error setting up tests. %v", err)
+	}
+	defer finalize(t)
+	setupRecordTests()
+	account, _ := db.AddAccount(accounts[0])
+	event1, _ := db.AddEvent(types.Event{
+		AccountIdentifier: account.Identifier,
+		Name:              "Event 1",
+		Slug:              "event1",
+	})
+	event2, _ := db.AddEvent(types.Event{
+		AccountIdentifier: account.Identifier,
+		Name:              "Event 2",
+		Slug:              "event2",
+	})
+	year2021, _ := db.AddEventYear(types.EventYear{
+		EventIdentifier: event1.Identifier,
+		Year:            "2021",
+		DateTime:        time.Date(2021, 04, 20, 9, 0, 0, 0, time.Local),
+		RankingType:     "gun",
+	})
+	year2020, _ := db.AddEventYear(types.EventYear{
+		EventIdentifier: event1.Identifier,
+		Year:            "2020",
+		DateTime:        time.Date(2020, 04, 20, 9, 0, 0, 0, time.Local),
+		RankingType:     "gun",
+	})
+	year2019, _ := db.AddEventYear(types.EventYear{
+		EventIdentifier: event1.Identifier,
+		Year:            "2019",
+		DateTime:        time.Date(2019, 04, 20, 9, 0, 0, 0, time.Local),
+		RankingType:     "gun",
+	})
+	other, _ := db.AddEventYear(types.EventYear{
+		EventIdentifier: event2.Identifier,
+		Year:            "2021",
+		DateTime:        time.Date(2021, 04, 20, 9, 0, 0, 0, time.Local),
+		RankingType:     "gun",
+	})
+	r, err := db.GetRecords(event1.Identifier)
+	if assert.NoError(t, err) {
+		assert.Equal(t, 0, len(r))
+	}
+	_, err = db.SetRecords(year2021.Identifier, records)
+	assert.NoError(t, err)
+	_, err = db.SetRecords(year2020.Identifier, records[:1])
+	assert.NoError(t, err)
+	_, err = db.SetRecords(year2019.Identifier, records[:2])
+	assert.NoError(t, err)
+	_, err = db.SetRecords(other.Identifier, records)
+	assert.NoError(t, err)
+	// Records are returned oldest year first.
+	r, err = db.GetRecords(event1.Identifier)
+	if assert.NoError(t, err) && assert.Equal(t, 6, len(r)) {
+		assert.Equal(t, "2019", r[0].Year)
+		assert.Equal(t, "2019", r[1].Year)
+		assert.Equal(t, "2020", r[2].Year)
+		assert.Equal(t, "2021", r[5].Year)
+	}
+	// Records from deleted years aren't returned.
+	err = db.DeleteEventYear(*year2019)
+	assert.NoError(t, err)
+	r, err = db.GetRecords(event1.Identifier)
+	if assert.NoError(t, err) {
+		assert.Equal(t, 4, len(r))
+		for _, rec := range r {
+			assert.NotEqual(t, "2019", rec.Year)
+		}
+	}
+	r, err = db.GetRecords(event2.Identifier)
+	if assert.NoError(t, err) {
+		assert.Equal(t, len(records), len(r))
+	}
+}
+
@@ -19,7 +19,9 @@ along with this program.  If not, see <https://www.gnu.org/licenses/>.
 package postgres
 
 import (
+	"chronokeep/results/database"
 	"chronokeep/results/types"
+	"chronokeep/results/util"
 	"context"
 	"errors"
 	"fmt"
@@ -36,6 +38,61 @@ const (
 	Last
 )
 
+const (
+	// Results that didn't finish, didn't start or were disqualified are listed after everyone else.
+	resultOrder = "CASE result_status WHEN 'dnf' THEN 1 WHEN 'dq' THEN 2 WHEN 'dns' THEN 3 ELSE 0 END ASC, seconds ASC"
+	// The columns scanned by scanResults.
+	resultColumns = "bib, first, last, age, gender, age_group, distance, seconds, milliseconds, " +
+		"chip_seconds, chip_milliseconds, segment, location, occurence, ranking, age_ranking, " +
+		"gender_ranking, finish, result_type, anonymous, alternate_id, local_time, division, " +
+		"division_ranking, result_status, status_reason"
+	// A bib that didn't finish, didn't start or was disqualified shows that as their last result.
+	lastResultKey = "(CASE WHEN result_status IN ('dnf', 'dns', 'dq') THEN 1 ELSE 0 END)*100000000+seconds"
+)
+
+// scanResults Reads every result from rows selecting resultColumns.
+func scanResults(res pgx.Rows) ([]types.Result, error) {
+	var outResults []types.Result
+	for res.Next() {
+		var result types.Result
+		var anonymous int
+		err := res.Scan(
+			&result.Bib,
+			&result.First,
+			&result.Last,
+			&result.Age,
+			&result.Gender,
+			&result.AgeGroup,
+			&result.Distance,
+			&result.Seconds,
+			&result.Milliseconds,
+			&result.ChipSeconds,
+			&result.ChipMilliseconds,
+			&result.Segment,
+			&result.Location,
+			&result.Occurence,
+			&result.Ranking,
+			&result.AgeRanking,
+			&result.GenderRanking,
+			&result.Finish,
+			&result.Type,
+			&anonymous,
+			&result.PersonId,
+			&result.LocalTime,
+			&result.Division,
+			&result.DivisionRanking,
+			&result.Status,
+			&result.StatusReason,
+		)
+		result.Anonymous = anonymous != 0
+		if err != nil {
+			return nil, fmt.Errorf("error getting result: %v", err)
+		}
+		outResults = append(outResults, result)
+	}
+	return outResults, nil
+}
+
 func (p *Postgres) getResultsInternal(eventYearID int64, bib *string, rtype ResultType, distance string, limit, page int) ([]types.Result, error) {
 	db, err := p.GetDB()
 	if err != nil {
@@ -50,11 +107,8 @@ func (p *Postgres) getResultsInternal(eventYearID int64, bib *string, rtype Resu
 		if limit > 0 {
 			res, err = db.Query(
 				ctx,
-				"SELECT bib, first, last, age, gender, age_group, distance, seconds, milliseconds, "+
-					"chip_seconds, chip_milliseconds, segment, location, occurence, ranking, age_ranking, "+
-					"gender_ranking, finish, result_type, anonymous, alternate_id, local_time, division, "+
-					"division_ranking FROM result NATURAL JOIN person "+
-					"WHERE event_year_id=$1 AND bib=$2 ORDER BY seconds ASC LIMIT $3 OFFSET $4;",
+				"SELECT "+resultColumns+" FROM result NATURAL JOIN person "+
+					"WHERE event_year_id=$1 AND bib=$2 ORDER BY "+resultOrder+" LIMIT $3 OFFSET $4;",
 				eventYearID,
 				bib,
 				limit,
@@ -63,11 +117,8 @@ func (p *Postgres) getResultsInternal(eventYearID int64, bib *string, rtype Resu
 		} else {
 			res, err = db.Query(
 				ctx,
-				"SELECT bib, first, last, age, gender, age_group, distance, seconds, milliseconds, "+
-					"chip_seconds, chip_milliseconds, segment, location, occurence, ranking, age_ranking, "+
-					"gender_ranking, finish, result_type, anonymous, alternate_id, local_time, division, "+
-					"division_ranking FROM result NATURAL JOIN person "+
-					"WHERE event_year_id=$1 AND bib=$2 ORDER BY seconds ASC;",
+				"SELECT "+resultColumns+" FROM result NATURAL JOIN person "+
+					"WHERE event_year_id=$1 AND bib=$2 ORDER BY "+resultOrder+";",
 				eventYearID,
 				bib,
 			)
@@ -77,11 +128,8 @@ func (p *Postgres) getResultsInternal(eventYearID int64, bib *string, rtype Resu
 			if limit > 0 {
 				res, err = db.Query(
 					ctx,
-					"SELECT bib, first, last, age, gender, age_group, distance, seconds, milliseconds, "+
-						"chip_seconds, chip_milliseconds, segment, location, occurence, ranking, age_ranking, "+
-						"gender_ranking, finish, result_type, anonymous, alternate_id, local_time, division, "+
-						"division_ranking FROM result NATURAL JOIN person WHERE "+
-						"finish=TRUE AND event_year_id=$1 AND distance=$2 ORDER BY seconds ASC LIMIT $3 OFFSET $4;",
+					"SELECT "+resultColumns+" FROM result NATURAL JOIN person WHERE "+
+						"finish=TRUE AND event_year_id=$1 AND distance=$2 ORDER BY "+resultOrder+" LIMIT $3 OFFSET $4;",
 					eventYearID,
 					distance,
 					limit,
@@ -90,11 +138,8 @@ func (p *Postgres) getResultsInternal(eventYearID int64, bib *string, rtype Resu
 			} else {
 				res, err = db.Query(
 					ctx,
-					"SELECT bib, first, last, age, gender, age_group, distance, seconds, milliseconds, "+
-						"chip_seconds, chip_milliseconds, segment, location, occurence, ranking, age_ranking, "+
-						"gender_ranking, finish, result_type, anonymous, alternate_id, local_time, division, "+
-						"division_ranking FROM result NATURAL JOIN person WHERE "+
-						"finish=TRUE AND event_year_id=$1 AND distance=$2 ORDER BY seconds ASC;",
+					"SELECT "+resultColumns+" FROM result NATURAL JOIN person WHERE "+
+						"finish=TRUE AND event_year_id=$1 AND distance=$2 ORDER BY "+resultOrder+";",
 					eventYearID,
 					distance,
 				)
@@ -103,11 +148,8 @@ func (p *Postgres) getResultsInternal(eventYearID int64, bib *string, rtype Resu
 			if limit > 0 {
 				res, err = db.Query(
 					ctx,
-					"SELECT bib, first, last, age, gender, age_group, distance, seconds, milliseconds, "+
-						"chip_seconds, chip_milliseconds, segment, location, occurence, ranking, age_ranking, "+
-						"gender_ranking, finish, result_type, anonymous, alternate_id, local_time, division, "+
-						"division_ranking FROM result NATURAL JOIN person WHERE "+
-						"event_year_id=$1 AND distance=$2 ORDER BY seconds ASC LIMIT $3 OFFSET $4;",
+					"SELECT "+resultColumns+" FROM result NATURAL JOIN person WHERE "+
+						"event_year_id=$1 AND distance=$2 ORDER BY "+resultOrder+" LIMIT $3 OFFSET $4;",
 					eventYearID,
 					distance,
 					limit,
@@ -116,11 +158,8 @@ func (p *Postgres) getResultsInternal(eventYearID int64, bib *string, rtype Resu
 			} else {
 				res, err = db.Query(
 					ctx,
-					"SELECT bib, first, last, age, gender, age_group, distance, seconds, milliseconds, "+
-						"chip_seconds, chip_milliseconds, segment, location, occurence, ranking, age_ranking, "+
-						"gender_ranking, finish, result_type, anonymous, alternate_id, local_time, division, "+
-						"division_ranking FROM result NATURAL JOIN person WHERE "+
-						"event_year_id=$1 AND distance=$2 ORDER BY seconds ASC;",
+					"SELECT "+resultColumns+" FROM result NATURAL JOIN person WHERE "+
+						"event_year_id=$1 AND distance=$2 ORDER BY "+resultOrder+";",
 					eventYearID,
 					distance,
 				)
@@ -129,15 +168,12 @@ func (p *Postgres) getResultsInternal(eventYearID int64, bib *string, rtype Resu
 			if limit > 0 {
 				res, err = db.Query(
 					ctx,
-					"SELECT bib, first, last, age, gender, age_group, distance, seconds, milliseconds, "+
-						"chip_seconds, chip_milliseconds, segment, location, occurence, ranking, age_ranking, "+
-						"gender_ranking, finish, result_type, anonymous, alternate_id, local_time, division, "+
-						"division_ranking FROM result r NATURAL JOIN person p "+
-						"JOIN (SELECT bib AS mx_bib, event_year_id AS mx_event_year_id, MAX(seconds) as mx_seconds "+
+					"SELECT "+resultColumns+" FROM result r NATURAL JOIN person p "+
+						"JOIN (SELECT bib AS mx_bib, event_year_id AS mx_event_year_id, MAX("+lastResultKey+") as mx_key "+
 						"FROM result NATURAL JOIN person GROUP BY bib, event_year_id, segment) b "+
 						"ON b.mx_bib=p.bib AND b.mx_event_year_id=p.event_year_id "+
-						"AND b.mx_seconds=r.seconds "+
-						"WHERE event_year_id=$1 AND distance=$2 ORDER BY seconds ASC LIMIT $3 OFFSET $4;",
+						"AND b.mx_key="+lastResultKey+" "+
+						"WHERE event_year_id=$1 AND distance=$2 ORDER BY "+resultOrder+" LIMIT $3 OFFSET $4;",
 					eventYearID,
 					distance,
 					limit,
@@ -146,15 +182,12 @@ func (p *Postgres) getResultsInternal(eventYearID int64, bib *string, rtype Resu
 			} else {
 				res, err = db.Query(
 					ctx,
-					"SELECT bib, first, last, age, gender, age_group, distance, seconds, milliseconds, "+
-						"chip_seconds, chip_milliseconds, segment, location, occurence, ranking, age_ranking, "+
-						"gender_ranking, finish, result_type, anonymous, alternate_id, local_time, division, "+
-						"division_ranking FROM result r NATURAL JOIN person p "+
-						"JOIN (SELECT bib AS mx_bib, event_year_id AS mx_event_year_id, MAX(seconds) as mx_seconds "+
+					"SELECT "+resultColumns+" FROM result r NATURAL JOIN person p "+
+						"JOIN (SELECT bib AS mx_bib, event_year_id AS mx_event_year_id, MAX("+lastResultKey+") as mx_key "+
 						"FROM result NATURAL JOIN person GROUP BY bib, event_year_id, segment) b "+
 						"ON b.mx_bib=p.bib AND b.mx_event_year_id=p.event_year_id "+
-						"AND b.mx_seconds=r.seconds "+
-						"WHERE event_year_id=$1 AND distance=$2 ORDER BY seconds ASC;",
+						"AND b.mx_key="+lastResultKey+" "+
+						"WHERE event_year_id=$1 AND distance=$2 ORDER BY "+resultOrder+";",
 					eventYearID,
 					distance,
 				)
@@ -164,11 +197,8 @@ func (p *Postgres) getResultsInternal(eventYearID int64, bib *string, rtype Resu
 		if limit > 0 {
 			res, err = db.Query(
 				ctx,
-				"SELECT bib, first, last, age, gender, age_group, distance, seconds, milliseconds, "+
-					"chip_seconds, chip_milliseconds, segment, location, occurence, ranking, age_ranking, "+
-					"gender_ranking, finish, result_type, anonymous, alternate_id, local_time, division, "+
-					"division_ranking FROM result NATURAL JOIN person "+
-					"WHERE event_year_id=$1 ORDER BY seconds ASC LIMIT $2 OFFSET $3;",
+				"SELECT "+resultColumns+" FROM result NATURAL JOIN person "+
+					"WHERE event_year_id=$1 ORDER BY "+resultOrder+" LIMIT $2 OFFSET $3;",
 				eventYearID,
 				limit,
 				page*limit,
@@ -176,11 +206,8 @@ func (p *Postgres) getResultsInternal(eventYearID int64, bib *string, rtype Resu
 		} else {
 			res, err = db.Query(
 				ctx,
-				"SELECT bib, first, last, age, gender, age_group, distance, seconds, milliseconds, "+
-					"chip_seconds, chip_milliseconds, segment, location, occurence, ranking, age_ranking, "+
-					"gender_ranking, finish, result_type, anonymous, alternate_id, local_time, division, "+
-					"division_ranking FROM result NATURAL JOIN person "+
-					"WHERE event_year_id=$1 ORDER BY seconds ASC;",
+				"SELECT "+resultColumns+" FROM result NATURAL JOIN person "+
+					"WHERE event_year_id=$1 ORDER BY "+resultOrder+";",
 				eventYearID,
 			)
 		}
@@ -188,11 +215,8 @@ func (p *Postgres) getResultsInternal(eventYearID int64, bib *string, rtype Resu
 		if limit > 0 {
 			res, err = db.Query(
 				ctx,
-				"SELECT bib, first, last, age, gender, age_group, distance, seconds, milliseconds, "+
-					"chip_seconds, chip_milliseconds, segment, location, occurence, ranking, age_ranking, "+
-					"gender_ranking, finish, result_type, anonymous, alternate_id, local_time, division, "+
-					"division_ranking FROM result NATURAL JOIN person "+
-					"WHERE finish=TRUE AND event_year_id=$1 ORDER BY seconds ASC LIMIT $2 OFFSET $3;",
+				"SELECT "+resultColumns+" FROM result NATURAL JOIN person "+
+					"WHERE finish=TRUE AND event_year_id=$1 ORDER BY "+resultOrder+" LIMIT $2 OFFSET $3;",
 				eventYearID,
 				limit,
 				page*limit,
@@ -200,11 +224,8 @@ func (p *Postgres) getResultsInternal(eventYearID int64, bib *string, rtype Resu
 		} else {
 			res, err = db.Query(
 				ctx,
-				"SELECT bib, first, last, age, gender, age_group, distance, seconds, milliseconds, "+
-					"chip_seconds, chip_milliseconds, segment, location, occurence, ranking, age_ranking, "+
-					"gender_ranking, finish, result_type, anonymous, alternate_id, local_time, division, "+
-					"division_ranking FROM result NATURAL JOIN person "+
-					"WHERE finish=TRUE AND event_year_id=$1 ORDER BY seconds ASC;",
+				"SELECT "+resultColumns+" FROM result NATURAL JOIN person "+
+					"WHERE finish=TRUE AND event_year_id=$1 ORDER BY "+resultOrder+";",
 				eventYearID,
 			)
 		}
@@ -212,15 +233,12 @@ func (p *Postgres) getResultsInternal(eventYearID int64, bib *string, rtype Resu
 		if limit > 0 {
 			res, err = db.Query(
 				ctx,
-				"SELECT bib, first, last, age, gender, age_group, distance, seconds, milliseconds, "+
-					"chip_seconds, chip_milliseconds, segment, location, occurence, ranking, age_ranking, "+
-					"gender_ranking, finish, result_type, anonymous, alternate_id, local_time, division, "+
-					"division_ranking FROM result r NATURAL JOIN person p "+
-					"JOIN (SELECT bib AS mx_bib, event_year_id AS mx_event_year_id, MAX(seconds) as mx_seconds "+
+				"SELECT "+resultColumns+" FROM result r NATURAL JOIN person p "+
+					"JOIN (SELECT bib AS mx_bib, event_year_id AS mx_event_year_id, MAX("+lastResultKey+") as mx_key "+
 					"FROM result NATURAL JOIN person GROUP BY bib, event_year_id, segment) b "+
 					"ON b.mx_bib=p.bib AND b.mx_event_year_id=p.event_year_id "+
-					"AND b.mx_seconds=r.seconds "+
-					"WHERE event_year_id=$1 ORDER BY seconds ASC LIMIT $2 OFFSET $3;",
+					"AND b.mx_key="+lastResultKey+" "+
+					"WHERE event_year_id=$1 ORDER BY "+resultOrder+" LIMIT $2 OFFSET $3;",
 				eventYearID,
 				limit,
 				page*limit,
@@ -228,15 +246,12 @@ func (p *Postgres) getResultsInternal(eventYearID int64, bib *string, rtype Resu
 		} else {
 			res, err = db.Query(
 				ctx,
-				"SELECT bib, first, last, age, gender, age_group, distance, seconds, milliseconds, "+
-					"chip_seconds, chip_milliseconds, segment, location, occurence, ranking, age_ranking, "+
-					"gender_ranking, finish, result_type, anonymous, alternate_id, local_time, division, "+
-					"division_ranking FROM result r NATURAL JOIN person p "+
-					"JOIN (SELECT bib AS mx_bib, event_year_id AS mx_event_year_id, MAX(seconds) as mx_seconds "+
+				"SELECT "+resultColumns+" FROM result r NATURAL JOIN person p "+
+					"JOIN (SELECT bib AS mx_bib, event_year_id AS mx_event_year_id, MAX("+lastResultKey+") as mx_key "+
 					"FROM result NATURAL JOIN person GROUP BY bib, event_year_id, segment) b "+
 					"ON b.mx_bib=p.bib AND b.mx_event_year_id=p.event_year_id "+
-					"AND b.mx_seconds=r.seconds "+
-					"WHERE event_year_id=$1 ORDER BY seconds ASC;",
+					"AND b.mx_key="+lastResultKey+" "+
+					"WHERE event_year_id=$1 ORDER BY "+resultOrder+";",
 				eventYearID,
 			)
 		}
@@ -245,43 +260,7 @@ func (p *Postgres) getResultsInternal(eventYearID int64, bib *string, rtype Resu
 		return nil, fmt.Errorf("error retrieving results: %v", err)
 	}
 	defer res.Close()
-	var outResults []types.Result
-	for res.Next() {
-		var result types.Result
-		var anonymous int
-		err := res.Scan(
-			&result.Bib,
-			&result.First,
-			&result.Last,
-			&result.Age,
-			&result.Gender,
-			&result.AgeGroup,
-			&result.Distance,
-			&result.Seconds,
-			&result.Milliseconds,
-			&result.ChipSeconds,
-			&result.ChipMilliseconds,
-			&result.Segment,
-			&result.Location,
-			&result.Occurence,
-			&result.Ranking,
-			&result.AgeRanking,
-			&result.GenderRanking,
-			&result.Finish,
-			&result.Type,
-			&anonymous,
-			&result.PersonId,
-			&result.LocalTime,
-			&result.Division,
-			&result.DivisionRanking,
-		)
-		result.Anonymous = anonymous != 0
-		if err != nil {
-			return nil, fmt.Errorf("error getting result: %v", err)
-		}
-		outResults = append(outResults, result)
-	}
-	return outResults, nil
+	return scanResults(res)
 }
 
 // GetResults Gets results for an event year.
@@ -314,8 +293,143 @@ func (p *Postgres) GetBibResults(eventYearID int64, bib string) ([]types.Result,
 	return p.getResultsInternal(eventYearID, &bib, All, "", 0, 0)
 }
 
+// GetUpdatedResults Gets all results for an event year (or just a distance) that have been added
+// or changed since updatedAfter, a unix timestamp in seconds.
+func (p *Postgres) GetUpdatedResults(eventYearID int64, distance string, updatedAfter int64) ([]types.Result, error) {
+	db, err := p.GetDB()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancelfunc := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancelfunc()
+	var res pgx.Rows
+	if distance != "" {
+		res, err = db.Query(
+			ctx,
+			"SELECT "+resultColumns+" FROM result NATURAL JOIN person "+
+				"WHERE event_year_id=$1 AND distance=$2 AND result_updated_at>=to_timestamp($3) ORDER BY "+resultOrder+";",
+			eventYearID,
+			distance,
+			updatedAfter,
+		)
+	} else {
+		res, err = db.Query(
+			ctx,
+			"SELECT "+resultColumns+" FROM result NATURAL JOIN person "+
+				"WHERE event_year_id=$1 AND result_updated_at>=to_timestamp($2) ORDER BY "+resultOrder+";",
+			eventYearID,
+			updatedAfter,
+		)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving updated results: %v", err)
+	}
+	defer res.Close()
+	return scanResults(res)
+}
+
+// GetLocationResults Gets the results for an event year (or just a distance) at a single timing
+// location.  The location is either the name of a segment or a location and the occurence of it,
+// and the results are ordered by arrival.
+func (p *Postgres) GetLocationResults(eventYearID int64, distance, location, segment string, occurence int) ([]types.Result, error) {
+	db, err := p.GetDB()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancelfunc := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancelfunc()
+	var res pgx.Rows
+	if distance != "" {
+		res, err = db.Query(
+			ctx,
+			"SELECT "+resultColumns+" FROM result NATURAL JOIN person "+
+				"WHERE event_year_id=$1 AND distance=$2 AND ((segment<>'' AND segment=$3) OR (location=$4 AND occurence=$5)) "+
+				"ORDER BY "+resultOrder+", milliseconds ASC;",
+			eventYearID,
+			distance,
+			segment,
+			location,
+			occurence,
+		)
+	} else {
+		res, err = db.Query(
+			ctx,
+			"SELECT "+resultColumns+" FROM result NATURAL JOIN person "+
+				"WHERE event_year_id=$1 AND ((segment<>'' AND segment=$2) OR (location=$3 AND occurence=$4)) "+
+				"ORDER BY "+resultOrder+", milliseconds ASC;",
+			eventYearID,
+			segment,
+			location,
+			occurence,
+		)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving location results: %v", err)
+	}
+	defer res.Close()
+	outResults, err := scanResults(res)
+	if outResults == nil && err == nil {
+		outResults = make([]types.Result, 0)
+	}
+	return outResults, err
+}
+
+// GetDeletedResults Gets the results for an event year (or just a distance) that have been deleted
+// since deletedAfter, a unix timestamp in seconds.  Only the bib, distance, location and occurence
+// of each result are returned.  Results that have been added again since are not included.
+func (p *Postgres) GetDeletedResults(eventYearID int64, distance string, deletedAfter int64) ([]types.Result, error) {
+	db, err := p.GetDB()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancelfunc := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancelfunc()
+	var res pgx.Rows
+	if distance != "" {
+		res, err = db.Query(
+			ctx,
+			"SELECT bib, distance, location, occurence FROM deleted_result d "+
+				"WHERE event_year_id=$1 AND distance=$2 AND deleted_at>=$3 AND NOT EXISTS ("+
+				"SELECT * FROM result r NATURAL JOIN person p WHERE p.event_year_id=d.event_year_id "+
+				"AND p.bib=d.bib AND r.location=d.location AND r.occurence=d.occurence);",
+			eventYearID,
+			distance,
+			deletedAfter,
+		)
+	} else {
+		res, err = db.Query(
+			ctx,
+			"SELECT bib, distance, location, occurence FROM deleted_result d "+
+				"WHERE event_year_id=$1 AND deleted_at>=$2 AND NOT EXISTS ("+
+				"SELECT * FROM result r NATURAL JOIN person p WHERE p.event_year_id=d.event_year_id "+
+				"AND p.bib=d.bib AND r.location=d.location AND r.occurence=d.occurence);",
+			eventYearID,
+			deletedAfter,
+		)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving deleted results: %v", err)
+	}
+	defer res.Close()
+	var outResults []types.Result
+	for res.Next() {
+		var result types.Result
+		err := res.Scan(
+			&result.Bib,
+			&result.Distance,
+			&result.Location,
+			&result.Occurence,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("error getting deleted result: %v", err)
+		}
+		outResults = append(outResults, result)
+	}
+	return outResults, nil
+}
+
 // DeleteResults Deletes results from the database.
-func (p *Postgres) DeleteResults(eventYearID int64, results []types.Result) (int64, error) {
+func (p *Postgres) DeleteResults(eventYearID int64, results []types.Result, actor *types.AuditActor) (int64, error) {
 	db, err := p.GetDB()
 	if err != nil {
 		return 0, err
@@ -326,7 +440,31 @@ func (p *Postgres) DeleteResults(eventYearID int64, results []types.Result) (int
 	if err != nil {
 		return 0, fmt.Errorf("unable to begin transaction to delete results: %v", err)
 	}
+	scope := database.ResultsAuditScope(results)
+	auditPeople, auditResults, err := getAuditState(ctx, tx, eventYearID, scope)
+	if err != nil {
+		tx.Rollback(ctx)
+		return 0, err
+	}
+	deletedAt := time.Now().Unix()
 	for _, result := range results {
+		_, err = tx.Exec(
+			ctx,
+			"INSERT INTO deleted_result(event_year_id, bib, distance, location, occurence, deleted_at) "+
+				"SELECT event_year_id, bib, distance, location, occurence, $5 FROM result NATURAL JOIN person "+
+				"WHERE event_year_id=$1 AND bib=$2 AND location=$3 AND occurence=$4 "+
+				"ON CONFLICT (event_year_id, bib, location, occurence) DO UPDATE SET "+
+				"distance=EXCLUDED.distance, deleted_at=EXCLUDED.deleted_at;",
+			eventYearID,
+			result.Bib,
+			result.Location,
+			result.Occurence,
+			deletedAt,
+		)
+		if err != nil {
+			tx.Rollback(ctx)
+			return 0, fmt.Errorf("error recording result deletion: %v", err)
+		}
 		_, err = tx.Exec(
 			ctx,
 			"DELETE FROM result r WHERE location=$3 AND occurence=$4 AND EXISTS (SELECT * FROM person p WHERE event_year_id=$1 AND bib=$2 AND r.person_id=p.person_id);",
@@ -339,11 +477,16 @@ func (p *Postgres) DeleteResults(eventYearID int64, results []types.Result) (int
 			return 0, fmt.Errorf("error executing delete query: %v", err)
 		}
 	}
+	err = addAuditEntries(ctx, tx, eventYearID, scope, actor, auditPeople, auditResults)
+	if err != nil {
+		tx.Rollback(ctx)
+		return 0, err
+	}
 	return int64(len(results)), tx.Commit(ctx)
 }
 
 // DeleteDistanceResults Deletes results for an Event Distance from the database.
-func (p *Postgres) DeleteDistanceResults(eventYearID int64, distance string) (int64, error) {
+func (p *Postgres) DeleteDistanceResults(eventYearID int64, distance string, actor *types.AuditActor) (int64, error) {
 	db, err := p.GetDB()
 	if err != nil {
 		return 0, err
@@ -354,6 +497,27 @@ func (p *Postgres) DeleteDistanceResults(eventYearID int64, distance string) (in
 	if err != nil {
 		return 0, fmt.Errorf("unable to start transaction: %v", err)
 	}
+	scope := database.AuditScope{Distance: distance}
+	auditPeople, auditResults, err := getAuditState(ctx, tx, eventYearID, scope)
+	if err != nil {
+		tx.Rollback(ctx)
+		return 0, err
+	}
+	_, err = tx.Exec(
+		ctx,
+		"INSERT INTO deleted_result(event_year_id, bib, distance, location, occurence, deleted_at) "+
+			"SELECT event_year_id, bib, distance, location, occurence, $1 FROM result NATURAL JOIN person "+
+			"WHERE event_year_id=$2 AND distance=$3 "+
+			"ON CONFLICT (event_year_id, bib, location, occurence) DO UPDATE SET "+
+			"distance=EXCLUDED.distance, deleted_at=EXCLUDED.deleted_at;",
+		time.Now().Unix(),
+		eventYearID,
+		distance,
+	)
+	if err != nil {
+		tx.Rollback(ctx)
+		return 0, fmt.Errorf("unable to record deleted results for event year & distance: %v", err)
+	}
 	res, err := tx.Exec(
 		ctx,
 		"DELETE FROM result AS r WHERE EXISTS (SELECT * FROM person AS p WHERE p.event_year_id=$1 AND p.distance=$2 AND p.person_id=r.person_id);",
@@ -374,6 +538,11 @@ func (p *Postgres) DeleteDistanceResults(eventYearID int64, distance string) (in
 		tx.Rollback(ctx)
 		return 0, fmt.Errorf("unable to delete persons for event year & distance: %v", err)
 	}
+	err = addAuditEntries(ctx, tx, eventYearID, scope, actor, auditPeople, auditResults)
+	if err != nil {
+		tx.Rollback(ctx)
+		return 0, err
+	}
 	err = tx.Commit(ctx)
 	if err != nil {
 		return 0, fmt.Errorf("unable to commit transaction: %v", err)
@@ -382,7 +551,7 @@ func (p *Postgres) DeleteDistanceResults(eventYearID int64, distance string) (in
 }
 
 // DeleteEventResults Deletes results for an event year.
-func (p *Postgres) DeleteEventResults(eventYearID int64) (int64, error) {
+func (p *Postgres) DeleteEventResults(eventYearID int64, actor *types.AuditActor) (int64, error) {
 	db, err := p.GetDB()
 	if err != nil {
 		return 0, err
@@ -393,6 +562,26 @@ func (p *Postgres) DeleteEventResults(eventYearID int64) (int64, error) {
 	if err != nil {
 		return 0, fmt.Errorf("unable to start transaction: %v", err)
 	}
+	scope := database.AuditScope{All: true}
+	auditPeople, auditResults, err := getAuditState(ctx, tx, eventYearID, scope)
+	if err != nil {
+		tx.Rollback(ctx)
+		return 0, err
+	}
+	_, err = tx.Exec(
+		ctx,
+		"INSERT INTO deleted_result(event_year_id, bib, distance, location, occurence, deleted_at) "+
+			"SELECT event_year_id, bib, distance, location, occurence, $1 FROM result NATURAL JOIN person "+
+			"WHERE event_year_id=$2 "+
+			"ON CONFLICT (event_year_id, bib, location, occurence) DO UPDATE SET "+
+			"distance=EXCLUDED.distance, deleted_at=EXCLUDED.deleted_at;",
+		time.Now().Unix(),
+		eventYearID,
+	)
+	if err != nil {
+		tx.Rollback(ctx)
+		return 0, fmt.Errorf("unable to record deleted results for event year: %v", err)
+	}
 	res, err := tx.Exec(
 		ctx,
 		"DELETE FROM result r WHERE EXISTS (SELECT * FROM person p WHERE event_year_id=$1 AND p.person_id=r.person_id);",
@@ -411,6 +600,11 @@ func (p *Postgres) DeleteEventResults(eventYearID int64) (int64, error) {
 		tx.Rollback(ctx)
 		return 0, fmt.Errorf("unable to delete persons for event year: %v", err)
 	}
+	err = addAuditEntries(ctx, tx, eventYearID, scope, actor, auditPeople, auditResults)
+	if err != nil {
+		tx.Rollback(ctx)
+		return 0, err
+	}
 	err = tx.Commit(ctx)
 	if err != nil {
 		tx.Rollback(ctx)
@@ -420,7 +614,7 @@ func (p *Postgres) DeleteEventResults(eventYearID int64) (int64, error) {
 }
 
 // AddResults Adds results to the database. (Also updates.)
-func (p *Postgres) AddResults(eventYearID int64, results []types.Result) ([]types.Result, error) {
+func (p *Postgres) AddResults(eventYearID int64, results []types.Result, actor *types.AuditActor) ([]types.Result, error) {
 	db, err := p.GetDB()
 	if err != nil {
 		return nil, err
@@ -431,6 +625,12 @@ func (p *Postgres) AddResults(eventYearID int64, results []types.Result) ([]type
 	if err != nil {
 		return nil, fmt.Errorf("unable to begin transaction to add results: %v", err)
 	}
+	scope := database.ResultsAuditScope(results)
+	auditPeople, auditResults, err := getAuditState(ctx, tx, eventYearID, scope)
+	if err != nil {
+		tx.Rollback(ctx)
+		return nil, err
+	}
 	for _, result := range results {
 		var id int64
 		err = tx.QueryRow(
@@ -496,9 +696,11 @@ func (p *Postgres) AddResults(eventYearID int64, results []types.Result) ([]type
 				"finish, "+
 				"result_type, "+
 				"local_time, "+
-				"division_ranking"+
+				"division_ranking, "+
+				"result_status, "+
+				"status_reason"+
 				") "+
-				" VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13,$14,$15) "+
+				" VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13,$14,$15,$16,$17) "+
 				"ON CONFLICT (person_id, location, occurence) DO UPDATE SET "+
 				"seconds=$2, "+
 				"milliseconds=$3, "+
@@ -511,7 +713,9 @@ func (p *Postgres) AddResults(eventYearID int64, results []types.Result) ([]type
 				"finish=$12, "+
 				"result_type=$13, "+
 				"local_time=$14, "+
-				"division_ranking=$15"+
+				"division_ranking=$15, "+
+				"result_status=$16, "+
+				"status_reason=$17"+
 				";",
 			id,
 			result.Seconds,
@@ -528,12 +732,19 @@ func (p *Postgres) AddResults(eventYearID int64, results []types.Result) ([]type
 			result.Type,
 			result.LocalTime,
 			result.DivisionRanking,
+			result.Status,
+			result.StatusReason,
 		)
 		if err != nil {
 			tx.Rollback(ctx)
 			return nil, err
 		}
 	}
+	err = addAuditEntries(ctx, tx, eventYearID, scope, actor, auditPeople, auditResults)
+	if err != nil {
+		tx.Rollback(ctx)
+		return nil, err
+	}
 	err = tx.Commit(ctx)
 	if err != nil {
 		tx.Rollback(ctx)
@@ -542,3 +753,124 @@ func (p *Postgres) AddResults(eventYearID int64, results []types.Result) ([]type
 	return results, nil
 }
 
+// UpdateRankings Recalculates the rankings for every result in an event year and stores
+// any that changed.  Returns the number of results updated.
+func (p *Postgres) UpdateRankings(eventYearID int64) (int64, error) {
+	db, err := p.GetDB()
+	if err != nil {
+		return 0, err
+	}
+	ctx, cancelfunc := context.WithTimeout(context.Background(), time.Second*15)
+	defer cancelfunc()
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("unable to start transaction: %v", err)
+	}
+	var rankingType, eventType string
+	err = tx.QueryRow(
+		ctx,
+		"SELECT y.ranking_type, e.event_type FROM event_year y JOIN event e ON y.event_id=e.event_id WHERE y.event_year_id=$1;",
+		eventYearID,
+	).Scan(&rankingType, &eventType)
+	if err != nil {
+		tx.Rollback(ctx)
+		return 0, fmt.Errorf("error retrieving ranking type: %v", err)
+	}
+	res, err := tx.Query(
+		ctx,
+		"SELECT person_id, bib, gender, age_group, distance, division, seconds, milliseconds, "+
+			"chip_seconds, chip_milliseconds, location, occurence, finish, result_type, result_status, ranking, age_ranking, "+
+			"gender_ranking, division_ranking FROM result NATURAL JOIN person WHERE event_year_id=$1;",
+		eventYearID,
+	)
+	if err != nil {
+		tx.Rollback(ctx)
+		return 0, fmt.Errorf("error retrieving results to rank: %v", err)
+	}
+	var ids []int64
+	var results []types.Result
+	for res.Next() {
+		var id int64
+		var result types.Result
+		err := res.Scan(
+			&id,
+			&result.Bib,
+			&result.Gender,
+			&result.AgeGroup,
+			&result.Distance,
+			&result.Division,
+			&result.Seconds,
+			&result.Milliseconds,
+			&result.ChipSeconds,
+			&result.ChipMilliseconds,
+			&result.Location,
+			&result.Occurence,
+			&result.Finish,
+			&result.Type,
+			&result.Status,
+			&result.Ranking,
+			&result.AgeRanking,
+			&result.GenderRanking,
+			&result.DivisionRanking,
+		)
+		if err != nil {
+			res.Close()
+			tx.Rollback(ctx)
+			return 0, fmt.Errorf("error getting result to rank: %v", err)
+		}
+		ids = append(ids, id)
+		results = append(results, result)
+	}
+	res.Close()
+	var count int64
+	// Backyard ultras are ranked by the yards completed and timed events by the distance
+	// covered instead of by time.
+	var ranked []types.Result
+	switch eventType {
+	case util.EVENT_TYPE_BACKYARDULTRA:
+		ranked = database.CalculateBackyardRankings(results)
+	case util.EVENT_TYPE_TIME:
+		distances, err := p.GetDistances(eventYearID)
+		if err != nil {
+			tx.Rollback(ctx)
+			return 0, fmt.Errorf("error retrieving distances to rank: %v", err)
+		}
+		segments, err := p.GetSegments(eventYearID)
+		if err != nil {
+			tx.Rollback(ctx)
+			return 0, fmt.Errorf("error retrieving segments to rank: %v", err)
+		}
+		ranked = database.CalculateLapRankings(results, distances, segments, rankingType)
+	default:
+		ranked = database.CalculateRankings(results, rankingType)
+	}
+	for ix := range ranked {
+		if !database.RankingsChanged(&ranked[ix], &results[ix]) {
+			continue
+		}
+		_, err = tx.Exec(
+			ctx,
+			"UPDATE result SET ranking=$1, age_ranking=$2, gender_ranking=$3, division_ranking=$4 "+
+				"WHERE person_id=$5 AND location=$6 AND occurence=$7;",
+			ranked[ix].Ranking,
+			ranked[ix].AgeRanking,
+			ranked[ix].GenderRanking,
+			ranked[ix].DivisionRanking,
+			ids[ix],
+			ranked[ix].Location,
+			ranked[ix].Occurence,
+		)
+		if err != nil {
+			tx.Rollback(ctx)
+			return 0, fmt.Errorf("error updating result ranking: %v", err)
+		}
+		count++
+	}
+	err = tx.Commit(ctx)
+	if err != nil {
+		tx.Rollback(ctx)
+		return 0, fmt.Errorf("unable to commit transaction: %v", err)
+	}
+	return count, nil
+}
+
@@ -0,0 +1,183 @@
+/*
+Chronokeep Desktop - Race Scoring Software
+Copyright (C) 2026 James Sentinella
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package postgres
+
+import (
+	"chronokeep/results/types"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func setupSeriesTests() {
+	if len(accounts) < 1 {
+		accounts = []types.Account{
+			{
+				Name:     "John Smith",
+				Email:    "j@test.com",
+				Type:     "admin",
+				Password: testHashPassword("password"),
+			},
+		}
+	}
+}
+
+func setupSeriesEventYears(t *testing.T, db *Postgres) (*types.Account, []types.EventYear) {
+	account, _ := db.AddAccount(accounts[0])
+	output := make([]types.EventYear, 0)
+	for ix, date := range []time.Time{
+		time.Date(2021, 06, 20, 9, 0, 0, 0, time.Local),
+		time.Date(2021, 04, 20, 9, 0, 0, 0, time.Local),
+	} {
+		event, _ := db.AddEvent(types.Event{
+			AccountIdentifier: account.Identifier,
+			Name:              "Event " + strconv.Itoa(ix+1),
+			Slug:              "event" + strconv.Itoa(ix+1),
+		})
+		eventYear, err := db.AddEventYear(types.EventYear{
+			EventIdentifier: event.Identifier,
+			Year:            "2021",
+			DateTime:        date,
+			DaysAllowed:     1,
+			RankingType:     "chip",
+		})
+		if err != nil {
+			t.Fatalf("Error adding event year: %v", err)
+		}
+		output = append(output, *eventYear)
+	}
+	return account, output
+}
+
+func TestAddSeries(t *testing.T) {
+	db, finalize, err := setupTests(t)
+	if err != nil {
+		t.Fatalf("Error setting up tests. %v", err)
+	}
+	defer finalize(t)
+	setupSeriesTests()
+	account, eventYears := setupSeriesEventYears(t, db)
+	series := types.Series{
+		AccountIdentifier: account.Identifier,
+		Slug:              "summer-series",
+		Name:              "Summer Series",
+		Points:            []int{10, 8, 6},
+		BestOf:            2,
+		MinimumRaces:      1,
+		TieBreakers:       []string{types.SeriesTieBestFinish, types.SeriesTieMostRecent},
+		Identity:          types.SeriesIdentityName,
+		Events: []types.SeriesEvent{
+			{EventYearIdentifier: eventYears[1].Identifier, Slug: "event2", Year: "2021", Distance: "5K"},
+			{EventYearIdentifier: eventYears[0].Identifier, Slug: "event1", Year: "2021", Distance: "10K"},
+		},
+	}
+	out, err := db.AddSeries(series)
+	if assert.NoError(t, err) {
+		assert.True(t, series.Equals(out))
+		assert.NotEqual(t, int64(0), out.Identifier)
+	}
+	// Adding a series with the same slug updates it and replaces its events.
+	series.Name = "Updated Series"
+	series.Points = []int{5}
+	series.TieBreakers = []string{}
+	series.Events = series.Events[1:]
+	_, err = db.AddSeries(series)
+	assert.NoError(t, err)
+	out, err = db.GetSeries(series.Slug)
+	if assert.NoError(t, err) && assert.NotNil(t, out) {
+		assert.True(t, series.Equals(out))
+		assert.Equal(t, account.Identifier, out.AccountIdentifier)
+		assert.Equal(t, eventYears[0].Identifier, out.Events[0].EventYearIdentifier)
+	}
+}
+
+func TestGetSeries(t *testing.T) {
+	db, finalize, err := setupTests(t)
+	if err != nil {
+		t.Fatalf("Error setting up tests. %v", err)
+	}
+	defer finalize(t)
+	setupSeriesTests()
+	account, eventYears := setupSeriesEventYears(t, db)
+	out, err := db.GetSeries("summer-series")
+	if assert.NoError(t, err) {
+		assert.Nil(t, out)
+	}
+	series := types.Series{
+		AccountIdentifier: account.Identifier,
+		Slug:              "summer-series",
+		Name:              "Summer Series",
+		Points:            []int{10, 8, 6},
+		TieBreakers:       []string{types.SeriesTieMostRaces},
+		Identity:          types.SeriesIdentityNameGender,
+		Events: []types.SeriesEvent{
+			{EventYearIdentifier: eventYears[0].Identifier, Slug: "event1", Year: "2021", Distance: "10K"},
+			{EventYearIdentifier: eventYears[1].Identifier, Slug: "event2", Year: "2021", Distance: "5K"},
+		},
+	}
+	_, err = db.AddSeries(series)
+	assert.NoError(t, err)
+	// Events are ordered by the date of the event year.
+	out, err = db.GetSeries(series.Slug)
+	if assert.NoError(t, err) && assert.NotNil(t, out) && assert.Equal(t, 2, len(out.Events)) {
+		assert.Equal(t, "event2", out.Events[0].Slug)
+		assert.Equal(t, "5K", out.Events[0].Distance)
+		assert.Equal(t, "event1", out.Events[1].Slug)
+		assert.Equal(t, series.Points, out.Points)
+		assert.Equal(t, series.TieBreakers, out.TieBreakers)
+		assert.Equal(t, series.Identity, out.Identity)
+	}
+}
+
+func TestDeleteSeries(t *testing.T) {
+	db, finalize, err := setupTests(t)
+	if err != nil {
+		t.Fatalf("Error setting up tests. %v", err)
+	}
+	defer finalize(t)
+	setupSeriesTests()
+	account, eventYears := setupSeriesEventYears(t, db)
+	series, err := db.AddSeries(types.Series{
+		AccountIdentifier: account.Identifier,
+		Slug:              "summer-series",
+		Name:              "Summer Series",
+		Points:            []int{10},
+		Identity:          types.SeriesIdentityPersonId,
+		Events: []types.SeriesEvent{
+			{EventYearIdentifier: eventYears[0].Identifier, Slug: "event1", Year: "2021", Distance: "10K"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Error adding series: %v", err)
+	}
+	err = db.DeleteSeries(*series)
+	assert.NoError(t, err)
+	out, err := db.GetSeries(series.Slug)
+	if assert.NoError(t, err) {
+		assert.Nil(t, out)
+	}
+	// The event years of a deleted series are left alone.
+	eventYear, err := db.GetEventYear("event1", "2021")
+	if assert.NoError(t, err) {
+		assert.NotNil(t, eventYear)
+	}
+}
+
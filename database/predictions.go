@@ -0,0 +1,147 @@
+/*
+Chronokeep Desktop - Race Scoring Software
+Copyright (C) 2026 James Sentinella
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package database
+
+import (
+	"chronokeep/results/types"
+	"chronokeep/results/util"
+	"math"
+	"sort"
+	"time"
+)
+
+// PredictArrivals Predicts when people still on the course will arrive at the segment named target, or
+// at the finish if target is empty, by keeping up their pace from their start to the last segment they
+// were seen at.  People start with their wave when they're in one and at the start of the event year
+// otherwise.  People who have finished, didn't finish, didn't start or were disqualified, and people
+// who are past the target, are left out along with those whose distances are unknown.  People who
+// should have arrived before now are returned separately as overdue so someone who stopped without
+// a DNF doesn't stay ahead of the upcoming arrivals.  Both are ordered by arrival.
+func PredictArrivals(results []types.Result, segments []types.Segment, distances []types.Distance, waves []types.Wave, target string, start, now time.Time) ([]types.Prediction, []types.Prediction) {
+	matchers := make(map[string]*segmentMatcher)
+	byDistance := make(map[string][]types.Segment)
+	for _, seg := range segments {
+		byDistance[seg.DistanceName] = append(byDistance[seg.DistanceName], seg)
+	}
+	for name, segs := range byDistance {
+		matchers[name] = newSegmentMatcher(segs)
+	}
+	lengths := make(map[string]types.Distance)
+	for _, dist := range distances {
+		lengths[dist.Name] = dist
+	}
+	// Find the last known split of everyone still on the course.
+	type lastSeen struct {
+		result types.Result
+		name   string
+		meters float64
+		unit   string
+	}
+	latest := make(map[string]*lastSeen)
+	done := make(map[string]bool)
+	for _, res := range results {
+		if res.Finish || res.IsDNF() || res.IsDNS() || res.IsDQ() {
+			done[res.Bib] = true
+			continue
+		}
+		matcher, ok := matchers[res.Distance]
+		if !ok {
+			continue
+		}
+		ix, ok := matcher.match(&res)
+		if !ok || ix >= len(matcher.ordered) || !matcher.ordered[ix].known || matcher.ordered[ix].meters <= 0 {
+			continue
+		}
+		if prev, ok := latest[res.Bib]; ok && !rankedBefore(&prev.result, &res, util.RANKING_TYPE_GUN) {
+			continue
+		}
+		seg := matcher.ordered[ix].segment
+		latest[res.Bib] = &lastSeen{
+			result: res,
+			name:   seg.Name,
+			meters: matcher.ordered[ix].meters,
+			unit:   DistanceUnit(seg.DistanceUnit),
+		}
+	}
+	upcoming := make([]types.Prediction, 0)
+	overdue := make([]types.Prediction, 0)
+	for bib, seen := range latest {
+		if done[bib] {
+			continue
+		}
+		var targetMeters float64
+		var known bool
+		if target == "" {
+			dist := lengths[seen.result.Distance]
+			targetMeters, known = DistanceMeters(dist.DistanceValue, DistanceUnit(dist.DistanceUnit))
+		} else if ix, ok := matchers[seen.result.Distance].byName[target]; ok {
+			seg := matchers[seen.result.Distance].ordered[ix]
+			targetMeters, known = seg.meters, seg.known
+		}
+		if !known || targetMeters <= seen.meters {
+			continue
+		}
+		started := start
+		if wave := FindWave(waves, seen.result.Distance, bib); wave != nil {
+			started = wave.StartTime
+		}
+		elapsed := readTime(&seen.result, start).Sub(started).Milliseconds()
+		if elapsed <= 0 {
+			continue
+		}
+		arrival := started.Add(time.Duration(math.Round(float64(elapsed)*targetMeters/seen.meters)) * time.Millisecond)
+		predicted := arrival.Sub(start).Milliseconds()
+		prediction := types.Prediction{
+			Bib:          bib,
+			First:        seen.result.First,
+			Last:         seen.result.Last,
+			Distance:     seen.result.Distance,
+			LastSeen:     seen.name,
+			Seconds:      int(predicted / 1000),
+			Milliseconds: int(predicted % 1000),
+			DateTime:     arrival,
+			Overdue:      arrival.Before(now),
+			PaceUnit:     paceUnit(seen.unit),
+		}
+		prediction.PaceSeconds = pace(elapsed, seen.meters, prediction.PaceUnit)
+		if seen.result.Anonymous {
+			prediction.First = ""
+			prediction.Last = ""
+		}
+		if prediction.Overdue {
+			overdue = append(overdue, prediction)
+		} else {
+			upcoming = append(upcoming, prediction)
+		}
+	}
+	sortPredictions(upcoming)
+	sortPredictions(overdue)
+	return upcoming, overdue
+}
+
+// sortPredictions Orders predictions by arrival.
+func sortPredictions(predictions []types.Prediction) {
+	sort.Slice(predictions, func(i, j int) bool {
+		if !predictions[i].DateTime.Equal(predictions[j].DateTime) {
+			return predictions[i].DateTime.Before(predictions[j].DateTime)
+		}
+		return predictions[i].Bib < predictions[j].Bib
+	})
+}
+
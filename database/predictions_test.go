@@ -0,0 +1,112 @@
+/*
+Chronokeep Desktop - Race Scoring Software
+Copyright (C) 2026 James Sentinella
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package database
+
+import (
+	"chronokeep/results/types"
+	"chronokeep/results/util"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPredictArrivals(t *testing.T) {
+	segments := []types.Segment{
+		{Location: "Turnaround", DistanceName: "10K", Name: "Halfway", DistanceValue: 5, DistanceUnit: "km"},
+		{Location: "Start/Finish", DistanceName: "10K", Name: "Lap 1", DistanceValue: 2.5, DistanceUnit: "km"},
+	}
+	distances := []types.Distance{
+		{Name: "10K", DistanceValue: 10, DistanceUnit: util.DISTANCE_TYPE_KILOMETER},
+	}
+	dnf := splitTestResult("4", "Start/Finish", "", 1, 650, false)
+	dnf.Status = types.ResultStatusDNF
+	anonymous := splitTestResult("6", "Start/Finish", "", 1, 700, false)
+	anonymous.Anonymous = true
+	unknown := splitTestResult("7", "Start/Finish", "", 1, 400, false)
+	unknown.Distance = "5K"
+	results := []types.Result{
+		splitTestResult("1", "Turnaround", "", 1, 1200, false),
+		splitTestResult("1", "Start/Finish", "", 1, 600, false),
+		splitTestResult("2", "Start/Finish", "", 1, 500, false),
+		splitTestResult("3", "Start/Finish", "", 1, 450, false),
+		splitTestResult("3", "Start/Finish", "", 2, 1900, true),
+		dnf,
+		// Only seen at the start.
+		splitTestResult("5", "Start/Finish", "", 0, 0, false),
+		anonymous,
+		unknown,
+	}
+	start := time.Date(2021, 6, 1, 9, 0, 0, 0, time.UTC)
+	predictions, overdue := PredictArrivals(results, segments, distances, nil, "", start, start)
+	assert.Equal(t, 0, len(overdue))
+	if assert.Equal(t, 3, len(predictions)) {
+		assert.Equal(t, "2", predictions[0].Bib)
+		assert.Equal(t, 2000, predictions[0].Seconds)
+		assert.Equal(t, start.Add(time.Second*2000), predictions[0].DateTime)
+		assert.Equal(t, "Lap 1", predictions[0].LastSeen)
+		assert.Equal(t, 200, predictions[0].PaceSeconds)
+		assert.False(t, predictions[0].Overdue)
+		assert.Equal(t, util.DISTANCE_TYPE_KILOMETER, predictions[0].PaceUnit)
+		assert.Equal(t, "1", predictions[1].Bib)
+		assert.Equal(t, 2400, predictions[1].Seconds)
+		assert.Equal(t, "Halfway", predictions[1].LastSeen)
+		assert.Equal(t, "Runner", predictions[1].First)
+		assert.Equal(t, "6", predictions[2].Bib)
+		assert.Equal(t, 2800, predictions[2].Seconds)
+		assert.Equal(t, "", predictions[2].First)
+		assert.Equal(t, "", predictions[2].Last)
+	}
+	// Arrivals expected before now are still listed, but separately as overdue.
+	predictions, overdue = PredictArrivals(results, segments, distances, nil, "", start, start.Add(time.Second*2100))
+	if assert.Equal(t, 1, len(overdue)) {
+		assert.Equal(t, "2", overdue[0].Bib)
+		assert.True(t, overdue[0].Overdue)
+	}
+	if assert.Equal(t, 2, len(predictions)) {
+		assert.Equal(t, "1", predictions[0].Bib)
+		assert.False(t, predictions[0].Overdue)
+		assert.Equal(t, "6", predictions[1].Bib)
+		assert.False(t, predictions[1].Overdue)
+	}
+	// People in a wave keep up their pace from the start of their wave.
+	waves := []types.Wave{
+		{Distance: "10K", Name: "Wave 2", StartTime: start.Add(time.Second * 100), Bibs: []string{"2"}},
+	}
+	predictions, _ = PredictArrivals(results, segments, distances, waves, "", start, start)
+	if assert.Equal(t, 3, len(predictions)) {
+		assert.Equal(t, "2", predictions[0].Bib)
+		assert.Equal(t, 1700, predictions[0].Seconds)
+		assert.Equal(t, start.Add(time.Second*1700), predictions[0].DateTime)
+		assert.Equal(t, 160, predictions[0].PaceSeconds)
+	}
+	// People already past a segment aren't expected there.
+	predictions, _ = PredictArrivals(results, segments, distances, nil, "Halfway", start, start)
+	if assert.Equal(t, 2, len(predictions)) {
+		assert.Equal(t, "2", predictions[0].Bib)
+		assert.Equal(t, 1000, predictions[0].Seconds)
+		assert.Equal(t, "6", predictions[1].Bib)
+		assert.Equal(t, 1400, predictions[1].Seconds)
+	}
+	predictions, overdue = PredictArrivals(results, segments, distances, nil, "Not a segment", start, start)
+	assert.Equal(t, 0, len(predictions)+len(overdue))
+	predictions, overdue = PredictArrivals(results, segments, nil, nil, "", start, start)
+	assert.Equal(t, 0, len(predictions)+len(overdue))
+}
+
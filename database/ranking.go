@@ -0,0 +1,117 @@
+/*
+Chronokeep Desktop - Race Scoring Software
+Copyright (C) 2026 James Sentinella
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package database
+
+import (
+	"chronokeep/results/types"
+	"chronokeep/results/util"
+	"sort"
+)
+
+// Unranked is the ranking value given to results that are not ranked.
+const Unranked = -1
+
+type rankGroup struct {
+	distance  string
+	location  string
+	occurence int
+}
+
+type subGroup struct {
+	gender   string
+	ageGroup string
+}
+
+// CalculateRankings Calculates the overall, gender, age group and division rankings for
+// a set of results.  Results are ranked against other results with the same distance,
+// location and occurence using chip time if the ranking type is chip and gun time otherwise.
+// DNF, DNS and DQ results are left unranked.  The returned slice is in the same order as the
+// results passed in.
+func CalculateRankings(results []types.Result, rankingType string) []types.Result {
+	out := make([]types.Result, len(results))
+	copy(out, results)
+	groups := make(map[rankGroup][]int)
+	for ix := range out {
+		if !out[ix].IsRanked() {
+			out[ix].Ranking = Unranked
+			out[ix].GenderRanking = Unranked
+			out[ix].AgeRanking = Unranked
+			out[ix].DivisionRanking = Unranked
+			continue
+		}
+		key := rankGroup{
+			distance:  out[ix].Distance,
+			location:  out[ix].Location,
+			occurence: out[ix].Occurence,
+		}
+		groups[key] = append(groups[key], ix)
+	}
+	for _, group := range groups {
+		sort.SliceStable(group, func(i, j int) bool {
+			return rankedBefore(&out[group[i]], &out[group[j]], rankingType)
+		})
+		genders := make(map[string]int)
+		ageGroups := make(map[subGroup]int)
+		divisions := make(map[string]int)
+		for place, ix := range group {
+			res := &out[ix]
+			res.Ranking = place + 1
+			genders[res.Gender]++
+			res.GenderRanking = genders[res.Gender]
+			ag := subGroup{gender: res.Gender, ageGroup: res.AgeGroup}
+			ageGroups[ag]++
+			res.AgeRanking = ageGroups[ag]
+			if res.Division != "" {
+				divisions[res.Division]++
+				res.DivisionRanking = divisions[res.Division]
+			} else {
+				res.DivisionRanking = Unranked
+			}
+		}
+	}
+	return out
+}
+
+// rankedBefore Returns true if one should be placed ahead of two.  Ties on the ranking
+// time are broken with the other time and then by bib.
+func rankedBefore(one, two *types.Result, rankingType string) bool {
+	oneGun := int64(one.Seconds)*1000 + int64(one.Milliseconds)
+	twoGun := int64(two.Seconds)*1000 + int64(two.Milliseconds)
+	oneChip := int64(one.ChipSeconds)*1000 + int64(one.ChipMilliseconds)
+	twoChip := int64(two.ChipSeconds)*1000 + int64(two.ChipMilliseconds)
+	first, second := []int64{oneGun, oneChip}, []int64{twoGun, twoChip}
+	if rankingType == util.RANKING_TYPE_CHIP {
+		first, second = []int64{oneChip, oneGun}, []int64{twoChip, twoGun}
+	}
+	for ix := range first {
+		if first[ix] != second[ix] {
+			return first[ix] < second[ix]
+		}
+	}
+	return one.Bib < two.Bib
+}
+
+// RankingsChanged Returns true if any of the rankings differ between the two results.
+func RankingsChanged(one, two *types.Result) bool {
+	return one.Ranking != two.Ranking ||
+		one.GenderRanking != two.GenderRanking ||
+		one.AgeRanking != two.AgeRanking ||
+		one.DivisionRanking != two.DivisionRanking
+}
+
@@ -0,0 +1,123 @@
+/*
+Chronokeep Desktop - Race Scoring Software
+Copyright (C) 2026 James Sentinella
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package database
+
+import (
+	"chronokeep/results/types"
+	"chronokeep/results/util"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func rankingTestResults() []types.Result {
+	return []types.Result{
+		{Bib: "1", Gender: "M", AgeGroup: "20-29", Distance: "5K", Location: "Finish", Occurence: 1, Seconds: 1200, ChipSeconds: 1190, Division: "Club"},
+		{Bib: "2", Gender: "F", AgeGroup: "20-29", Distance: "5K", Location: "Finish", Occurence: 1, Seconds: 1100, ChipSeconds: 1100},
+		{Bib: "3", Gender: "M", AgeGroup: "30-39", Distance: "5K", Location: "Finish", Occurence: 1, Seconds: 1150, ChipSeconds: 1140, Division: "Club"},
+		{Bib: "4", Gender: "M", AgeGroup: "20-29", Distance: "5K", Location: "Finish", Occurence: 1, Seconds: 1180, ChipSeconds: 1120},
+		{Bib: "5", Gender: "F", AgeGroup: "20-29", Distance: "5K", Location: "Finish", Occurence: 1, Seconds: 1000000, Type: types.ResultTypeDNF},
+		{Bib: "6", Gender: "F", AgeGroup: "20-29", Distance: "10K", Location: "Finish", Occurence: 1, Seconds: 2500, ChipSeconds: 2500},
+		{Bib: "7", Gender: "F", AgeGroup: "20-29", Distance: "10K", Location: "Finish", Occurence: 1, Seconds: 2400, ChipSeconds: 2400, Status: types.ResultStatusDQ, StatusReason: "Course cut"},
+		{Bib: "1", Gender: "M", AgeGroup: "20-29", Distance: "5K", Location: "Mile 1", Occurence: 1, Seconds: 400, ChipSeconds: 390, Division: "Club"},
+	}
+}
+
+func TestCalculateRankingsGun(t *testing.T) {
+	results := rankingTestResults()
+	ranked := CalculateRankings(results, util.RANKING_TYPE_GUN)
+	if !assert.Equal(t, len(results), len(ranked)) {
+		return
+	}
+	// Order is preserved.
+	for ix := range results {
+		assert.Equal(t, results[ix].Bib, ranked[ix].Bib)
+	}
+	// Overall: 2, 3, 4, 1
+	assert.Equal(t, 4, ranked[0].Ranking)
+	assert.Equal(t, 1, ranked[1].Ranking)
+	assert.Equal(t, 2, ranked[2].Ranking)
+	assert.Equal(t, 3, ranked[3].Ranking)
+	// Gender: M -> 3, 4, 1; F -> 2
+	assert.Equal(t, 3, ranked[0].GenderRanking)
+	assert.Equal(t, 1, ranked[1].GenderRanking)
+	assert.Equal(t, 1, ranked[2].GenderRanking)
+	assert.Equal(t, 2, ranked[3].GenderRanking)
+	// Age group is ranked within gender.
+	assert.Equal(t, 2, ranked[0].AgeRanking)
+	assert.Equal(t, 1, ranked[1].AgeRanking)
+	assert.Equal(t, 1, ranked[2].AgeRanking)
+	assert.Equal(t, 1, ranked[3].AgeRanking)
+	// Division: only those with a division are ranked.
+	assert.Equal(t, 2, ranked[0].DivisionRanking)
+	assert.Equal(t, Unranked, ranked[1].DivisionRanking)
+	assert.Equal(t, 1, ranked[2].DivisionRanking)
+	assert.Equal(t, Unranked, ranked[3].DivisionRanking)
+	// DNF is unranked.
+	assert.Equal(t, Unranked, ranked[4].Ranking)
+	assert.Equal(t, Unranked, ranked[4].GenderRanking)
+	assert.Equal(t, Unranked, ranked[4].AgeRanking)
+	assert.Equal(t, Unranked, ranked[4].DivisionRanking)
+	// Other distances and locations are ranked separately.
+	assert.Equal(t, 1, ranked[5].Ranking)
+	assert.Equal(t, 1, ranked[7].Ranking)
+	assert.Equal(t, 1, ranked[7].DivisionRanking)
+	// DQ is unranked even with the fastest time.
+	assert.Equal(t, Unranked, ranked[6].Ranking)
+	assert.Equal(t, Unranked, ranked[6].GenderRanking)
+	// The input isn't modified.
+	assert.Equal(t, 0, results[0].Ranking)
+}
+
+func TestCalculateRankingsChip(t *testing.T) {
+	ranked := CalculateRankings(rankingTestResults(), util.RANKING_TYPE_CHIP)
+	// Overall: 2, 4, 3, 1
+	assert.Equal(t, 4, ranked[0].Ranking)
+	assert.Equal(t, 1, ranked[1].Ranking)
+	assert.Equal(t, 3, ranked[2].Ranking)
+	assert.Equal(t, 2, ranked[3].Ranking)
+	assert.Equal(t, 1, ranked[3].GenderRanking)
+	assert.Equal(t, 1, ranked[3].AgeRanking)
+	assert.Equal(t, 2, ranked[0].AgeRanking)
+}
+
+func TestCalculateRankingsTies(t *testing.T) {
+	results := []types.Result{
+		{Bib: "20", Gender: "M", Distance: "5K", Location: "Finish", Seconds: 1200, ChipSeconds: 1180},
+		{Bib: "10", Gender: "M", Distance: "5K", Location: "Finish", Seconds: 1200, ChipSeconds: 1180},
+		{Bib: "30", Gender: "M", Distance: "5K", Location: "Finish", Seconds: 1200, ChipSeconds: 1170},
+	}
+	ranked := CalculateRankings(results, util.RANKING_TYPE_GUN)
+	// Gun ties are broken by chip time and then bib.
+	assert.Equal(t, 3, ranked[0].Ranking)
+	assert.Equal(t, 2, ranked[1].Ranking)
+	assert.Equal(t, 1, ranked[2].Ranking)
+}
+
+func TestRankingsChanged(t *testing.T) {
+	one := types.Result{Ranking: 1, GenderRanking: 1, AgeRanking: 1, DivisionRanking: 1}
+	two := one
+	assert.False(t, RankingsChanged(&one, &two))
+	two.DivisionRanking = 2
+	assert.True(t, RankingsChanged(&one, &two))
+	two = one
+	two.AgeRanking = 2
+	assert.True(t, RankingsChanged(&one, &two))
+}
+
@@ -0,0 +1,164 @@
+/*
+Chronokeep Desktop - Race Scoring Software
+Copyright (C) 2026 James Sentinella
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package database
+
+import (
+	"chronokeep/results/types"
+	"chronokeep/results/util"
+)
+
+type recordKey struct {
+	distance string
+	category string
+	gender   string
+	ageGroup string
+}
+
+// recordKeys Returns the key for each record category a result competes in.
+func recordKeys(res *types.Result) []recordKey {
+	return []recordKey{
+		{distance: res.Distance, category: types.RecordCourse},
+		{distance: res.Distance, category: types.RecordGender, gender: res.Gender},
+		{distance: res.Distance, category: types.RecordAgeGroup, gender: res.Gender, ageGroup: res.AgeGroup},
+	}
+}
+
+func recordKeyOf(rec *types.Record) recordKey {
+	return recordKey{
+		distance: rec.Distance,
+		category: rec.Category,
+		gender:   rec.Gender,
+		ageGroup: rec.AgeGroup,
+	}
+}
+
+// isRecordFinish Returns true if the result is a finish that can hold a record.
+func isRecordFinish(res *types.Result) bool {
+	return res.Finish && res.ResultStatus() == types.ResultStatusFinished
+}
+
+// recordTime Returns the time a result is compared with for records.
+func recordTime(res *types.Result, rankingType string) (int, int) {
+	if rankingType == util.RANKING_TYPE_CHIP {
+		return res.ChipSeconds, res.ChipMilliseconds
+	}
+	return res.Seconds, res.Milliseconds
+}
+
+// CalculateRecords Finds the best finish for each distance in every record category from the
+// results of a single event year.  Finishes are compared using chip time if the ranking type is
+// chip and gun time otherwise, with ties going to whoever would be ranked first.  Anonymous
+// results can hold records but their names are not kept.
+func CalculateRecords(results []types.Result, year, rankingType string) []types.Record {
+	best := make(map[recordKey]int)
+	order := make([]recordKey, 0)
+	for ix := range results {
+		res := &results[ix]
+		if !isRecordFinish(res) {
+			continue
+		}
+		for _, key := range recordKeys(res) {
+			cur, ok := best[key]
+			if !ok {
+				order = append(order, key)
+			}
+			if !ok || rankedBefore(res, &results[cur], rankingType) {
+				best[key] = ix
+			}
+		}
+	}
+	out := make([]types.Record, 0, len(order))
+	for _, key := range order {
+		res := &results[best[key]]
+		seconds, milliseconds := recordTime(res, rankingType)
+		rec := types.Record{
+			Year:         year,
+			Distance:     key.distance,
+			Category:     key.category,
+			Gender:       key.gender,
+			AgeGroup:     key.ageGroup,
+			Bib:          res.Bib,
+			Seconds:      seconds,
+			Milliseconds: milliseconds,
+		}
+		if !res.Anonymous {
+			rec.First = res.First
+			rec.Last = res.Last
+		}
+		out = append(out, rec)
+	}
+	return out
+}
+
+// BestRecords Picks the record for each distance in every record category from the records of
+// several event years.  Records should be ordered oldest year first since matching a record
+// doesn't break it.
+func BestRecords(records []types.Record) []types.Record {
+	best := make(map[recordKey]int)
+	order := make([]recordKey, 0)
+	for ix := range records {
+		key := recordKeyOf(&records[ix])
+		cur, ok := best[key]
+		if !ok {
+			order = append(order, key)
+			best[key] = ix
+			continue
+		}
+		one := int64(records[ix].Seconds)*1000 + int64(records[ix].Milliseconds)
+		two := int64(records[cur].Seconds)*1000 + int64(records[cur].Milliseconds)
+		if one < two {
+			best[key] = ix
+		}
+	}
+	out := make([]types.Record, 0, len(order))
+	for _, key := range order {
+		out = append(out, records[best[key]])
+	}
+	return out
+}
+
+// FlagRecords Sets the broadest record category held by each result from an event year.  A
+// course record holder also holds their gender and age group records so only course is set.
+// Records should be the best records for the event.
+func FlagRecords(results []types.Result, year types.EventYear, records []types.Record) {
+	held := make(map[recordKey]*types.Record)
+	for ix := range records {
+		if records[ix].Year == year.Year {
+			held[recordKeyOf(&records[ix])] = &records[ix]
+		}
+	}
+	if len(held) == 0 {
+		return
+	}
+	for ix := range results {
+		res := &results[ix]
+		if !isRecordFinish(res) {
+			continue
+		}
+		seconds, milliseconds := recordTime(res, year.RankingType)
+		for _, key := range recordKeys(res) {
+			rec, ok := held[key]
+			if ok && rec.Bib == res.Bib && rec.Seconds == seconds && rec.Milliseconds == milliseconds {
+				res.Record = key.category
+				break
+			}
+		}
+	}
+}
+
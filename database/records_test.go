@@ -0,0 +1,145 @@
+/*
+Chronokeep Desktop - Race Scoring Software
+Copyright (C) 2026 James Sentinella
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package database
+
+import (
+	"chronokeep/results/types"
+	"chronokeep/results/util"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func recordTestResults() []types.Result {
+	return []types.Result{
+		{Bib: "1", First: "John", Last: "Smith", Gender: "M", AgeGroup: "20-29", Distance: "5K", Location: "Finish", Occurence: 1, Seconds: 1200, ChipSeconds: 1100, Finish: true},
+		{Bib: "2", First: "Jane", Last: "Doe", Gender: "F", AgeGroup: "20-29", Distance: "5K", Location: "Finish", Occurence: 1, Seconds: 1150, ChipSeconds: 1150, Finish: true},
+		{Bib: "3", First: "Jim", Last: "Smith", Gender: "M", AgeGroup: "30-39", Distance: "5K", Location: "Finish", Occurence: 1, Seconds: 1180, ChipSeconds: 1180, Finish: true, Anonymous: true},
+		{Bib: "4", First: "Jill", Last: "Doe", Gender: "F", AgeGroup: "20-29", Distance: "5K", Location: "Finish", Occurence: 1, Seconds: 900, ChipSeconds: 900, Finish: true, Status: types.ResultStatusDQ},
+		{Bib: "5", First: "Joe", Last: "Jones", Gender: "M", AgeGroup: "20-29", Distance: "5K", Location: "Mile 1", Occurence: 1, Seconds: 300, ChipSeconds: 300},
+		{Bib: "6", First: "Jen", Last: "Jones", Gender: "F", AgeGroup: "40-49", Distance: "10K", Location: "Finish", Occurence: 1, Seconds: 2500, ChipSeconds: 2500, Finish: true},
+	}
+}
+
+// findRecord Returns the record for a distance and category or nil.
+func findRecord(records []types.Record, distance, category, gender, ageGroup string) *types.Record {
+	for ix := range records {
+		rec := &records[ix]
+		if rec.Distance == distance && rec.Category == category && rec.Gender == gender && rec.AgeGroup == ageGroup {
+			return rec
+		}
+	}
+	return nil
+}
+
+func TestCalculateRecords(t *testing.T) {
+	results := recordTestResults()
+	records := CalculateRecords(results, "2021", util.RANKING_TYPE_GUN)
+	// 5K: course, M, F, M 20-29, M 30-39, F 20-29.  10K: course, F, F 40-49.
+	assert.Equal(t, 9, len(records))
+	rec := findRecord(records, "5K", types.RecordCourse, "", "")
+	if assert.NotNil(t, rec) {
+		assert.Equal(t, "2", rec.Bib)
+		assert.Equal(t, "2021", rec.Year)
+		assert.Equal(t, 1150, rec.Seconds)
+		assert.Equal(t, "Jane", rec.First)
+	}
+	// Anonymous results keep records without names.
+	rec = findRecord(records, "5K", types.RecordGender, "M", "")
+	if assert.NotNil(t, rec) {
+		assert.Equal(t, "3", rec.Bib)
+		assert.Equal(t, "", rec.First)
+		assert.Equal(t, "", rec.Last)
+	}
+	rec = findRecord(records, "5K", types.RecordAgeGroup, "M", "20-29")
+	if assert.NotNil(t, rec) {
+		assert.Equal(t, "1", rec.Bib)
+	}
+	// Disqualified results and results that aren't finishes don't hold records.
+	for _, rec := range records {
+		assert.NotEqual(t, "4", rec.Bib)
+		assert.NotEqual(t, "5", rec.Bib)
+	}
+	rec = findRecord(records, "10K", types.RecordAgeGroup, "F", "40-49")
+	if assert.NotNil(t, rec) {
+		assert.Equal(t, "6", rec.Bib)
+	}
+	// Chip time is used when ranking by chip time.
+	records = CalculateRecords(results, "2021", util.RANKING_TYPE_CHIP)
+	rec = findRecord(records, "5K", types.RecordCourse, "", "")
+	if assert.NotNil(t, rec) {
+		assert.Equal(t, "1", rec.Bib)
+		assert.Equal(t, 1100, rec.Seconds)
+	}
+	assert.Equal(t, 0, len(CalculateRecords(nil, "2021", util.RANKING_TYPE_GUN)))
+}
+
+func TestBestRecords(t *testing.T) {
+	records := []types.Record{
+		{Year: "2020", Distance: "5K", Category: types.RecordCourse, Bib: "10", Seconds: 1100},
+		{Year: "2020", Distance: "5K", Category: types.RecordGender, Gender: "F", Bib: "11", Seconds: 1200},
+		{Year: "2021", Distance: "5K", Category: types.RecordCourse, Bib: "20", Seconds: 1100},
+		{Year: "2021", Distance: "5K", Category: types.RecordGender, Gender: "F", Bib: "21", Seconds: 1150},
+		{Year: "2022", Distance: "5K", Category: types.RecordCourse, Bib: "30", Seconds: 1100, Milliseconds: 1},
+		{Year: "2022", Distance: "10K", Category: types.RecordCourse, Bib: "31", Seconds: 2500},
+	}
+	best := BestRecords(records)
+	assert.Equal(t, 3, len(best))
+	// Matching a record doesn't break it.
+	rec := findRecord(best, "5K", types.RecordCourse, "", "")
+	if assert.NotNil(t, rec) {
+		assert.Equal(t, "2020", rec.Year)
+		assert.Equal(t, "10", rec.Bib)
+	}
+	rec = findRecord(best, "5K", types.RecordGender, "F", "")
+	if assert.NotNil(t, rec) {
+		assert.Equal(t, "2021", rec.Year)
+		assert.Equal(t, "21", rec.Bib)
+	}
+	rec = findRecord(best, "10K", types.RecordCourse, "", "")
+	if assert.NotNil(t, rec) {
+		assert.Equal(t, "31", rec.Bib)
+	}
+}
+
+func TestFlagRecords(t *testing.T) {
+	results := recordTestResults()
+	year := types.EventYear{Year: "2021", RankingType: util.RANKING_TYPE_GUN}
+	previous := []types.Record{
+		{Year: "2020", Distance: "5K", Category: types.RecordCourse, Bib: "50", Seconds: 1100},
+		{Year: "2020", Distance: "5K", Category: types.RecordGender, Gender: "M", Bib: "50", Seconds: 1100},
+		{Year: "2020", Distance: "5K", Category: types.RecordAgeGroup, Gender: "M", AgeGroup: "20-29", Bib: "50", Seconds: 1100},
+	}
+	records := BestRecords(append(previous, CalculateRecords(results, year.Year, year.RankingType)...))
+	FlagRecords(results, year, records)
+	// Only the broadest record held is flagged.
+	assert.Equal(t, "", results[0].Record)
+	assert.Equal(t, types.RecordGender, results[1].Record)
+	assert.Equal(t, types.RecordAgeGroup, results[2].Record)
+	assert.Equal(t, "", results[3].Record)
+	assert.Equal(t, "", results[4].Record)
+	assert.Equal(t, types.RecordCourse, results[5].Record)
+	// Records from another year aren't flagged.
+	results = recordTestResults()
+	FlagRecords(results, types.EventYear{Year: "2022"}, records)
+	for _, res := range results {
+		assert.Equal(t, "", res.Record)
+	}
+}
+
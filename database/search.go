@@ -0,0 +1,86 @@
+/*
+Chronokeep Desktop - Race Scoring Software
+Copyright (C) 2026 James Sentinella
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package database
+
+import (
+	"chronokeep/results/types"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+// foldReplacer Handles letters that don't decompose into a base letter and an accent.
+var foldReplacer = strings.NewReplacer("ß", "ss", "æ", "ae", "œ", "oe", "ø", "o", "ł", "l", "đ", "d", "þ", "th")
+
+// FoldSearch Returns the text lower cased with accents removed so it can be compared ignoring both.
+func FoldSearch(text string) string {
+	t := transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
+	folded, _, err := transform.String(t, strings.ToLower(strings.TrimSpace(text)))
+	if err != nil {
+		folded = strings.ToLower(strings.TrimSpace(text))
+	}
+	return foldReplacer.Replace(folded)
+}
+
+// MatchesSearch Returns true if the result matches every criteria of the search.  Every word of
+// the name has to be found in the first or last name, but anonymous results never match by name.
+func MatchesSearch(result types.Result, search types.ResultSearch) bool {
+	if search.Bib != "" && !strings.Contains(FoldSearch(result.Bib), FoldSearch(search.Bib)) {
+		return false
+	}
+	if search.Distance != "" && FoldSearch(result.Distance) != FoldSearch(search.Distance) {
+		return false
+	}
+	if search.AgeGroup != "" && FoldSearch(result.AgeGroup) != FoldSearch(search.AgeGroup) {
+		return false
+	}
+	if search.Gender != "" && FoldSearch(result.Gender) != FoldSearch(search.Gender) {
+		return false
+	}
+	if search.Division != "" && FoldSearch(result.Division) != FoldSearch(search.Division) {
+		return false
+	}
+	if search.Name != "" {
+		if result.Anonymous {
+			return false
+		}
+		name := FoldSearch(result.First + " " + result.Last)
+		for _, word := range strings.Fields(FoldSearch(search.Name)) {
+			if !strings.Contains(name, word) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// SearchResults Returns the results that match the search in the order they were given.
+func SearchResults(results []types.Result, search types.ResultSearch) []types.Result {
+	output := make([]types.Result, 0)
+	for _, result := range results {
+		if MatchesSearch(result, search) {
+			output = append(output, result)
+		}
+	}
+	return output
+}
+
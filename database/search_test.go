@@ -0,0 +1,67 @@
+/*
+Chronokeep Desktop - Race Scoring Software
+Copyright (C) 2026 James Sentinella
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package database
+
+import (
+	"chronokeep/results/types"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFoldSearch(t *testing.T) {
+	assert.Equal(t, "jose", FoldSearch("José"))
+	assert.Equal(t, "zoe", FoldSearch(" ZOË "))
+	assert.Equal(t, "strasse", FoldSearch("Straße"))
+	assert.Equal(t, "bjorn", FoldSearch("Bjørn"))
+	assert.Equal(t, "", FoldSearch(""))
+}
+
+func TestSearchResults(t *testing.T) {
+	results := []types.Result{
+		{Bib: "100", First: "José", Last: "Núñez", Gender: "M", AgeGroup: "20-29", Distance: "5K", Division: "Open"},
+		{Bib: "1001", First: "Zoë", Last: "Smith", Gender: "F", AgeGroup: "30-39", Distance: "5K"},
+		{Bib: "205", First: "Joseph", Last: "Jones", Gender: "M", AgeGroup: "30-39", Distance: "10K", Division: "Clydesdale"},
+		{Bib: "310", First: "Jose", Last: "Hidden", Gender: "M", AgeGroup: "20-29", Distance: "10K", Anonymous: true},
+	}
+	bibs := func(results []types.Result) []string {
+		output := make([]string, 0)
+		for _, res := range results {
+			output = append(output, res.Bib)
+		}
+		return output
+	}
+	assert.Equal(t, []string{"100", "1001", "205", "310"}, bibs(SearchResults(results, types.ResultSearch{})))
+	// Names ignore case and accents but never match anonymous results.
+	assert.Equal(t, []string{"100", "205"}, bibs(SearchResults(results, types.ResultSearch{Name: "JOSE"})))
+	assert.Equal(t, []string{"100"}, bibs(SearchResults(results, types.ResultSearch{Name: "jose nunez"})))
+	assert.Equal(t, []string{"1001"}, bibs(SearchResults(results, types.ResultSearch{Name: "zoe"})))
+	assert.Equal(t, []string{}, bibs(SearchResults(results, types.ResultSearch{Name: "hidden"})))
+	// Bibs match partially, anonymous or not.
+	assert.Equal(t, []string{"100", "1001", "310"}, bibs(SearchResults(results, types.ResultSearch{Bib: "10"})))
+	assert.Equal(t, []string{"310"}, bibs(SearchResults(results, types.ResultSearch{Bib: "31"})))
+	// Everything else has to match fully.
+	assert.Equal(t, []string{"1001"}, bibs(SearchResults(results, types.ResultSearch{Gender: "f"})))
+	assert.Equal(t, []string{"1001", "205"}, bibs(SearchResults(results, types.ResultSearch{AgeGroup: "30-39"})))
+	assert.Equal(t, []string{}, bibs(SearchResults(results, types.ResultSearch{AgeGroup: "30"})))
+	assert.Equal(t, []string{"205", "310"}, bibs(SearchResults(results, types.ResultSearch{Distance: "10k"})))
+	assert.Equal(t, []string{"205"}, bibs(SearchResults(results, types.ResultSearch{Division: "clydesdale"})))
+	assert.Equal(t, []string{"205"}, bibs(SearchResults(results, types.ResultSearch{Name: "jo", Gender: "M", Distance: "10K"})))
+}
+
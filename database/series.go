@@ -0,0 +1,254 @@
+/*
+Chronokeep Desktop - Race Scoring Software
+Copyright (C) 2026 James Sentinella
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package database
+
+import (
+	"chronokeep/results/types"
+	"sort"
+	"strings"
+)
+
+// SeriesRace holds the results of one event of a series along with how the event year is ranked.
+type SeriesRace struct {
+	Event       types.SeriesEvent
+	RankingType string
+	Results     []types.Result
+}
+
+// seriesPerson holds a person while their series standing is being calculated.
+type seriesPerson struct {
+	standing types.SeriesStanding
+	// points in each race of the series, indexed the same as the races
+	points    []int
+	places    []int
+	raced     []bool
+	anonymous bool
+}
+
+// seriesIdentity Returns the key used to match the results of a person across the races of a series.
+func seriesIdentity(res *types.Result, identity string) string {
+	switch identity {
+	case types.SeriesIdentityPersonId:
+		return res.PersonId
+	case types.SeriesIdentityNameGender:
+		return strings.ToLower(strings.TrimSpace(res.First)) + "|" + strings.ToLower(strings.TrimSpace(res.Last)) + "|" + strings.ToLower(res.Gender)
+	}
+	return strings.ToLower(strings.TrimSpace(res.First)) + "|" + strings.ToLower(strings.TrimSpace(res.Last))
+}
+
+// seriesPlace Returns the place of a result used for the standings of a category.
+func seriesPlace(res *types.Result, category string) int {
+	switch category {
+	case types.SeriesCategoryGender:
+		return res.GenderRanking
+	case types.SeriesCategoryAgeGroup:
+		return res.AgeRanking
+	}
+	return res.Ranking
+}
+
+// seriesGroup Returns the name of the standings a person is listed in for a category.
+func seriesGroup(standing *types.SeriesStanding, category string) string {
+	switch category {
+	case types.SeriesCategoryGender:
+		return standing.Gender
+	case types.SeriesCategoryAgeGroup:
+		return standing.Gender + " " + standing.AgeGroup
+	}
+	return "Overall"
+}
+
+// bestFinish Returns the best place of a person or zero if they haven't placed.
+func (p *seriesPerson) bestFinish() int {
+	best := 0
+	for ix, place := range p.places {
+		if p.raced[ix] && (best == 0 || place < best) {
+			best = place
+		}
+	}
+	return best
+}
+
+// compareTieBreaker Returns a negative number if one should be ranked ahead of two using the
+// tie breaker given, a positive number if two should be, or zero if they are still tied.
+func compareTieBreaker(one, two *seriesPerson, tieBreaker string) int {
+	switch tieBreaker {
+	case types.SeriesTieBestFinish:
+		return one.bestFinish() - two.bestFinish()
+	case types.SeriesTieMostRaces:
+		return two.standing.Races - one.standing.Races
+	case types.SeriesTieMostRecent:
+		for ix := len(one.points) - 1; ix >= 0; ix-- {
+			if !one.raced[ix] && !two.raced[ix] {
+				continue
+			}
+			if one.points[ix] != two.points[ix] {
+				return two.points[ix] - one.points[ix]
+			}
+		}
+	}
+	return 0
+}
+
+// compareStandings Returns a negative number if one should be ranked ahead of two, a positive
+// number if two should be, or zero if they are tied after all of the tie breakers.
+func compareStandings(one, two *seriesPerson, tieBreakers []string) int {
+	if one.standing.Points != two.standing.Points {
+		return two.standing.Points - one.standing.Points
+	}
+	for _, tieBreaker := range tieBreakers {
+		if cmp := compareTieBreaker(one, two, tieBreaker); cmp != 0 {
+			return cmp
+		}
+	}
+	return 0
+}
+
+// CalculateSeriesStandings Calculates the standings of a series for a category from the results
+// of its races, which must be in the order they were run.  Finishers are matched across races using
+// the identity rule of the series and given points for their place in the category in each race.
+// Only the best races of each person count towards their total if the series has a best of rule.
+// People are listed in the standings of the group they were in for their most recent race.  People
+// that are still tied after the tie breakers share a ranking, and people that haven't raced enough
+// to qualify are left unranked at the end of the standings.
+func CalculateSeriesStandings(series types.Series, races []SeriesRace, category string) map[string][]types.SeriesStanding {
+	people := make(map[string]*seriesPerson)
+	order := make([]*seriesPerson, 0)
+	for raceIx, race := range races {
+		for ix := range race.Results {
+			res := &race.Results[ix]
+			if res.Distance != race.Event.Distance || !isRecordFinish(res) {
+				continue
+			}
+			place := seriesPlace(res, category)
+			if place < 1 {
+				continue
+			}
+			key := seriesIdentity(res, series.Identity)
+			if key == "" || key == "|" {
+				continue
+			}
+			person, ok := people[key]
+			if !ok {
+				person = &seriesPerson{
+					standing: types.SeriesStanding{
+						Results: make([]types.SeriesRaceResult, 0),
+					},
+					points: make([]int, len(races)),
+					places: make([]int, len(races)),
+					raced:  make([]bool, len(races)),
+				}
+				people[key] = person
+				order = append(order, person)
+			}
+			// Only the best place of a person counts if they're matched more than once in a race.
+			if person.raced[raceIx] && person.places[raceIx] <= place {
+				continue
+			}
+			points := 0
+			if place <= len(series.Points) {
+				points = series.Points[place-1]
+			}
+			seconds, milliseconds := recordTime(res, race.RankingType)
+			raceResult := types.SeriesRaceResult{
+				Slug:         race.Event.Slug,
+				Year:         race.Event.Year,
+				Bib:          res.Bib,
+				Seconds:      seconds,
+				Milliseconds: milliseconds,
+				Place:        place,
+				Points:       points,
+			}
+			if person.raced[raceIx] {
+				person.standing.Results[len(person.standing.Results)-1] = raceResult
+			} else {
+				person.standing.Results = append(person.standing.Results, raceResult)
+			}
+			person.raced[raceIx] = true
+			person.places[raceIx] = place
+			person.points[raceIx] = points
+			// Names are hidden if the person chose to be anonymous in any race.
+			person.anonymous = person.anonymous || res.Anonymous
+			if person.anonymous {
+				person.standing.First = ""
+				person.standing.Last = ""
+			} else {
+				person.standing.First = res.First
+				person.standing.Last = res.Last
+			}
+			person.standing.Gender = res.Gender
+			person.standing.AgeGroup = res.AgeGroup
+		}
+	}
+	groups := make(map[string][]*seriesPerson)
+	for _, person := range order {
+		standing := &person.standing
+		standing.Races = len(standing.Results)
+		counted := make([]int, len(standing.Results))
+		for ix := range counted {
+			counted[ix] = ix
+		}
+		// Races with more points count first, with earlier races winning ties.
+		sort.SliceStable(counted, func(i, j int) bool {
+			return standing.Results[counted[i]].Points > standing.Results[counted[j]].Points
+		})
+		if series.BestOf > 0 && len(counted) > series.BestOf {
+			counted = counted[:series.BestOf]
+		}
+		for _, ix := range counted {
+			standing.Results[ix].Counted = true
+			standing.Points += standing.Results[ix].Points
+		}
+		group := seriesGroup(standing, category)
+		groups[group] = append(groups[group], person)
+	}
+	output := make(map[string][]types.SeriesStanding)
+	for group, members := range groups {
+		qualified := func(p *seriesPerson) bool {
+			return p.standing.Races >= series.MinimumRaces
+		}
+		sort.SliceStable(members, func(i, j int) bool {
+			one, two := members[i], members[j]
+			if qualified(one) != qualified(two) {
+				return qualified(one)
+			}
+			if cmp := compareStandings(one, two, series.TieBreakers); cmp != 0 {
+				return cmp < 0
+			}
+			if one.standing.Last != two.standing.Last {
+				return one.standing.Last < two.standing.Last
+			}
+			return one.standing.First < two.standing.First
+		})
+		standings := make([]types.SeriesStanding, len(members))
+		for ix, person := range members {
+			standings[ix] = person.standing
+			if !qualified(person) {
+				standings[ix].Ranking = Unranked
+			} else if ix > 0 && qualified(members[ix-1]) && compareStandings(members[ix-1], person, series.TieBreakers) == 0 {
+				standings[ix].Ranking = standings[ix-1].Ranking
+			} else {
+				standings[ix].Ranking = ix + 1
+			}
+		}
+		output[group] = standings
+	}
+	return output
+}
+
@@ -0,0 +1,166 @@
+/*
+Chronokeep Desktop - Race Scoring Software
+Copyright (C) 2026 James Sentinella
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package database
+
+import (
+	"chronokeep/results/types"
+	"chronokeep/results/util"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func seriesTestResult(first, last, gender string, ranking, genderRanking, seconds int) types.Result {
+	return types.Result{
+		PersonId:      first + last,
+		Bib:           first,
+		First:         first,
+		Last:          last,
+		Gender:        gender,
+		AgeGroup:      "30-39",
+		Distance:      "5K",
+		Location:      "Finish",
+		Occurence:     1,
+		Seconds:       seconds + 30,
+		ChipSeconds:   seconds,
+		Ranking:       ranking,
+		GenderRanking: genderRanking,
+		AgeRanking:    genderRanking,
+		Finish:        true,
+	}
+}
+
+func seriesTestRaces() []SeriesRace {
+	dnf := seriesTestResult("Eve", "Solo", "F", 5, 3, 0)
+	dnf.Status = types.ResultStatusDNF
+	races := []SeriesRace{
+		{
+			Event: types.SeriesEvent{Slug: "race1", Year: "2021", Distance: "5K"},
+			Results: []types.Result{
+				seriesTestResult("ANN", "LEE", "F", 1, 1, 1000),
+				seriesTestResult("Bob", "Ray", "M", 2, 1, 1010),
+				seriesTestResult("Cat", "Kim", "F", 3, 2, 1020),
+				seriesTestResult("Dan", "Fox", "M", 4, 2, 1030),
+				dnf,
+			},
+		},
+		{
+			Event: types.SeriesEvent{Slug: "race2", Year: "2021", Distance: "5K"},
+			Results: []types.Result{
+				seriesTestResult("Bob", "Ray", "M", 1, 1, 1000),
+				seriesTestResult("Ann", "Lee", "F", 2, 1, 1010),
+				seriesTestResult("Dan", "Fox", "M", 3, 2, 1020),
+				seriesTestResult("Cat", "Kim", "F", 4, 2, 1030),
+			},
+		},
+		{
+			Event: types.SeriesEvent{Slug: "race3", Year: "2021", Distance: "5K"},
+			Results: []types.Result{
+				seriesTestResult("Cat", "Kim", "F", 1, 1, 1000),
+				seriesTestResult("Dan", "Fox", "M", 2, 1, 1010),
+				seriesTestResult("Bob", "Ray", "M", 3, 2, 1020),
+				seriesTestResult("Eve", "Solo", "F", 5, 2, 1040),
+			},
+		},
+	}
+	races[2].Results[1].Anonymous = true
+	for ix := range races {
+		races[ix].RankingType = util.RANKING_TYPE_CHIP
+	}
+	return races
+}
+
+func TestCalculateSeriesStandings(t *testing.T) {
+	series := types.Series{
+		Points:       []int{10, 8, 6, 4},
+		BestOf:       2,
+		MinimumRaces: 2,
+		TieBreakers:  []string{types.SeriesTieBestFinish},
+		Identity:     types.SeriesIdentityName,
+	}
+	standings := CalculateSeriesStandings(series, seriesTestRaces(), types.SeriesCategoryOverall)
+	overall := standings["Overall"]
+	if assert.Equal(t, 1, len(standings)) && assert.Equal(t, 5, len(overall)) {
+		// Ann and Bob are still tied after their best finishes so they share first.
+		assert.Equal(t, "Lee", overall[0].Last)
+		assert.Equal(t, 1, overall[0].Ranking)
+		assert.Equal(t, 18, overall[0].Points)
+		assert.Equal(t, 2, overall[0].Races)
+		assert.Equal(t, "Ray", overall[1].Last)
+		assert.Equal(t, 1, overall[1].Ranking)
+		assert.Equal(t, 18, overall[1].Points)
+		if assert.Equal(t, 3, len(overall[1].Results)) {
+			// Only the best two races count.
+			assert.True(t, overall[1].Results[0].Counted)
+			assert.True(t, overall[1].Results[1].Counted)
+			assert.False(t, overall[1].Results[2].Counted)
+			assert.Equal(t, 6, overall[1].Results[2].Points)
+			assert.Equal(t, 1020, overall[1].Results[2].Seconds)
+			assert.Equal(t, "race3", overall[1].Results[2].Slug)
+		}
+		assert.Equal(t, "Kim", overall[2].Last)
+		assert.Equal(t, 3, overall[2].Ranking)
+		assert.Equal(t, 16, overall[2].Points)
+		// Anonymous people keep their standing without their name.
+		assert.Equal(t, "", overall[3].Last)
+		assert.Equal(t, 4, overall[3].Ranking)
+		assert.Equal(t, 14, overall[3].Points)
+		// The DNF doesn't count as a race so Eve hasn't qualified.
+		assert.Equal(t, "Solo", overall[4].Last)
+		assert.Equal(t, Unranked, overall[4].Ranking)
+		assert.Equal(t, 1, overall[4].Races)
+		assert.Equal(t, 0, overall[4].Points)
+	}
+	// Ties can be broken by the number of races.
+	series.TieBreakers = []string{types.SeriesTieBestFinish, types.SeriesTieMostRaces}
+	overall = CalculateSeriesStandings(series, seriesTestRaces(), types.SeriesCategoryOverall)["Overall"]
+	if assert.Equal(t, 5, len(overall)) {
+		assert.Equal(t, "Ray", overall[0].Last)
+		assert.Equal(t, 1, overall[0].Ranking)
+		assert.Equal(t, "Lee", overall[1].Last)
+		assert.Equal(t, 2, overall[1].Ranking)
+	}
+	// Or by the most recent race.
+	series.TieBreakers = []string{types.SeriesTieMostRecent}
+	overall = CalculateSeriesStandings(series, seriesTestRaces(), types.SeriesCategoryOverall)["Overall"]
+	if assert.Equal(t, 5, len(overall)) {
+		assert.Equal(t, "Ray", overall[0].Last)
+		assert.Equal(t, 2, overall[1].Ranking)
+	}
+	// Gender standings use gender places and all races when there is no best of rule.
+	series.BestOf = 0
+	standings = CalculateSeriesStandings(series, seriesTestRaces(), types.SeriesCategoryGender)
+	if assert.Equal(t, 2, len(standings)) && assert.Equal(t, 3, len(standings["F"])) && assert.Equal(t, 2, len(standings["M"])) {
+		assert.Equal(t, "Kim", standings["F"][0].Last)
+		assert.Equal(t, 26, standings["F"][0].Points)
+		assert.Equal(t, "Lee", standings["F"][1].Last)
+		assert.Equal(t, 20, standings["F"][1].Points)
+		assert.Equal(t, Unranked, standings["F"][2].Ranking)
+		assert.Equal(t, "Ray", standings["M"][0].Last)
+		assert.Equal(t, 28, standings["M"][0].Points)
+	}
+	standings = CalculateSeriesStandings(series, seriesTestRaces(), types.SeriesCategoryAgeGroup)
+	assert.Equal(t, 2, len(standings))
+	assert.Equal(t, 3, len(standings["F 30-39"]))
+	// People can be matched by person id instead of name.
+	series.Identity = types.SeriesIdentityPersonId
+	overall = CalculateSeriesStandings(series, seriesTestRaces(), types.SeriesCategoryOverall)["Overall"]
+	assert.Equal(t, 6, len(overall))
+}
+
@@ -0,0 +1,231 @@
+/*
+Chronokeep Desktop - Race Scoring Software
+Copyright (C) 2026 James Sentinella
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package database
+
+import (
+	"chronokeep/results/types"
+	"chronokeep/results/util"
+	"math"
+	"sort"
+	"strings"
+)
+
+// splitGroup identifies the results that are compared with each other at a split.
+type splitGroup struct {
+	distance string
+	split    int
+}
+
+// locationOccurence identifies a segment by its location and how many times that location
+// has been passed when reaching it.
+type locationOccurence struct {
+	location  string
+	occurence int
+}
+
+// DistanceUnit Returns the distance unit matching a unit or one of its common abbreviations,
+// or an empty string if it isn't known.
+func DistanceUnit(unit string) string {
+	switch strings.ToLower(strings.TrimSpace(unit)) {
+	case util.DISTANCE_TYPE_MILE, "mile", "mi":
+		return util.DISTANCE_TYPE_MILE
+	case util.DISTANCE_TYPE_METER, "meter", "metres", "metre", "m":
+		return util.DISTANCE_TYPE_METER
+	case util.DISTANCE_TYPE_KILOMETER, "kilometer", "kilometres", "kilometre", "km", "k":
+		return util.DISTANCE_TYPE_KILOMETER
+	case util.DISTANCE_TYPE_YARD, "yard", "yd", "yds":
+		return util.DISTANCE_TYPE_YARD
+	case util.DISTANCE_TYPE_FEET, "foot", "ft":
+		return util.DISTANCE_TYPE_FEET
+	}
+	return ""
+}
+
+// paceUnit Returns the unit paces are given in for a distance unit.  Imperial units use miles
+// and metric units use kilometers.
+func paceUnit(unit string) string {
+	switch unit {
+	case util.DISTANCE_TYPE_MILE, util.DISTANCE_TYPE_YARD, util.DISTANCE_TYPE_FEET:
+		return util.DISTANCE_TYPE_MILE
+	case util.DISTANCE_TYPE_METER, util.DISTANCE_TYPE_KILOMETER:
+		return util.DISTANCE_TYPE_KILOMETER
+	}
+	return ""
+}
+
+// pace Returns the seconds taken per unit of distance, rounded to the nearest second.
+func pace(milliseconds int64, meters float64, unit string) int {
+	unitMeters, ok := DistanceMeters(1, unit)
+	if !ok || meters <= 0 || milliseconds <= 0 {
+		return 0
+	}
+	return int(math.Round(float64(milliseconds) / 1000 / (meters / unitMeters)))
+}
+
+// orderedSegment is a segment along with its distance from the start in meters.
+type orderedSegment struct {
+	segment types.Segment
+	meters  float64
+	known   bool
+}
+
+// segmentMatcher finds the segment of a distance that a result was recorded at.
+type segmentMatcher struct {
+	ordered    []orderedSegment
+	byName     map[string]int
+	byLocation map[locationOccurence]int
+}
+
+// newSegmentMatcher Orders the segments of a distance by their distance from the start.  Segments
+// sharing a location are numbered in that order to find them by location and occurence.
+func newSegmentMatcher(segments []types.Segment) *segmentMatcher {
+	m := &segmentMatcher{
+		ordered:    make([]orderedSegment, 0, len(segments)),
+		byName:     make(map[string]int),
+		byLocation: make(map[locationOccurence]int),
+	}
+	for _, seg := range segments {
+		meters, known := DistanceMeters(seg.DistanceValue, DistanceUnit(seg.DistanceUnit))
+		m.ordered = append(m.ordered, orderedSegment{segment: seg, meters: meters, known: known})
+	}
+	sort.SliceStable(m.ordered, func(i, j int) bool {
+		return m.ordered[i].meters < m.ordered[j].meters
+	})
+	locationCount := make(map[string]int)
+	for ix, seg := range m.ordered {
+		m.byName[seg.segment.Name] = ix
+		locationCount[seg.segment.Location]++
+		m.byLocation[locationOccurence{location: seg.segment.Location, occurence: locationCount[seg.segment.Location]}] = ix
+	}
+	return m
+}
+
+// match Returns the index of the segment a result was recorded at, matching by segment name and
+// then by location and occurence.  Finishes that aren't a segment are placed after every segment.
+// Returns false if the result is neither.
+func (m *segmentMatcher) match(res *types.Result) (int, bool) {
+	if ix, ok := m.byName[res.Segment]; ok && res.Segment != "" {
+		return ix, true
+	}
+	if ix, ok := m.byLocation[locationOccurence{location: res.Location, occurence: res.Occurence}]; ok {
+		return ix, true
+	}
+	return len(m.ordered), res.Finish
+}
+
+// CalculateSplits Joins the results of a person to the segments of their distance and works out
+// the time taken, pace, place and time behind the leader at each.  Results are matched to a segment
+// by segment name, or by location and occurence, with the segments sharing a location numbered in
+// order of distance.  A finish that isn't a segment is added as the last split using the length of
+// the distance, which may be nil.  The field holds every result of the distance and is used for the
+// place and the leader at each split.  Results that match neither a segment nor the finish are left
+// out.
+func CalculateSplits(results, field []types.Result, segments []types.Segment, distance *types.Distance, rankingType string) []types.Split {
+	matcher := newSegmentMatcher(segments)
+	ordered := matcher.ordered
+	// Find where each result belongs.  Finishes that aren't segments go after every segment.
+	type matched struct {
+		result types.Result
+		split  int
+	}
+	matches := make([]matched, 0)
+	for _, res := range results {
+		if ix, ok := matcher.match(&res); ok {
+			matches = append(matches, matched{result: res, split: ix})
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].split != matches[j].split {
+			return matches[i].split < matches[j].split
+		}
+		return rankedBefore(&matches[i].result, &matches[j].result, rankingType)
+	})
+	// Find the leader and the ranked results at each split.
+	leaders := make(map[splitGroup]*types.Result)
+	ranked := make(map[splitGroup][]*types.Result)
+	for ix := range field {
+		res := &field[ix]
+		split, ok := matcher.match(res)
+		if !ok || !res.IsRanked() {
+			continue
+		}
+		key := splitGroup{distance: res.Distance, split: split}
+		ranked[key] = append(ranked[key], res)
+		if leader, ok := leaders[key]; !ok || rankedBefore(res, leader, rankingType) {
+			leaders[key] = res
+		}
+	}
+	output := make([]types.Split, 0, len(matches))
+	prevMillis, prevMeters, prevKnown := int64(0), 0.0, true
+	for _, match := range matches {
+		res := match.result
+		split := types.Split{
+			Location:  res.Location,
+			Occurence: res.Occurence,
+			Finish:    res.Finish,
+			Ranking:   Unranked,
+		}
+		meters, known := 0.0, false
+		unit := ""
+		if match.split < len(ordered) {
+			seg := ordered[match.split]
+			split.Name = seg.segment.Name
+			split.DistanceValue = seg.segment.DistanceValue
+			split.DistanceUnit = seg.segment.DistanceUnit
+			meters, known = seg.meters, seg.known
+			unit = DistanceUnit(seg.segment.DistanceUnit)
+		} else {
+			split.Name = res.Location
+			if distance != nil {
+				split.DistanceValue = distance.DistanceValue
+				split.DistanceUnit = distance.DistanceUnit
+				meters, known = DistanceMeters(distance.DistanceValue, DistanceUnit(distance.DistanceUnit))
+				unit = DistanceUnit(distance.DistanceUnit)
+			}
+		}
+		seconds, milliseconds := recordTime(&res, rankingType)
+		millis := int64(seconds)*1000 + int64(milliseconds)
+		split.Seconds, split.Milliseconds = seconds, milliseconds
+		legMillis := millis - prevMillis
+		split.SplitSeconds, split.SplitMilliseconds = int(legMillis/1000), int(legMillis%1000)
+		if known && meters > 0 {
+			split.PaceUnit = paceUnit(unit)
+			split.AveragePaceSeconds = pace(millis, meters, split.PaceUnit)
+			if prevKnown {
+				split.PaceSeconds = pace(legMillis, meters-prevMeters, split.PaceUnit)
+			}
+		}
+		prevMillis, prevMeters, prevKnown = millis, meters, known
+		key := splitGroup{distance: res.Distance, split: match.split}
+		if leader, ok := leaders[key]; ok && res.IsRanked() {
+			split.Ranking = 1
+			for _, other := range ranked[key] {
+				if other.Bib != res.Bib && rankedBefore(other, &res, rankingType) {
+					split.Ranking++
+				}
+			}
+			leaderSeconds, leaderMilliseconds := recordTime(leader, rankingType)
+			behind := max(millis-(int64(leaderSeconds)*1000+int64(leaderMilliseconds)), 0)
+			split.BehindSeconds, split.BehindMilliseconds = int(behind/1000), int(behind%1000)
+		}
+		output = append(output, split)
+	}
+	return output
+}
+
@@ -0,0 +1,131 @@
+/*
+Chronokeep Desktop - Race Scoring Software
+Copyright (C) 2026 James Sentinella
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package database
+
+import (
+	"chronokeep/results/types"
+	"chronokeep/results/util"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func splitTestResult(bib, location, segment string, occurence, seconds int, finish bool) types.Result {
+	return types.Result{
+		PersonId:    bib,
+		Bib:         bib,
+		First:       "Runner",
+		Last:        bib,
+		Gender:      "Woman",
+		AgeGroup:    "30-39",
+		Distance:    "10K",
+		Seconds:     seconds,
+		ChipSeconds: seconds,
+		Segment:     segment,
+		Location:    location,
+		Occurence:   occurence,
+		Finish:      finish,
+	}
+}
+
+func TestCalculateSplits(t *testing.T) {
+	segments := []types.Segment{
+		{Location: "Turnaround", DistanceName: "10K", Name: "Halfway", DistanceValue: 5, DistanceUnit: "km"},
+		{Location: "Start/Finish", DistanceName: "10K", Name: "Lap 1", DistanceValue: 2.5, DistanceUnit: util.DISTANCE_TYPE_KILOMETER},
+	}
+	distance := &types.Distance{Name: "10K", DistanceValue: 10, DistanceUnit: util.DISTANCE_TYPE_KILOMETER}
+	results := []types.Result{
+		splitTestResult("1", "Start/Finish", "", 2, 2500, true),
+		// Matched by the name of the segment instead of the location.
+		splitTestResult("1", "Other", "Halfway", 1, 1200, false),
+		splitTestResult("1", "Start/Finish", "", 1, 600, false),
+		// Not a segment or a finish.
+		splitTestResult("1", "Aid Station", "", 1, 900, false),
+	}
+	field := []types.Result{
+		results[0],
+		results[1],
+		results[2],
+		splitTestResult("2", "Start/Finish", "", 1, 590, false),
+		splitTestResult("2", "Turnaround", "", 1, 1210, false),
+		splitTestResult("2", "Start/Finish", "", 2, 2400, true),
+		splitTestResult("3", "Start/Finish", "", 1, 500, false),
+	}
+	field[6].Status = types.ResultStatusDNF
+	splits := CalculateSplits(results, field, segments, distance, util.RANKING_TYPE_CHIP)
+	if assert.Equal(t, 3, len(splits)) {
+		assert.Equal(t, "Lap 1", splits[0].Name)
+		assert.Equal(t, 600, splits[0].Seconds)
+		assert.Equal(t, 600, splits[0].SplitSeconds)
+		assert.Equal(t, 240, splits[0].PaceSeconds)
+		assert.Equal(t, 240, splits[0].AveragePaceSeconds)
+		assert.Equal(t, util.DISTANCE_TYPE_KILOMETER, splits[0].PaceUnit)
+		assert.Equal(t, 2, splits[0].Ranking)
+		assert.Equal(t, 10, splits[0].BehindSeconds)
+
+		assert.Equal(t, "Halfway", splits[1].Name)
+		assert.Equal(t, 5.0, splits[1].DistanceValue)
+		assert.Equal(t, 1200, splits[1].Seconds)
+		assert.Equal(t, 600, splits[1].SplitSeconds)
+		assert.Equal(t, 240, splits[1].PaceSeconds)
+		assert.Equal(t, 240, splits[1].AveragePaceSeconds)
+		assert.Equal(t, 1, splits[1].Ranking)
+		assert.Equal(t, 0, splits[1].BehindSeconds)
+
+		assert.Equal(t, "Start/Finish", splits[2].Name)
+		assert.True(t, splits[2].Finish)
+		assert.Equal(t, 10.0, splits[2].DistanceValue)
+		assert.Equal(t, 2500, splits[2].Seconds)
+		assert.Equal(t, 1300, splits[2].SplitSeconds)
+		assert.Equal(t, 260, splits[2].PaceSeconds)
+		assert.Equal(t, 250, splits[2].AveragePaceSeconds)
+		assert.Equal(t, 2, splits[2].Ranking)
+		assert.Equal(t, 100, splits[2].BehindSeconds)
+	}
+	// Paces for imperial distances are per mile.
+	segments = []types.Segment{
+		{Location: "Mile 1", DistanceName: "10K", Name: "Mile 1", DistanceValue: 1, DistanceUnit: "Mi"},
+	}
+	results = []types.Result{
+		splitTestResult("2", "Mile 1", "", 1, 400, false),
+		splitTestResult("2", "Start/Finish", "", 1, 2400, true),
+	}
+	splits = CalculateSplits(results, results, segments, nil, util.RANKING_TYPE_GUN)
+	if assert.Equal(t, 2, len(splits)) {
+		assert.Equal(t, util.DISTANCE_TYPE_MILE, splits[0].PaceUnit)
+		assert.Equal(t, 400, splits[0].PaceSeconds)
+		assert.Equal(t, 1, splits[0].Ranking)
+		assert.Equal(t, 0, splits[0].BehindSeconds)
+		// Without the length of the distance there is no pace to the finish.
+		assert.Equal(t, "", splits[1].PaceUnit)
+		assert.Equal(t, 0, splits[1].PaceSeconds)
+		assert.Equal(t, 2000, splits[1].SplitSeconds)
+	}
+}
+
+func TestDistanceUnit(t *testing.T) {
+	assert.Equal(t, util.DISTANCE_TYPE_MILE, DistanceUnit("Mi"))
+	assert.Equal(t, util.DISTANCE_TYPE_MILE, DistanceUnit("miles"))
+	assert.Equal(t, util.DISTANCE_TYPE_KILOMETER, DistanceUnit("KM"))
+	assert.Equal(t, util.DISTANCE_TYPE_METER, DistanceUnit("meters"))
+	assert.Equal(t, util.DISTANCE_TYPE_YARD, DistanceUnit("yd"))
+	assert.Equal(t, util.DISTANCE_TYPE_FEET, DistanceUnit("ft"))
+	assert.Equal(t, "", DistanceUnit("furlongs"))
+}
+
@@ -0,0 +1,225 @@
+/*
+Chronokeep Desktop - Race Scoring Software
+Copyright (C) 2026 James Sentinella
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package sqlite
+
+import (
+	"chronokeep/results/types"
+	"context"
+	"fmt"
+	"time"
+)
+
+// GetAthlete Gets an athlete by slug.
+func (s *SQLite) GetAthlete(slug string) (*types.Athlete, error) {
+	db, err := s.GetDB()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancelfunc := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancelfunc()
+	res, err := db.QueryContext(
+		ctx,
+		"SELECT athlete_id, account_id, athlete_slug, first, last, birthdate FROM athletes WHERE athlete_slug=?;",
+		slug,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving athlete: %v", err)
+	}
+	defer res.Close()
+	if res.Next() {
+		var outAthlete types.Athlete
+		err := res.Scan(
+			&outAthlete.Identifier,
+			&outAthlete.AccountIdentifier,
+			&outAthlete.Slug,
+			&outAthlete.First,
+			&outAthlete.Last,
+			&outAthlete.Birthdate,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("error getting athlete: %v", err)
+		}
+		return &outAthlete, nil
+	}
+	return nil, nil
+}
+
+// AddAthlete Adds an athlete or updates the one with the same slug.
+func (s *SQLite) AddAthlete(athlete types.Athlete) (*types.Athlete, error) {
+	db, err := s.GetDB()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancelfunc := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancelfunc()
+	_, err = db.ExecContext(
+		ctx,
+		"INSERT INTO athletes(account_id, athlete_slug, first, last, birthdate) VALUES (?,?,?,?,?) "+
+			"ON CONFLICT (athlete_slug) DO UPDATE SET first=excluded.first, last=excluded.last, birthdate=excluded.birthdate;",
+		athlete.AccountIdentifier,
+		athlete.Slug,
+		athlete.First,
+		athlete.Last,
+		athlete.Birthdate,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error adding athlete to database: %v", err)
+	}
+	return s.GetAthlete(athlete.Slug)
+}
+
+// DeleteAthlete Deletes an athlete and the bibs claimed for them.
+func (s *SQLite) DeleteAthlete(athlete types.Athlete) error {
+	db, err := s.GetDB()
+	if err != nil {
+		return err
+	}
+	ctx, cancelfunc := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancelfunc()
+	tx, err := db.Begin()
+	if err != nil {
+		return fmt.Errorf("unable to start transaction: %v", err)
+	}
+	_, err = tx.ExecContext(
+		ctx,
+		"DELETE FROM athlete_links WHERE athlete_id=?;",
+		athlete.Identifier,
+	)
+	if err != nil {
+		tx.Rollback()
+		return fmt.Errorf("error deleting athlete links: %v", err)
+	}
+	_, err = tx.ExecContext(
+		ctx,
+		"DELETE FROM athletes WHERE athlete_id=?;",
+		athlete.Identifier,
+	)
+	if err != nil {
+		tx.Rollback()
+		return fmt.Errorf("error deleting athlete: %v", err)
+	}
+	err = tx.Commit()
+	if err != nil {
+		tx.Rollback()
+		return fmt.Errorf("error committing transaction: %v", err)
+	}
+	return nil
+}
+
+// AddAthleteLink Claims the bib of an event year for an athlete.
+func (s *SQLite) AddAthleteLink(athleteID, eventYearID int64, bib string) error {
+	db, err := s.GetDB()
+	if err != nil {
+		return err
+	}
+	ctx, cancelfunc := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancelfunc()
+	_, err = db.ExecContext(
+		ctx,
+		"INSERT INTO athlete_links(athlete_id, event_year_id, bib) VALUES (?,?,?) ON CONFLICT (athlete_id, event_year_id, bib) DO NOTHING;",
+		athleteID,
+		eventYearID,
+		bib,
+	)
+	if err != nil {
+		return fmt.Errorf("error adding athlete link: %v", err)
+	}
+	return nil
+}
+
+// DeleteAthleteLink Removes the claim of an athlete on the bib of an event year.
+func (s *SQLite) DeleteAthleteLink(athleteID, eventYearID int64, bib string) (int64, error) {
+	db, err := s.GetDB()
+	if err != nil {
+		return 0, err
+	}
+	ctx, cancelfunc := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancelfunc()
+	res, err := db.ExecContext(
+		ctx,
+		"DELETE FROM athlete_links WHERE athlete_id=? AND event_year_id=? AND bib=?;",
+		athleteID,
+		eventYearID,
+		bib,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("error deleting athlete link: %v", err)
+	}
+	count, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("error fetching rows affected from athlete link deletion: %v", err)
+	}
+	return count, nil
+}
+
+// GetAthleteEntries Gets the event years an athlete may have taken part in.  These are the entries of
+// participants with the same name as the athlete in events of the athlete's account along with the bibs
+// claimed for the athlete.  Deleted events and event years are left out.
+func (s *SQLite) GetAthleteEntries(athlete types.Athlete) ([]types.AthleteEntry, error) {
+	db, err := s.GetDB()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancelfunc := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancelfunc()
+	res, err := db.QueryContext(
+		ctx,
+		"SELECT y.event_year_id, e.account_id, e.access_restricted, p.birthdate, p.anonymous, 0, e.slug, e.event_name, y.year, y.date_time, p.bib "+
+			"FROM participant p JOIN event_year y ON p.event_year_id=y.event_year_id JOIN event e ON y.event_id=e.event_id "+
+			"WHERE LOWER(p.first)=LOWER(?) AND LOWER(p.last)=LOWER(?) AND e.account_id=? AND y.year_deleted=FALSE AND e.event_deleted=FALSE "+
+			"UNION ALL "+
+			"SELECT y.event_year_id, e.account_id, e.access_restricted, '', 0, 1, e.slug, e.event_name, y.year, y.date_time, l.bib "+
+			"FROM athlete_links l JOIN event_year y ON l.event_year_id=y.event_year_id JOIN event e ON y.event_id=e.event_id "+
+			"WHERE l.athlete_id=? AND y.year_deleted=FALSE AND e.event_deleted=FALSE;",
+		athlete.First,
+		athlete.Last,
+		athlete.AccountIdentifier,
+		athlete.Identifier,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving athlete entries: %v", err)
+	}
+	defer res.Close()
+	output := make([]types.AthleteEntry, 0)
+	for res.Next() {
+		var entry types.AthleteEntry
+		var anonymous, claimed int
+		err := res.Scan(
+			&entry.EventYearIdentifier,
+			&entry.AccountIdentifier,
+			&entry.AccessRestricted,
+			&entry.Birthdate,
+			&anonymous,
+			&claimed,
+			&entry.Slug,
+			&entry.Name,
+			&entry.Year,
+			&entry.DateTime,
+			&entry.Bib,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("error getting athlete entry: %v", err)
+		}
+		entry.Anonymous = anonymous != 0
+		entry.Claimed = claimed != 0
+		output = append(output, entry)
+	}
+	return output, nil
+}
+
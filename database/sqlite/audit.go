@@ -0,0 +1,438 @@
+/*
+Chronokeep Desktop - Race Scoring Software
+Copyright (C) 2026 James Sentinella
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package sqlite
+
+import (
+	"chronokeep/results/database"
+	"chronokeep/results/types"
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// auditScopeSize is the most alternate ids or bibs used to retrieve people for the audit history
+// at once.
+const auditScopeSize = 500
+
+// auditScopeFilter Returns the condition and parameters selecting the people of an event year in
+// part of an audit scope.
+func auditScopeFilter(eventYearID int64, scope database.AuditScope) (string, []any) {
+	args := []any{eventYearID}
+	column, values := "bib", scope.Bibs
+	switch {
+	case scope.All:
+		return "event_year_id=?", args
+	case scope.Distance != "":
+		return "event_year_id=? AND distance=?", append(args, scope.Distance)
+	case len(scope.AlternateIds) > 0:
+		column, values = "alternate_id", scope.AlternateIds
+	}
+	for _, value := range values {
+		args = append(args, value)
+	}
+	return "event_year_id=? AND " + column + " IN (" + strings.TrimPrefix(strings.Repeat(",?", len(values)), ",") + ")", args
+}
+
+// getAuditState Gets the people and results in the scope of a change to an event year with the
+// values kept in the audit history.
+func getAuditState(ctx context.Context, tx *sql.Tx, eventYearID int64, scope database.AuditScope) ([]types.Result, []types.Result, error) {
+	people := make([]types.Result, 0)
+	results := make([]types.Result, 0)
+	for _, part := range scope.Parts(auditScopeSize) {
+		filter, args := auditScopeFilter(eventYearID, part)
+		partPeople, partResults, err := getAuditPart(ctx, tx, filter, args)
+		if err != nil {
+			return nil, nil, err
+		}
+		people = append(people, partPeople...)
+		results = append(results, partResults...)
+	}
+	return people, results, nil
+}
+
+// getAuditPart Gets the people matching the condition given and their results with the values
+// kept in the audit history.
+func getAuditPart(ctx context.Context, tx *sql.Tx, filter string, args []any) ([]types.Result, []types.Result, error) {
+	res, err := tx.QueryContext(
+		ctx,
+		"SELECT alternate_id, bib, first, last, age, gender, age_group, distance, anonymous, division "+
+			"FROM person WHERE "+filter+" ORDER BY person_id;",
+		args...,
+	)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error retrieving people for audit: %v", err)
+	}
+	people := make([]types.Result, 0)
+	for res.Next() {
+		var person types.Result
+		var anonymous int
+		err := res.Scan(
+			&person.PersonId,
+			&person.Bib,
+			&person.First,
+			&person.Last,
+			&person.Age,
+			&person.Gender,
+			&person.AgeGroup,
+			&person.Distance,
+			&anonymous,
+			&person.Division,
+		)
+		if err != nil {
+			res.Close()
+			return nil, nil, fmt.Errorf("error getting person for audit: %v", err)
+		}
+		person.Anonymous = anonymous != 0
+		people = append(people, person)
+	}
+	res.Close()
+	res, err = tx.QueryContext(
+		ctx,
+		"SELECT alternate_id, bib, first, last, age, gender, age_group, distance, anonymous, division, "+
+			"seconds, milliseconds, chip_seconds, chip_milliseconds, segment, location, occurence, finish, "+
+			"result_type, local_time, result_status, status_reason FROM result NATURAL JOIN person "+
+			"WHERE "+filter+" ORDER BY person_id, location, occurence;",
+		args...,
+	)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error retrieving results for audit: %v", err)
+	}
+	defer res.Close()
+	results := make([]types.Result, 0)
+	for res.Next() {
+		var result types.Result
+		var anonymous int
+		err := res.Scan(
+			&result.PersonId,
+			&result.Bib,
+			&result.First,
+			&result.Last,
+			&result.Age,
+			&result.Gender,
+			&result.AgeGroup,
+			&result.Distance,
+			&anonymous,
+			&result.Division,
+			&result.Seconds,
+			&result.Milliseconds,
+			&result.ChipSeconds,
+			&result.ChipMilliseconds,
+			&result.Segment,
+			&result.Location,
+			&result.Occurence,
+			&result.Finish,
+			&result.Type,
+			&result.LocalTime,
+			&result.Status,
+			&result.StatusReason,
+		)
+		if err != nil {
+			return nil, nil, fmt.Errorf("error getting result for audit: %v", err)
+		}
+		result.Anonymous = anonymous != 0
+		results = append(results, result)
+	}
+	return people, results, nil
+}
+
+// addAuditEntries Records the changes made to the people and results in the scope of a change to
+// an event year since the state given was retrieved.
+func addAuditEntries(ctx context.Context, tx *sql.Tx, eventYearID int64, scope database.AuditScope, actor *types.AuditActor, people, results []types.Result) error {
+	afterPeople, afterResults, err := getAuditState(ctx, tx, eventYearID, scope)
+	if err != nil {
+		return err
+	}
+	entries := database.AuditChanges(people, results, afterPeople, afterResults)
+	if len(entries) == 0 {
+		return nil
+	}
+	stmt, err := tx.PrepareContext(
+		ctx,
+		"INSERT INTO result_audit(event_year_id, audit_type, action, bib, location, occurence, "+
+			"account_email, key_name, key_type, before_value, after_value, changed_at) "+
+			"VALUES (?,?,?,?,?,?,?,?,?,?,?,?);",
+	)
+	if err != nil {
+		return fmt.Errorf("unable to prepare statement for audit entries: %v", err)
+	}
+	defer stmt.Close()
+	if actor == nil {
+		actor = &types.AuditActor{}
+	}
+	changedAt := time.Now().Unix()
+	for _, entry := range entries {
+		before, err := database.AuditValue(entry.Before)
+		if err != nil {
+			return err
+		}
+		after, err := database.AuditValue(entry.After)
+		if err != nil {
+			return err
+		}
+		_, err = stmt.ExecContext(
+			ctx,
+			eventYearID,
+			entry.Type,
+			entry.Action,
+			entry.Bib,
+			entry.Location,
+			entry.Occurence,
+			actor.Account,
+			actor.KeyName,
+			actor.KeyType,
+			before,
+			after,
+			changedAt,
+		)
+		if err != nil {
+			return fmt.Errorf("error adding audit entry: %v", err)
+		}
+	}
+	return nil
+}
+
+// GetResultAudit Gets the audit history of the people and results of an event year in the order
+// the changes were made.  Only the history of a bib is returned when one is given.
+func (s *SQLite) GetResultAudit(eventYearID int64, bib string) ([]types.AuditEntry, error) {
+	db, err := s.GetDB()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancelfunc := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancelfunc()
+	var res *sql.Rows
+	if bib != "" {
+		res, err = db.QueryContext(
+			ctx,
+			"SELECT audit_id, audit_type, action, bib, location, occurence, account_email, key_name, "+
+				"key_type, before_value, after_value, changed_at FROM result_audit "+
+				"WHERE event_year_id=? AND bib=? ORDER BY audit_id;",
+			eventYearID,
+			bib,
+		)
+	} else {
+		res, err = db.QueryContext(
+			ctx,
+			"SELECT audit_id, audit_type, action, bib, location, occurence, account_email, key_name, "+
+				"key_type, before_value, after_value, changed_at FROM result_audit "+
+				"WHERE event_year_id=? ORDER BY audit_id;",
+			eventYearID,
+		)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving audit entries: %v", err)
+	}
+	defer res.Close()
+	output := make([]types.AuditEntry, 0)
+	for res.Next() {
+		var entry types.AuditEntry
+		var before, after string
+		err := res.Scan(
+			&entry.Identifier,
+			&entry.Type,
+			&entry.Action,
+			&entry.Bib,
+			&entry.Location,
+			&entry.Occurence,
+			&entry.Account,
+			&entry.KeyName,
+			&entry.KeyType,
+			&before,
+			&after,
+			&entry.ChangedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("error getting audit entry: %v", err)
+		}
+		if entry.Before, err = database.ParseAuditValue(before); err != nil {
+			return nil, err
+		}
+		if entry.After, err = database.ParseAuditValue(after); err != nil {
+			return nil, err
+		}
+		output = append(output, entry)
+	}
+	return output, nil
+}
+
+// RevertAudit Applies the changes needed to revert people and results to an earlier state, setting
+// each to the value after the change or deleting it when there is none.  The changes are recorded
+// in the audit history as made by the actor given.
+func (s *SQLite) RevertAudit(eventYearID int64, changes []types.AuditEntry, actor *types.AuditActor) (int64, error) {
+	db, err := s.GetDB()
+	if err != nil {
+		return 0, err
+	}
+	ctx, cancelfunc := context.WithTimeout(context.Background(), time.Second*15)
+	defer cancelfunc()
+	tx, err := db.Begin()
+	if err != nil {
+		return 0, fmt.Errorf("unable to start transaction: %v", err)
+	}
+	scope := database.ChangesAuditScope(changes)
+	people, results, err := getAuditState(ctx, tx, eventYearID, scope)
+	if err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+	known := make(map[string]bool)
+	for _, person := range people {
+		known[person.PersonId] = true
+	}
+	// People are set first so the results being set have someone to belong to, and deleted last
+	// so their results are deleted before them.
+	for _, change := range changes {
+		if change.After == nil || (change.Type != types.AuditTypePerson && known[change.After.PersonId]) {
+			continue
+		}
+		_, err = tx.ExecContext(
+			ctx,
+			"INSERT INTO person(event_year_id, bib, first, last, age, gender, age_group, distance, "+
+				"anonymous, alternate_id, division) VALUES (?,?,?,?,?,?,?,?,?,?,?) "+
+				"ON CONFLICT (event_year_id, alternate_id) DO UPDATE SET bib=excluded.bib, "+
+				"first=excluded.first, last=excluded.last, age=excluded.age, gender=excluded.gender, "+
+				"age_group=excluded.age_group, distance=excluded.distance, anonymous=excluded.anonymous, "+
+				"division=excluded.division;",
+			eventYearID,
+			change.After.Bib,
+			change.After.First,
+			change.After.Last,
+			change.After.Age,
+			change.After.Gender,
+			change.After.AgeGroup,
+			change.After.Distance,
+			change.After.AnonyInt(),
+			change.After.PersonId,
+			change.After.Division,
+		)
+		if err != nil {
+			tx.Rollback()
+			return 0, fmt.Errorf("error reverting person: %v", err)
+		}
+		known[change.After.PersonId] = true
+	}
+	deletedAt := time.Now().Unix()
+	for _, change := range changes {
+		if change.Type != types.AuditTypeResult {
+			continue
+		}
+		if change.After == nil {
+			err = deleteAuditResult(ctx, tx, eventYearID, change.Before, deletedAt)
+		} else {
+			_, err = tx.ExecContext(
+				ctx,
+				"INSERT INTO result(person_id, seconds, milliseconds, chip_seconds, chip_milliseconds, "+
+					"segment, location, occurence, finish, result_type, local_time, result_status, status_reason) "+
+					"SELECT person_id, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ? FROM person WHERE event_year_id=? AND alternate_id=? "+
+					"ON CONFLICT (person_id, location, occurence) DO UPDATE SET seconds=excluded.seconds, "+
+					"milliseconds=excluded.milliseconds, chip_seconds=excluded.chip_seconds, "+
+					"chip_milliseconds=excluded.chip_milliseconds, segment=excluded.segment, finish=excluded.finish, "+
+					"result_type=excluded.result_type, local_time=excluded.local_time, "+
+					"result_status=excluded.result_status, status_reason=excluded.status_reason;",
+				change.After.Seconds,
+				change.After.Milliseconds,
+				change.After.ChipSeconds,
+				change.After.ChipMilliseconds,
+				change.After.Segment,
+				change.After.Location,
+				change.After.Occurence,
+				change.After.Finish,
+				change.After.Type,
+				change.After.LocalTime,
+				change.After.Status,
+				change.After.StatusReason,
+				eventYearID,
+				change.After.PersonId,
+			)
+		}
+		if err != nil {
+			tx.Rollback()
+			return 0, fmt.Errorf("error reverting result: %v", err)
+		}
+	}
+	for _, change := range changes {
+		if change.Type != types.AuditTypePerson || change.After != nil {
+			continue
+		}
+		for ix := range results {
+			if results[ix].PersonId != change.Before.PersonId {
+				continue
+			}
+			err = deleteAuditResult(ctx, tx, eventYearID, &results[ix], deletedAt)
+			if err != nil {
+				tx.Rollback()
+				return 0, fmt.Errorf("error reverting result: %v", err)
+			}
+		}
+		_, err = tx.ExecContext(
+			ctx,
+			"DELETE FROM person WHERE event_year_id=? AND alternate_id=?;",
+			eventYearID,
+			change.Before.PersonId,
+		)
+		if err != nil {
+			tx.Rollback()
+			return 0, fmt.Errorf("error reverting person: %v", err)
+		}
+	}
+	err = addAuditEntries(ctx, tx, eventYearID, scope, actor, people, results)
+	if err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+	err = tx.Commit()
+	if err != nil {
+		tx.Rollback()
+		return 0, fmt.Errorf("error committing transaction: %v", err)
+	}
+	return int64(len(changes)), nil
+}
+
+// deleteAuditResult Deletes a result of a person, noting the deletion for clients fetching changes.
+func deleteAuditResult(ctx context.Context, tx *sql.Tx, eventYearID int64, result *types.Result, deletedAt int64) error {
+	_, err := tx.ExecContext(
+		ctx,
+		"INSERT INTO deleted_result(event_year_id, bib, distance, location, occurence, deleted_at) "+
+			"SELECT event_year_id, bib, distance, location, occurence, ? FROM result NATURAL JOIN person "+
+			"WHERE event_year_id=? AND alternate_id=? AND location=? AND occurence=? "+
+			"ON CONFLICT (event_year_id, bib, location, occurence) DO UPDATE SET "+
+			"distance=excluded.distance, deleted_at=excluded.deleted_at;",
+		deletedAt,
+		eventYearID,
+		result.PersonId,
+		result.Location,
+		result.Occurence,
+	)
+	if err != nil {
+		return err
+	}
+	_, err = tx.ExecContext(
+		ctx,
+		"DELETE FROM result AS r WHERE location=? AND occurence=? AND EXISTS "+
+			"(SELECT * FROM person AS p WHERE event_year_id=? AND alternate_id=? AND p.person_id=r.person_id);",
+		result.Location,
+		result.Occurence,
+		eventYearID,
+		result.PersonId,
+	)
+	return err
+}
+
@@ -41,10 +41,14 @@ func (s *SQLite) AddDistances(eventYearID int64, distances []types.Distance) ([]
 		"INSERT INTO distances("+
 			"event_year_id, "+
 			"distance_name, "+
-			"certification"+
-			") VALUES ($1,$2,$3) "+
+			"certification, "+
+			"distance_value, "+
+			"distance_unit"+
+			") VALUES ($1,$2,$3,$4,$5) "+
 			"ON CONFLICT (event_year_id, distance_name) DO UPDATE SET "+
-			"certification=$3"+
+			"certification=$3, "+
+			"distance_value=$4, "+
+			"distance_unit=$5"+
 			";",
 	)
 	if err != nil {
@@ -56,6 +60,8 @@ func (s *SQLite) AddDistances(eventYearID int64, distances []types.Distance) ([]
 			eventYearID,
 			dist.Name,
 			dist.Certification,
+			dist.DistanceValue,
+			dist.DistanceUnit,
 		)
 		if err != nil {
 			tx.Rollback()
@@ -72,6 +78,8 @@ func (s *SQLite) AddDistances(eventYearID int64, distances []types.Distance) ([]
 		output = append(output, types.Distance{
 			Name:          dist.Name,
 			Certification: dist.Certification,
+			DistanceValue: dist.DistanceValue,
+			DistanceUnit:  dist.DistanceUnit,
 		})
 	}
 	return output, nil
@@ -86,7 +94,7 @@ func (s *SQLite) GetDistance(eventYearID int64, dist_name string) (*types.Distan
 	defer cancelfunc()
 	res, err := db.QueryContext(
 		ctx,
-		"SELECT distance_id, distance_name, certification "+
+		"SELECT distance_id, distance_name, certification, distance_value, distance_unit "+
 			"FROM distances WHERE event_year_id=$1 AND distance_name=$2;",
 		eventYearID,
 		dist_name,
@@ -101,6 +109,8 @@ func (s *SQLite) GetDistance(eventYearID int64, dist_name string) (*types.Distan
 			&dist.Identifier,
 			&dist.Name,
 			&dist.Certification,
+			&dist.DistanceValue,
+			&dist.DistanceUnit,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("error getting distance: %v", err)
@@ -119,7 +129,7 @@ func (s *SQLite) GetDistances(eventYearID int64) ([]types.Distance, error) {
 	defer cancelfunc()
 	res, err := db.QueryContext(
 		ctx,
-		"SELECT distance_id, distance_name, certification "+
+		"SELECT distance_id, distance_name, certification, distance_value, distance_unit "+
 			"FROM distances WHERE event_year_id=$1;",
 		eventYearID,
 	)
@@ -134,6 +144,8 @@ func (s *SQLite) GetDistances(eventYearID int64) ([]types.Distance, error) {
 			&dist.Identifier,
 			&dist.Name,
 			&dist.Certification,
+			&dist.DistanceValue,
+			&dist.DistanceUnit,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("error getting distance: %v", err)
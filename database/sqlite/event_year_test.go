@@ -580,6 +580,75 @@ func TestUpdateEventYear(t *testing.T) {
 	}
 }
 
+func TestEventYearStatus(t *testing.T) {
+	db, finalize, err := setupTests(t)
+	if err != nil {
+		t.Fatalf("Error setting up test. %v", err)
+	}
+	defer finalize(t)
+	setupEventYearTests()
+	account1, _ := db.AddAccount(accounts[0])
+	event1 := &types.Event{
+		AccountIdentifier: account1.Identifier,
+		Name:              "Event 1",
+		Slug:              "event1",
+		ContactEmail:      "event1@test.com",
+		AccessRestricted:  false,
+	}
+	event1, _ = db.AddEvent(*event1)
+	eventYear1, err := db.AddEventYear(types.EventYear{
+		EventIdentifier: event1.Identifier,
+		Year:            "2021",
+		DateTime:        time.Date(2021, 10, 06, 9, 1, 15, 0, time.Local),
+		Live:            true,
+		DaysAllowed:     1,
+		RankingType:     "chip",
+	})
+	if err != nil {
+		t.Fatalf("Error adding event year: %v", err)
+	}
+	eventYear2, err := db.AddEventYear(types.EventYear{
+		EventIdentifier: event1.Identifier,
+		Year:            "2020",
+		DateTime:        time.Date(2020, 10, 05, 9, 0, 0, 0, time.Local),
+		DaysAllowed:     1,
+		RankingType:     "gun",
+	})
+	if err != nil {
+		t.Fatalf("Error adding event year: %v", err)
+	}
+	// Years without a status are live or a draft.
+	if eventYear1.Status != types.EventYearStatusLive {
+		t.Errorf("Expected status %v, found %v.", types.EventYearStatusLive, eventYear1.Status)
+	}
+	year, _ := db.GetEventYear(event1.Slug, "2020")
+	if year.Status != types.EventYearStatusDraft || year.Locked() {
+		t.Errorf("Expected unlocked status %v, found %v.", types.EventYearStatusDraft, year.Status)
+	}
+	eventYear2.Status = types.EventYearStatusOfficial
+	err = db.UpdateEventYear(*eventYear2)
+	if err != nil {
+		t.Fatalf("Error updating event year: %v", err)
+	}
+	year, _ = db.GetEventYear(event1.Slug, "2020")
+	if year.Status != types.EventYearStatusOfficial || !year.Locked() {
+		t.Errorf("Expected locked status %v, found %v.", types.EventYearStatusOfficial, year.Status)
+	}
+	mult, _ := db.GetEventAndYear(event1.Slug, "2020")
+	if mult == nil || mult.EventYear.Status != types.EventYearStatusOfficial {
+		t.Errorf("Expected status %v from event and year.", types.EventYearStatusOfficial)
+	}
+	years, _ := db.GetEventYears(event1.Slug)
+	for _, y := range years {
+		if y.Year == "2021" && y.Status != types.EventYearStatusLive {
+			t.Errorf("Expected status %v, found %v.", types.EventYearStatusLive, y.Status)
+		}
+		if y.Year == "2020" && y.Status != types.EventYearStatusOfficial {
+			t.Errorf("Expected status %v, found %v.", types.EventYearStatusOfficial, y.Status)
+		}
+	}
+}
+
 func TestBadDatabaseEventYear(t *testing.T) {
 	db := badTestSetup(t)
 	_, err := db.GetEventYear("", "")
@@ -0,0 +1,75 @@
+/*
+Chronokeep Desktop - Race Scoring Software
+Copyright (C) 2026 James Sentinella
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package sqlite
+
+import (
+	"chronokeep/results/types"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandicapScoring(t *testing.T) {
+	db, finalize, err := setupTests(t)
+	if err != nil {
+		t.Fatalf("Error setting up tests. %v", err)
+	}
+	defer finalize(t)
+	setupTeamTests()
+	eventYear := setupTeamEventYear(t, db)
+	scoring, err := db.GetHandicapScoring(eventYear.Identifier)
+	if assert.NoError(t, err) {
+		assert.Nil(t, scoring)
+	}
+	set := types.HandicapScoring{
+		Enabled: true,
+		Handicaps: []types.Handicap{
+			{Bib: "10", Seconds: 300},
+			{Bib: "11", Seconds: 120, Milliseconds: 500},
+			{Bib: "10", Seconds: 600},
+			{Bib: "12", Seconds: 120},
+		},
+	}
+	expected := types.HandicapScoring{
+		Enabled: true,
+		Handicaps: []types.Handicap{
+			{Bib: "12", Seconds: 120},
+			{Bib: "11", Seconds: 120, Milliseconds: 500},
+			{Bib: "10", Seconds: 300},
+		},
+	}
+	scoring, err = db.SetHandicapScoring(eventYear.Identifier, set)
+	if assert.NoError(t, err) && assert.NotNil(t, scoring) {
+		assert.Equal(t, expected, *scoring)
+	}
+	scoring, err = db.GetHandicapScoring(eventYear.Identifier)
+	if assert.NoError(t, err) && assert.NotNil(t, scoring) {
+		assert.Equal(t, expected, *scoring)
+	}
+	// Setting it again replaces the old scoring.
+	set = types.HandicapScoring{
+		Enabled:   false,
+		Handicaps: []types.Handicap{},
+	}
+	scoring, err = db.SetHandicapScoring(eventYear.Identifier, set)
+	if assert.NoError(t, err) && assert.NotNil(t, scoring) {
+		assert.Equal(t, set, *scoring)
+	}
+}
+
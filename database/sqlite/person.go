@@ -19,6 +19,7 @@ along with this program.  If not, see <https://www.gnu.org/licenses/>.
 package sqlite
 
 import (
+	"chronokeep/results/database"
 	"chronokeep/results/types"
 	"context"
 	"fmt"
@@ -111,7 +112,7 @@ func (s *SQLite) GetPeople(slug, year string) ([]types.Person, error) {
 	return output, nil
 }
 
-func (s *SQLite) AddPerson(eventYearID int64, person types.Person) (*types.Person, error) {
+func (s *SQLite) AddPerson(eventYearID int64, person types.Person, actor *types.AuditActor) (*types.Person, error) {
 	db, err := s.GetDB()
 	if err != nil {
 		return nil, err
@@ -122,6 +123,12 @@ func (s *SQLite) AddPerson(eventYearID int64, person types.Person) (*types.Perso
 	}
 	ctx, cancelfunc := context.WithTimeout(context.Background(), time.Second*5)
 	defer cancelfunc()
+	scope := database.AuditScope{AlternateIds: []string{person.AlternateId}}
+	auditPeople, auditResults, err := getAuditState(ctx, tx, eventYearID, scope)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
 	_, err = tx.ExecContext(
 		ctx,
 		"INSERT INTO person("+
@@ -190,6 +197,11 @@ func (s *SQLite) AddPerson(eventYearID int64, person types.Person) (*types.Perso
 		tx.Rollback()
 		return nil, fmt.Errorf("person not found after add: %v", err)
 	}
+	err = addAuditEntries(ctx, tx, eventYearID, scope, actor, auditPeople, auditResults)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
 	err = tx.Commit()
 	if err != nil {
 		tx.Rollback()
@@ -198,7 +210,7 @@ func (s *SQLite) AddPerson(eventYearID int64, person types.Person) (*types.Perso
 	return &output, nil
 }
 
-func (s *SQLite) AddPeople(eventYearID int64, people []types.Person) ([]types.Person, error) {
+func (s *SQLite) AddPeople(eventYearID int64, people []types.Person, actor *types.AuditActor) ([]types.Person, error) {
 	db, err := s.GetDB()
 	if err != nil {
 		return nil, err
@@ -237,6 +249,12 @@ func (s *SQLite) AddPeople(eventYearID int64, people []types.Person) ([]types.Pe
 	if err != nil {
 		return nil, fmt.Errorf("error preparing statement for adding people: %v", err)
 	}
+	scope := database.PeopleAuditScope(people)
+	auditPeople, auditResults, err := getAuditState(ctx, tx, eventYearID, scope)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
 	for _, person := range people {
 		_, err = personstmt.ExecContext(
 			ctx,
@@ -298,6 +316,11 @@ func (s *SQLite) AddPeople(eventYearID int64, people []types.Person) ([]types.Pe
 			return nil, fmt.Errorf("person not found after add: %v", err)
 		}
 	}
+	err = addAuditEntries(ctx, tx, eventYearID, scope, actor, auditPeople, auditResults)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
 	err = tx.Commit()
 	if err != nil {
 		tx.Rollback()
@@ -306,7 +329,7 @@ func (s *SQLite) AddPeople(eventYearID int64, people []types.Person) ([]types.Pe
 	return output, nil
 }
 
-func (s *SQLite) DeletePeople(eventYearId int64, alternateIds []string) (int64, error) {
+func (s *SQLite) DeletePeople(eventYearId int64, alternateIds []string, actor *types.AuditActor) (int64, error) {
 	db, err := s.GetDB()
 	if err != nil {
 		return 0, err
@@ -317,6 +340,12 @@ func (s *SQLite) DeletePeople(eventYearId int64, alternateIds []string) (int64,
 	}
 	ctx, cancelfunc := context.WithTimeout(context.Background(), time.Second*5)
 	defer cancelfunc()
+	scope := database.AuditScope{AlternateIds: alternateIds}
+	auditPeople, auditResults, err := getAuditState(ctx, tx, eventYearId, scope)
+	if err != nil {
+		tx.Rollback()
+		return 0, err
+	}
 	var count int64
 	if len(alternateIds) > 0 {
 		count = 0
@@ -349,6 +378,11 @@ func (s *SQLite) DeletePeople(eventYearId int64, alternateIds []string) (int64,
 			return 0, fmt.Errorf("error fetching rows affected from person deletion: %v", err)
 		}
 	}
+	err = addAuditEntries(ctx, tx, eventYearId, scope, actor, auditPeople, auditResults)
+	if err != nil {
+		tx.Rollback()
+		return 0, err
+	}
 	err = tx.Commit()
 	if err != nil {
 		tx.Rollback()
@@ -357,7 +391,7 @@ func (s *SQLite) DeletePeople(eventYearId int64, alternateIds []string) (int64,
 	return count, nil
 }
 
-func (s *SQLite) UpdatePerson(eventYearID int64, person types.Person) (*types.Person, error) {
+func (s *SQLite) UpdatePerson(eventYearID int64, person types.Person, actor *types.AuditActor) (*types.Person, error) {
 	db, err := s.GetDB()
 	if err != nil {
 		return nil, err
@@ -368,6 +402,12 @@ func (s *SQLite) UpdatePerson(eventYearID int64, person types.Person) (*types.Pe
 	if err != nil {
 		return nil, fmt.Errorf("unable to start transaction: %v", err)
 	}
+	scope := database.AuditScope{AlternateIds: []string{person.AlternateId}}
+	auditPeople, auditResults, err := getAuditState(ctx, tx, eventYearID, scope)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
 	_, err = tx.ExecContext(
 		ctx,
 		"UPDATE person SET "+
@@ -425,6 +465,11 @@ func (s *SQLite) UpdatePerson(eventYearID int64, person types.Person) (*types.Pe
 		tx.Rollback()
 		return nil, fmt.Errorf("person not found after add: %v", err)
 	}
+	err = addAuditEntries(ctx, tx, eventYearID, scope, actor, auditPeople, auditResults)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
 	err = tx.Commit()
 	if err != nil {
 		tx.Rollback()
@@ -0,0 +1,139 @@
+/*
+Chronokeep Desktop - Race Scoring Software
+Copyright (C) 2026 James Sentinella
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package sqlite
+
+import (
+	"chronokeep/results/types"
+	"context"
+	"fmt"
+	"time"
+)
+
+// GetPublishSettings Gets the publish settings of an event year.  Returns nil if none have been set.
+func (s *SQLite) GetPublishSettings(eventYearID int64) (*types.PublishSettings, error) {
+	db, err := s.GetDB()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancelfunc := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancelfunc()
+	res, err := db.QueryContext(
+		ctx,
+		"SELECT publish_at, owner_only FROM publish_settings WHERE event_year_id=?;",
+		eventYearID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving publish settings: %v", err)
+	}
+	defer res.Close()
+	if !res.Next() {
+		return nil, nil
+	}
+	settings := types.PublishSettings{
+		HiddenDistances: make([]string, 0),
+	}
+	err = res.Scan(
+		&settings.PublishAt,
+		&settings.OwnerOnly,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error getting publish settings: %v", err)
+	}
+	res.Close()
+	res, err = db.QueryContext(
+		ctx,
+		"SELECT distance FROM hidden_distances WHERE event_year_id=?;",
+		eventYearID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving hidden distances: %v", err)
+	}
+	defer res.Close()
+	for res.Next() {
+		var distance string
+		err := res.Scan(&distance)
+		if err != nil {
+			return nil, fmt.Errorf("error getting hidden distance: %v", err)
+		}
+		settings.HiddenDistances = append(settings.HiddenDistances, distance)
+	}
+	return &settings, nil
+}
+
+// SetPublishSettings Replaces the publish settings of an event year.
+func (s *SQLite) SetPublishSettings(eventYearID int64, settings types.PublishSettings) (*types.PublishSettings, error) {
+	db, err := s.GetDB()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancelfunc := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancelfunc()
+	tx, err := db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("unable to start transaction: %v", err)
+	}
+	_, err = tx.ExecContext(
+		ctx,
+		"INSERT INTO publish_settings(event_year_id, publish_at, owner_only) VALUES (?,?,?) "+
+			"ON CONFLICT (event_year_id) DO UPDATE SET publish_at=excluded.publish_at, owner_only=excluded.owner_only;",
+		eventYearID,
+		settings.PublishAt,
+		settings.OwnerOnly,
+	)
+	if err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("error adding publish settings to database: %v", err)
+	}
+	_, err = tx.ExecContext(
+		ctx,
+		"DELETE FROM hidden_distances WHERE event_year_id=?;",
+		eventYearID,
+	)
+	if err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("error deleting old hidden distances: %v", err)
+	}
+	hidden := make([]string, 0)
+	seen := make(map[string]bool)
+	for _, distance := range settings.HiddenDistances {
+		if seen[distance] {
+			continue
+		}
+		seen[distance] = true
+		_, err = tx.ExecContext(
+			ctx,
+			"INSERT INTO hidden_distances(event_year_id, distance) VALUES (?,?);",
+			eventYearID,
+			distance,
+		)
+		if err != nil {
+			tx.Rollback()
+			return nil, fmt.Errorf("error adding hidden distance to database: %v", err)
+		}
+		hidden = append(hidden, distance)
+	}
+	err = tx.Commit()
+	if err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("error committing transaction: %v", err)
+	}
+	settings.HiddenDistances = hidden
+	return &settings, nil
+}
+
@@ -0,0 +1,74 @@
+/*
+Chronokeep Desktop - Race Scoring Software
+Copyright (C) 2026 James Sentinella
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package sqlite
+
+import (
+	"chronokeep/results/types"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPublishSettings(t *testing.T) {
+	db, finalize, err := setupTests(t)
+	if err != nil {
+		t.Fatalf("Error setting up tests. %v", err)
+	}
+	defer finalize(t)
+	setupTeamTests()
+	eventYear := setupTeamEventYear(t, db)
+	settings, err := db.GetPublishSettings(eventYear.Identifier)
+	if assert.NoError(t, err) {
+		assert.Nil(t, settings)
+	}
+	settings, err = db.SetPublishSettings(eventYear.Identifier, types.PublishSettings{
+		PublishAt:       1700000000,
+		OwnerOnly:       true,
+		HiddenDistances: []string{"5K", "10K", "5K"},
+	})
+	if assert.NoError(t, err) {
+		assert.Equal(t, int64(1700000000), settings.PublishAt)
+		assert.True(t, settings.OwnerOnly)
+		assert.ElementsMatch(t, []string{"5K", "10K"}, settings.HiddenDistances)
+	}
+	settings, err = db.GetPublishSettings(eventYear.Identifier)
+	if assert.NoError(t, err) && assert.NotNil(t, settings) {
+		assert.Equal(t, int64(1700000000), settings.PublishAt)
+		assert.True(t, settings.OwnerOnly)
+		assert.ElementsMatch(t, []string{"5K", "10K"}, settings.HiddenDistances)
+	}
+	// Setting them again replaces the old settings.
+	_, err = db.SetPublishSettings(eventYear.Identifier, types.PublishSettings{
+		HiddenDistances: []string{"Half Marathon"},
+	})
+	assert.NoError(t, err)
+	settings, err = db.GetPublishSettings(eventYear.Identifier)
+	if assert.NoError(t, err) && assert.NotNil(t, settings) {
+		assert.Equal(t, int64(0), settings.PublishAt)
+		assert.False(t, settings.OwnerOnly)
+		assert.Equal(t, []string{"Half Marathon"}, settings.HiddenDistances)
+	}
+	_, err = db.SetPublishSettings(eventYear.Identifier, types.PublishSettings{})
+	assert.NoError(t, err)
+	settings, err = db.GetPublishSettings(eventYear.Identifier)
+	if assert.NoError(t, err) && assert.NotNil(t, settings) {
+		assert.Equal(t, 0, len(settings.HiddenDistances))
+	}
+}
+
@@ -0,0 +1,137 @@
+/*
+Chronokeep Desktop - Race Scoring Software
+Copyright (C) 2026 James Sentinella
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package sqlite
+
+import (
+	"chronokeep/results/types"
+	"context"
+	"fmt"
+	"time"
+)
+
+// GetRecords Gets the best finishes of every year of an event, oldest year first.
+func (s *SQLite) GetRecords(eventID int64) ([]types.Record, error) {
+	db, err := s.GetDB()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancelfunc := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancelfunc()
+	res, err := db.QueryContext(
+		ctx,
+		"SELECT year, distance, category, gender, age_group, bib, first, last, seconds, milliseconds "+
+			"FROM records NATURAL JOIN event_year WHERE event_id=? AND year_deleted=FALSE "+
+			"ORDER BY date_time ASC;",
+		eventID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving records: %v", err)
+	}
+	defer res.Close()
+	output := make([]types.Record, 0)
+	for res.Next() {
+		var rec types.Record
+		err := res.Scan(
+			&rec.Year,
+			&rec.Distance,
+			&rec.Category,
+			&rec.Gender,
+			&rec.AgeGroup,
+			&rec.Bib,
+			&rec.First,
+			&rec.Last,
+			&rec.Seconds,
+			&rec.Milliseconds,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("error getting record: %v", err)
+		}
+		output = append(output, rec)
+	}
+	return output, nil
+}
+
+// SetRecords Replaces the best finishes stored for an event year.
+func (s *SQLite) SetRecords(eventYearID int64, records []types.Record) ([]types.Record, error) {
+	db, err := s.GetDB()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancelfunc := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancelfunc()
+	tx, err := db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("unable to start transaction: %v", err)
+	}
+	_, err = tx.ExecContext(
+		ctx,
+		"DELETE FROM records WHERE event_year_id=?;",
+		eventYearID,
+	)
+	if err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("error deleting old records: %v", err)
+	}
+	stmt, err := tx.PrepareContext(
+		ctx,
+		"INSERT INTO records("+
+			"event_year_id, "+
+			"distance, "+
+			"category, "+
+			"gender, "+
+			"age_group, "+
+			"bib, "+
+			"first, "+
+			"last, "+
+			"seconds, "+
+			"milliseconds"+
+			") VALUES (?,?,?,?,?,?,?,?,?,?);",
+	)
+	if err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("error preparing statement for adding records: %v", err)
+	}
+	defer stmt.Close()
+	for _, rec := range records {
+		_, err = stmt.ExecContext(
+			ctx,
+			eventYearID,
+			rec.Distance,
+			rec.Category,
+			rec.Gender,
+			rec.AgeGroup,
+			rec.Bib,
+			rec.First,
+			rec.Last,
+			rec.Seconds,
+			rec.Milliseconds,
+		)
+		if err != nil {
+			tx.Rollback()
+			return nil, fmt.Errorf("error adding record to database: %v", err)
+		}
+	}
+	err = tx.Commit()
+	if err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("error committing transaction: %v", err)
+	}
+	return records, nil
+}
+
@@ -287,7 +287,7 @@ func TestAddResults(t *testing.T) {
 		RankingType:     "chip",
 	}
 	eventYear, _ = db.AddEventYear(*eventYear)
-	res, err := db.AddResults(eventYear.Identifier, results)
+	res, err := db.AddResults(eventYear.Identifier, results, nil)
 	if err != nil {
 		t.Fatalf("Error adding results: %v", err)
 	}
@@ -322,7 +322,7 @@ func TestAddResults(t *testing.T) {
 	results[0].LocalTime = "updated-local-time"
 	results[0].Division = "UpdatedDivisions!"
 	results[0].DivisionRanking = 100
-	res, err = db.AddResults(eventYear.Identifier, results[0:1])
+	res, err = db.AddResults(eventYear.Identifier, results[0:1], nil)
 	if err != nil {
 		t.Fatalf("Error adding results: %v", err)
 	}
@@ -394,7 +394,7 @@ func TestAddResults(t *testing.T) {
 	results[1].AgeRanking = 231
 	results[1].GenderRanking = 451
 	results[1].Division = "AnotherUpdate!"
-	res, err = db.AddResults(eventYear.Identifier, results[1:2])
+	res, err = db.AddResults(eventYear.Identifier, results[1:2], nil)
 	if err != nil {
 		t.Fatalf("Error adding results: %v", err)
 	}
@@ -468,7 +468,7 @@ func TestAddResults(t *testing.T) {
 		t.Errorf("Expected to find %v %v in the results but did not.", results[1].First, results[1].Last)
 	}
 	setupPageResultTests()
-	_, err = db.AddResults(eventYear.Identifier, results)
+	_, err = db.AddResults(eventYear.Identifier, results, nil)
 	if err != nil {
 		t.Fatalf("Error adding large number of results at once: %v", err)
 	}
@@ -504,7 +504,7 @@ func TestGetLastResults(t *testing.T) {
 	if len(res) != 0 {
 		t.Errorf("Results not added but we've found %v results.", len(res))
 	}
-	db.AddResults(eventYear.Identifier, results[0:1])
+	db.AddResults(eventYear.Identifier, results[0:1], nil)
 	res, err = db.GetLastResults(eventYear.Identifier, 0, 0)
 	if err != nil {
 		t.Fatalf("Error getting last results: %v", err)
@@ -515,7 +515,7 @@ func TestGetLastResults(t *testing.T) {
 	if res[0] != results[0] {
 		t.Errorf("Expected results %+v, found %+v.", results[0], res[0])
 	}
-	db.AddResults(eventYear.Identifier, results)
+	db.AddResults(eventYear.Identifier, results, nil)
 	res, err = db.GetLastResults(eventYear.Identifier, 0, 0)
 	if err != nil {
 		t.Fatalf("Error getting last results: %v", err)
@@ -558,7 +558,7 @@ func TestGetLastResultsPage(t *testing.T) {
 		RankingType:     "chip",
 	}
 	eventYear, _ = db.AddEventYear(*eventYear)
-	db.AddResults(eventYear.EventIdentifier, results)
+	db.AddResults(eventYear.EventIdentifier, results, nil)
 	res, err := db.GetLastResults(eventYear.EventIdentifier, 50, 0)
 	if err != nil {
 		t.Fatalf("Error getting first page of results: %v", err)
@@ -629,7 +629,7 @@ func TestGetDistanceResults(t *testing.T) {
 	if len(res) != 0 {
 		t.Errorf("Results not added but we've found %v results.", len(res))
 	}
-	db.AddResults(eventYear.Identifier, results[0:1])
+	db.AddResults(eventYear.Identifier, results[0:1], nil)
 	res, err = db.GetDistanceResults(eventYear.Identifier, results[0].Distance, 0, 0)
 	if err != nil {
 		t.Fatalf("Error getting last results: %v", err)
@@ -640,7 +640,7 @@ func TestGetDistanceResults(t *testing.T) {
 	if res[0] != results[0] {
 		t.Errorf("Expected results %+v, found %+v.", results[0], res[0])
 	}
-	db.AddResults(eventYear.Identifier, results)
+	db.AddResults(eventYear.Identifier, results, nil)
 	res, err = db.GetDistanceResults(eventYear.Identifier, results[3].Distance, 0, 0)
 	if err != nil {
 		t.Fatalf("Error getting last results: %v", err)
@@ -691,7 +691,7 @@ func TestGetDistanceResultsPage(t *testing.T) {
 	}
 	eventYear, _ = db.AddEventYear(*eventYear)
 	for i := 0; i < len(results); i += 10 {
-		_, err = db.AddResults(eventYear.EventIdentifier, results[i:i+10])
+		_, err = db.AddResults(eventYear.EventIdentifier, results[i:i+10], nil)
 		if err != nil {
 			t.Fatalf("Something went wrong trying to add results: %v", err)
 		}
@@ -806,7 +806,7 @@ func TestGetAllDistanceResults(t *testing.T) {
 	if len(res) != 0 {
 		t.Errorf("Results not added but we've found %v results.", len(res))
 	}
-	db.AddResults(eventYear.Identifier, results[0:1])
+	db.AddResults(eventYear.Identifier, results[0:1], nil)
 	res, err = db.GetAllDistanceResults(eventYear.Identifier, results[0].Distance, 0, 0)
 	if err != nil {
 		t.Fatalf("Error getting last results: %v", err)
@@ -817,7 +817,7 @@ func TestGetAllDistanceResults(t *testing.T) {
 	if res[0] != results[0] {
 		t.Errorf("Expected results %+v, found %+v.", results[0], res[0])
 	}
-	db.AddResults(eventYear.Identifier, results)
+	db.AddResults(eventYear.Identifier, results, nil)
 	res, err = db.GetAllDistanceResults(eventYear.Identifier, results[3].Distance, 0, 0)
 	if err != nil {
 		t.Fatalf("Error getting last results: %v", err)
@@ -868,7 +868,7 @@ func TestGetAllDistanceResultsPage(t *testing.T) {
 	}
 	eventYear, _ = db.AddEventYear(*eventYear)
 	for i := 0; i < len(results); i += 10 {
-		_, err = db.AddResults(eventYear.EventIdentifier, results[i:i+10])
+		_, err = db.AddResults(eventYear.EventIdentifier, results[i:i+10], nil)
 		if err != nil {
 			t.Fatalf("Something went wrong trying to add results: %v", err)
 		}
@@ -983,7 +983,7 @@ func TestGetFinishResults(t *testing.T) {
 	if len(res) != 0 {
 		t.Errorf("Results not added but we've found %v results.", len(res))
 	}
-	db.AddResults(eventYear.Identifier, results[0:1])
+	db.AddResults(eventYear.Identifier, results[0:1], nil)
 	res, err = db.GetFinishResults(eventYear.Identifier, "", 0, 0)
 	if err != nil {
 		t.Fatalf("Error getting finish results (2): %v", err)
@@ -991,7 +991,7 @@ func TestGetFinishResults(t *testing.T) {
 	if len(res) != 0 {
 		t.Fatalf("Expected %v results to be added, %v added.", 0, len(res))
 	}
-	db.AddResults(eventYear.Identifier, results[1:2])
+	db.AddResults(eventYear.Identifier, results[1:2], nil)
 	res, err = db.GetFinishResults(eventYear.Identifier, "", 0, 0)
 	if err != nil {
 		t.Fatalf("Error getting finish results (3): %v", err)
@@ -1002,7 +1002,7 @@ func TestGetFinishResults(t *testing.T) {
 	if res[0] != results[1] {
 		t.Errorf("Expected results %+v, found %+v.", results[0], res[0])
 	}
-	db.AddResults(eventYear.Identifier, results)
+	db.AddResults(eventYear.Identifier, results, nil)
 	res, err = db.GetFinishResults(eventYear.Identifier, results[0].Distance, 0, 0)
 	if err != nil {
 		t.Fatalf("Error getting finish results (4): %v", err)
@@ -1010,7 +1010,7 @@ func TestGetFinishResults(t *testing.T) {
 	if len(res) != (len(results) - 3) {
 		t.Errorf("Expected %v results to be added, %v added.", len(results)-3, len(res))
 	}
-	db.AddResults(eventYear.Identifier, results)
+	db.AddResults(eventYear.Identifier, results, nil)
 	res, err = db.GetFinishResults(eventYear.Identifier, "", 0, 0)
 	if err != nil {
 		t.Fatalf("Error getting finish results (5): %v", err)
@@ -1054,7 +1054,7 @@ func TestGetFinishResultsPage(t *testing.T) {
 	}
 	eventYear, _ = db.AddEventYear(*eventYear)
 	for i := 0; i < len(results); i += 10 {
-		_, err = db.AddResults(eventYear.EventIdentifier, results[i:i+10])
+		_, err = db.AddResults(eventYear.EventIdentifier, results[i:i+10], nil)
 		if err != nil {
 			t.Fatalf("Something went wrong trying to add results: %v", err)
 		}
@@ -1170,7 +1170,7 @@ func TestGetResults(t *testing.T) {
 	if len(res) != 0 {
 		t.Errorf("Results not added but we've found %v results.", len(res))
 	}
-	db.AddResults(eventYear.Identifier, results[0:1])
+	db.AddResults(eventYear.Identifier, results[0:1], nil)
 	res, err = db.GetResults(eventYear.Identifier, 0, 0)
 	if err != nil {
 		t.Fatalf("Error getting results: %v", err)
@@ -1181,7 +1181,7 @@ func TestGetResults(t *testing.T) {
 	if res[0] != results[0] {
 		t.Errorf("Expected results %+v, found %+v.", results[0], res[0])
 	}
-	db.AddResults(eventYear.Identifier, results)
+	db.AddResults(eventYear.Identifier, results, nil)
 	res, err = db.GetResults(eventYear.Identifier, 0, 0)
 	if err != nil {
 		t.Fatalf("Error getting results: %v", err)
@@ -1225,7 +1225,7 @@ func TestGetResultsPage(t *testing.T) {
 	}
 	eventYear, _ = db.AddEventYear(*eventYear)
 	for i := 0; i < len(results); i += 10 {
-		_, err = db.AddResults(eventYear.EventIdentifier, results[i:i+10])
+		_, err = db.AddResults(eventYear.EventIdentifier, results[i:i+10], nil)
 		if err != nil {
 			t.Fatalf("Something went wrong trying to add results: %v", err)
 		}
@@ -1293,7 +1293,7 @@ func TestPageNoLimit(t *testing.T) {
 		RankingType:     "chip",
 	}
 	eventYear, _ = db.AddEventYear(*eventYear)
-	db.AddResults(eventYear.EventIdentifier, results)
+	db.AddResults(eventYear.EventIdentifier, results, nil)
 	res, err := db.GetResults(eventYear.EventIdentifier, 0, 50)
 	if err != nil {
 		t.Fatalf("Error getting first page of results: %v", err)
@@ -1370,9 +1370,9 @@ func TestDeleteResults(t *testing.T) {
 	}
 	eventYear, _ = db.AddEventYear(*eventYear)
 	eventYear2, _ = db.AddEventYear(*eventYear2)
-	db.AddResults(eventYear.Identifier, results)
-	db.AddResults(eventYear2.Identifier, results)
-	count, err := db.DeleteResults(eventYear.Identifier, results[1:2])
+	db.AddResults(eventYear.Identifier, results, nil)
+	db.AddResults(eventYear2.Identifier, results, nil)
+	count, err := db.DeleteResults(eventYear.Identifier, results[1:2], nil)
 	if assert.Nil(t, err) {
 		assert.Equal(t, int64(1), count)
 	}
@@ -1429,9 +1429,9 @@ func TestDeleteEventResults(t *testing.T) {
 	}
 	eventYear, _ = db.AddEventYear(*eventYear)
 	eventYear2, _ = db.AddEventYear(*eventYear2)
-	db.AddResults(eventYear.Identifier, results)
-	db.AddResults(eventYear2.Identifier, results)
-	count, err := db.DeleteEventResults(eventYear.Identifier)
+	db.AddResults(eventYear.Identifier, results, nil)
+	db.AddResults(eventYear2.Identifier, results, nil)
+	count, err := db.DeleteEventResults(eventYear.Identifier, nil)
 	if err != nil {
 		t.Fatalf("Error deleting specific results: %v", err)
 	}
@@ -1486,9 +1486,9 @@ func TestDeleteDistanceResults(t *testing.T) {
 	}
 	eventYear, _ = db.AddEventYear(*eventYear)
 	eventYear2, _ = db.AddEventYear(*eventYear2)
-	db.AddResults(eventYear.Identifier, results)
-	db.AddResults(eventYear2.Identifier, results)
-	count, err := db.DeleteDistanceResults(eventYear.Identifier, results[0].Distance)
+	db.AddResults(eventYear.Identifier, results, nil)
+	db.AddResults(eventYear2.Identifier, results, nil)
+	count, err := db.DeleteDistanceResults(eventYear.Identifier, results[0].Distance, nil)
 	if assert.Nil(t, err) {
 		assert.Equal(t, int64(3), count)
 		res, _ := db.GetDistanceResults(eventYear.Identifier, results[0].Distance, 0, 0)
@@ -1498,7 +1498,7 @@ func TestDeleteDistanceResults(t *testing.T) {
 		res, _ = db.GetResults(eventYear2.Identifier, 0, 0)
 		assert.Equal(t, len(results), len(res))
 	}
-	count, err = db.DeleteDistanceResults(eventYear2.Identifier, results[4].Distance)
+	count, err = db.DeleteDistanceResults(eventYear2.Identifier, results[4].Distance, nil)
 	if assert.Nil(t, err) {
 		assert.Equal(t, int64(2), count)
 		res, _ := db.GetDistanceResults(eventYear2.Identifier, results[4].Distance, 0, 0)
@@ -1539,7 +1539,7 @@ func TestGetBibResults(t *testing.T) {
 	if len(res) != 0 {
 		t.Errorf("Expected %v results to be added, %v added.", 0, len(res))
 	}
-	db.AddResults(eventYear.Identifier, results[0:lastIX])
+	db.AddResults(eventYear.Identifier, results[0:lastIX], nil)
 	res, err = db.GetBibResults(eventYear.Identifier, results[lastIX].Bib)
 	if err != nil {
 		t.Fatalf("Error getting bib results: %v", err)
@@ -1547,7 +1547,7 @@ func TestGetBibResults(t *testing.T) {
 	if len(res) != 1 {
 		t.Errorf("Expected %v results to be added, %v added.", 1, len(res))
 	}
-	db.AddResults(eventYear.Identifier, results)
+	db.AddResults(eventYear.Identifier, results, nil)
 	res, err = db.GetBibResults(eventYear.Identifier, results[lastIX].Bib)
 	if err != nil {
 		t.Fatalf("Error getting bib results: %v", err)
@@ -1567,6 +1567,533 @@ func TestGetBibResults(t *testing.T) {
 	}
 }
 
+func TestUpdateRankings(t *testing.T) {
+	db, finalize, err := setupTests(t)
+	if err != nil {
+		t.Fatalf("setup error: %v", err)
+	}
+	defer finalize(t)
+	setupResultTests()
+	account, _ := db.AddAccount(accounts[0])
+	event := &types.Event{
+		AccountIdentifier: account.Identifier,
+		Name:              "Event 1",
+		Slug:              "event1",
+	}
+	event, _ = db.AddEvent(*event)
+	eventYear := &types.EventYear{
+		EventIdentifier: event.Identifier,
+		Year:            "2021",
+		DateTime:        time.Date(2021, 04, 20, 9, 0, 0, 0, time.Local),
+		Live:            false,
+		DaysAllowed:     1,
+		RankingType:     "chip",
+	}
+	eventYear, _ = db.AddEventYear(*eventYear)
+	_, err = db.AddResults(eventYear.Identifier, results, nil)
+	if err != nil {
+		t.Fatalf("Error adding results: %v", err)
+	}
+	// Only the two 5 Mile results were uploaded with incorrect rankings.
+	count, err := db.UpdateRankings(eventYear.Identifier)
+	if assert.NoError(t, err) {
+		assert.Equal(t, int64(2), count)
+	}
+	res, err := db.GetBibResults(eventYear.Identifier, "287")
+	if assert.NoError(t, err) {
+		assert.Equal(t, 2, len(res))
+		for _, r := range res {
+			assert.Equal(t, 1, r.Ranking)
+			assert.Equal(t, 1, r.GenderRanking)
+			assert.Equal(t, 1, r.AgeRanking)
+			assert.Equal(t, 1, r.DivisionRanking)
+		}
+	}
+	count, err = db.UpdateRankings(eventYear.Identifier)
+	if assert.NoError(t, err) {
+		assert.Equal(t, int64(0), count)
+	}
+	// DNF results should be unranked and the results behind them moved up.
+	results[2].Type = types.ResultTypeDNF
+	_, err = db.AddResults(eventYear.Identifier, results[2:3], nil)
+	if err != nil {
+		t.Fatalf("Error adding results: %v", err)
+	}
+	count, err = db.UpdateRankings(eventYear.Identifier)
+	if assert.NoError(t, err) {
+		assert.Equal(t, int64(2), count)
+	}
+	res, err = db.GetBibResults(eventYear.Identifier, results[2].Bib)
+	if assert.NoError(t, err) && assert.Equal(t, 1, len(res)) {
+		assert.Equal(t, -1, res[0].Ranking)
+		assert.Equal(t, -1, res[0].GenderRanking)
+		assert.Equal(t, -1, res[0].AgeRanking)
+		assert.Equal(t, -1, res[0].DivisionRanking)
+	}
+	res, err = db.GetBibResults(eventYear.Identifier, results[1].Bib)
+	if assert.NoError(t, err) && assert.Equal(t, 1, len(res)) {
+		assert.Equal(t, 2, res[0].Ranking)
+		assert.Equal(t, 1, res[0].GenderRanking)
+	}
+	_, err = db.UpdateRankings(eventYear.Identifier + 100)
+	assert.Error(t, err)
+}
+
+func TestUpdateBackyardRankings(t *testing.T) {
+	db, finalize, err := setupTests(t)
+	if err != nil {
+		t.Fatalf("setup error: %v", err)
+	}
+	defer finalize(t)
+	setupResultTests()
+	account, _ := db.AddAccount(accounts[0])
+	event := &types.Event{
+		AccountIdentifier: account.Identifier,
+		Name:              "Event 1",
+		Slug:              "event1",
+		Type:              "backyardultra",
+	}
+	event, _ = db.AddEvent(*event)
+	eventYear := &types.EventYear{
+		EventIdentifier: event.Identifier,
+		Year:            "2021",
+		DateTime:        time.Date(2021, 04, 20, 9, 0, 0, 0, time.Local),
+		Live:            false,
+		DaysAllowed:     1,
+		RankingType:     "gun",
+	}
+	eventYear, _ = db.AddEventYear(*eventYear)
+	yards := make([]types.Result, 0)
+	for _, runner := range []struct {
+		bib         string
+		yards       int
+		yardSeconds int
+	}{
+		{bib: "1", yards: 3, yardSeconds: 3500},
+		{bib: "2", yards: 2, yardSeconds: 2500},
+	} {
+		for yard := 1; yard <= runner.yards; yard++ {
+			yards = append(yards, types.Result{
+				PersonId:  runner.bib,
+				Bib:       runner.bib,
+				First:     "Runner",
+				Last:      runner.bib,
+				Gender:    "Woman",
+				AgeGroup:  "30-39",
+				Distance:  "Backyard",
+				Seconds:   (yard-1)*3600 + runner.yardSeconds,
+				Location:  "Start/Finish",
+				Occurence: yard,
+				Finish:    true,
+			})
+		}
+	}
+	_, err = db.AddResults(eventYear.Identifier, yards, nil)
+	if err != nil {
+		t.Fatalf("Error adding results: %v", err)
+	}
+	_, err = db.UpdateRankings(eventYear.Identifier)
+	assert.NoError(t, err)
+	// Runners are ranked by the yards they completed instead of their times.
+	res, err := db.GetBibResults(eventYear.Identifier, "1")
+	if assert.NoError(t, err) && assert.Equal(t, 3, len(res)) {
+		for _, r := range res {
+			assert.Equal(t, 1, r.Ranking)
+			assert.Equal(t, 1, r.GenderRanking)
+		}
+	}
+	res, err = db.GetBibResults(eventYear.Identifier, "2")
+	if assert.NoError(t, err) && assert.Equal(t, 2, len(res)) {
+		for _, r := range res {
+			assert.Equal(t, 2, r.Ranking)
+			assert.Equal(t, 2, r.GenderRanking)
+		}
+	}
+}
+
+func TestUpdateLapRankings(t *testing.T) {
+	db, finalize, err := setupTests(t)
+	if err != nil {
+		t.Fatalf("setup error: %v", err)
+	}
+	defer finalize(t)
+	setupResultTests()
+	account, _ := db.AddAccount(accounts[0])
+	event := &types.Event{
+		AccountIdentifier: account.Identifier,
+		Name:              "Event 1",
+		Slug:              "event1",
+		Type:              "time",
+	}
+	event, _ = db.AddEvent(*event)
+	eventYear := &types.EventYear{
+		EventIdentifier: event.Identifier,
+		Year:            "2021",
+		DateTime:        time.Date(2021, 04, 20, 9, 0, 0, 0, time.Local),
+		Live:            false,
+		DaysAllowed:     1,
+		RankingType:     "gun",
+	}
+	eventYear, _ = db.AddEventYear(*eventYear)
+	_, err = db.AddDistances(eventYear.Identifier, []types.Distance{
+		{
+			Name:          "6 Hour",
+			Certification: "None",
+			DistanceValue: 1,
+			DistanceUnit:  "miles",
+		},
+	})
+	if err != nil {
+		t.Fatalf("Error adding distances: %v", err)
+	}
+	laps := []types.Result{
+		{
+			PersonId:  "1",
+			Bib:       "1",
+			Gender:    "Woman",
+			AgeGroup:  "30-39",
+			Distance:  "6 Hour",
+			Location:  "Start/Finish",
+			Occurence: 0,
+		},
+	}
+	for _, runner := range []struct {
+		bib        string
+		laps       int
+		lapSeconds int
+	}{
+		{bib: "1", laps: 5, lapSeconds: 1000},
+		{bib: "2", laps: 4, lapSeconds: 900},
+		{bib: "3", laps: 4, lapSeconds: 1000},
+	} {
+		for lap := 1; lap <= runner.laps; lap++ {
+			laps = append(laps, types.Result{
+				PersonId:  runner.bib,
+				Bib:       runner.bib,
+				First:     "Runner",
+				Last:      runner.bib,
+				Gender:    "Woman",
+				AgeGroup:  "30-39",
+				Distance:  "6 Hour",
+				Seconds:   lap * runner.lapSeconds,
+				Location:  "Start/Finish",
+				Occurence: lap,
+			})
+		}
+	}
+	_, err = db.AddResults(eventYear.Identifier, laps, nil)
+	if err != nil {
+		t.Fatalf("Error adding results: %v", err)
+	}
+	_, err = db.UpdateRankings(eventYear.Identifier)
+	assert.NoError(t, err)
+	// Runners are ranked by the distance covered and then the time of their last lap.
+	for bib, ranking := range map[string]int{"1": 1, "2": 2, "3": 3} {
+		res, err := db.GetBibResults(eventYear.Identifier, bib)
+		if assert.NoError(t, err) {
+			for _, r := range res {
+				if r.Occurence == 0 {
+					assert.Equal(t, -1, r.Ranking)
+					continue
+				}
+				assert.Equal(t, ranking, r.Ranking)
+				assert.Equal(t, ranking, r.AgeRanking)
+			}
+		}
+	}
+}
+
+func TestResultStatus(t *testing.T) {
+	db, finalize, err := setupTests(t)
+	if err != nil {
+		t.Fatalf("setup error: %v", err)
+	}
+	defer finalize(t)
+	setupResultTests()
+	account, _ := db.AddAccount(accounts[0])
+	event := &types.Event{
+		AccountIdentifier: account.Identifier,
+		Name:              "Event 1",
+		Slug:              "event1",
+	}
+	event, _ = db.AddEvent(*event)
+	eventYear := &types.EventYear{
+		EventIdentifier: event.Identifier,
+		Year:            "2021",
+		DateTime:        time.Date(2021, 04, 20, 9, 0, 0, 0, time.Local),
+		Live:            false,
+		DaysAllowed:     1,
+		RankingType:     "chip",
+	}
+	eventYear, _ = db.AddEventYear(*eventYear)
+	statusResults := make([]types.Result, 5)
+	copy(statusResults, results[0:5])
+	statusResults[0].Status = types.ResultStatusDQ
+	statusResults[0].StatusReason = "Course cut"
+	statusResults[1].Status = types.ResultStatusFinished
+	statusResults[2].Status = types.ResultStatusFinished
+	statusResults[3].Status = types.ResultStatusDNF
+	statusResults[3].Seconds = 0
+	statusResults[4].Status = types.ResultStatusFinished
+	_, err = db.AddResults(eventYear.Identifier, statusResults, nil)
+	if err != nil {
+		t.Fatalf("Error adding results: %v", err)
+	}
+	// Finishers are listed by time with DNF and DQ results last.
+	res, err := db.GetResults(eventYear.Identifier, 0, 0)
+	if assert.NoError(t, err) && assert.Equal(t, len(statusResults), len(res)) {
+		assert.Equal(t, statusResults[2], res[0])
+		assert.Equal(t, statusResults[1], res[1])
+		assert.Equal(t, statusResults[4], res[2])
+		assert.Equal(t, statusResults[3], res[3])
+		assert.Equal(t, statusResults[0], res[4])
+		assert.Equal(t, "Course cut", res[4].StatusReason)
+	}
+	// A DNF is the last result for a bib even without a time.
+	res, err = db.GetLastResults(eventYear.Identifier, 0, 0)
+	if assert.NoError(t, err) {
+		found := false
+		for _, r := range res {
+			if r.Bib == statusResults[3].Bib {
+				assert.Equal(t, types.ResultStatusDNF, r.Status)
+				found = true
+			}
+		}
+		assert.True(t, found)
+	}
+	// Changing the status is saved.
+	statusResults[0].Status = types.ResultStatusFinished
+	statusResults[0].StatusReason = ""
+	_, err = db.AddResults(eventYear.Identifier, statusResults[0:1], nil)
+	if err != nil {
+		t.Fatalf("Error adding results: %v", err)
+	}
+	res, err = db.GetResults(eventYear.Identifier, 0, 0)
+	if assert.NoError(t, err) && assert.Equal(t, len(statusResults), len(res)) {
+		assert.Equal(t, statusResults[0], res[0])
+	}
+}
+
+func TestGetUpdatedResults(t *testing.T) {
+	db, finalize, err := setupTests(t)
+	if err != nil {
+		t.Fatalf("setup error: %v", err)
+	}
+	defer finalize(t)
+	setupResultTests()
+	account, _ := db.AddAccount(accounts[0])
+	event := &types.Event{
+		AccountIdentifier: account.Identifier,
+		Name:              "Event 1",
+		Slug:              "event1",
+	}
+	event, _ = db.AddEvent(*event)
+	eventYear := &types.EventYear{
+		EventIdentifier: event.Identifier,
+		Year:            "2021",
+		DateTime:        time.Date(2021, 04, 20, 9, 0, 0, 0, time.Local),
+		Live:            false,
+		DaysAllowed:     1,
+		RankingType:     "chip",
+	}
+	eventYear, _ = db.AddEventYear(*eventYear)
+	_, err = db.AddResults(eventYear.Identifier, results, nil)
+	if err != nil {
+		t.Fatalf("Error adding results: %v", err)
+	}
+	res, err := db.GetUpdatedResults(eventYear.Identifier, "", 0)
+	if assert.NoError(t, err) {
+		assert.Equal(t, len(results), len(res))
+	}
+	// Timestamps are stored to the second so wait for the next one.
+	mark := time.Now().Unix() + 1
+	time.Sleep(time.Until(time.Unix(mark, 0)))
+	res, err = db.GetUpdatedResults(eventYear.Identifier, "", mark)
+	if assert.NoError(t, err) {
+		assert.Equal(t, 0, len(res))
+	}
+	results[3].Seconds = results[3].Seconds + 10
+	_, err = db.AddResults(eventYear.Identifier, results[3:4], nil)
+	if err != nil {
+		t.Fatalf("Error adding results: %v", err)
+	}
+	res, err = db.GetUpdatedResults(eventYear.Identifier, "", mark)
+	if assert.NoError(t, err) && assert.Equal(t, 1, len(res)) {
+		assert.Equal(t, results[3], res[0])
+	}
+	res, err = db.GetUpdatedResults(eventYear.Identifier, results[3].Distance, mark)
+	if assert.NoError(t, err) {
+		assert.Equal(t, 1, len(res))
+	}
+	res, err = db.GetUpdatedResults(eventYear.Identifier, results[0].Distance, mark)
+	if assert.NoError(t, err) {
+		assert.Equal(t, 0, len(res))
+	}
+	// Changing the person changes every one of their results.
+	mark = time.Now().Unix() + 1
+	time.Sleep(time.Until(time.Unix(mark, 0)))
+	_, err = db.UpdatePerson(eventYear.Identifier, types.Person{
+		AlternateId: results[4].PersonId,
+		Bib:         results[4].Bib,
+		First:       results[4].First,
+		Last:        "Renamed",
+		Age:         results[4].Age,
+		Gender:      results[4].Gender,
+		AgeGroup:    results[4].AgeGroup,
+		Distance:    results[4].Distance,
+		Anonymous:   results[4].Anonymous,
+	}, nil)
+	if err != nil {
+		t.Fatalf("Error updating person: %v", err)
+	}
+	res, err = db.GetUpdatedResults(eventYear.Identifier, "", mark)
+	if assert.NoError(t, err) && assert.Equal(t, 2, len(res)) {
+		for _, outer := range res {
+			assert.Equal(t, results[4].PersonId, outer.PersonId)
+			assert.Equal(t, "Renamed", outer.Last)
+		}
+	}
+	res, err = db.GetUpdatedResults(eventYear.Identifier+100, "", 0)
+	if assert.NoError(t, err) {
+		assert.Equal(t, 0, len(res))
+	}
+}
+
+func TestGetLocationResults(t *testing.T) {
+	db, finalize, err := setupTests(t)
+	if err != nil {
+		t.Fatalf("setup error: %v", err)
+	}
+	defer finalize(t)
+	setupResultTests()
+	account, _ := db.AddAccount(accounts[0])
+	event := &types.Event{
+		AccountIdentifier: account.Identifier,
+		Name:              "Event 1",
+		Slug:              "event1",
+	}
+	event, _ = db.AddEvent(*event)
+	eventYear := &types.EventYear{
+		EventIdentifier: event.Identifier,
+		Year:            "2021",
+		DateTime:        time.Date(2021, 04, 20, 9, 0, 0, 0, time.Local),
+		Live:            false,
+		DaysAllowed:     1,
+		RankingType:     "chip",
+	}
+	eventYear, _ = db.AddEventYear(*eventYear)
+	lap := results[4]
+	lap.Segment = "Lap 3"
+	lap.Occurence = 3
+	lap.Seconds = 1500
+	_, err = db.AddResults(eventYear.Identifier, append([]types.Result{lap}, results...), nil)
+	if err != nil {
+		t.Fatalf("Error adding results: %v", err)
+	}
+	res, err := db.GetLocationResults(eventYear.Identifier, "", "Start/Finish", "", 1)
+	if assert.NoError(t, err) && assert.Equal(t, 4, len(res)) {
+		// Results are ordered by arrival.
+		assert.Equal(t, "100", res[0].Bib)
+		assert.Equal(t, "209", res[1].Bib)
+		assert.Equal(t, "106", res[2].Bib)
+		assert.Equal(t, "287", res[3].Bib)
+	}
+	res, err = db.GetLocationResults(eventYear.Identifier, "1 Mile", "Start/Finish", "", 1)
+	if assert.NoError(t, err) {
+		assert.Equal(t, 3, len(res))
+	}
+	res, err = db.GetLocationResults(eventYear.Identifier, "", "Start/Finish", "", 2)
+	if assert.NoError(t, err) && assert.Equal(t, 1, len(res)) {
+		assert.Equal(t, results[4], res[0])
+	}
+	res, err = db.GetLocationResults(eventYear.Identifier, "5 Mile", "", "Lap 3", 0)
+	if assert.NoError(t, err) && assert.Equal(t, 1, len(res)) {
+		assert.Equal(t, lap, res[0])
+	}
+	res, err = db.GetLocationResults(eventYear.Identifier, "", "Aid Station", "", 1)
+	if assert.NoError(t, err) {
+		assert.Equal(t, 0, len(res))
+	}
+}
+
+func TestGetDeletedResults(t *testing.T) {
+	db, finalize, err := setupTests(t)
+	if err != nil {
+		t.Fatalf("setup error: %v", err)
+	}
+	defer finalize(t)
+	setupResultTests()
+	account, _ := db.AddAccount(accounts[0])
+	event := &types.Event{
+		AccountIdentifier: account.Identifier,
+		Name:              "Event 1",
+		Slug:              "event1",
+	}
+	event, _ = db.AddEvent(*event)
+	eventYear := &types.EventYear{
+		EventIdentifier: event.Identifier,
+		Year:            "2021",
+		DateTime:        time.Date(2021, 04, 20, 9, 0, 0, 0, time.Local),
+		Live:            false,
+		DaysAllowed:     1,
+		RankingType:     "chip",
+	}
+	eventYear, _ = db.AddEventYear(*eventYear)
+	_, err = db.AddResults(eventYear.Identifier, results, nil)
+	if err != nil {
+		t.Fatalf("Error adding results: %v", err)
+	}
+	res, err := db.GetDeletedResults(eventYear.Identifier, "", 0)
+	if assert.NoError(t, err) {
+		assert.Equal(t, 0, len(res))
+	}
+	mark := time.Now().Unix()
+	_, err = db.DeleteResults(eventYear.Identifier, results[1:2], nil)
+	if err != nil {
+		t.Fatalf("Error deleting results: %v", err)
+	}
+	res, err = db.GetDeletedResults(eventYear.Identifier, "", mark)
+	if assert.NoError(t, err) && assert.Equal(t, 1, len(res)) {
+		assert.Equal(t, results[1].Bib, res[0].Bib)
+		assert.Equal(t, results[1].Distance, res[0].Distance)
+		assert.Equal(t, results[1].Location, res[0].Location)
+		assert.Equal(t, results[1].Occurence, res[0].Occurence)
+	}
+	res, err = db.GetDeletedResults(eventYear.Identifier, results[3].Distance, mark)
+	if assert.NoError(t, err) {
+		assert.Equal(t, 0, len(res))
+	}
+	res, err = db.GetDeletedResults(eventYear.Identifier, "", mark+10)
+	if assert.NoError(t, err) {
+		assert.Equal(t, 0, len(res))
+	}
+	// Results added again are no longer deleted.
+	_, err = db.AddResults(eventYear.Identifier, results[1:2], nil)
+	if err != nil {
+		t.Fatalf("Error adding results: %v", err)
+	}
+	res, err = db.GetDeletedResults(eventYear.Identifier, "", mark)
+	if assert.NoError(t, err) {
+		assert.Equal(t, 0, len(res))
+	}
+	_, err = db.DeleteDistanceResults(eventYear.Identifier, results[3].Distance, nil)
+	if err != nil {
+		t.Fatalf("Error deleting distance results: %v", err)
+	}
+	res, err = db.GetDeletedResults(eventYear.Identifier, results[3].Distance, mark)
+	if assert.NoError(t, err) {
+		assert.Equal(t, 2, len(res))
+	}
+	_, err = db.DeleteEventResults(eventYear.Identifier, nil)
+	if err != nil {
+		t.Fatalf("Error deleting event results: %v", err)
+	}
+	res, err = db.GetDeletedResults(eventYear.Identifier, "", mark)
+	if assert.NoError(t, err) {
+		assert.Equal(t, len(results), len(res))
+	}
+}
+
 func TestBadDatabaseResult(t *testing.T) {
 	db := badTestSetup(t)
 	_, err := db.GetResults(0, 0, 0)
@@ -1589,22 +2116,34 @@ func TestBadDatabaseResult(t *testing.T) {
 	if err == nil {
 		t.Fatalf("Expected error getting results by event year and bib.")
 	}
-	_, err = db.DeleteResults(0, make([]types.Result, 0))
+	_, err = db.DeleteResults(0, make([]types.Result, 0), nil)
 	if err == nil {
 		t.Fatalf("Expected error deleting results.")
 	}
-	_, err = db.DeleteEventResults(0)
+	_, err = db.DeleteEventResults(0, nil)
 	if err == nil {
 		t.Fatalf("Expected error deleting event year results.")
 	}
-	_, err = db.DeleteDistanceResults(0, "")
+	_, err = db.DeleteDistanceResults(0, "", nil)
 	if err == nil {
 		t.Fatalf("Expected error deleting event year & results.")
 	}
-	_, err = db.AddResults(0, make([]types.Result, 0))
+	_, err = db.AddResults(0, make([]types.Result, 0), nil)
 	if err == nil {
 		t.Fatalf("Expected error adding results.")
 	}
+	_, err = db.UpdateRankings(0)
+	if err == nil {
+		t.Fatalf("Expected error updating rankings.")
+	}
+	_, err = db.GetUpdatedResults(0, "", 0)
+	if err == nil {
+		t.Fatalf("Expected error getting updated results.")
+	}
+	_, err = db.GetDeletedResults(0, "", 0)
+	if err == nil {
+		t.Fatalf("Expected error getting deleted results.")
+	}
 }
 
 func TestNoDatabaseResult(t *testing.T) {
@@ -1629,21 +2168,33 @@ func TestNoDatabaseResult(t *testing.T) {
 	if err == nil {
 		t.Fatalf("Expected error getting results by event year and bib.")
 	}
-	_, err = db.DeleteResults(0, make([]types.Result, 0))
+	_, err = db.DeleteResults(0, make([]types.Result, 0), nil)
 	if err == nil {
 		t.Fatalf("Expected error deleting results.")
 	}
-	_, err = db.DeleteEventResults(0)
+	_, err = db.DeleteEventResults(0, nil)
 	if err == nil {
 		t.Fatalf("Expected error deleting event year results.")
 	}
-	_, err = db.DeleteDistanceResults(0, "")
+	_, err = db.DeleteDistanceResults(0, "", nil)
 	if err == nil {
 		t.Fatalf("Expected error deleting event year & results.")
 	}
-	_, err = db.AddResults(0, make([]types.Result, 0))
+	_, err = db.AddResults(0, make([]types.Result, 0), nil)
 	if err == nil {
 		t.Fatalf("Expected error adding results.")
 	}
+	_, err = db.UpdateRankings(0)
+	if err == nil {
+		t.Fatalf("Expected error updating rankings.")
+	}
+	_, err = db.GetUpdatedResults(0, "", 0)
+	if err == nil {
+		t.Fatalf("Expected error getting updated results.")
+	}
+	_, err = db.GetDeletedResults(0, "", 0)
+	if err == nil {
+		t.Fatalf("Expected error getting deleted results.")
+	}
 }
 
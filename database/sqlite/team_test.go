@@ -0,0 +1,221 @@
+/*
+Chronokeep Desktop - Race Scoring Software
+Copyright (C) 2026 James Sentinella
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+
+package sqlite
+
+import (
+	"chronokeep/results/types"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	teams []types.Team
+)
+
+func setupTeamTests() {
+	if len(accounts) < 1 {
+		accounts = []types.Account{
+			{
+				Name:     "John Smith",
+				Email:    "j@test.com",
+				Type:     "admin",
+				Password: testHashPassword("password"),
+			},
+		}
+	}
+	teams = []types.Team{
+		{
+			Name:     "Harriers",
+			Distance: "5K",
+			Members: []types.TeamMember{
+				{Bib: "100"},
+				{Bib: "101"},
+				{PersonId: "p102"},
+			},
+		},
+		{
+			Name:     "Striders",
+			Distance: "5K",
+			Members: []types.TeamMember{
+				{Bib: "200", PersonId: "p200"},
+			},
+		},
+		{
+			Name:     "Joggers",
+			Distance: "10K",
+			Members:  []types.TeamMember{},
+		},
+	}
+}
+
+func setupTeamEventYear(t *testing.T, db *SQLite) *types.EventYear {
+	account, _ := db.AddAccount(accounts[0])
+	event, _ := db.AddEvent(types.Event{
+		AccountIdentifier: account.Identifier,
+		Name:              "Event 1",
+		Slug:              "event1",
+	})
+	eventYear, err := db.AddEventYear(types.EventYear{
+		EventIdentifier: event.Identifier,
+		Year:            "2021",
+		DateTime:        time.Date(2021, 04, 20, 9, 0, 0, 0, time.Local),
+		DaysAllowed:     1,
+		RankingType:     "chip",
+	})
+	if err != nil {
+		t.Fatalf("Error adding event year: %v", err)
+	}
+	return eventYear
+}
+
+func TestAddTeams(t *testing.T) {
+	db, finalize, err := setupTests(t)
+	if err != nil {
+		t.Fatalf("Error setting up tests. %v", err)
+	}
+	defer finalize(t)
+	setupTeamTests()
+	eventYear := setupTeamEventYear(t, db)
+	tm, err := db.AddTeams(eventYear.Identifier, teams)
+	if assert.NoError(t, err) && assert.Equal(t, len(teams), len(tm)) {
+		for ix := range teams {
+			assert.True(t, teams[ix].Equals(tm[ix]))
+			assert.NotEqual(t, int64(0), tm[ix].Identifier)
+		}
+	}
+	// Adding a team with the same name updates it and replaces its members.
+	update := types.Team{
+		Name:     "Harriers",
+		Distance: "10K",
+		Members: []types.TeamMember{
+			{Bib: "300"},
+		},
+	}
+	_, err = db.AddTeams(eventYear.Identifier, []types.Team{update})
+	assert.NoError(t, err)
+	tm, err = db.GetTeams(eventYear.Identifier)
+	if assert.NoError(t, err) && assert.Equal(t, len(teams), len(tm)) {
+		for _, team := range tm {
+			if team.Name == update.Name {
+				assert.True(t, update.Equals(team))
+			}
+		}
+	}
+}
+
+func TestGetTeams(t *testing.T) {
+	db, finalize, err := setupTests(t)
+	if err != nil {
+		t.Fatalf("Error setting up tests. %v", err)
+	}
+	defer finalize(t)
+	setupTeamTests()
+	eventYear := setupTeamEventYear(t, db)
+	tm, err := db.GetTeams(eventYear.Identifier)
+	if assert.NoError(t, err) {
+		assert.Equal(t, 0, len(tm))
+	}
+	_, err = db.AddTeams(eventYear.Identifier, teams)
+	assert.NoError(t, err)
+	tm, err = db.GetTeams(eventYear.Identifier)
+	if assert.NoError(t, err) && assert.Equal(t, len(teams), len(tm)) {
+		// Teams are ordered by name.
+		assert.Equal(t, "Harriers", tm[0].Name)
+		assert.Equal(t, "Joggers", tm[1].Name)
+		assert.Equal(t, "Striders", tm[2].Name)
+		for _, outer := range teams {
+			found := false
+			for _, inner := range tm {
+				if outer.Name == inner.Name {
+					found = true
+					assert.Equal(t, outer.Distance, inner.Distance)
+					assert.ElementsMatch(t, outer.Members, inner.Members)
+				}
+			}
+			assert.True(t, found)
+		}
+	}
+}
+
+func TestDeleteTeams(t *testing.T) {
+	db, finalize, err := setupTests(t)
+	if err != nil {
+		t.Fatalf("Error setting up tests. %v", err)
+	}
+	defer finalize(t)
+	setupTeamTests()
+	eventYear := setupTeamEventYear(t, db)
+	_, err = db.AddTeams(eventYear.Identifier, teams)
+	assert.NoError(t, err)
+	count, err := db.DeleteTeams(eventYear.Identifier, []string{"Harriers", "Not A Team"})
+	if assert.NoError(t, err) {
+		assert.Equal(t, int64(1), count)
+	}
+	tm, err := db.GetTeams(eventYear.Identifier)
+	if assert.NoError(t, err) && assert.Equal(t, len(teams)-1, len(tm)) {
+		for _, team := range tm {
+			assert.NotEqual(t, "Harriers", team.Name)
+		}
+	}
+	count, err = db.DeleteTeams(eventYear.Identifier, nil)
+	if assert.NoError(t, err) {
+		assert.Equal(t, int64(len(teams)-1), count)
+	}
+	tm, err = db.GetTeams(eventYear.Identifier)
+	if assert.NoError(t, err) {
+		assert.Equal(t, 0, len(tm))
+	}
+}
+
+func TestSetTeamScoring(t *testing.T) {
+	db, finalize, err := setupTests(t)
+	if err != nil {
+		t.Fatalf("Error setting up tests. %v", err)
+	}
+	defer finalize(t)
+	setupTeamTests()
+	eventYear := setupTeamEventYear(t, db)
+	s, err := db.GetTeamScoring(eventYear.Identifier)
+	if assert.NoError(t, err) {
+		assert.Equal(t, 0, len(s))
+	}
+	scoring := []types.TeamScoring{
+		{Distance: "5K", Scorers: 5, Displacers: 2, Method: types.TeamScoringPlaces},
+		{Distance: "10K", Scorers: 3, Displacers: 0, Method: types.TeamScoringTimes},
+	}
+	s, err = db.SetTeamScoring(eventYear.Identifier, scoring)
+	if assert.NoError(t, err) {
+		assert.Equal(t, len(scoring), len(s))
+	}
+	s, err = db.GetTeamScoring(eventYear.Identifier)
+	if assert.NoError(t, err) {
+		assert.ElementsMatch(t, scoring, s)
+	}
+	// Setting the scoring replaces the old rules.
+	_, err = db.SetTeamScoring(eventYear.Identifier, scoring[1:])
+	assert.NoError(t, err)
+	s, err = db.GetTeamScoring(eventYear.Identifier)
+	if assert.NoError(t, err) {
+		assert.Equal(t, scoring[1:], s)
+	}
+}
+
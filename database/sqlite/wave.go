@@ -0,0 +1,115 @@
+/*
+Chronokeep Desktop - Race Scoring Software
+Copyright (C) 2026 James Sentinella
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package sqlite
+
+import (
+	"chronokeep/results/types"
+	"context"
+	"fmt"
+	"time"
+)
+
+// GetWaves Gets the waves of an event year ordered by distance and start time.
+func (s *SQLite) GetWaves(eventYearID int64) ([]types.Wave, error) {
+	db, err := s.GetDB()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancelfunc := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancelfunc()
+	res, err := db.QueryContext(
+		ctx,
+		"SELECT distance, name, start_time, bib_ranges, bibs FROM waves WHERE event_year_id=? "+
+			"ORDER BY distance ASC, start_time ASC, name ASC;",
+		eventYearID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving waves: %v", err)
+	}
+	defer res.Close()
+	outWaves := make([]types.Wave, 0)
+	for res.Next() {
+		var wave types.Wave
+		var startTime int64
+		var bibRanges, bibs string
+		err := res.Scan(
+			&wave.Distance,
+			&wave.Name,
+			&startTime,
+			&bibRanges,
+			&bibs,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("error getting wave: %v", err)
+		}
+		wave.StartTime = time.UnixMilli(startTime).UTC()
+		if err = wave.DecodeBibRanges(bibRanges); err != nil {
+			return nil, err
+		}
+		wave.DecodeBibs(bibs)
+		outWaves = append(outWaves, wave)
+	}
+	return outWaves, nil
+}
+
+// SetWaves Replaces the waves of an event year.
+func (s *SQLite) SetWaves(eventYearID int64, waves []types.Wave) ([]types.Wave, error) {
+	db, err := s.GetDB()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancelfunc := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancelfunc()
+	tx, err := db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("unable to start transaction: %v", err)
+	}
+	_, err = tx.ExecContext(
+		ctx,
+		"DELETE FROM waves WHERE event_year_id=?;",
+		eventYearID,
+	)
+	if err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("error deleting old waves: %v", err)
+	}
+	for _, wave := range waves {
+		_, err = tx.ExecContext(
+			ctx,
+			"INSERT INTO waves(event_year_id, distance, name, start_time, bib_ranges, bibs) VALUES (?,?,?,?,?,?);",
+			eventYearID,
+			wave.Distance,
+			wave.Name,
+			wave.StartTime.UnixMilli(),
+			wave.EncodeBibRanges(),
+			wave.EncodeBibs(),
+		)
+		if err != nil {
+			tx.Rollback()
+			return nil, fmt.Errorf("error adding wave to database: %v", err)
+		}
+	}
+	err = tx.Commit()
+	if err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("error committing transaction: %v", err)
+	}
+	return s.GetWaves(eventYearID)
+}
+
@@ -0,0 +1,49 @@
+/*
+Chronokeep Desktop - Race Scoring Software
+Copyright (C) 2026 James Sentinella
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package database
+
+import "chronokeep/results/types"
+
+// DefaultHistogramBucket is the size of each finish time histogram bucket in seconds when one
+// isn't given.
+const DefaultHistogramBucket = 300
+
+// DNFRate Returns the fraction of the starters that didn't finish, or zero without any starters.
+func DNFRate(dnf, starters int) float64 {
+	if starters < 1 {
+		return 0
+	}
+	return float64(dnf) / float64(starters)
+}
+
+// FillHistogram Returns the histogram with an empty bucket added for every gap between the
+// buckets given.  Buckets are expected to be in order and the size is in seconds.
+func FillHistogram(buckets []types.HistogramBucket, size int) []types.HistogramBucket {
+	output := make([]types.HistogramBucket, 0, len(buckets))
+	for _, bucket := range buckets {
+		if len(output) > 0 && size > 0 {
+			for start := output[len(output)-1].Start + size; start < bucket.Start; start += size {
+				output = append(output, types.HistogramBucket{Start: start})
+			}
+		}
+		output = append(output, bucket)
+	}
+	return output
+}
+
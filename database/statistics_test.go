@@ -0,0 +1,49 @@
+/*
+Chronokeep Desktop - Race Scoring Software
+Copyright (C) 2026 James Sentinella
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package database
+
+import (
+	"chronokeep/results/types"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDNFRate(t *testing.T) {
+	assert.Equal(t, 0.0, DNFRate(0, 0))
+	assert.Equal(t, 0.0, DNFRate(0, 10))
+	assert.Equal(t, 0.25, DNFRate(1, 4))
+}
+
+func TestFillHistogram(t *testing.T) {
+	assert.Equal(t, []types.HistogramBucket{}, FillHistogram(nil, 300))
+	buckets := []types.HistogramBucket{
+		{Start: 900, Count: 2},
+		{Start: 1200, Count: 1},
+		{Start: 2100, Count: 4},
+	}
+	assert.Equal(t, []types.HistogramBucket{
+		{Start: 900, Count: 2},
+		{Start: 1200, Count: 1},
+		{Start: 1500, Count: 0},
+		{Start: 1800, Count: 0},
+		{Start: 2100, Count: 4},
+	}, FillHistogram(buckets, 300))
+}
+
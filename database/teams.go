@@ -0,0 +1,227 @@
+/*
+Chronokeep Desktop - Race Scoring Software
+Copyright (C) 2026 James Sentinella
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+
+package database
+
+import (
+	"chronokeep/results/types"
+	"sort"
+)
+
+// Scoring rules used for distances that haven't been given any.
+const (
+	DefaultTeamScorers    = 5
+	DefaultTeamDisplacers = 2
+)
+
+// DefaultTeamScoring Returns the scoring rules used for a distance without any.
+func DefaultTeamScoring(distance string) types.TeamScoring {
+	return types.TeamScoring{
+		Distance:   distance,
+		Scorers:    DefaultTeamScorers,
+		Displacers: DefaultTeamDisplacers,
+		Method:     types.TeamScoringPlaces,
+	}
+}
+
+// teamScore holds a team while it is being scored.
+type teamScore struct {
+	team      *types.Team
+	rules     types.TeamScoring
+	finishers []*types.Result
+	places    []int
+	score     int64
+	time      int64
+}
+
+// complete Returns true if the team has enough finishers to be scored.
+func (t *teamScore) complete() bool {
+	return len(t.finishers) >= t.rules.Scorers
+}
+
+// displacer Returns the place of the first displacer or zero if there isn't one.
+func (t *teamScore) displacer() int {
+	if len(t.places) > t.rules.Scorers {
+		return t.places[t.rules.Scorers]
+	}
+	return 0
+}
+
+// scoredBefore Returns true if team one should be ranked ahead of team two.  Ties are broken
+// by the first displacer, then by the total time of the scorers and then by name.
+func scoredBefore(one, two *teamScore) bool {
+	if one.score != two.score {
+		return one.score < two.score
+	}
+	oneDisp, twoDisp := one.displacer(), two.displacer()
+	if oneDisp != twoDisp {
+		if oneDisp == 0 || twoDisp == 0 {
+			return twoDisp == 0
+		}
+		return oneDisp < twoDisp
+	}
+	if one.time != two.time {
+		return one.time < two.time
+	}
+	return one.team.Name < two.team.Name
+}
+
+// CalculateTeamResults Scores teams using the results of an event year.  Finishers are matched to
+// the teams of their distance by bib or person id and are ordered the same way they are ranked.
+// Only the scorers and displacers of teams with enough finishers to be scored are given a place,
+// so finishers without a team don't affect the score of any team.  A finisher on more than one
+// team only counts for the first.  The results are ordered by distance and then by ranking with
+// teams that can't be scored at the end.
+func CalculateTeamResults(teams []types.Team, scoring []types.TeamScoring, results []types.Result, rankingType string) []types.TeamResult {
+	rules := make(map[string]types.TeamScoring)
+	for _, r := range scoring {
+		rules[r.Distance] = r
+	}
+	scores := make([]*teamScore, len(teams))
+	type memberKey struct {
+		distance string
+		bib      string
+		personId string
+	}
+	members := make(map[memberKey]*teamScore)
+	for ix := range teams {
+		team := &teams[ix]
+		r, ok := rules[team.Distance]
+		if !ok {
+			r = DefaultTeamScoring(team.Distance)
+		}
+		scores[ix] = &teamScore{team: team, rules: r}
+		for _, member := range team.Members {
+			keys := []memberKey{}
+			if member.Bib != "" {
+				keys = append(keys, memberKey{distance: team.Distance, bib: member.Bib})
+			}
+			if member.PersonId != "" {
+				keys = append(keys, memberKey{distance: team.Distance, personId: member.PersonId})
+			}
+			for _, key := range keys {
+				if _, ok := members[key]; !ok {
+					members[key] = scores[ix]
+				}
+			}
+		}
+	}
+	finishers := make([]*types.Result, 0)
+	for ix := range results {
+		if isRecordFinish(&results[ix]) {
+			finishers = append(finishers, &results[ix])
+		}
+	}
+	sort.SliceStable(finishers, func(i, j int) bool {
+		return rankedBefore(finishers[i], finishers[j], rankingType)
+	})
+	for _, res := range finishers {
+		team, ok := members[memberKey{distance: res.Distance, bib: res.Bib}]
+		if !ok {
+			team, ok = members[memberKey{distance: res.Distance, personId: res.PersonId}]
+		}
+		if ok {
+			team.finishers = append(team.finishers, res)
+		}
+	}
+	// Give places to everyone that counts for a team, in finish order for each distance.
+	counted := make(map[*types.Result]*teamScore)
+	for _, team := range scores {
+		if !team.complete() {
+			continue
+		}
+		limit := min(len(team.finishers), team.rules.Scorers+team.rules.Displacers)
+		for _, res := range team.finishers[:limit] {
+			counted[res] = team
+		}
+	}
+	places := make(map[string]int)
+	placeOf := make(map[*types.Result]int)
+	for _, res := range finishers {
+		if team, ok := counted[res]; ok {
+			places[res.Distance]++
+			placeOf[res] = places[res.Distance]
+			team.places = append(team.places, places[res.Distance])
+		}
+	}
+	for _, team := range scores {
+		if !team.complete() {
+			continue
+		}
+		for ix, res := range team.finishers[:team.rules.Scorers] {
+			seconds, milliseconds := recordTime(res, rankingType)
+			team.time += int64(seconds)*1000 + int64(milliseconds)
+			team.score += int64(team.places[ix])
+		}
+		if team.rules.Method == types.TeamScoringTimes {
+			team.score = team.time
+		}
+	}
+	sort.SliceStable(scores, func(i, j int) bool {
+		one, two := scores[i], scores[j]
+		if one.team.Distance != two.team.Distance {
+			return one.team.Distance < two.team.Distance
+		}
+		if one.complete() != two.complete() {
+			return one.complete()
+		}
+		if !one.complete() {
+			return one.team.Name < two.team.Name
+		}
+		return scoredBefore(one, two)
+	})
+	output := make([]types.TeamResult, 0, len(scores))
+	ranking := 0
+	for ix, team := range scores {
+		if ix == 0 || team.team.Distance != scores[ix-1].team.Distance {
+			ranking = 0
+		}
+		out := types.TeamResult{
+			Name:         team.team.Name,
+			Distance:     team.team.Distance,
+			Ranking:      Unranked,
+			Seconds:      int(team.time / 1000),
+			Milliseconds: int(team.time % 1000),
+			Members:      make([]types.TeamResultMember, 0, len(team.finishers)),
+		}
+		if team.complete() {
+			ranking++
+			out.Ranking = ranking
+			out.Score = int(team.score)
+		}
+		for mx, res := range team.finishers {
+			seconds, milliseconds := recordTime(res, rankingType)
+			member := types.TeamResultMember{
+				Bib:          res.Bib,
+				Seconds:      seconds,
+				Milliseconds: milliseconds,
+				Place:        placeOf[res],
+				Scorer:       team.complete() && mx < team.rules.Scorers,
+			}
+			if !res.Anonymous {
+				member.First = res.First
+				member.Last = res.Last
+			}
+			out.Members = append(out.Members, member)
+		}
+		output = append(output, out)
+	}
+	return output
+}
+
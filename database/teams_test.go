@@ -0,0 +1,143 @@
+/*
+Chronokeep Desktop - Race Scoring Software
+Copyright (C) 2026 James Sentinella
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+
+package database
+
+import (
+	"chronokeep/results/types"
+	"chronokeep/results/util"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func teamTestResult(bib, distance string, seconds int) types.Result {
+	return types.Result{
+		PersonId:    "p" + bib,
+		Bib:         bib,
+		First:       "First" + bib,
+		Last:        "Last" + bib,
+		Distance:    distance,
+		Location:    "Finish",
+		Occurence:   1,
+		Seconds:     seconds,
+		ChipSeconds: seconds,
+		Finish:      true,
+	}
+}
+
+func TestCalculateTeamResults(t *testing.T) {
+	results := make([]types.Result, 0)
+	order := []string{"a1", "u1", "b1", "c1", "a2", "b2", "a3", "b3", "a4", "b4", "a5", "b5", "a6", "a7"}
+	for ix, bib := range order {
+		results = append(results, teamTestResult(bib, "5K", 100+ix*10))
+	}
+	results[0].Anonymous = true
+	dnf := teamTestResult("b6", "5K", 50)
+	dnf.Status = types.ResultStatusDNF
+	results = append(results, dnf)
+	teams := []types.Team{
+		{Name: "A", Distance: "5K"},
+		{Name: "B", Distance: "5K"},
+		{Name: "C", Distance: "5K", Members: []types.TeamMember{{Bib: "c1"}}},
+	}
+	for ix := 1; ix <= 7; ix++ {
+		teams[0].Members = append(teams[0].Members, types.TeamMember{Bib: "a" + strconv.Itoa(ix)})
+	}
+	for ix := 1; ix <= 4; ix++ {
+		teams[1].Members = append(teams[1].Members, types.TeamMember{Bib: "b" + strconv.Itoa(ix)})
+	}
+	// Members can be given by person id.
+	teams[1].Members = append(teams[1].Members, types.TeamMember{PersonId: "pb5"}, types.TeamMember{Bib: "b6"})
+	out := CalculateTeamResults(teams, nil, results, util.RANKING_TYPE_GUN)
+	if assert.Equal(t, 3, len(out)) {
+		assert.Equal(t, "A", out[0].Name)
+		assert.Equal(t, 1, out[0].Ranking)
+		assert.Equal(t, 1+3+5+7+9, out[0].Score)
+		assert.Equal(t, 100+140+160+180+200, out[0].Seconds)
+		if assert.Equal(t, 7, len(out[0].Members)) {
+			assert.Equal(t, "", out[0].Members[0].First)
+			assert.Equal(t, 1, out[0].Members[0].Place)
+			assert.True(t, out[0].Members[4].Scorer)
+			// Displacers take places but don't score.
+			assert.False(t, out[0].Members[5].Scorer)
+			assert.Equal(t, 11, out[0].Members[5].Place)
+			assert.Equal(t, 12, out[0].Members[6].Place)
+		}
+		assert.Equal(t, "B", out[1].Name)
+		assert.Equal(t, 2, out[1].Ranking)
+		assert.Equal(t, 2+4+6+8+10, out[1].Score)
+		assert.Equal(t, 5, len(out[1].Members))
+		assert.Equal(t, "C", out[2].Name)
+		assert.Equal(t, Unranked, out[2].Ranking)
+		assert.Equal(t, 0, out[2].Score)
+		if assert.Equal(t, 1, len(out[2].Members)) {
+			assert.Equal(t, 0, out[2].Members[0].Place)
+			assert.False(t, out[2].Members[0].Scorer)
+		}
+	}
+	// Ties are broken by the first displacer.
+	results = []types.Result{
+		teamTestResult("x1", "1M", 300),
+		teamTestResult("y1", "1M", 310),
+		teamTestResult("y2", "1M", 320),
+		teamTestResult("x2", "1M", 330),
+		teamTestResult("x3", "1M", 340),
+		teamTestResult("y3", "1M", 350),
+	}
+	teams = []types.Team{
+		{Name: "Y", Distance: "1M", Members: []types.TeamMember{{Bib: "y1"}, {Bib: "y2"}, {Bib: "y3"}}},
+		{Name: "X", Distance: "1M", Members: []types.TeamMember{{Bib: "x1"}, {Bib: "x2"}, {Bib: "x3"}}},
+	}
+	scoring := []types.TeamScoring{
+		{Distance: "1M", Scorers: 2, Displacers: 1, Method: types.TeamScoringPlaces},
+	}
+	out = CalculateTeamResults(teams, scoring, results, util.RANKING_TYPE_GUN)
+	if assert.Equal(t, 2, len(out)) {
+		assert.Equal(t, out[0].Score, out[1].Score)
+		assert.Equal(t, "X", out[0].Name)
+		assert.Equal(t, 1, out[0].Ranking)
+		assert.Equal(t, 2, out[1].Ranking)
+	}
+	// Teams can be scored on time.
+	results = []types.Result{
+		teamTestResult("t1", "10K", 1000),
+		teamTestResult("s1", "10K", 1100),
+		teamTestResult("s2", "10K", 1150),
+		teamTestResult("t2", "10K", 1300),
+	}
+	results[1].ChipSeconds = 1050
+	teams = []types.Team{
+		{Name: "T", Distance: "10K", Members: []types.TeamMember{{Bib: "t1"}, {Bib: "t2"}}},
+		{Name: "S", Distance: "10K", Members: []types.TeamMember{{Bib: "s1"}, {Bib: "s2"}}},
+	}
+	scoring = []types.TeamScoring{
+		{Distance: "10K", Scorers: 2, Method: types.TeamScoringTimes},
+	}
+	out = CalculateTeamResults(teams, scoring, results, util.RANKING_TYPE_CHIP)
+	if assert.Equal(t, 2, len(out)) {
+		assert.Equal(t, "S", out[0].Name)
+		assert.Equal(t, 2200000, out[0].Score)
+		assert.Equal(t, 2200, out[0].Seconds)
+		assert.Equal(t, "T", out[1].Name)
+		assert.Equal(t, 2300000, out[1].Score)
+	}
+}
+
@@ -0,0 +1,121 @@
+/*
+Chronokeep Desktop - Race Scoring Software
+Copyright (C) 2026 James Sentinella
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package database
+
+import (
+	"chronokeep/results/types"
+	"regexp"
+	"time"
+)
+
+// readTimeLayouts are the formats Chronokeep Desktop has sent the local time of a result in.
+var readTimeLayouts = []string{
+	time.RFC3339Nano,
+	"2006/01/02 15:04:05.000-07:00",
+	"2006/01/02 15:04:05-07:00",
+	"2006/01/02 15:04:05.000 -07:00",
+	"2006/01/02 15:04:05 -07:00",
+}
+
+// naiveReadTimeLayouts are the formats without a time zone, which are read in the zone of the event year.
+var naiveReadTimeLayouts = []string{
+	"2006/01/02 15:04:05.000",
+	"2006/01/02 15:04:05",
+}
+
+// shortOffset Matches a time zone offset without a leading zero on the hour, e.g. -7:00.
+var shortOffset = regexp.MustCompile(`([+-])(\d):(\d\d)$`)
+
+// ParseReadTime Parses the local time of a result.  Times without a zone are read in the location given.
+func ParseReadTime(local string, loc *time.Location) (time.Time, bool) {
+	local = shortOffset.ReplaceAllString(local, "${1}0${2}:${3}")
+	for _, layout := range readTimeLayouts {
+		if t, err := time.Parse(layout, local); err == nil {
+			return t, true
+		}
+	}
+	for _, layout := range naiveReadTimeLayouts {
+		if t, err := time.ParseInLocation(layout, local, loc); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// readTime Returns when a result was read.  This is its local time when that can be read and the gun
+// time after the start of the event year otherwise.
+func readTime(res *types.Result, start time.Time) time.Time {
+	if read, ok := ParseReadTime(res.LocalTime, start.Location()); ok {
+		return read
+	}
+	return start.Add(time.Duration(res.Seconds)*time.Second + time.Duration(res.Milliseconds)*time.Millisecond)
+}
+
+// FindWave Returns the wave a bib in a distance started in, or nil if it isn't in one.  A bib that's
+// listed explicitly belongs to that wave even when it's also in the bib range of another.
+func FindWave(waves []types.Wave, distance, bib string) *types.Wave {
+	var ranged *types.Wave
+	for ix := range waves {
+		if waves[ix].Distance != distance {
+			continue
+		}
+		if waves[ix].Listed(bib) {
+			return &waves[ix]
+		}
+		if ranged == nil && waves[ix].InRange(bib) {
+			ranged = &waves[ix]
+		}
+	}
+	return ranged
+}
+
+// ApplyWaves Sets the chip time of each result in a wave to the time between the start of the wave
+// and when the result was read.  The read time is the local time of the result when it can be read
+// and the gun time after the start of the event year otherwise.  Results are updated in place and
+// the ones whose chip time changed are returned.
+func ApplyWaves(results []types.Result, waves []types.Wave, start time.Time) []types.Result {
+	changed := make([]types.Result, 0)
+	if len(waves) == 0 {
+		return changed
+	}
+	for ix := range results {
+		res := &results[ix]
+		if res.IsDNS() {
+			continue
+		}
+		wave := FindWave(waves, res.Distance, res.Bib)
+		if wave == nil {
+			continue
+		}
+		elapsed := readTime(res, start).Sub(wave.StartTime)
+		if elapsed < 0 {
+			continue
+		}
+		seconds := int(elapsed / time.Second)
+		milliseconds := int((elapsed % time.Second) / time.Millisecond)
+		if res.ChipSeconds == seconds && res.ChipMilliseconds == milliseconds {
+			continue
+		}
+		res.ChipSeconds = seconds
+		res.ChipMilliseconds = milliseconds
+		changed = append(changed, *res)
+	}
+	return changed
+}
+
@@ -0,0 +1,122 @@
+/*
+Chronokeep Desktop - Race Scoring Software
+Copyright (C) 2026 James Sentinella
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package database
+
+import (
+	"chronokeep/results/types"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseReadTime(t *testing.T) {
+	loc := time.FixedZone("MST", -7*3600)
+	expected := time.Date(2024, 5, 4, 11, 12, 12, 0, loc)
+	for _, local := range []string{
+		"2024/05/04 11:12:12-7:00",
+		"2024/05/04 11:12:12-07:00",
+		"2024/05/04 11:12:12 -07:00",
+		"2024-05-04T11:12:12-07:00",
+		"2024/05/04 11:12:12",
+	} {
+		read, ok := ParseReadTime(local, loc)
+		if assert.True(t, ok, local) {
+			assert.True(t, expected.Equal(read), local)
+		}
+	}
+	read, ok := ParseReadTime("2024/05/04 11:12:12.250-7:00", loc)
+	if assert.True(t, ok) {
+		assert.True(t, expected.Add(250*time.Millisecond).Equal(read))
+	}
+	_, ok = ParseReadTime("", loc)
+	assert.False(t, ok)
+	_, ok = ParseReadTime("not a time", loc)
+	assert.False(t, ok)
+}
+
+func TestFindWave(t *testing.T) {
+	waves := []types.Wave{
+		{Distance: "5K", Name: "Wave 1", BibRanges: []types.BibRange{{First: 1, Last: 99}, {First: 200, Last: 299}}},
+		{Distance: "5K", Name: "Wave 2", BibRanges: []types.BibRange{{First: 100, Last: 199}}, Bibs: []string{"5", "A1"}},
+		{Distance: "10K", Name: "Wave 1", BibRanges: []types.BibRange{{First: 1, Last: 1000}}},
+	}
+	name := func(wave *types.Wave) string {
+		if wave == nil {
+			return ""
+		}
+		return wave.Distance + " " + wave.Name
+	}
+	assert.Equal(t, "5K Wave 1", name(FindWave(waves, "5K", "1")))
+	assert.Equal(t, "5K Wave 1", name(FindWave(waves, "5K", "250")))
+	assert.Equal(t, "5K Wave 2", name(FindWave(waves, "5K", "150")))
+	assert.Equal(t, "5K Wave 2", name(FindWave(waves, "5K", "5")))
+	assert.Equal(t, "5K Wave 2", name(FindWave(waves, "5K", "A1")))
+	assert.Equal(t, "10K Wave 1", name(FindWave(waves, "10K", "5")))
+	assert.Equal(t, "", name(FindWave(waves, "5K", "300")))
+	assert.Equal(t, "", name(FindWave(waves, "5K", "B2")))
+	assert.Equal(t, "", name(FindWave(waves, "Half", "5")))
+	assert.Equal(t, "", name(FindWave(nil, "5K", "5")))
+}
+
+func TestApplyWaves(t *testing.T) {
+	start := time.Date(2024, 5, 4, 8, 0, 0, 0, time.UTC)
+	waves := []types.Wave{
+		{Distance: "5K", Name: "Wave 1", StartTime: start, BibRanges: []types.BibRange{{First: 1, Last: 99}}},
+		{Distance: "5K", Name: "Wave 2", StartTime: start.Add(10 * time.Minute), BibRanges: []types.BibRange{{First: 100, Last: 199}}},
+	}
+	results := []types.Result{
+		// Read time from the local time.
+		{Bib: "1", Distance: "5K", Seconds: 1300, LocalTime: "2024/05/04 08:20:00.500+0:00"},
+		// Read time from the gun time.
+		{Bib: "100", Distance: "5K", Seconds: 1800, Milliseconds: 250},
+		// Already correct.
+		{Bib: "2", Distance: "5K", Seconds: 600, ChipSeconds: 600},
+		// Not in a wave.
+		{Bib: "300", Distance: "5K", Seconds: 1500, ChipSeconds: 1490},
+		// Read before the wave started.
+		{Bib: "101", Distance: "5K", Seconds: 300, ChipSeconds: 290},
+		// Didn't start.
+		{Bib: "102", Distance: "5K", Seconds: 0, ChipSeconds: 0, Type: types.ResultTypeDNS},
+	}
+	changed := ApplyWaves(results, waves, start)
+	if assert.Equal(t, 2, len(changed)) {
+		assert.Equal(t, "1", changed[0].Bib)
+		assert.Equal(t, 1200, changed[0].ChipSeconds)
+		assert.Equal(t, 500, changed[0].ChipMilliseconds)
+		assert.Equal(t, "100", changed[1].Bib)
+		assert.Equal(t, 1200, changed[1].ChipSeconds)
+		assert.Equal(t, 250, changed[1].ChipMilliseconds)
+	}
+	assert.Equal(t, 1200, results[0].ChipSeconds)
+	assert.Equal(t, 1200, results[1].ChipSeconds)
+	assert.Equal(t, 600, results[2].ChipSeconds)
+	assert.Equal(t, 1490, results[3].ChipSeconds)
+	assert.Equal(t, 290, results[4].ChipSeconds)
+	assert.Equal(t, 0, results[5].ChipSeconds)
+	// Correcting the start of a wave changes the results in it.
+	waves[1].StartTime = start.Add(11 * time.Minute)
+	changed = ApplyWaves(results, waves, start)
+	if assert.Equal(t, 1, len(changed)) {
+		assert.Equal(t, "100", changed[0].Bib)
+		assert.Equal(t, 1140, changed[0].ChipSeconds)
+	}
+	assert.Equal(t, 0, len(ApplyWaves(results, nil, start)))
+}
+
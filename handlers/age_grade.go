@@ -0,0 +1,124 @@
+/*
+Chronokeep Desktop - Race Scoring Software
+Copyright (C) 2026 James Sentinella
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package handlers
+
+import (
+	db "chronokeep/results/database"
+	"chronokeep/results/types"
+	"net/http"
+
+	"github.com/labstack/echo/v5"
+)
+
+// ageGradeResults Sets the age graded time and percentage of the finishes from an event year.
+// Time based events don't finish on a time and aren't age graded, same as with records.
+func ageGradeResults(event *types.Event, year *types.EventYear, results []types.Result) error {
+	if !keepsRecords(event) || len(results) < 1 {
+		return nil
+	}
+	distances, err := database.GetDistances(year.Identifier)
+	if err != nil {
+		return err
+	}
+	db.AgeGrade(results, distances, year.RankingType)
+	return nil
+}
+
+func (h Handler) GetAgeGradedResults(c *echo.Context) error {
+	// Get Key from Authorization Header
+	k, err := retrieveKey(c.Request())
+	if err != nil {
+		return getAPIError(c, http.StatusUnauthorized, "Error Getting Key From Authorization Header", err)
+	}
+	if k == nil {
+		return getAPIError(c, http.StatusUnauthorized, "Key Not Provided in Authorization Header", nil)
+	}
+	var request types.GetResultsRequest
+	if err := c.Bind(&request); err != nil {
+		return getAPIError(c, http.StatusBadRequest, "Invalid Request Body", err)
+	}
+	// Get Key
+	mkey, err := database.GetKeyAndAccount(*k)
+	if err != nil {
+		return getAPIError(c, http.StatusInternalServerError, "Error Retrieving Key/Account", err)
+	}
+	if mkey == nil || mkey.Key == nil || mkey.Account == nil {
+		return getAPIError(c, http.StatusUnauthorized, "Key/Account Not Found", nil)
+	}
+	// Check for expired key
+	if mkey.Key.Expired() {
+		return getAPIError(c, http.StatusUnauthorized, "Expired Key", nil)
+	}
+	// Check for host being allowed.
+	if !mkey.Key.IsAllowed(c.Request().Referer()) {
+		return getAPIError(c, http.StatusUnauthorized, "Host Not Allowed", nil)
+	}
+	// And Event for verification of whether or not we can allow access to this key
+	year := ""
+	if request.Year != nil {
+		year = *request.Year
+	}
+	mult, err := database.GetEventAndYear(request.Slug, year)
+	if err != nil {
+		return getAPIError(c, http.StatusInternalServerError, "Error Retrieving Event/Year", err)
+	}
+	if mult == nil || mult.Event == nil || mult.EventYear == nil {
+		return getAPIError(c, http.StatusNotFound, "Event/Year Not Found", nil)
+	}
+	if mult.Event.AccessRestricted && mkey.Account.Identifier != mult.Event.AccountIdentifier {
+		return getAPIError(c, http.StatusUnauthorized, "Restricted Event", nil)
+	}
+	years, err := database.GetEventYears(request.Slug)
+	if err != nil {
+		return getAPIError(c, http.StatusInternalServerError, "Error Retrieving Event Years", err)
+	}
+	distance := ""
+	if request.Distance != nil {
+		distance = *request.Distance
+	}
+	// Every finish is needed to rank them so limit and page aren't used.
+	results, err := database.GetFinishResults(mult.EventYear.Identifier, distance, 0, 0)
+	if err != nil {
+		return getAPIError(c, http.StatusInternalServerError, "Error Retrieving Results", err)
+	}
+	results, err = publishedResults(mult.Event, mult.EventYear, mkey.Account, results)
+	if err != nil {
+		return getAPIError(c, http.StatusInternalServerError, "Error Retrieving Publish Settings", err)
+	}
+	err = ageGradeResults(mult.Event, mult.EventYear, results)
+	if err != nil {
+		return getAPIError(c, http.StatusInternalServerError, "Error Retrieving Distances", err)
+	}
+	results = db.AgeGradedRankings(results)
+	outRes := make(map[string][]types.Result)
+	for _, result := range results {
+		if _, ok := outRes[result.Distance]; !ok {
+			outRes[result.Distance] = make([]types.Result, 0, 1)
+		}
+		outRes[result.Distance] = append(outRes[result.Distance], result)
+	}
+	return c.JSON(http.StatusOK, types.GetResultsResponse{
+		Event:     *mult.Event,
+		EventYear: *mult.EventYear,
+		Years:     years,
+		Results:   outRes,
+		Count:     len(results),
+	})
+}
+
@@ -0,0 +1,167 @@
+/*
+Chronokeep Desktop - Race Scoring Software
+Copyright (C) 2026 James Sentinella
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package handlers
+
+import (
+	"chronokeep/results/types"
+	"chronokeep/results/util"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+func getTestAgeGraded(t *testing.T, h Handler, key string, request types.GetResultsRequest) (int, types.GetResultsResponse) {
+	var out types.GetResultsResponse
+	body, err := json.Marshal(request)
+	if err != nil {
+		t.Fatalf("Error encoding request body into json object: %v", err)
+	}
+	req := httptest.NewRequest(http.MethodPost, "/results/age-graded", strings.NewReader(string(body)))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	if key != "" {
+		req.Header.Set(echo.HeaderAuthorization, "Bearer "+key)
+	}
+	response := httptest.NewRecorder()
+	c := echo.New().NewContext(req, response)
+	if assert.NoError(t, h.GetAgeGradedResults(c)) && response.Code == http.StatusOK {
+		assert.NoError(t, json.Unmarshal(response.Body.Bytes(), &out))
+	}
+	return response.Code, out
+}
+
+func TestGetAgeGradedResults(t *testing.T) {
+	// POST, /results/age-graded
+	variables, finalize := setupTests(t)
+	defer finalize(t)
+	h := Handler{}
+	h.Setup()
+	year := "2021"
+	request := types.GetResultsRequest{
+		Slug: variables.events["event2"].Slug,
+		Year: &year,
+	}
+	// Test no key
+	t.Log("Testing no key given.")
+	code, _ := getTestAgeGraded(t, h, "", request)
+	assert.Equal(t, http.StatusUnauthorized, code)
+	// Test expired key
+	t.Log("Testing expired key.")
+	code, _ = getTestAgeGraded(t, h, variables.knownValues["expired"], request)
+	assert.Equal(t, http.StatusUnauthorized, code)
+	// Test invalid key
+	t.Log("Testing invalid key.")
+	code, _ = getTestAgeGraded(t, h, "not-a-valid-key", request)
+	assert.Equal(t, http.StatusUnauthorized, code)
+	// Test invalid host
+	t.Log("Testing invalid host.")
+	code, _ = getTestAgeGraded(t, h, variables.knownValues["delete"], request)
+	assert.Equal(t, http.StatusUnauthorized, code)
+	// Test restricted event
+	t.Log("Testing restricted event but unauthorized key.")
+	code, _ = getTestAgeGraded(t, h, variables.knownValues["write"], request)
+	assert.Equal(t, http.StatusUnauthorized, code)
+	// Test invalid event
+	t.Log("Testing event not found.")
+	code, _ = getTestAgeGraded(t, h, variables.knownValues["read"], types.GetResultsRequest{Slug: "invalid-event"})
+	assert.Equal(t, http.StatusNotFound, code)
+	// Test distances without a length
+	t.Log("Testing distances without a length.")
+	request.Slug = variables.events["event1"].Slug
+	code, resp := getTestAgeGraded(t, h, variables.knownValues["read"], request)
+	if assert.Equal(t, http.StatusOK, code) {
+		assert.Equal(t, 0, resp.Count)
+		assert.Equal(t, 0, len(resp.Results))
+	}
+	// Test distances with a length
+	t.Log("Testing distances with a length.")
+	eventYear := variables.eventYears["event1"]["2021"]
+	dists := variables.distances["event1"]["2021"]
+	for ix := range dists {
+		switch dists[ix].Name {
+		case "Marathon":
+			dists[ix].DistanceValue = 26.2
+			dists[ix].DistanceUnit = util.DISTANCE_TYPE_MILE
+		case "Half Marathon":
+			dists[ix].DistanceValue = 13.1
+			dists[ix].DistanceUnit = util.DISTANCE_TYPE_MILE
+		}
+	}
+	_, err := database.AddDistances(eventYear.Identifier, dists)
+	if err != nil {
+		t.Fatalf("Error updating distances: %v", err)
+	}
+	results := variables.results["event1"]["2021"]
+	for ix := range results {
+		results[ix].ChipSeconds = results[ix].Seconds
+	}
+	uploadTestResults(t, h, variables.knownValues["write"], "2021", results)
+	graded := make(map[string]int)
+	for _, res := range results {
+		if res.Finish && res.IsRanked() && res.Age > 0 && (res.Gender == "Man" || res.Gender == "Woman" || res.Gender == "M" || res.Gender == "F") &&
+			(res.Distance == "Marathon" || res.Distance == "Half Marathon") {
+			graded[res.Distance]++
+		}
+	}
+	code, resp = getTestAgeGraded(t, h, variables.knownValues["read"], request)
+	if assert.Equal(t, http.StatusOK, code) {
+		assert.Less(t, 0, resp.Count)
+		assert.Equal(t, len(graded), len(resp.Results))
+		for distance, results := range resp.Results {
+			assert.Equal(t, graded[distance], len(results))
+			for ix, res := range results {
+				assert.Equal(t, distance, res.Distance)
+				assert.Equal(t, ix+1, res.AgeGradedRanking)
+				assert.Less(t, 0.0, res.AgeGradedPercentage)
+				if ix > 0 {
+					assert.GreaterOrEqual(t, results[ix-1].AgeGradedPercentage, res.AgeGradedPercentage)
+				}
+			}
+		}
+	}
+	// Test a single distance
+	t.Log("Testing a single distance.")
+	distance := "Half Marathon"
+	request.Distance = &distance
+	code, resp = getTestAgeGraded(t, h, variables.knownValues["read"], request)
+	if assert.Equal(t, http.StatusOK, code) {
+		assert.Equal(t, graded[distance], resp.Count)
+		for name := range resp.Results {
+			assert.Equal(t, distance, name)
+		}
+	}
+	// Time based events aren't age graded.
+	t.Log("Testing a time based event.")
+	event := variables.events["event1"]
+	event.Type = util.EVENT_TYPE_TIME
+	err = database.UpdateEvent(event)
+	if err != nil {
+		t.Fatalf("Error updating event: %v", err)
+	}
+	request.Distance = nil
+	code, resp = getTestAgeGraded(t, h, variables.knownValues["read"], request)
+	if assert.Equal(t, http.StatusOK, code) {
+		assert.Equal(t, 0, resp.Count)
+	}
+}
+
@@ -0,0 +1,297 @@
+/*
+Chronokeep Desktop - Race Scoring Software
+Copyright (C) 2026 James Sentinella
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package handlers
+
+import (
+	db "chronokeep/results/database"
+	"chronokeep/results/types"
+	"net/http"
+
+	"github.com/labstack/echo/v5"
+)
+
+func (h Handler) GetAthlete(c *echo.Context) error {
+	// Get Key from Authorization Header
+	k, err := retrieveKey(c.Request())
+	if err != nil {
+		return getAPIError(c, http.StatusUnauthorized, "Error Getting Key From Authorization Header", err)
+	}
+	if k == nil {
+		return getAPIError(c, http.StatusUnauthorized, "Key Not Provided in Authorization Header", nil)
+	}
+	var request types.GetAthleteRequest
+	if err := c.Bind(&request); err != nil {
+		return getAPIError(c, http.StatusBadRequest, "Invalid Request Body", err)
+	}
+	// Get Key
+	mkey, err := database.GetKeyAndAccount(*k)
+	if err != nil {
+		return getAPIError(c, http.StatusInternalServerError, "Error Retrieving Key/Account", err)
+	}
+	if mkey == nil || mkey.Key == nil || mkey.Account == nil {
+		return getAPIError(c, http.StatusUnauthorized, "Key/Account Not Found", nil)
+	}
+	// Check for expired key
+	if mkey.Key.Expired() {
+		return getAPIError(c, http.StatusUnauthorized, "Expired Key", nil)
+	}
+	// Check for host being allowed.
+	if !mkey.Key.IsAllowed(c.Request().Referer()) {
+		return getAPIError(c, http.StatusUnauthorized, "Host Not Allowed", nil)
+	}
+	athlete, err := database.GetAthlete(request.Slug)
+	if err != nil {
+		return getAPIError(c, http.StatusInternalServerError, "Error Retrieving Athlete", err)
+	}
+	if athlete == nil {
+		return getAPIError(c, http.StatusNotFound, "Athlete Not Found", nil)
+	}
+	entries, err := database.GetAthleteEntries(*athlete)
+	if err != nil {
+		return getAPIError(c, http.StatusInternalServerError, "Error Retrieving Athlete Entries", err)
+	}
+	events := make([]types.AthleteEvent, 0)
+	for _, entry := range db.MatchAthleteEntries(*athlete, entries) {
+		// Anonymous entries and entries in restricted events aren't shown on the profile.
+		if entry.Anonymous || (entry.AccessRestricted && mkey.Account.Identifier != entry.AccountIdentifier) {
+			continue
+		}
+		results, err := database.GetBibResults(entry.EventYearIdentifier, entry.Bib)
+		if err != nil {
+			return getAPIError(c, http.StatusInternalServerError, "Error Retrieving Results", err)
+		}
+		results, err = publishedYearResults(entry.AccountIdentifier, entry.EventYearIdentifier, mkey.Account, results)
+		if err != nil {
+			return getAPIError(c, http.StatusInternalServerError, "Error Retrieving Publish Settings", err)
+		}
+		anonymous := false
+		for _, res := range results {
+			anonymous = anonymous || res.Anonymous
+		}
+		if anonymous || len(results) < 1 {
+			continue
+		}
+		events = append(events, types.AthleteEvent{
+			AthleteEntry: entry,
+			Results:      results,
+		})
+	}
+	// The birthdate is only used for matching and is kept private.
+	athlete.Birthdate = ""
+	return c.JSON(http.StatusOK, types.GetAthleteResponse{
+		Athlete: *athlete,
+		Events:  events,
+	})
+}
+
+func (h Handler) AddAthlete(c *echo.Context) error {
+	// Get Key from Authorization Header
+	k, err := retrieveKey(c.Request())
+	if err != nil {
+		return getAPIError(c, http.StatusUnauthorized, "Error Getting Key From Authorization Header", err)
+	}
+	if k == nil {
+		return getAPIError(c, http.StatusUnauthorized, "Key Not Provided in Authorization Header", nil)
+	}
+	var request types.AddAthleteRequest
+	if err := c.Bind(&request); err != nil {
+		return getAPIError(c, http.StatusBadRequest, "Invalid Request Body", err)
+	}
+	// Validate the Athlete
+	if err := request.Athlete.Validate(h.validate); err != nil {
+		return getAPIError(c, http.StatusBadRequest, "Validation Error", err)
+	}
+	// Get Key
+	mkey, err := database.GetKeyAndAccount(*k)
+	if err != nil {
+		return getAPIError(c, http.StatusInternalServerError, "Error Retrieving Key/Account", err)
+	}
+	if mkey == nil || mkey.Key == nil || mkey.Account == nil {
+		return getAPIError(c, http.StatusUnauthorized, "Key/Account Not Found", nil)
+	}
+	// Check for expired key
+	if mkey.Key.Expired() {
+		return getAPIError(c, http.StatusUnauthorized, "Expired Key", nil)
+	}
+	// Check for host being allowed.
+	if !mkey.Key.IsAllowed(c.Request().Referer()) {
+		return getAPIError(c, http.StatusUnauthorized, "Host Not Allowed", nil)
+	}
+	// Verify key access level.  Readonly cannot write or modify values.
+	if mkey.Key.Type == "read" {
+		return getAPIError(c, http.StatusUnauthorized, "Key is ReadOnly", nil)
+	}
+	existing, err := database.GetAthlete(request.Athlete.Slug)
+	if err != nil {
+		return getAPIError(c, http.StatusInternalServerError, "Error Retrieving Athlete", err)
+	}
+	if existing != nil && existing.AccountIdentifier != mkey.Account.Identifier {
+		return getAPIError(c, http.StatusUnauthorized, "Ownership Error", nil)
+	}
+	athlete, err := database.AddAthlete(types.Athlete{
+		AccountIdentifier: mkey.Account.Identifier,
+		Slug:              request.Athlete.Slug,
+		First:             request.Athlete.First,
+		Last:              request.Athlete.Last,
+		Birthdate:         request.Athlete.Birthdate,
+	})
+	if err != nil {
+		return getAPIError(c, http.StatusInternalServerError, "Error Adding Athlete", err)
+	}
+	return c.JSON(http.StatusOK, types.ModifyAthleteResponse{
+		Athlete: *athlete,
+	})
+}
+
+func (h Handler) DeleteAthlete(c *echo.Context) error {
+	// Get Key from Authorization Header
+	k, err := retrieveKey(c.Request())
+	if err != nil {
+		return getAPIError(c, http.StatusUnauthorized, "Error Getting Key From Authorization Header", err)
+	}
+	if k == nil {
+		return getAPIError(c, http.StatusUnauthorized, "Key Not Provided in Authorization Header", nil)
+	}
+	var request types.DeleteAthleteRequest
+	if err := c.Bind(&request); err != nil {
+		return getAPIError(c, http.StatusBadRequest, "Invalid Request Body", err)
+	}
+	// Get Key
+	mkey, err := database.GetKeyAndAccount(*k)
+	if err != nil {
+		return getAPIError(c, http.StatusInternalServerError, "Error Retrieving Key/Account", err)
+	}
+	if mkey == nil || mkey.Key == nil || mkey.Account == nil {
+		return getAPIError(c, http.StatusUnauthorized, "Key/Account Not Found", nil)
+	}
+	// Check for expired key
+	if mkey.Key.Expired() {
+		return getAPIError(c, http.StatusUnauthorized, "Expired Key", nil)
+	}
+	// Check for host being allowed.
+	if !mkey.Key.IsAllowed(c.Request().Referer()) {
+		return getAPIError(c, http.StatusUnauthorized, "Host Not Allowed", nil)
+	}
+	// Verify access level. Delete is the only level that can delete values.
+	if mkey.Key.Type != "delete" {
+		return getAPIError(c, http.StatusUnauthorized, "Key is ReadOnly/Write", nil)
+	}
+	athlete, err := database.GetAthlete(request.Slug)
+	if err != nil {
+		return getAPIError(c, http.StatusInternalServerError, "Error Retrieving Athlete", err)
+	}
+	if athlete == nil {
+		return getAPIError(c, http.StatusNotFound, "Athlete Not Found", nil)
+	}
+	if athlete.AccountIdentifier != mkey.Account.Identifier {
+		return getAPIError(c, http.StatusUnauthorized, "Ownership Error", nil)
+	}
+	err = database.DeleteAthlete(*athlete)
+	if err != nil {
+		return getAPIError(c, http.StatusInternalServerError, "Error Deleting Athlete", err)
+	}
+	return c.NoContent(http.StatusOK)
+}
+
+// LinkAthlete Claims the bib of an event year for an athlete.  Both the athlete and the event
+// must belong to the account of the key.
+func (h Handler) LinkAthlete(c *echo.Context) error {
+	return h.changeAthleteLink(c, true)
+}
+
+// UnlinkAthlete Removes the claim of an athlete on the bib of an event year.
+func (h Handler) UnlinkAthlete(c *echo.Context) error {
+	return h.changeAthleteLink(c, false)
+}
+
+func (h Handler) changeAthleteLink(c *echo.Context, link bool) error {
+	// Get Key from Authorization Header
+	k, err := retrieveKey(c.Request())
+	if err != nil {
+		return getAPIError(c, http.StatusUnauthorized, "Error Getting Key From Authorization Header", err)
+	}
+	if k == nil {
+		return getAPIError(c, http.StatusUnauthorized, "Key Not Provided in Authorization Header", nil)
+	}
+	var request types.LinkAthleteRequest
+	if err := c.Bind(&request); err != nil {
+		return getAPIError(c, http.StatusBadRequest, "Invalid Request Body", err)
+	}
+	if request.Bib == "" {
+		return getAPIError(c, http.StatusBadRequest, "Bib Not Provided", nil)
+	}
+	// Get Key
+	mkey, err := database.GetKeyAndAccount(*k)
+	if err != nil {
+		return getAPIError(c, http.StatusInternalServerError, "Error Retrieving Key/Account", err)
+	}
+	if mkey == nil || mkey.Key == nil || mkey.Account == nil {
+		return getAPIError(c, http.StatusUnauthorized, "Key/Account Not Found", nil)
+	}
+	// Check for expired key
+	if mkey.Key.Expired() {
+		return getAPIError(c, http.StatusUnauthorized, "Expired Key", nil)
+	}
+	// Check for host being allowed.
+	if !mkey.Key.IsAllowed(c.Request().Referer()) {
+		return getAPIError(c, http.StatusUnauthorized, "Host Not Allowed", nil)
+	}
+	// Verify key access level.  Readonly cannot write or modify values.
+	if mkey.Key.Type == "read" {
+		return getAPIError(c, http.StatusUnauthorized, "Key is ReadOnly", nil)
+	}
+	athlete, err := database.GetAthlete(request.Athlete)
+	if err != nil {
+		return getAPIError(c, http.StatusInternalServerError, "Error Retrieving Athlete", err)
+	}
+	if athlete == nil {
+		return getAPIError(c, http.StatusNotFound, "Athlete Not Found", nil)
+	}
+	mult, err := database.GetEventAndYear(request.Slug, request.Year)
+	if err != nil {
+		return getAPIError(c, http.StatusInternalServerError, "Error Retrieving Event/Year", err)
+	}
+	if mult == nil || mult.Event == nil || mult.EventYear == nil {
+		return getAPIError(c, http.StatusNotFound, "Event/Year Not Found", nil)
+	}
+	// Check if they own the athlete and the event.
+	if athlete.AccountIdentifier != mkey.Account.Identifier || mult.Event.AccountIdentifier != mkey.Account.Identifier {
+		return getAPIError(c, http.StatusUnauthorized, "Ownership Error", nil)
+	}
+	if !link {
+		_, err = database.DeleteAthleteLink(athlete.Identifier, mult.EventYear.Identifier, request.Bib)
+		if err != nil {
+			return getAPIError(c, http.StatusInternalServerError, "Error Unlinking Athlete", err)
+		}
+		return c.NoContent(http.StatusOK)
+	}
+	person, err := database.GetPerson(request.Slug, request.Year, request.Bib)
+	if err != nil {
+		return getAPIError(c, http.StatusInternalServerError, "Error Retrieving Person", err)
+	}
+	if person == nil {
+		return getAPIError(c, http.StatusNotFound, "Person Not Found", nil)
+	}
+	err = database.AddAthleteLink(athlete.Identifier, mult.EventYear.Identifier, request.Bib)
+	if err != nil {
+		return getAPIError(c, http.StatusInternalServerError, "Error Linking Athlete", err)
+	}
+	return c.NoContent(http.StatusOK)
+}
+
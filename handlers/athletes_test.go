@@ -0,0 +1,380 @@
+/*
+Chronokeep Desktop - Race Scoring Software
+Copyright (C) 2026 James Sentinella
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package handlers
+
+import (
+	"chronokeep/results/types"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func setupTestAthlete(t *testing.T, variables *SetupVariables) *types.Athlete {
+	athlete, err := database.AddAthlete(types.Athlete{
+		AccountIdentifier: variables.accounts[0].Identifier,
+		Slug:              "ann-lee",
+		First:             "Ann",
+		Last:              "Lee",
+		Birthdate:         "1990-04-05",
+	})
+	if err != nil {
+		t.Fatalf("Error adding athlete: %v", err)
+	}
+	for _, entry := range []struct {
+		slug      string
+		year      string
+		bib       string
+		birthdate string
+		anonymous bool
+	}{
+		{slug: "event1", year: "2021", bib: "A1", birthdate: "4/5/1990"},
+		{slug: "event1", year: "2020", bib: "A2", birthdate: "1990/04/05"},
+		{slug: "event2", year: "2021", bib: "A3", birthdate: "4/5/1990"},
+		{slug: "event2", year: "2020", bib: "A4", birthdate: "4/5/1990", anonymous: true},
+		// Someone else with the same name.
+		{slug: "event2", year: "2019", bib: "A5", birthdate: "1/1/1970"},
+	} {
+		eventYearID := variables.eventYears[entry.slug][entry.year].Identifier
+		_, err := database.AddParticipants(eventYearID, []types.Participant{
+			{
+				AlternateId: entry.bib,
+				Bib:         entry.bib,
+				First:       "Ann",
+				Last:        "Lee",
+				Birthdate:   entry.birthdate,
+				Gender:      "Woman",
+				AgeGroup:    "30-39",
+				Distance:    "5K",
+				Anonymous:   entry.anonymous,
+			},
+		})
+		if err != nil {
+			t.Fatalf("Error adding participants: %v", err)
+		}
+		result := seriesTestResult("Ann", "Lee", "Woman", 1, 1)
+		result.Bib = entry.bib
+		result.Anonymous = entry.anonymous
+		_, err = database.AddResults(eventYearID, []types.Result{result}, nil)
+		if err != nil {
+			t.Fatalf("Error adding results: %v", err)
+		}
+	}
+	return athlete
+}
+
+func TestGetAthlete(t *testing.T) {
+	// POST, /athlete
+	variables, finalize := setupTests(t)
+	defer finalize(t)
+	h := Handler{}
+	h.Setup()
+	request := types.GetAthleteRequest{
+		Slug: "ann-lee",
+	}
+	var resp types.GetAthleteResponse
+	// Test no key
+	t.Log("Testing no key given.")
+	code := jsonTestRequest(t, http.MethodPost, "/athlete", "", request, h.GetAthlete, &resp)
+	assert.Equal(t, http.StatusUnauthorized, code)
+	// Test expired key
+	t.Log("Testing expired key.")
+	code = jsonTestRequest(t, http.MethodPost, "/athlete", variables.knownValues["expired"], request, h.GetAthlete, &resp)
+	assert.Equal(t, http.StatusUnauthorized, code)
+	// Test invalid key
+	t.Log("Testing invalid key.")
+	code = jsonTestRequest(t, http.MethodPost, "/athlete", "not-a-valid-key", request, h.GetAthlete, &resp)
+	assert.Equal(t, http.StatusUnauthorized, code)
+	// Test invalid host
+	t.Log("Testing invalid host.")
+	code = jsonTestRequest(t, http.MethodPost, "/athlete", variables.knownValues["delete"], request, h.GetAthlete, &resp)
+	assert.Equal(t, http.StatusUnauthorized, code)
+	// Test athlete not found
+	t.Log("Testing athlete not found.")
+	code = jsonTestRequest(t, http.MethodPost, "/athlete", variables.knownValues["read"], request, h.GetAthlete, &resp)
+	assert.Equal(t, http.StatusNotFound, code)
+	athlete := setupTestAthlete(t, &variables)
+	// Test valid request, restricted events of other accounts are left out
+	t.Log("Testing valid request.")
+	resp = types.GetAthleteResponse{}
+	code = jsonTestRequest(t, http.MethodPost, "/athlete", variables.knownValues["write"], request, h.GetAthlete, &resp)
+	if assert.Equal(t, http.StatusOK, code) {
+		assert.Equal(t, athlete.Slug, resp.Athlete.Slug)
+		assert.Equal(t, athlete.First, resp.Athlete.First)
+		assert.Equal(t, athlete.Last, resp.Athlete.Last)
+		assert.Equal(t, "", resp.Athlete.Birthdate)
+		if assert.Equal(t, 2, len(resp.Events)) {
+			assert.Equal(t, "event1", resp.Events[0].Slug)
+			assert.Equal(t, "2021", resp.Events[0].Year)
+			assert.Equal(t, "A1", resp.Events[0].Bib)
+			assert.Equal(t, 1, len(resp.Events[0].Results))
+			assert.Equal(t, "event1", resp.Events[1].Slug)
+			assert.Equal(t, "2020", resp.Events[1].Year)
+			assert.Equal(t, "A2", resp.Events[1].Bib)
+			assert.Equal(t, 1, len(resp.Events[1].Results))
+		}
+	}
+	// Test valid request, participants in events of other accounts aren't matched by birthdate
+	// even for the account that owns the event
+	t.Log("Testing valid request with event of another account.")
+	resp = types.GetAthleteResponse{}
+	code = jsonTestRequest(t, http.MethodPost, "/athlete", variables.knownValues["read"], request, h.GetAthlete, &resp)
+	if assert.Equal(t, http.StatusOK, code) {
+		if assert.Equal(t, 2, len(resp.Events)) {
+			assert.Equal(t, "event1", resp.Events[0].Slug)
+			assert.Equal(t, "A1", resp.Events[0].Bib)
+			assert.Equal(t, "event1", resp.Events[1].Slug)
+			assert.Equal(t, "A2", resp.Events[1].Bib)
+		}
+	}
+	// Test claimed bib
+	t.Log("Testing claimed bib.")
+	err := database.AddAthleteLink(athlete.Identifier, variables.eventYears["event1"]["2021"].Identifier, "0")
+	if err != nil {
+		t.Fatalf("Error adding athlete link: %v", err)
+	}
+	resp = types.GetAthleteResponse{}
+	code = jsonTestRequest(t, http.MethodPost, "/athlete", variables.knownValues["write"], request, h.GetAthlete, &resp)
+	if assert.Equal(t, http.StatusOK, code) {
+		if assert.Equal(t, 3, len(resp.Events)) {
+			bibs := []string{}
+			for _, event := range resp.Events {
+				bibs = append(bibs, event.Bib)
+			}
+			assert.ElementsMatch(t, []string{"A1", "0", "A2"}, bibs)
+		}
+	}
+}
+
+func TestAddAthlete(t *testing.T) {
+	// POST, /athletes/add
+	variables, finalize := setupTests(t)
+	defer finalize(t)
+	h := Handler{}
+	h.Setup()
+	request := types.AddAthleteRequest{
+		Athlete: types.Athlete{
+			Slug:      "ann-lee",
+			First:     "Ann",
+			Last:      "Lee",
+			Birthdate: "4/5/1990",
+		},
+	}
+	var resp types.ModifyAthleteResponse
+	// Test no key
+	t.Log("Testing no key given.")
+	code := jsonTestRequest(t, http.MethodPost, "/athletes/add", "", request, h.AddAthlete, &resp)
+	assert.Equal(t, http.StatusUnauthorized, code)
+	// Test expired key
+	t.Log("Testing expired key.")
+	code = jsonTestRequest(t, http.MethodPost, "/athletes/add", variables.knownValues["expired"], request, h.AddAthlete, &resp)
+	assert.Equal(t, http.StatusUnauthorized, code)
+	// Test read key
+	t.Log("Testing read key.")
+	code = jsonTestRequest(t, http.MethodPost, "/athletes/add", variables.knownValues["read"], request, h.AddAthlete, &resp)
+	assert.Equal(t, http.StatusUnauthorized, code)
+	// Test validation errors
+	t.Log("Testing validation errors.")
+	invalid := request
+	invalid.Athlete.Slug = "ann lee"
+	code = jsonTestRequest(t, http.MethodPost, "/athletes/add", variables.knownValues["write"], invalid, h.AddAthlete, &resp)
+	assert.Equal(t, http.StatusBadRequest, code)
+	invalid = request
+	invalid.Athlete.Birthdate = "not-a-date"
+	code = jsonTestRequest(t, http.MethodPost, "/athletes/add", variables.knownValues["write"], invalid, h.AddAthlete, &resp)
+	assert.Equal(t, http.StatusBadRequest, code)
+	invalid = request
+	invalid.Athlete.Last = ""
+	code = jsonTestRequest(t, http.MethodPost, "/athletes/add", variables.knownValues["write"], invalid, h.AddAthlete, &resp)
+	assert.Equal(t, http.StatusBadRequest, code)
+	// Test valid request
+	t.Log("Testing valid request.")
+	code = jsonTestRequest(t, http.MethodPost, "/athletes/add", variables.knownValues["write"], request, h.AddAthlete, &resp)
+	if assert.Equal(t, http.StatusOK, code) {
+		assert.Equal(t, "ann-lee", resp.Athlete.Slug)
+		assert.Equal(t, "4/5/1990", resp.Athlete.Birthdate)
+	}
+	stored, err := database.GetAthlete("ann-lee")
+	if assert.NoError(t, err) && assert.NotNil(t, stored) {
+		assert.Equal(t, variables.accounts[0].Identifier, stored.AccountIdentifier)
+	}
+	// Test update
+	t.Log("Testing update.")
+	request.Athlete.Last = "Leigh"
+	code = jsonTestRequest(t, http.MethodPost, "/athletes/add", variables.knownValues["write"], request, h.AddAthlete, &resp)
+	if assert.Equal(t, http.StatusOK, code) {
+		assert.Equal(t, "Leigh", resp.Athlete.Last)
+	}
+	// Test wrong account
+	t.Log("Testing wrong account.")
+	code = jsonTestRequest(t, http.MethodPost, "/athletes/add", variables.knownValues["write2"], request, h.AddAthlete, &resp)
+	assert.Equal(t, http.StatusUnauthorized, code)
+}
+
+func TestDeleteAthlete(t *testing.T) {
+	// DELETE, /athletes/delete
+	variables, finalize := setupTests(t)
+	defer finalize(t)
+	h := Handler{}
+	h.Setup()
+	athlete := setupTestAthlete(t, &variables)
+	request := types.DeleteAthleteRequest{
+		Slug: athlete.Slug,
+	}
+	// Test no key
+	t.Log("Testing no key given.")
+	code := jsonTestRequest(t, http.MethodDelete, "/athletes/delete", "", request, h.DeleteAthlete, nil)
+	assert.Equal(t, http.StatusUnauthorized, code)
+	// Test expired key
+	t.Log("Testing expired key.")
+	code = jsonTestRequest(t, http.MethodDelete, "/athletes/delete", variables.knownValues["expired"], request, h.DeleteAthlete, nil)
+	assert.Equal(t, http.StatusUnauthorized, code)
+	// Test write key
+	t.Log("Testing write key.")
+	code = jsonTestRequest(t, http.MethodDelete, "/athletes/delete", variables.knownValues["write"], request, h.DeleteAthlete, nil)
+	assert.Equal(t, http.StatusUnauthorized, code)
+	// Test wrong account
+	t.Log("Testing wrong account.")
+	code = jsonTestRequest(t, http.MethodDelete, "/athletes/delete", variables.knownValues["delete2"], request, h.DeleteAthlete, nil)
+	assert.Equal(t, http.StatusUnauthorized, code)
+	// Test athlete not found
+	t.Log("Testing athlete not found.")
+	code = jsonTestRequest(t, http.MethodDelete, "/athletes/delete", variables.knownValues["delete3"], types.DeleteAthleteRequest{Slug: "not-an-athlete"}, h.DeleteAthlete, nil)
+	assert.Equal(t, http.StatusNotFound, code)
+	// Test valid request
+	t.Log("Testing valid request.")
+	code = jsonTestRequest(t, http.MethodDelete, "/athletes/delete", variables.knownValues["delete3"], request, h.DeleteAthlete, nil)
+	assert.Equal(t, http.StatusOK, code)
+	out, err := database.GetAthlete(athlete.Slug)
+	if assert.NoError(t, err) {
+		assert.Nil(t, out)
+	}
+}
+
+func TestLinkAthlete(t *testing.T) {
+	// POST, /athletes/link
+	variables, finalize := setupTests(t)
+	defer finalize(t)
+	h := Handler{}
+	h.Setup()
+	athlete := setupTestAthlete(t, &variables)
+	request := types.LinkAthleteRequest{
+		Athlete: athlete.Slug,
+		Slug:    "event1",
+		Year:    "2021",
+		Bib:     "0",
+	}
+	// Test no key
+	t.Log("Testing no key given.")
+	code := jsonTestRequest(t, http.MethodPost, "/athletes/link", "", request, h.LinkAthlete, nil)
+	assert.Equal(t, http.StatusUnauthorized, code)
+	// Test expired key
+	t.Log("Testing expired key.")
+	code = jsonTestRequest(t, http.MethodPost, "/athletes/link", variables.knownValues["expired"], request, h.LinkAthlete, nil)
+	assert.Equal(t, http.StatusUnauthorized, code)
+	// Test read key
+	t.Log("Testing read key.")
+	code = jsonTestRequest(t, http.MethodPost, "/athletes/link", variables.knownValues["read"], request, h.LinkAthlete, nil)
+	assert.Equal(t, http.StatusUnauthorized, code)
+	// Test no bib
+	t.Log("Testing no bib.")
+	noBib := request
+	noBib.Bib = ""
+	code = jsonTestRequest(t, http.MethodPost, "/athletes/link", variables.knownValues["write"], noBib, h.LinkAthlete, nil)
+	assert.Equal(t, http.StatusBadRequest, code)
+	// Test athlete not found
+	t.Log("Testing athlete not found.")
+	notFound := request
+	notFound.Athlete = "not-an-athlete"
+	code = jsonTestRequest(t, http.MethodPost, "/athletes/link", variables.knownValues["write"], notFound, h.LinkAthlete, nil)
+	assert.Equal(t, http.StatusNotFound, code)
+	// Test event year not found
+	t.Log("Testing event year not found.")
+	notFound = request
+	notFound.Year = "2010"
+	code = jsonTestRequest(t, http.MethodPost, "/athletes/link", variables.knownValues["write"], notFound, h.LinkAthlete, nil)
+	assert.Equal(t, http.StatusNotFound, code)
+	// Test wrong account
+	t.Log("Testing wrong account.")
+	code = jsonTestRequest(t, http.MethodPost, "/athletes/link", variables.knownValues["write2"], request, h.LinkAthlete, nil)
+	assert.Equal(t, http.StatusUnauthorized, code)
+	otherEvent := request
+	otherEvent.Slug = "event2"
+	code = jsonTestRequest(t, http.MethodPost, "/athletes/link", variables.knownValues["write"], otherEvent, h.LinkAthlete, nil)
+	assert.Equal(t, http.StatusUnauthorized, code)
+	// Test person not found
+	t.Log("Testing person not found.")
+	notFound = request
+	notFound.Bib = "not-a-bib"
+	code = jsonTestRequest(t, http.MethodPost, "/athletes/link", variables.knownValues["write"], notFound, h.LinkAthlete, nil)
+	assert.Equal(t, http.StatusNotFound, code)
+	// Test valid request
+	t.Log("Testing valid request.")
+	code = jsonTestRequest(t, http.MethodPost, "/athletes/link", variables.knownValues["write"], request, h.LinkAthlete, nil)
+	assert.Equal(t, http.StatusOK, code)
+	entries, err := database.GetAthleteEntries(*athlete)
+	if assert.NoError(t, err) {
+		found := false
+		for _, entry := range entries {
+			found = found || (entry.Claimed && entry.Bib == "0" && entry.EventYearIdentifier == variables.eventYears["event1"]["2021"].Identifier)
+		}
+		assert.True(t, found)
+	}
+}
+
+func TestUnlinkAthlete(t *testing.T) {
+	// DELETE, /athletes/unlink
+	variables, finalize := setupTests(t)
+	defer finalize(t)
+	h := Handler{}
+	h.Setup()
+	athlete := setupTestAthlete(t, &variables)
+	eventYearID := variables.eventYears["event1"]["2021"].Identifier
+	err := database.AddAthleteLink(athlete.Identifier, eventYearID, "0")
+	if err != nil {
+		t.Fatalf("Error adding athlete link: %v", err)
+	}
+	request := types.LinkAthleteRequest{
+		Athlete: athlete.Slug,
+		Slug:    "event1",
+		Year:    "2021",
+		Bib:     "0",
+	}
+	// Test no key
+	t.Log("Testing no key given.")
+	code := jsonTestRequest(t, http.MethodDelete, "/athletes/unlink", "", request, h.UnlinkAthlete, nil)
+	assert.Equal(t, http.StatusUnauthorized, code)
+	// Test read key
+	t.Log("Testing read key.")
+	code = jsonTestRequest(t, http.MethodDelete, "/athletes/unlink", variables.knownValues["read"], request, h.UnlinkAthlete, nil)
+	assert.Equal(t, http.StatusUnauthorized, code)
+	// Test wrong account
+	t.Log("Testing wrong account.")
+	code = jsonTestRequest(t, http.MethodDelete, "/athletes/unlink", variables.knownValues["write2"], request, h.UnlinkAthlete, nil)
+	assert.Equal(t, http.StatusUnauthorized, code)
+	// Test valid request
+	t.Log("Testing valid request.")
+	code = jsonTestRequest(t, http.MethodDelete, "/athletes/unlink", variables.knownValues["write"], request, h.UnlinkAthlete, nil)
+	assert.Equal(t, http.StatusOK, code)
+	count, err := database.DeleteAthleteLink(athlete.Identifier, eventYearID, "0")
+	if assert.NoError(t, err) {
+		assert.Equal(t, int64(0), count)
+	}
+}
+
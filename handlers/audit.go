@@ -0,0 +1,164 @@
+/*
+Chronokeep Desktop - Race Scoring Software
+Copyright (C) 2026 James Sentinella
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package handlers
+
+import (
+	db "chronokeep/results/database"
+	"chronokeep/results/types"
+	"net/http"
+
+	"github.com/labstack/echo/v5"
+)
+
+func (h Handler) GetResultHistory(c *echo.Context) error {
+	// Get Key from Authorization Header
+	k, err := retrieveKey(c.Request())
+	if err != nil {
+		return getAPIError(c, http.StatusUnauthorized, "Error Getting Key From Authorization Header", err)
+	}
+	if k == nil {
+		return getAPIError(c, http.StatusUnauthorized, "Key Not Provided in Authorization Header", nil)
+	}
+	var request types.GetResultHistoryRequest
+	if err := c.Bind(&request); err != nil {
+		return getAPIError(c, http.StatusBadRequest, "Invalid Request Body", err)
+	}
+	// Get Key
+	mkey, err := database.GetKeyAndAccount(*k)
+	if err != nil {
+		return getAPIError(c, http.StatusInternalServerError, "Error Retrieving Key/Account", err)
+	}
+	if mkey == nil || mkey.Key == nil || mkey.Account == nil {
+		return getAPIError(c, http.StatusUnauthorized, "Key/Account Not Found", nil)
+	}
+	// Check for expired key
+	if mkey.Key.Expired() {
+		return getAPIError(c, http.StatusUnauthorized, "Expired Key", nil)
+	}
+	// Check for host being allowed.
+	if !mkey.Key.IsAllowed(c.Request().Referer()) {
+		return getAPIError(c, http.StatusUnauthorized, "Host Not Allowed", nil)
+	}
+	if len(request.Bib) < 1 {
+		return getAPIError(c, http.StatusBadRequest, "Bib Not Provided", nil)
+	}
+	year := ""
+	if request.Year != nil {
+		year = *request.Year
+	}
+	mult, err := database.GetEventAndYear(request.Slug, year)
+	if err != nil {
+		return getAPIError(c, http.StatusInternalServerError, "Error Retrieving Event/Year", err)
+	}
+	if mult == nil || mult.Event == nil || mult.EventYear == nil {
+		return getAPIError(c, http.StatusNotFound, "Event/Year Not Found", nil)
+	}
+	// The history shows who made changes so only the owner gets to see it.
+	if mult.Event.AccountIdentifier != mkey.Account.Identifier {
+		return getAPIError(c, http.StatusUnauthorized, "Ownership Error", nil)
+	}
+	history, err := database.GetResultAudit(mult.EventYear.Identifier, request.Bib)
+	if err != nil {
+		return getAPIError(c, http.StatusInternalServerError, "Error Retrieving Result History", err)
+	}
+	return c.JSON(http.StatusOK, types.GetResultHistoryResponse{
+		Event:     *mult.Event,
+		EventYear: *mult.EventYear,
+		Bib:       request.Bib,
+		History:   history,
+	})
+}
+
+func (h Handler) RevertResults(c *echo.Context) error {
+	// Get Key from Authorization Header
+	k, err := retrieveKey(c.Request())
+	if err != nil {
+		return getAPIError(c, http.StatusUnauthorized, "Error Getting Key From Authorization Header", err)
+	}
+	if k == nil {
+		return getAPIError(c, http.StatusUnauthorized, "Key Not Provided in Authorization Header", nil)
+	}
+	var request types.RevertResultsRequest
+	if err := c.Bind(&request); err != nil {
+		return getAPIError(c, http.StatusBadRequest, "Invalid Request Body", err)
+	}
+	// Get Key
+	mkey, err := database.GetKeyAndAccount(*k)
+	if err != nil {
+		return getAPIError(c, http.StatusInternalServerError, "Error Retrieving Key/Account", err)
+	}
+	if mkey == nil || mkey.Key == nil || mkey.Account == nil {
+		return getAPIError(c, http.StatusUnauthorized, "Key/Account Not Found", nil)
+	}
+	// Check for expired key
+	if mkey.Key.Expired() {
+		return getAPIError(c, http.StatusUnauthorized, "Expired Key", nil)
+	}
+	// Check for host being allowed.
+	if !mkey.Key.IsAllowed(c.Request().Referer()) {
+		return getAPIError(c, http.StatusUnauthorized, "Host Not Allowed", nil)
+	}
+	if mkey.Key.Type == "read" {
+		return getAPIError(c, http.StatusUnauthorized, "Key is ReadOnly", nil)
+	}
+	year := ""
+	if request.Year != nil {
+		year = *request.Year
+	}
+	mult, err := database.GetEventAndYear(request.Slug, year)
+	if err != nil {
+		return getAPIError(c, http.StatusInternalServerError, "Error Retrieving Event/Year", err)
+	}
+	if mult == nil || mult.Event == nil || mult.EventYear == nil {
+		return getAPIError(c, http.StatusNotFound, "Event/Year Not Found", nil)
+	}
+	// Check if they own this event.
+	if mult.Event.AccountIdentifier != mkey.Account.Identifier {
+		return getAPIError(c, http.StatusUnauthorized, "Ownership Error", nil)
+	}
+	// Official results can't be changed until an admin unlocks the event year.
+	if mult.EventYear.Locked() {
+		return getAPIError(c, http.StatusForbidden, "Event Year Is Locked", nil)
+	}
+	entries, err := database.GetResultAudit(mult.EventYear.Identifier, "")
+	if err != nil {
+		return getAPIError(c, http.StatusInternalServerError, "Error Retrieving Result History", err)
+	}
+	changes, found := db.RevertChanges(entries, request.AuditID, request.Single)
+	if !found {
+		return getAPIError(c, http.StatusNotFound, "Audit Entry Not Found", nil)
+	}
+	count, err := database.RevertAudit(mult.EventYear.Identifier, changes, auditActor(mkey))
+	if err != nil {
+		return getAPIError(c, http.StatusInternalServerError, "Error Reverting Results", err)
+	}
+	_, err = database.UpdateRankings(mult.EventYear.Identifier)
+	if err != nil {
+		return getAPIError(c, http.StatusInternalServerError, "Error Updating Rankings", err)
+	}
+	err = updateRecords(mult.Event, mult.EventYear)
+	if err != nil {
+		return getAPIError(c, http.StatusInternalServerError, "Error Updating Records", err)
+	}
+	publishReset(mult.EventYear.Identifier)
+	return c.JSON(http.StatusOK, types.AddResultsResponse{
+		Count: int(count),
+	})
+}
+
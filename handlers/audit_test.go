@@ -0,0 +1,173 @@
+/*
+Chronokeep Desktop - Race Scoring Software
+Copyright (C) 2026 James Sentinella
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package handlers
+
+import (
+	"chronokeep/results/types"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResultHistory(t *testing.T) {
+	// POST, /results/history
+	// POST, /results/revert
+	variables, finalize := setupTests(t)
+	defer finalize(t)
+	h := Handler{}
+	h.Setup()
+	_, err := database.AddEventYear(types.EventYear{
+		EventIdentifier: variables.events["event2"].Identifier,
+		Year:            "2023",
+		DateTime:        time.Date(2023, 04, 05, 11, 0, 0, 0, time.Local),
+		DaysAllowed:     1,
+		RankingType:     "chip",
+	})
+	if err != nil {
+		t.Fatalf("Error adding event year: %v", err)
+	}
+	results := variables.results["event2"]["2021"]
+	var added types.AddResultsResponse
+	code := jsonTestRequest(t, http.MethodPost, "/results/add", variables.knownValues["write2"], types.AddResultsRequest{
+		Slug:    "event2",
+		Year:    "2023",
+		Results: results[0:1],
+	}, h.AddResults, &added)
+	if !assert.Equal(t, http.StatusOK, code) {
+		t.FailNow()
+	}
+	updated := results[0]
+	updated.Seconds = results[0].Seconds + 100
+	code = jsonTestRequest(t, http.MethodPost, "/results/add", variables.knownValues["write2"], types.AddResultsRequest{
+		Slug:    "event2",
+		Year:    "2023",
+		Results: []types.Result{updated},
+	}, h.AddResults, &added)
+	if !assert.Equal(t, http.StatusOK, code) {
+		t.FailNow()
+	}
+	year := "2023"
+	request := types.GetResultHistoryRequest{
+		Slug: "event2",
+		Year: &year,
+		Bib:  results[0].Bib,
+	}
+	var resp types.GetResultHistoryResponse
+	// Test no key
+	t.Log("Testing no key given.")
+	code = jsonTestRequest(t, http.MethodPost, "/results/history", "", request, h.GetResultHistory, &resp)
+	assert.Equal(t, http.StatusUnauthorized, code)
+	// Test expired key
+	t.Log("Testing expired key.")
+	code = jsonTestRequest(t, http.MethodPost, "/results/history", variables.knownValues["expired"], request, h.GetResultHistory, &resp)
+	assert.Equal(t, http.StatusUnauthorized, code)
+	// Test invalid host
+	t.Log("Testing invalid host.")
+	code = jsonTestRequest(t, http.MethodPost, "/results/history", variables.knownValues["delete"], request, h.GetResultHistory, &resp)
+	assert.Equal(t, http.StatusUnauthorized, code)
+	// Test no bib
+	t.Log("Testing no bib.")
+	noBib := request
+	noBib.Bib = ""
+	code = jsonTestRequest(t, http.MethodPost, "/results/history", variables.knownValues["read"], noBib, h.GetResultHistory, &resp)
+	assert.Equal(t, http.StatusBadRequest, code)
+	// Test event year not found
+	t.Log("Testing event year not found.")
+	notFound := request
+	notFound.Slug = "not-an-event"
+	code = jsonTestRequest(t, http.MethodPost, "/results/history", variables.knownValues["read"], notFound, h.GetResultHistory, &resp)
+	assert.Equal(t, http.StatusNotFound, code)
+	// Test different account's key
+	t.Log("Testing different account's key.")
+	code = jsonTestRequest(t, http.MethodPost, "/results/history", variables.knownValues["write"], request, h.GetResultHistory, &resp)
+	assert.Equal(t, http.StatusUnauthorized, code)
+	// Test valid request
+	t.Log("Testing valid request.")
+	code = jsonTestRequest(t, http.MethodPost, "/results/history", variables.knownValues["read"], request, h.GetResultHistory, &resp)
+	if assert.Equal(t, http.StatusOK, code) && assert.Equal(t, 3, len(resp.History)) {
+		assert.Equal(t, results[0].Bib, resp.Bib)
+		assert.Equal(t, "2023", resp.EventYear.Year)
+		assert.Equal(t, types.AuditTypePerson, resp.History[0].Type)
+		assert.Equal(t, types.AuditActionInsert, resp.History[0].Action)
+		assert.Equal(t, types.AuditTypeResult, resp.History[1].Type)
+		assert.Equal(t, types.AuditActionInsert, resp.History[1].Action)
+		assert.Equal(t, types.AuditActionUpdate, resp.History[2].Action)
+		assert.Equal(t, results[0].Seconds, resp.History[2].Before.Seconds)
+		assert.Equal(t, updated.Seconds, resp.History[2].After.Seconds)
+		assert.Equal(t, variables.accounts[1].Email, resp.History[2].Account)
+		assert.Equal(t, "write2", resp.History[2].KeyName)
+		assert.Equal(t, "write", resp.History[2].KeyType)
+	}
+	history := resp.History
+	revert := types.RevertResultsRequest{
+		Slug:    "event2",
+		Year:    &year,
+		AuditID: history[1].Identifier,
+		Single:  true,
+	}
+	var count types.AddResultsResponse
+	// Test read key
+	t.Log("Testing read key.")
+	code = jsonTestRequest(t, http.MethodPost, "/results/revert", variables.knownValues["read"], revert, h.RevertResults, &count)
+	assert.Equal(t, http.StatusUnauthorized, code)
+	// Test different account's key
+	t.Log("Testing different account's key.")
+	code = jsonTestRequest(t, http.MethodPost, "/results/revert", variables.knownValues["write"], revert, h.RevertResults, &count)
+	assert.Equal(t, http.StatusUnauthorized, code)
+	// Test audit entry not found
+	t.Log("Testing audit entry not found.")
+	missing := revert
+	missing.AuditID = history[2].Identifier + 1000
+	code = jsonTestRequest(t, http.MethodPost, "/results/revert", variables.knownValues["write2"], missing, h.RevertResults, &count)
+	assert.Equal(t, http.StatusNotFound, code)
+	// Test reverting a single result
+	t.Log("Testing reverting a single result.")
+	code = jsonTestRequest(t, http.MethodPost, "/results/revert", variables.knownValues["write2"], revert, h.RevertResults, &count)
+	if assert.Equal(t, http.StatusOK, code) {
+		assert.Equal(t, 1, count.Count)
+	}
+	mult, err := database.GetEventAndYear("event2", "2023")
+	if err != nil {
+		t.Fatalf("Error getting event year: %v", err)
+	}
+	res, err := database.GetResults(mult.EventYear.Identifier, 0, 0)
+	if assert.NoError(t, err) && assert.Equal(t, 1, len(res)) {
+		assert.Equal(t, results[0].Seconds, res[0].Seconds)
+	}
+	code = jsonTestRequest(t, http.MethodPost, "/results/history", variables.knownValues["read"], request, h.GetResultHistory, &resp)
+	if assert.Equal(t, http.StatusOK, code) && assert.Equal(t, 4, len(resp.History)) {
+		assert.Equal(t, updated.Seconds, resp.History[3].Before.Seconds)
+		assert.Equal(t, results[0].Seconds, resp.History[3].After.Seconds)
+	}
+	// Test reverting the whole event year
+	t.Log("Testing reverting the whole event year.")
+	all := revert
+	all.AuditID = 0
+	all.Single = false
+	code = jsonTestRequest(t, http.MethodPost, "/results/revert", variables.knownValues["write2"], all, h.RevertResults, &count)
+	if assert.Equal(t, http.StatusOK, code) {
+		assert.Equal(t, 2, count.Count)
+	}
+	res, err = database.GetResults(mult.EventYear.Identifier, 0, 0)
+	assert.NoError(t, err)
+	assert.Empty(t, res)
+}
+
@@ -0,0 +1,303 @@
+/*
+Chronokeep Desktop - Race Scoring Software
+Copyright (C) 2026 James Sentinella
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package handlers
+
+import (
+	db "chronokeep/results/database"
+	"chronokeep/results/types"
+	"chronokeep/results/util"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v5"
+	log "github.com/sirupsen/logrus"
+)
+
+// awardCategoryNames are the names of the award categories used in exported files.
+var awardCategoryNames = map[string]string{
+	types.AwardOverall:  "Overall",
+	types.AwardMasters:  "Masters",
+	types.AwardAgeGroup: "Age Group",
+	types.AwardDivision: "Division",
+}
+
+// awardColumns Returns the columns of an exported awards file.  The time shown is the one
+// the event year is ranked on.
+func awardColumns(rankingType string) []exportColumn[types.Award] {
+	return []exportColumn[types.Award]{
+		{name: "Distance", value: func(a *types.Award) string { return a.Distance }},
+		{name: "Category", value: func(a *types.Award) string { return awardCategoryNames[a.Category] }},
+		{name: "Group", value: func(a *types.Award) string { return a.Group }},
+		{name: "Place", numeric: true, value: func(a *types.Award) string { return strconv.Itoa(a.Place) }},
+		{name: "Bib", value: func(a *types.Award) string { return a.Result.Bib }},
+		{name: "First", value: func(a *types.Award) string { return a.Result.First }},
+		{name: "Last", value: func(a *types.Award) string { return a.Result.Last }},
+		{name: "Age", numeric: true, value: func(a *types.Award) string { return strconv.Itoa(a.Result.Age) }},
+		{name: "Gender", value: func(a *types.Award) string { return a.Result.Gender }},
+		{name: "Time", value: func(a *types.Award) string {
+			if rankingType == util.RANKING_TYPE_CHIP {
+				return a.Result.ChipTime()
+			}
+			return a.Result.GunTime()
+		}},
+	}
+}
+
+// awardRules Returns the award rules of an event year or the default rules if it has none.
+func awardRules(year *types.EventYear) (types.AwardRules, error) {
+	rules, err := database.GetAwardRules(year.Identifier)
+	if err != nil {
+		return types.AwardRules{}, err
+	}
+	if rules == nil {
+		return db.DefaultAwardRules(), nil
+	}
+	return *rules, nil
+}
+
+func (h Handler) GetAwards(c *echo.Context) error {
+	// Get Key from Authorization Header
+	k, err := retrieveKey(c.Request())
+	if err != nil {
+		return getAPIError(c, http.StatusUnauthorized, "Error Getting Key From Authorization Header", err)
+	}
+	if k == nil {
+		return getAPIError(c, http.StatusUnauthorized, "Key Not Provided in Authorization Header", nil)
+	}
+	var request types.GetAwardsRequest
+	if err := c.Bind(&request); err != nil {
+		return getAPIError(c, http.StatusBadRequest, "Invalid Request Body", err)
+	}
+	// Get Key
+	mkey, err := database.GetKeyAndAccount(*k)
+	if err != nil {
+		return getAPIError(c, http.StatusInternalServerError, "Error Retrieving Key/Account", err)
+	}
+	if mkey == nil || mkey.Key == nil || mkey.Account == nil {
+		return getAPIError(c, http.StatusUnauthorized, "Key/Account Not Found", nil)
+	}
+	// Check for expired key
+	if mkey.Key.Expired() {
+		return getAPIError(c, http.StatusUnauthorized, "Expired Key", nil)
+	}
+	// Check for host being allowed.
+	if !mkey.Key.IsAllowed(c.Request().Referer()) {
+		return getAPIError(c, http.StatusUnauthorized, "Host Not Allowed", nil)
+	}
+	// And Event for verification of whether or not we can allow access to this key
+	year := ""
+	if request.Year != nil {
+		year = *request.Year
+	}
+	mult, err := database.GetEventAndYear(request.Slug, year)
+	if err != nil {
+		return getAPIError(c, http.StatusInternalServerError, "Error Retrieving Event/Year", err)
+	}
+	if mult == nil || mult.Event == nil || mult.EventYear == nil {
+		return getAPIError(c, http.StatusNotFound, "Event/Year Not Found", nil)
+	}
+	if mult.Event.AccessRestricted && mkey.Account.Identifier != mult.Event.AccountIdentifier {
+		return getAPIError(c, http.StatusUnauthorized, "Restricted Event", nil)
+	}
+	rules, err := awardRules(mult.EventYear)
+	if err != nil {
+		return getAPIError(c, http.StatusInternalServerError, "Error Retrieving Award Rules", err)
+	}
+	distance := ""
+	if request.Distance != nil {
+		distance = *request.Distance
+	}
+	results, err := database.GetFinishResults(mult.EventYear.Identifier, distance, 0, 0)
+	if err != nil {
+		return getAPIError(c, http.StatusInternalServerError, "Error Retrieving Results", err)
+	}
+	results, err = publishedResults(mult.Event, mult.EventYear, mkey.Account, results)
+	if err != nil {
+		return getAPIError(c, http.StatusInternalServerError, "Error Retrieving Publish Settings", err)
+	}
+	outAwards := make(map[string][]types.Award)
+	for _, award := range db.CalculateAwards(results, rules, mult.EventYear.RankingType) {
+		outAwards[award.Distance] = append(outAwards[award.Distance], award)
+	}
+	return c.JSON(http.StatusOK, types.GetAwardsResponse{
+		Event:     *mult.Event,
+		EventYear: *mult.EventYear,
+		Rules:     rules,
+		Awards:    outAwards,
+	})
+}
+
+func (h Handler) SetAwardRules(c *echo.Context) error {
+	// Get Key from Authorization Header
+	k, err := retrieveKey(c.Request())
+	if err != nil {
+		return getAPIError(c, http.StatusUnauthorized, "Error Getting Key From Authorization Header", err)
+	}
+	if k == nil {
+		return getAPIError(c, http.StatusUnauthorized, "Key Not Provided in Authorization Header", nil)
+	}
+	var request types.SetAwardRulesRequest
+	if err := c.Bind(&request); err != nil {
+		return getAPIError(c, http.StatusBadRequest, "Invalid Request Body", err)
+	}
+	if err := h.validate.Struct(request.Rules); err != nil {
+		return getAPIError(c, http.StatusBadRequest, "Invalid Award Rules", err)
+	}
+	// Get Key
+	mkey, err := database.GetKeyAndAccount(*k)
+	if err != nil {
+		return getAPIError(c, http.StatusInternalServerError, "Error Retrieving Key/Account", err)
+	}
+	if mkey == nil || mkey.Key == nil || mkey.Account == nil {
+		return getAPIError(c, http.StatusUnauthorized, "Key/Account Not Found", nil)
+	}
+	// Check for expired key
+	if mkey.Key.Expired() {
+		return getAPIError(c, http.StatusUnauthorized, "Expired Key", nil)
+	}
+	// Check for host being allowed.
+	if !mkey.Key.IsAllowed(c.Request().Referer()) {
+		return getAPIError(c, http.StatusUnauthorized, "Host Not Allowed", nil)
+	}
+	if mkey.Key.Type == "read" {
+		return getAPIError(c, http.StatusUnauthorized, "Key is ReadOnly", nil)
+	}
+	// And Event for verification of whether or not we can allow access to this key
+	mult, err := database.GetEventAndYear(request.Slug, request.Year)
+	if err != nil {
+		return getAPIError(c, http.StatusInternalServerError, "Error Retrieving Event/Year", err)
+	}
+	if mult == nil || mult.Event == nil || mult.EventYear == nil {
+		return getAPIError(c, http.StatusNotFound, "Event/Year Not Found", nil)
+	}
+	// Check if they own this event.
+	if mult.Event.AccountIdentifier != mkey.Account.Identifier {
+		return getAPIError(c, http.StatusUnauthorized, "Ownership Error", nil)
+	}
+	rules, err := database.SetAwardRules(mult.EventYear.Identifier, request.Rules)
+	if err != nil {
+		return getAPIError(c, http.StatusInternalServerError, "Error Setting Award Rules", err)
+	}
+	return c.JSON(http.StatusOK, types.SetAwardRulesResponse{
+		Rules: *rules,
+	})
+}
+
+func (h Handler) ExportAwards(c *echo.Context) error {
+	// Get Key from Authorization Header
+	k, err := retrieveKey(c.Request())
+	if err != nil {
+		return getAPIError(c, http.StatusUnauthorized, "Error Getting Key From Authorization Header", err)
+	}
+	if k == nil {
+		return getAPIError(c, http.StatusUnauthorized, "Key Not Provided in Authorization Header", nil)
+	}
+	var request types.ExportAwardsRequest
+	if err := c.Bind(&request); err != nil {
+		return getAPIError(c, http.StatusBadRequest, "Invalid Request Body", err)
+	}
+	format := strings.ToLower(request.Format)
+	if format == "" {
+		format = util.EXPORT_FORMAT_CSV
+	}
+	if format != util.EXPORT_FORMAT_CSV && format != util.EXPORT_FORMAT_XLSX {
+		return getAPIError(c, http.StatusBadRequest, "Invalid Export Format", nil)
+	}
+	// Get Key
+	mkey, err := database.GetKeyAndAccount(*k)
+	if err != nil {
+		return getAPIError(c, http.StatusInternalServerError, "Error Retrieving Key/Account", err)
+	}
+	if mkey == nil || mkey.Key == nil || mkey.Account == nil {
+		return getAPIError(c, http.StatusUnauthorized, "Key/Account Not Found", nil)
+	}
+	// Check for expired key
+	if mkey.Key.Expired() {
+		return getAPIError(c, http.StatusUnauthorized, "Expired Key", nil)
+	}
+	// Check for host being allowed.
+	if !mkey.Key.IsAllowed(c.Request().Referer()) {
+		return getAPIError(c, http.StatusUnauthorized, "Host Not Allowed", nil)
+	}
+	// And Event for verification of whether or not we can allow access to this key
+	year := ""
+	if request.Year != nil {
+		year = *request.Year
+	}
+	mult, err := database.GetEventAndYear(request.Slug, year)
+	if err != nil {
+		return getAPIError(c, http.StatusInternalServerError, "Error Retrieving Event/Year", err)
+	}
+	if mult == nil || mult.Event == nil || mult.EventYear == nil {
+		return getAPIError(c, http.StatusNotFound, "Event/Year Not Found", nil)
+	}
+	if mult.Event.AccessRestricted && mkey.Account.Identifier != mult.Event.AccountIdentifier {
+		return getAPIError(c, http.StatusUnauthorized, "Restricted Event", nil)
+	}
+	rules, err := awardRules(mult.EventYear)
+	if err != nil {
+		return getAPIError(c, http.StatusInternalServerError, "Error Retrieving Award Rules", err)
+	}
+	distance := ""
+	if request.Distance != nil {
+		distance = *request.Distance
+	}
+	results, err := database.GetFinishResults(mult.EventYear.Identifier, distance, 0, 0)
+	if err != nil {
+		return getAPIError(c, http.StatusInternalServerError, "Error Retrieving Results", err)
+	}
+	results, err = publishedResults(mult.Event, mult.EventYear, mkey.Account, results)
+	if err != nil {
+		return getAPIError(c, http.StatusInternalServerError, "Error Retrieving Publish Settings", err)
+	}
+	awards := db.CalculateAwards(results, rules, mult.EventYear.RankingType)
+	for ix := range awards {
+		awards[ix].Result = exportResult(awards[ix].Result)
+	}
+	nameParts := []string{mult.Event.Slug, mult.EventYear.Year}
+	if distance != "" {
+		nameParts = append(nameParts, distance)
+	}
+	nameParts = append(nameParts, "awards")
+	fileName := exportFileName(nameParts...) + "." + format
+	c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", fileName))
+	columns := awardColumns(mult.EventYear.RankingType)
+	if format == util.EXPORT_FORMAT_XLSX {
+		c.Response().Header().Set(echo.HeaderContentType, mimeXLSX)
+		c.Response().WriteHeader(http.StatusOK)
+		err = writeXLSX(c.Response(), columns, awards)
+	} else {
+		c.Response().Header().Set(echo.HeaderContentType, mimeCSV)
+		c.Response().WriteHeader(http.StatusOK)
+		err = writeCSV(c.Response(), columns, awards)
+	}
+	if err != nil {
+		// Headers have already been sent so all we can do is note it.
+		log.WithFields(log.Fields{
+			"slug":   mult.Event.Slug,
+			"year":   mult.EventYear.Year,
+			"format": format,
+			"error":  err,
+		}).Error("Error writing awards export.")
+	}
+	return nil
+}
+
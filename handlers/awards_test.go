@@ -0,0 +1,246 @@
+/*
+Chronokeep Desktop - Race Scoring Software
+Copyright (C) 2026 James Sentinella
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package handlers
+
+import (
+	db "chronokeep/results/database"
+	"chronokeep/results/types"
+	"encoding/csv"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetAwards(t *testing.T) {
+	// POST, /awards
+	variables, finalize := setupTests(t)
+	defer finalize(t)
+	h := Handler{}
+	h.Setup()
+	year := "2021"
+	request := types.GetAwardsRequest{
+		Slug: variables.events["event2"].Slug,
+		Year: &year,
+	}
+	var resp types.GetAwardsResponse
+	// Test no key
+	t.Log("Testing no key given.")
+	code := jsonTestRequest(t, http.MethodPost, "/awards", "", request, h.GetAwards, &resp)
+	assert.Equal(t, http.StatusUnauthorized, code)
+	// Test expired key
+	t.Log("Testing expired key.")
+	code = jsonTestRequest(t, http.MethodPost, "/awards", variables.knownValues["expired"], request, h.GetAwards, &resp)
+	assert.Equal(t, http.StatusUnauthorized, code)
+	// Test restricted event
+	t.Log("Testing restricted event but unauthorized key.")
+	code = jsonTestRequest(t, http.MethodPost, "/awards", variables.knownValues["write"], request, h.GetAwards, &resp)
+	assert.Equal(t, http.StatusUnauthorized, code)
+	// Test invalid event
+	t.Log("Testing event not found.")
+	code = jsonTestRequest(t, http.MethodPost, "/awards", variables.knownValues["read"], types.GetAwardsRequest{Slug: "invalid-event"}, h.GetAwards, &resp)
+	assert.Equal(t, http.StatusNotFound, code)
+	// Test default rules
+	t.Log("Testing default rules.")
+	eventYear := variables.eventYears["event2"]["2021"]
+	results, err := database.GetFinishResults(eventYear.Identifier, "", 0, 0)
+	if err != nil {
+		t.Fatalf("Error getting results: %v", err)
+	}
+	expected := db.CalculateAwards(results, db.DefaultAwardRules(), eventYear.RankingType)
+	assert.NotEqual(t, 0, len(expected))
+	resp = types.GetAwardsResponse{}
+	code = jsonTestRequest(t, http.MethodPost, "/awards", variables.knownValues["read"], request, h.GetAwards, &resp)
+	if assert.Equal(t, http.StatusOK, code) {
+		assert.Equal(t, db.DefaultOverallPlaces, resp.Rules.OverallPlaces)
+		count := 0
+		for distance, awards := range resp.Awards {
+			for _, award := range awards {
+				assert.Equal(t, distance, award.Distance)
+				assert.LessOrEqual(t, award.Place, db.DefaultOverallPlaces)
+			}
+			count += len(awards)
+		}
+		assert.Equal(t, len(expected), count)
+	}
+	// Test stored rules
+	t.Log("Testing stored rules.")
+	_, err = database.SetAwardRules(eventYear.Identifier, types.AwardRules{OverallPlaces: 1})
+	assert.NoError(t, err)
+	resp = types.GetAwardsResponse{}
+	code = jsonTestRequest(t, http.MethodPost, "/awards", variables.knownValues["read"], request, h.GetAwards, &resp)
+	if assert.Equal(t, http.StatusOK, code) {
+		assert.Equal(t, 1, resp.Rules.OverallPlaces)
+		for _, awards := range resp.Awards {
+			for _, award := range awards {
+				assert.Equal(t, types.AwardOverall, award.Category)
+				assert.Equal(t, 1, award.Place)
+			}
+		}
+	}
+}
+
+func TestSetAwardRules(t *testing.T) {
+	// POST, /awards/rules
+	variables, finalize := setupTests(t)
+	defer finalize(t)
+	h := Handler{}
+	h.Setup()
+	request := types.SetAwardRulesRequest{
+		Slug: variables.events["event1"].Slug,
+		Year: "2021",
+		Rules: types.AwardRules{
+			OverallPlaces:  3,
+			AgeGroupPlaces: 3,
+			MastersAges:    []int{40},
+			MastersPlaces:  1,
+		},
+	}
+	var resp types.SetAwardRulesResponse
+	// Test no key
+	t.Log("Testing no key given.")
+	code := jsonTestRequest(t, http.MethodPost, "/awards/rules", "", request, h.SetAwardRules, &resp)
+	assert.Equal(t, http.StatusUnauthorized, code)
+	// Test expired key
+	t.Log("Testing expired key.")
+	code = jsonTestRequest(t, http.MethodPost, "/awards/rules", variables.knownValues["expired"], request, h.SetAwardRules, &resp)
+	assert.Equal(t, http.StatusUnauthorized, code)
+	// Test read key
+	t.Log("Testing read key.")
+	code = jsonTestRequest(t, http.MethodPost, "/awards/rules", variables.knownValues["read"], request, h.SetAwardRules, &resp)
+	assert.Equal(t, http.StatusUnauthorized, code)
+	// Test wrong account
+	t.Log("Testing wrong account.")
+	code = jsonTestRequest(t, http.MethodPost, "/awards/rules", variables.knownValues["write2"], request, h.SetAwardRules, &resp)
+	assert.Equal(t, http.StatusUnauthorized, code)
+	// Test invalid event
+	t.Log("Testing event not found.")
+	code = jsonTestRequest(t, http.MethodPost, "/awards/rules", variables.knownValues["write"], types.SetAwardRulesRequest{Slug: "invalid-event", Year: "2021"}, h.SetAwardRules, &resp)
+	assert.Equal(t, http.StatusNotFound, code)
+	// Test invalid rules
+	t.Log("Testing invalid rules.")
+	for _, invalid := range []types.AwardRules{
+		{OverallPlaces: -1},
+		{AgeGroupPlaces: -1},
+		{DivisionPlaces: -1},
+		{MastersPlaces: -1},
+		{MastersAges: []int{0}},
+	} {
+		code = jsonTestRequest(t, http.MethodPost, "/awards/rules", variables.knownValues["write"], types.SetAwardRulesRequest{
+			Slug:  request.Slug,
+			Year:  request.Year,
+			Rules: invalid,
+		}, h.SetAwardRules, &resp)
+		assert.Equal(t, http.StatusBadRequest, code)
+	}
+	// Test valid request
+	t.Log("Testing valid request.")
+	code = jsonTestRequest(t, http.MethodPost, "/awards/rules", variables.knownValues["write"], request, h.SetAwardRules, &resp)
+	if assert.Equal(t, http.StatusOK, code) {
+		assert.Equal(t, request.Rules, resp.Rules)
+	}
+	stored, err := database.GetAwardRules(variables.eventYears["event1"]["2021"].Identifier)
+	if assert.NoError(t, err) && assert.NotNil(t, stored) {
+		assert.Equal(t, request.Rules, *stored)
+	}
+}
+
+func TestExportAwards(t *testing.T) {
+	// POST, /awards/export
+	variables, finalize := setupTests(t)
+	defer finalize(t)
+	e := echo.New()
+	h := Handler{}
+	year := "2021"
+	exportRequest := func(request types.ExportAwardsRequest, key string) *httptest.ResponseRecorder {
+		body, err := json.Marshal(request)
+		if err != nil {
+			t.Fatalf("Error encoding request body into json object: %v", err)
+		}
+		req := httptest.NewRequest(http.MethodPost, "/awards/export", strings.NewReader(string(body)))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		if key != "" {
+			req.Header.Set(echo.HeaderAuthorization, "Bearer "+key)
+		}
+		response := httptest.NewRecorder()
+		assert.NoError(t, h.ExportAwards(e.NewContext(req, response)))
+		return response
+	}
+	request := types.ExportAwardsRequest{
+		Slug: variables.events["event2"].Slug,
+		Year: &year,
+	}
+	// Test no key
+	t.Log("Testing no key given.")
+	assert.Equal(t, http.StatusUnauthorized, exportRequest(request, "").Code)
+	// Test expired key
+	t.Log("Testing expired key.")
+	assert.Equal(t, http.StatusUnauthorized, exportRequest(request, variables.knownValues["expired"]).Code)
+	// Test restricted event
+	t.Log("Testing restricted event but unauthorized key.")
+	assert.Equal(t, http.StatusUnauthorized, exportRequest(request, variables.knownValues["write"]).Code)
+	// Test invalid format
+	t.Log("Testing invalid format.")
+	assert.Equal(t, http.StatusBadRequest, exportRequest(types.ExportAwardsRequest{
+		Slug:   request.Slug,
+		Year:   &year,
+		Format: "pdf",
+	}, variables.knownValues["read"]).Code)
+	// Test invalid event
+	t.Log("Testing event not found.")
+	assert.Equal(t, http.StatusNotFound, exportRequest(types.ExportAwardsRequest{Slug: "invalid-event"}, variables.knownValues["read"]).Code)
+	// Test csv export
+	t.Log("Testing csv export.")
+	eventYear := variables.eventYears["event2"]["2021"]
+	results, err := database.GetFinishResults(eventYear.Identifier, "", 0, 0)
+	if err != nil {
+		t.Fatalf("Error getting results: %v", err)
+	}
+	expected := db.CalculateAwards(results, db.DefaultAwardRules(), eventYear.RankingType)
+	response := exportRequest(request, variables.knownValues["read"])
+	if assert.Equal(t, http.StatusOK, response.Code) {
+		assert.Equal(t, mimeCSV, response.Header().Get(echo.HeaderContentType))
+		assert.Contains(t, response.Header().Get(echo.HeaderContentDisposition), "awards.csv")
+		rows, err := csv.NewReader(response.Body).ReadAll()
+		if assert.NoError(t, err) && assert.Equal(t, len(expected)+1, len(rows)) {
+			assert.Equal(t, []string{"Distance", "Category", "Group", "Place", "Bib", "First", "Last", "Age", "Gender", "Time"}, rows[0])
+			for ix, award := range expected {
+				assert.Equal(t, award.Distance, rows[ix+1][0])
+				assert.Equal(t, award.Group, rows[ix+1][2])
+				assert.Equal(t, award.Result.Bib, rows[ix+1][4])
+			}
+		}
+	}
+	// Test xlsx export
+	t.Log("Testing xlsx export.")
+	response = exportRequest(types.ExportAwardsRequest{
+		Slug:   request.Slug,
+		Year:   &year,
+		Format: "xlsx",
+	}, variables.knownValues["read"])
+	if assert.Equal(t, http.StatusOK, response.Code) {
+		assert.Equal(t, mimeXLSX, response.Header().Get(echo.HeaderContentType))
+		assert.Contains(t, response.Header().Get(echo.HeaderContentDisposition), "awards.xlsx")
+	}
+}
+
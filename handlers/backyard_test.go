@@ -0,0 +1,146 @@
+/*
+Chronokeep Desktop - Race Scoring Software
+Copyright (C) 2026 James Sentinella
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package handlers
+
+import (
+	"chronokeep/results/types"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetBackyardStandings(t *testing.T) {
+	// POST, /results/backyard
+	variables, finalize := setupTests(t)
+	defer finalize(t)
+	h := Handler{}
+	h.Setup()
+	event, err := database.AddEvent(types.Event{
+		AccountIdentifier: variables.accounts[0].Identifier,
+		Name:              "Backyard",
+		Slug:              "backyard",
+		ContactEmail:      "backyard@test.com",
+		Type:              "backyardultra",
+	})
+	if err != nil {
+		t.Fatalf("Error adding event: %v", err)
+	}
+	eventYear, err := database.AddEventYear(types.EventYear{
+		EventIdentifier: event.Identifier,
+		Year:            "2021",
+		DateTime:        time.Date(2021, 10, 06, 9, 0, 0, 0, time.Local),
+		DaysAllowed:     3,
+		RankingType:     "gun",
+	})
+	if err != nil {
+		t.Fatalf("Error adding event year: %v", err)
+	}
+	yards := make([]types.Result, 0)
+	for _, runner := range []struct {
+		bib   string
+		yards int
+	}{
+		{bib: "1", yards: 4},
+		{bib: "2", yards: 3},
+		{bib: "3", yards: 1},
+	} {
+		for yard := 1; yard <= runner.yards; yard++ {
+			yards = append(yards, types.Result{
+				PersonId:  runner.bib,
+				Bib:       runner.bib,
+				First:     "Runner",
+				Last:      runner.bib,
+				Gender:    "Woman",
+				AgeGroup:  "30-39",
+				Distance:  "Backyard",
+				Seconds:   (yard-1)*3600 + 3000,
+				Location:  "Start/Finish",
+				Occurence: yard,
+				Finish:    true,
+			})
+		}
+	}
+	if _, err := database.AddResults(eventYear.Identifier, yards, nil); err != nil {
+		t.Fatalf("Error adding results: %v", err)
+	}
+	year := "2021"
+	request := types.GetResultsRequest{
+		Slug: "backyard",
+		Year: &year,
+	}
+	var resp types.GetBackyardStandingsResponse
+	// Test no key
+	t.Log("Testing no key given.")
+	code := jsonTestRequest(t, http.MethodPost, "/results/backyard", "", request, h.GetBackyardStandings, &resp)
+	assert.Equal(t, http.StatusUnauthorized, code)
+	// Test expired key
+	t.Log("Testing expired key.")
+	code = jsonTestRequest(t, http.MethodPost, "/results/backyard", variables.knownValues["expired"], request, h.GetBackyardStandings, &resp)
+	assert.Equal(t, http.StatusUnauthorized, code)
+	// Test invalid host
+	t.Log("Testing invalid host.")
+	code = jsonTestRequest(t, http.MethodPost, "/results/backyard", variables.knownValues["delete"], request, h.GetBackyardStandings, &resp)
+	assert.Equal(t, http.StatusUnauthorized, code)
+	// Test event year not found
+	t.Log("Testing event year not found.")
+	notFound := request
+	notFound.Slug = "not-an-event"
+	code = jsonTestRequest(t, http.MethodPost, "/results/backyard", variables.knownValues["read"], notFound, h.GetBackyardStandings, &resp)
+	assert.Equal(t, http.StatusNotFound, code)
+	// Test restricted event
+	t.Log("Testing restricted event.")
+	restricted := request
+	restricted.Slug = "event2"
+	code = jsonTestRequest(t, http.MethodPost, "/results/backyard", variables.knownValues["write"], restricted, h.GetBackyardStandings, &resp)
+	assert.Equal(t, http.StatusUnauthorized, code)
+	// Test event that isn't a backyard ultra
+	t.Log("Testing event that isn't a backyard ultra.")
+	distanceEvent := request
+	distanceEvent.Slug = "event1"
+	code = jsonTestRequest(t, http.MethodPost, "/results/backyard", variables.knownValues["read"], distanceEvent, h.GetBackyardStandings, &resp)
+	assert.Equal(t, http.StatusBadRequest, code)
+	// Test valid request
+	t.Log("Testing valid request.")
+	code = jsonTestRequest(t, http.MethodPost, "/results/backyard", variables.knownValues["read"], request, h.GetBackyardStandings, &resp)
+	if assert.Equal(t, http.StatusOK, code) && assert.Equal(t, 3, len(resp.Standings["Backyard"])) {
+		standings := resp.Standings["Backyard"]
+		assert.Equal(t, "1", standings[0].Bib)
+		assert.Equal(t, 4, standings[0].YardsCompleted)
+		assert.Equal(t, types.BackyardStatusWinner, standings[0].Status)
+		assert.Equal(t, 4, len(standings[0].Yards))
+		assert.Equal(t, "2", standings[1].Bib)
+		assert.Equal(t, 4, standings[1].DroppedIn)
+		assert.Equal(t, types.BackyardStatusAssist, standings[1].Status)
+		assert.Equal(t, "3", standings[2].Bib)
+		assert.Equal(t, 2, standings[2].DroppedIn)
+		assert.Equal(t, types.BackyardStatusDropped, standings[2].Status)
+	}
+	// Test distance
+	t.Log("Testing distance.")
+	resp = types.GetBackyardStandingsResponse{}
+	distance := "Not A Distance"
+	request.Distance = &distance
+	code = jsonTestRequest(t, http.MethodPost, "/results/backyard", variables.knownValues["read"], request, h.GetBackyardStandings, &resp)
+	if assert.Equal(t, http.StatusOK, code) {
+		assert.Equal(t, 0, len(resp.Standings))
+	}
+}
+
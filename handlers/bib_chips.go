@@ -125,6 +125,10 @@ func (h Handler) AddBibChips(c *echo.Context) error {
 	if mkey.Account.Identifier != mult.Event.AccountIdentifier {
 		return getAPIError(c, http.StatusUnauthorized, "Restricted Event", nil)
 	}
+	// Official results can't be changed until an admin unlocks the event year.
+	if mult.EventYear.Locked() {
+		return getAPIError(c, http.StatusForbidden, "Event Year Is Locked", nil)
+	}
 	bibChips, err := database.AddBibChips(mult.EventYear.Identifier, request.BibChips)
 	if err != nil {
 		return getAPIError(c, http.StatusInternalServerError, "Error Adding BibChips", err)
@@ -181,6 +185,10 @@ func (h Handler) DeleteBibChips(c *echo.Context) error {
 	if mkey.Account.Identifier != mult.Event.AccountIdentifier {
 		return getAPIError(c, http.StatusUnauthorized, "Restricted Event", nil)
 	}
+	// Official results can't be changed until an admin unlocks the event year.
+	if mult.EventYear.Locked() {
+		return getAPIError(c, http.StatusForbidden, "Event Year Is Locked", nil)
+	}
 	count, err := database.DeleteBibChips(mult.EventYear.Identifier)
 	if err != nil {
 		return getAPIError(c, http.StatusInternalServerError, "Error Adding BibChips", err)
@@ -47,9 +47,20 @@ func (h Handler) Bind(group *echo.Group) {
 	group.POST("/results/all", h.GetAllResults)
 	group.POST("/results/multi", h.GetMultiResults)
 	group.POST("/results/finish", h.GetFinishResults)
+	group.POST("/results/age-graded", h.GetAgeGradedResults)
+	group.POST("/results/teams", h.GetTeamResults)
 	group.POST("/results/bib", h.GetBibResults)
+	group.POST("/results/search", h.SearchResults)
+	group.POST("/results/location", h.GetLocationResults)
+	group.POST("/results/predictions", h.GetPredictions)
+	group.POST("/results/backyard", h.GetBackyardStandings)
+	group.POST("/results/laps", h.GetLapStandings)
 	group.POST("/results/add", h.AddResults)
 	group.DELETE("/results/delete", h.DeleteResults)
+	group.POST("/results/history", h.GetResultHistory)
+	group.POST("/results/revert", h.RevertResults)
+	group.POST("/results/export", h.ExportResults)
+	group.GET("/results/stream", h.StreamResults)
 	// Participants handlers
 	group.POST("/participants", h.GetParticipants)
 	group.POST("/participants/add", h.AddParticipants)
@@ -80,6 +91,39 @@ func (h Handler) Bind(group *echo.Group) {
 	group.POST("/distances", h.GetDistances)
 	group.POST("/distances/add", h.AddDistances)
 	group.DELETE("/distances/delete", h.DeleteDistances)
+	// Records
+	group.POST("/records", h.GetRecords)
+	// Teams
+	group.POST("/teams", h.GetTeams)
+	group.POST("/teams/add", h.AddTeams)
+	group.DELETE("/teams/delete", h.DeleteTeams)
+	group.POST("/teams/scoring", h.SetTeamScoring)
+	// Publish settings
+	group.POST("/publish", h.GetPublishSettings)
+	group.POST("/publish/set", h.SetPublishSettings)
+	// Awards
+	group.POST("/awards", h.GetAwards)
+	group.POST("/awards/rules", h.SetAwardRules)
+	group.POST("/awards/export", h.ExportAwards)
+	// Waves
+	group.POST("/waves", h.GetWaves)
+	group.POST("/waves/set", h.SetWaves)
+	// Handicaps
+	group.POST("/handicaps", h.GetHandicapScoring)
+	group.POST("/handicaps/set", h.SetHandicapScoring)
+	// Statistics
+	group.POST("/statistics", h.GetStatistics)
+	// Series
+	group.POST("/series", h.GetSeries)
+	group.POST("/series/standings", h.GetSeriesStandings)
+	group.POST("/series/add", h.AddSeries)
+	group.DELETE("/series/delete", h.DeleteSeries)
+	// Athletes
+	group.POST("/athlete", h.GetAthlete)
+	group.POST("/athletes/add", h.AddAthlete)
+	group.DELETE("/athletes/delete", h.DeleteAthlete)
+	group.POST("/athletes/link", h.LinkAthlete)
+	group.DELETE("/athletes/unlink", h.UnlinkAthlete)
 }
 
 func (h Handler) BindRestricted(group *echo.Group) {
@@ -115,6 +159,7 @@ func (h Handler) BindRestricted(group *echo.Group) {
 	group.POST("/r/participants", h.RGetParticipants)
 	group.POST("/r/participants/add", h.RAddParticipant)
 	group.POST("/r/participants/add-many", h.RAddManyParticipants)
+	group.POST("/r/participants/import", h.RImportParticipants)
 	group.DELETE("/r/participants/delete", h.RDeleteParticipants)
 	group.POST("r/participants/update", h.RUpdateParticipant)
 	group.POST("r/participants/update-many", h.RUpdateManyParticipants)
@@ -137,6 +137,10 @@ func (h Handler) AddDistances(c *echo.Context) error {
 	if mult.Event.AccountIdentifier != mkey.Account.Identifier {
 		return getAPIError(c, http.StatusUnauthorized, "Ownership Error", nil)
 	}
+	// Official results can't be changed until an admin unlocks the event year.
+	if mult.EventYear.Locked() {
+		return getAPIError(c, http.StatusForbidden, "Event Year Is Locked", nil)
+	}
 	distances, err := database.AddDistances(mult.EventYear.Identifier, distToAdd)
 	if err != nil {
 		return getAPIError(c, http.StatusInternalServerError, "Error Adding Distances", err)
@@ -191,6 +195,10 @@ func (h Handler) DeleteDistances(c *echo.Context) error {
 	if mult.Event.AccountIdentifier != mkey.Account.Identifier {
 		return getAPIError(c, http.StatusUnauthorized, "Ownership Error", nil)
 	}
+	// Official results can't be changed until an admin unlocks the event year.
+	if mult.EventYear.Locked() {
+		return getAPIError(c, http.StatusForbidden, "Event Year Is Locked", nil)
+	}
 	count, err := database.DeleteDistances(mult.EventYear.Identifier)
 	if err != nil {
 		return getAPIError(c, http.StatusInternalServerError, "Error Deleting Distances", nil)
@@ -534,6 +534,32 @@ func TestAddDistances(t *testing.T) {
 			assert.Equal(t, len(distances)-1, len(resp.Distances))
 		}
 	}
+	// Test validation - Distance Unit
+	t.Log("Test validation check - Distance Unit.")
+	database.DeleteDistances(eventYear.Identifier)
+	distances[0].Name = "Marathon"
+	distances[0].DistanceValue = 26.2
+	distances[0].DistanceUnit = "furlongs"
+	body, err = json.Marshal(types.AddDistancesRequest{
+		Slug:      variables.events["event2"].Slug,
+		Year:      "2023",
+		Distances: distances,
+	})
+	if err != nil {
+		t.Fatalf("Error encoding request body into json object: %v", err)
+	}
+	request = httptest.NewRequest(http.MethodPost, "/distances/add", strings.NewReader(string(body)))
+	request.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	request.Header.Set(echo.HeaderAuthorization, "Bearer "+variables.knownValues["delete2"])
+	response = httptest.NewRecorder()
+	c = e.NewContext(request, response)
+	if assert.NoError(t, h.AddDistances(c)) {
+		assert.Equal(t, http.StatusOK, response.Code)
+		var resp types.GetDistancesResponse
+		if assert.NoError(t, json.Unmarshal(response.Body.Bytes(), &resp)) {
+			assert.Equal(t, len(distances)-1, len(resp.Distances))
+		}
+	}
 }
 
 func TestDeleteDistances(t *testing.T) {
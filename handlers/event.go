@@ -155,6 +155,10 @@ func (h Handler) GetEvent(c *echo.Context) error {
 		if err != nil {
 			return getAPIError(c, http.StatusInternalServerError, "Error Retrieving Results", err)
 		}
+		res, err = publishedResults(event, recent, mkey.Account, res)
+		if err != nil {
+			return getAPIError(c, http.StatusInternalServerError, "Error Retrieving Publish Settings", err)
+		}
 		parts, err = database.GetParticipants(recent.Identifier, 0, 0, nil)
 		if err != nil {
 			return getAPIError(c, http.StatusInternalServerError, "Error Retrieving participants", err)
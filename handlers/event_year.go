@@ -168,13 +168,15 @@ func (h Handler) AddEventYear(c *echo.Context) error {
 	if mkey.Account.Identifier != event.AccountIdentifier {
 		return getAPIError(c, http.StatusUnauthorized, "Ownership Error", nil)
 	}
+	status := request.EventYear.GetStatus("")
 	eventYear, err := database.AddEventYear(types.EventYear{
 		EventIdentifier: event.Identifier,
 		Year:            request.EventYear.Year,
 		DateTime:        request.EventYear.GetDateTime(),
-		Live:            request.EventYear.Live,
+		Live:            status == types.EventYearStatusLive,
 		DaysAllowed:     request.EventYear.DaysAllowed,
 		RankingType:     request.EventYear.RankingType,
+		Status:          status,
 	})
 	if err != nil {
 		return getAPIError(c, http.StatusInternalServerError, "Error Adding Event Year (Duplicate Year Likely)", err)
@@ -233,15 +235,27 @@ func (h Handler) UpdateEventYear(c *echo.Context) error {
 	if mkey.Account.Identifier != mult.Event.AccountIdentifier {
 		return getAPIError(c, http.StatusUnauthorized, "Ownership Error", nil)
 	}
-	err = database.UpdateEventYear(types.EventYear{
+	status := request.EventYear.GetStatus(mult.EventYear.GetStatus())
+	updated := types.EventYear{
 		EventIdentifier: mult.EventYear.EventIdentifier,
 		Identifier:      mult.EventYear.Identifier,
 		Year:            mult.EventYear.Year,
 		DateTime:        request.EventYear.GetDateTime(),
-		Live:            request.EventYear.Live,
+		Live:            status == types.EventYearStatusLive,
 		DaysAllowed:     request.EventYear.DaysAllowed,
 		RankingType:     request.EventYear.RankingType,
-	})
+		Status:          status,
+	}
+	// Only an admin can unlock an official or archived year, and what changes the results of a
+	// locked year can only be changed while unlocking it.
+	unlocking := mult.EventYear.Locked() && !updated.Locked()
+	if unlocking && mkey.Account.Type != "admin" {
+		return getAPIError(c, http.StatusForbidden, "Event Year Is Locked", nil)
+	}
+	if mult.EventYear.Locked() && !unlocking && mult.EventYear.ResultsChanged(&updated) {
+		return getAPIError(c, http.StatusForbidden, "Event Year Is Locked", nil)
+	}
+	err = database.UpdateEventYear(updated)
 	if err != nil {
 		return getAPIError(c, http.StatusInternalServerError, "Error Updating Event", err)
 	}
@@ -989,6 +989,170 @@ func TestUpdateEventYear(t *testing.T) {
 	}
 }
 
+func TestEventYearLocked(t *testing.T) {
+	// PUT, /event-year/update
+	variables, finalize := setupTests(t)
+	defer finalize(t)
+	h := Handler{}
+	h.Setup()
+	update := types.ModifyEventYearRequest{
+		Slug: "event2",
+		EventYear: types.RequestYear{
+			Year:        "2021",
+			DateTime:    "2021/04/05 11:00:00 -07:00",
+			DaysAllowed: 1,
+			RankingType: "chip",
+			Status:      types.EventYearStatusOfficial,
+		},
+	}
+	var resp types.EventYearResponse
+	// Test invalid status
+	t.Log("Testing invalid status.")
+	invalid := update
+	invalid.EventYear.Status = "certified"
+	code := jsonTestRequest(t, http.MethodPut, "/event-year/update", variables.knownValues["write2"], invalid, h.UpdateEventYear, &resp)
+	assert.Equal(t, http.StatusBadRequest, code)
+	// Test making the year official
+	t.Log("Testing making the year official.")
+	code = jsonTestRequest(t, http.MethodPut, "/event-year/update", variables.knownValues["write2"], update, h.UpdateEventYear, &resp)
+	if assert.Equal(t, http.StatusOK, code) {
+		assert.Equal(t, types.EventYearStatusOfficial, resp.EventYear.Status)
+		assert.False(t, resp.EventYear.Live)
+	}
+	// Test writes being rejected
+	t.Log("Testing writes to an official year.")
+	code = jsonTestRequest(t, http.MethodPost, "/results/add", variables.knownValues["write2"], types.AddResultsRequest{
+		Slug:    "event2",
+		Year:    "2021",
+		Results: variables.results["event2"]["2021"][0:1],
+	}, h.AddResults, nil)
+	assert.Equal(t, http.StatusForbidden, code)
+	year := "2021"
+	code = jsonTestRequest(t, http.MethodDelete, "/results/delete", variables.knownValues["delete2"], types.GetResultsRequest{
+		Slug: "event2",
+		Year: &year,
+	}, h.DeleteResults, nil)
+	assert.Equal(t, http.StatusForbidden, code)
+	code = jsonTestRequest(t, http.MethodPost, "/participants/add", variables.knownValues["write2"], types.AddParticipantsRequest{
+		Slug: "event2",
+		Year: "2021",
+		Participants: []types.Participant{
+			{
+				AlternateId: "1024",
+				Bib:         "1024",
+				First:       "John",
+				Last:        "Jacob",
+				Birthdate:   "1/1/2004",
+				Gender:      "Man",
+				AgeGroup:    "10-20",
+				Distance:    "1 Mile",
+			},
+		},
+	}, h.AddParticipants, nil)
+	assert.Equal(t, http.StatusForbidden, code)
+	code = jsonTestRequest(t, http.MethodPost, "/bibchips/add", variables.knownValues["write2"], types.AddBibChipsRequest{
+		Slug:     "event2",
+		Year:     "2021",
+		BibChips: []types.BibChip{{Bib: "1024", Chip: "1024"}},
+	}, h.AddBibChips, nil)
+	assert.Equal(t, http.StatusForbidden, code)
+	code = jsonTestRequest(t, http.MethodPost, "/segments/add", variables.knownValues["write2"], types.AddSegmentsRequest{
+		Slug: "event2",
+		Year: "2021",
+		Segments: []types.Segment{
+			{
+				Location:      "Start/Finish",
+				DistanceName:  "1 Mile",
+				Name:          "Finish",
+				DistanceValue: 1,
+				DistanceUnit:  "miles",
+			},
+		},
+	}, h.AddSegments, nil)
+	assert.Equal(t, http.StatusForbidden, code)
+	code = jsonTestRequest(t, http.MethodPost, "/distances/add", variables.knownValues["write2"], types.AddDistancesRequest{
+		Slug: "event2",
+		Year: "2021",
+		Distances: []types.Distance{
+			{
+				Name:          "1 Mile",
+				Certification: "None",
+			},
+		},
+	}, h.AddDistances, nil)
+	assert.Equal(t, http.StatusForbidden, code)
+	// Test a stale client only sending the live flag
+	t.Log("Testing a client without a status.")
+	stale := update
+	stale.EventYear.Status = ""
+	stale.EventYear.DaysAllowed = 2
+	code = jsonTestRequest(t, http.MethodPut, "/event-year/update", variables.knownValues["write2"], stale, h.UpdateEventYear, &resp)
+	if assert.Equal(t, http.StatusOK, code) {
+		assert.Equal(t, types.EventYearStatusOfficial, resp.EventYear.Status)
+		assert.Equal(t, 2, resp.EventYear.DaysAllowed)
+	}
+	stale.EventYear.Live = true
+	code = jsonTestRequest(t, http.MethodPut, "/event-year/update", variables.knownValues["write2"], stale, h.UpdateEventYear, &resp)
+	assert.Equal(t, http.StatusForbidden, code)
+	// Test archiving
+	t.Log("Testing archiving an official year.")
+	archive := update
+	archive.EventYear.Status = types.EventYearStatusArchived
+	code = jsonTestRequest(t, http.MethodPut, "/event-year/update", variables.knownValues["write2"], archive, h.UpdateEventYear, &resp)
+	if assert.Equal(t, http.StatusOK, code) {
+		assert.Equal(t, types.EventYearStatusArchived, resp.EventYear.Status)
+	}
+	// Test changing what the results depend on
+	t.Log("Testing changing the ranking type and start of a locked year.")
+	ranking := archive
+	ranking.EventYear.RankingType = "gun"
+	code = jsonTestRequest(t, http.MethodPut, "/event-year/update", variables.knownValues["write2"], ranking, h.UpdateEventYear, &resp)
+	assert.Equal(t, http.StatusForbidden, code)
+	start := archive
+	start.EventYear.DateTime = "2021/04/05 12:00:00 -07:00"
+	code = jsonTestRequest(t, http.MethodPut, "/event-year/update", variables.knownValues["write2"], start, h.UpdateEventYear, &resp)
+	assert.Equal(t, http.StatusForbidden, code)
+	// Test unlocking without being an admin
+	t.Log("Testing unlocking without being an admin.")
+	unlock := update
+	unlock.EventYear.Status = types.EventYearStatusUnofficial
+	code = jsonTestRequest(t, http.MethodPut, "/event-year/update", variables.knownValues["write2"], unlock, h.UpdateEventYear, &resp)
+	assert.Equal(t, http.StatusForbidden, code)
+	// Test an admin unlocking their year
+	t.Log("Testing an admin unlocking.")
+	official := update
+	official.Slug = "event1"
+	code = jsonTestRequest(t, http.MethodPut, "/event-year/update", variables.knownValues["write"], official, h.UpdateEventYear, &resp)
+	assert.Equal(t, http.StatusOK, code)
+	official.EventYear.RankingType = "gun"
+	code = jsonTestRequest(t, http.MethodPut, "/event-year/update", variables.knownValues["write"], official, h.UpdateEventYear, &resp)
+	assert.Equal(t, http.StatusForbidden, code)
+	// The ranking type can change along with unlocking.
+	unlock.Slug = "event1"
+	unlock.EventYear.RankingType = "gun"
+	code = jsonTestRequest(t, http.MethodPut, "/event-year/update", variables.knownValues["write"], unlock, h.UpdateEventYear, &resp)
+	if assert.Equal(t, http.StatusOK, code) {
+		assert.Equal(t, types.EventYearStatusUnofficial, resp.EventYear.Status)
+		assert.Equal(t, "gun", resp.EventYear.RankingType)
+	}
+	code = jsonTestRequest(t, http.MethodPost, "/results/add", variables.knownValues["write"], types.AddResultsRequest{
+		Slug:    "event1",
+		Year:    "2021",
+		Results: variables.results["event1"]["2021"][0:1],
+	}, h.AddResults, nil)
+	assert.Equal(t, http.StatusOK, code)
+	// Test the status being public
+	t.Log("Testing the status in public responses.")
+	var getResp types.EventYearResponse
+	code = jsonTestRequest(t, http.MethodPost, "/event-year", variables.knownValues["write2"], types.GetEventYearRequest{
+		Slug: "event2",
+		Year: "2021",
+	}, h.GetEventYear, &getResp)
+	if assert.Equal(t, http.StatusOK, code) {
+		assert.Equal(t, types.EventYearStatusArchived, getResp.EventYear.Status)
+	}
+}
+
 func TestDeleteEventYear(t *testing.T) {
 	// DELETE, /event-year/delete
 	variables, finalize := setupTests(t)
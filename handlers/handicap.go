@@ -0,0 +1,182 @@
+/*
+Chronokeep Desktop - Race Scoring Software
+Copyright (C) 2026 James Sentinella
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package handlers
+
+import (
+	db "chronokeep/results/database"
+	"chronokeep/results/types"
+	"net/http"
+
+	"github.com/labstack/echo/v5"
+)
+
+// handicapResults Sets the handicap values of the results from an event year when it uses handicap
+// scoring.  Every finish in the event year the account can see is needed to place them, so results
+// can be a single page or bib.  Returns true if the event year uses handicap scoring.
+func handicapResults(event *types.Event, year *types.EventYear, account *types.Account, results []types.Result) (bool, error) {
+	scoring, err := database.GetHandicapScoring(year.Identifier)
+	if err != nil {
+		return false, err
+	}
+	if scoring == nil || !scoring.Enabled {
+		return false, nil
+	}
+	if len(results) < 1 {
+		return true, nil
+	}
+	field, err := database.GetFinishResults(year.Identifier, "", 0, 0)
+	if err != nil {
+		return false, err
+	}
+	// Hidden results can't change the places of the ones that are shown.
+	field, err = publishedResults(event, year, account, field)
+	if err != nil {
+		return false, err
+	}
+	db.ScoreHandicaps(results, field, scoring.Handicaps)
+	return true, nil
+}
+
+func (h Handler) GetHandicapScoring(c *echo.Context) error {
+	// Get Key from Authorization Header
+	k, err := retrieveKey(c.Request())
+	if err != nil {
+		return getAPIError(c, http.StatusUnauthorized, "Error Getting Key From Authorization Header", err)
+	}
+	if k == nil {
+		return getAPIError(c, http.StatusUnauthorized, "Key Not Provided in Authorization Header", nil)
+	}
+	var request types.GetHandicapScoringRequest
+	if err := c.Bind(&request); err != nil {
+		return getAPIError(c, http.StatusBadRequest, "Invalid Request Body", err)
+	}
+	// Get Key
+	mkey, err := database.GetKeyAndAccount(*k)
+	if err != nil {
+		return getAPIError(c, http.StatusInternalServerError, "Error Retrieving Key/Account", err)
+	}
+	if mkey == nil || mkey.Key == nil || mkey.Account == nil {
+		return getAPIError(c, http.StatusUnauthorized, "Key/Account Not Found", nil)
+	}
+	// Check for expired key
+	if mkey.Key.Expired() {
+		return getAPIError(c, http.StatusUnauthorized, "Expired Key", nil)
+	}
+	// Check for host being allowed.
+	if !mkey.Key.IsAllowed(c.Request().Referer()) {
+		return getAPIError(c, http.StatusUnauthorized, "Host Not Allowed", nil)
+	}
+	// And Event for verification of whether or not we can allow access to this key
+	year := ""
+	if request.Year != nil {
+		year = *request.Year
+	}
+	mult, err := database.GetEventAndYear(request.Slug, year)
+	if err != nil {
+		return getAPIError(c, http.StatusInternalServerError, "Error Retrieving Event/Year", err)
+	}
+	if mult == nil || mult.Event == nil || mult.EventYear == nil {
+		return getAPIError(c, http.StatusNotFound, "Event/Year Not Found", nil)
+	}
+	if mult.Event.AccessRestricted && mkey.Account.Identifier != mult.Event.AccountIdentifier {
+		return getAPIError(c, http.StatusUnauthorized, "Restricted Event", nil)
+	}
+	scoring, err := database.GetHandicapScoring(mult.EventYear.Identifier)
+	if err != nil {
+		return getAPIError(c, http.StatusInternalServerError, "Error Retrieving Handicaps", err)
+	}
+	if scoring == nil {
+		scoring = &types.HandicapScoring{
+			Handicaps: make([]types.Handicap, 0),
+		}
+	}
+	return c.JSON(http.StatusOK, types.GetHandicapScoringResponse{
+		Event:     *mult.Event,
+		EventYear: *mult.EventYear,
+		Scoring:   *scoring,
+	})
+}
+
+func (h Handler) SetHandicapScoring(c *echo.Context) error {
+	// Get Key from Authorization Header
+	k, err := retrieveKey(c.Request())
+	if err != nil {
+		return getAPIError(c, http.StatusUnauthorized, "Error Getting Key From Authorization Header", err)
+	}
+	if k == nil {
+		return getAPIError(c, http.StatusUnauthorized, "Key Not Provided in Authorization Header", nil)
+	}
+	var request types.SetHandicapScoringRequest
+	if err := c.Bind(&request); err != nil {
+		return getAPIError(c, http.StatusBadRequest, "Invalid Request Body", err)
+	}
+	if err := h.validate.Struct(request.Scoring); err != nil {
+		return getAPIError(c, http.StatusBadRequest, "Invalid Handicap Scoring", err)
+	}
+	seen := make(map[string]bool)
+	for _, handicap := range request.Scoring.Handicaps {
+		if seen[handicap.Bib] {
+			return getAPIError(c, http.StatusBadRequest, "Duplicate Handicap", nil)
+		}
+		seen[handicap.Bib] = true
+	}
+	// Get Key
+	mkey, err := database.GetKeyAndAccount(*k)
+	if err != nil {
+		return getAPIError(c, http.StatusInternalServerError, "Error Retrieving Key/Account", err)
+	}
+	if mkey == nil || mkey.Key == nil || mkey.Account == nil {
+		return getAPIError(c, http.StatusUnauthorized, "Key/Account Not Found", nil)
+	}
+	// Check for expired key
+	if mkey.Key.Expired() {
+		return getAPIError(c, http.StatusUnauthorized, "Expired Key", nil)
+	}
+	// Check for host being allowed.
+	if !mkey.Key.IsAllowed(c.Request().Referer()) {
+		return getAPIError(c, http.StatusUnauthorized, "Host Not Allowed", nil)
+	}
+	if mkey.Key.Type == "read" {
+		return getAPIError(c, http.StatusUnauthorized, "Key is ReadOnly", nil)
+	}
+	// And Event for verification of whether or not we can allow access to this key
+	mult, err := database.GetEventAndYear(request.Slug, request.Year)
+	if err != nil {
+		return getAPIError(c, http.StatusInternalServerError, "Error Retrieving Event/Year", err)
+	}
+	if mult == nil || mult.Event == nil || mult.EventYear == nil {
+		return getAPIError(c, http.StatusNotFound, "Event/Year Not Found", nil)
+	}
+	// Check if they own this event.
+	if mult.Event.AccountIdentifier != mkey.Account.Identifier {
+		return getAPIError(c, http.StatusUnauthorized, "Ownership Error", nil)
+	}
+	// Handicaps change the places given, which can't happen until an admin unlocks the event year.
+	if mult.EventYear.Locked() {
+		return getAPIError(c, http.StatusForbidden, "Event Year Is Locked", nil)
+	}
+	scoring, err := database.SetHandicapScoring(mult.EventYear.Identifier, request.Scoring)
+	if err != nil {
+		return getAPIError(c, http.StatusInternalServerError, "Error Setting Handicaps", err)
+	}
+	return c.JSON(http.StatusOK, types.SetHandicapScoringResponse{
+		Scoring: *scoring,
+	})
+}
+
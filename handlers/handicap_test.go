@@ -0,0 +1,269 @@
+/*
+Chronokeep Desktop - Race Scoring Software
+Copyright (C) 2026 James Sentinella
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package handlers
+
+import (
+	"chronokeep/results/types"
+	"encoding/csv"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetHandicapScoring(t *testing.T) {
+	// POST, /handicaps
+	variables, finalize := setupTests(t)
+	defer finalize(t)
+	h := Handler{}
+	h.Setup()
+	year := "2021"
+	request := types.GetHandicapScoringRequest{
+		Slug: variables.events["event2"].Slug,
+		Year: &year,
+	}
+	var resp types.GetHandicapScoringResponse
+	// Test no key
+	t.Log("Testing no key given.")
+	code := jsonTestRequest(t, http.MethodPost, "/handicaps", "", request, h.GetHandicapScoring, &resp)
+	assert.Equal(t, http.StatusUnauthorized, code)
+	// Test expired key
+	t.Log("Testing expired key.")
+	code = jsonTestRequest(t, http.MethodPost, "/handicaps", variables.knownValues["expired"], request, h.GetHandicapScoring, &resp)
+	assert.Equal(t, http.StatusUnauthorized, code)
+	// Test restricted event
+	t.Log("Testing restricted event but unauthorized key.")
+	code = jsonTestRequest(t, http.MethodPost, "/handicaps", variables.knownValues["write"], request, h.GetHandicapScoring, &resp)
+	assert.Equal(t, http.StatusUnauthorized, code)
+	// Test invalid event
+	t.Log("Testing event not found.")
+	code = jsonTestRequest(t, http.MethodPost, "/handicaps", variables.knownValues["read"], types.GetHandicapScoringRequest{Slug: "invalid-event"}, h.GetHandicapScoring, &resp)
+	assert.Equal(t, http.StatusNotFound, code)
+	// Test no handicap scoring
+	t.Log("Testing no handicap scoring.")
+	code = jsonTestRequest(t, http.MethodPost, "/handicaps", variables.knownValues["read"], request, h.GetHandicapScoring, &resp)
+	if assert.Equal(t, http.StatusOK, code) {
+		assert.Equal(t, variables.events["event2"].Slug, resp.Event.Slug)
+		assert.False(t, resp.Scoring.Enabled)
+		assert.Equal(t, 0, len(resp.Scoring.Handicaps))
+	}
+	// Test handicap scoring
+	t.Log("Testing handicap scoring.")
+	scoring := types.HandicapScoring{
+		Enabled:   true,
+		Handicaps: []types.Handicap{{Bib: "100", Seconds: 60}},
+	}
+	_, err := database.SetHandicapScoring(variables.eventYears["event2"]["2021"].Identifier, scoring)
+	assert.NoError(t, err)
+	resp = types.GetHandicapScoringResponse{}
+	code = jsonTestRequest(t, http.MethodPost, "/handicaps", variables.knownValues["read"], request, h.GetHandicapScoring, &resp)
+	if assert.Equal(t, http.StatusOK, code) {
+		assert.Equal(t, scoring, resp.Scoring)
+	}
+}
+
+func TestSetHandicapScoring(t *testing.T) {
+	// POST, /handicaps/set
+	variables, finalize := setupTests(t)
+	defer finalize(t)
+	h := Handler{}
+	h.Setup()
+	request := types.SetHandicapScoringRequest{
+		Slug: variables.events["event1"].Slug,
+		Year: "2021",
+		Scoring: types.HandicapScoring{
+			Enabled: true,
+			Handicaps: []types.Handicap{
+				{Bib: "1", Seconds: 30},
+				{Bib: "0", Seconds: 100, Milliseconds: 500},
+			},
+		},
+	}
+	var resp types.SetHandicapScoringResponse
+	// Test no key
+	t.Log("Testing no key given.")
+	code := jsonTestRequest(t, http.MethodPost, "/handicaps/set", "", request, h.SetHandicapScoring, &resp)
+	assert.Equal(t, http.StatusUnauthorized, code)
+	// Test expired key
+	t.Log("Testing expired key.")
+	code = jsonTestRequest(t, http.MethodPost, "/handicaps/set", variables.knownValues["expired"], request, h.SetHandicapScoring, &resp)
+	assert.Equal(t, http.StatusUnauthorized, code)
+	// Test read key
+	t.Log("Testing read key.")
+	code = jsonTestRequest(t, http.MethodPost, "/handicaps/set", variables.knownValues["read"], request, h.SetHandicapScoring, &resp)
+	assert.Equal(t, http.StatusUnauthorized, code)
+	// Test wrong account
+	t.Log("Testing wrong account.")
+	code = jsonTestRequest(t, http.MethodPost, "/handicaps/set", variables.knownValues["write2"], request, h.SetHandicapScoring, &resp)
+	assert.Equal(t, http.StatusUnauthorized, code)
+	// Test invalid event
+	t.Log("Testing event not found.")
+	code = jsonTestRequest(t, http.MethodPost, "/handicaps/set", variables.knownValues["write"], types.SetHandicapScoringRequest{Slug: "invalid-event", Year: "2021"}, h.SetHandicapScoring, &resp)
+	assert.Equal(t, http.StatusNotFound, code)
+	// Test invalid handicaps
+	t.Log("Testing invalid handicaps.")
+	for _, invalid := range [][]types.Handicap{
+		{{Seconds: 30}},
+		{{Bib: "1", Seconds: -1}},
+		{{Bib: "1", Milliseconds: 1000}},
+		{{Bib: "1", Seconds: 30}, {Bib: "1", Seconds: 60}},
+	} {
+		code = jsonTestRequest(t, http.MethodPost, "/handicaps/set", variables.knownValues["write"], types.SetHandicapScoringRequest{
+			Slug:    request.Slug,
+			Year:    request.Year,
+			Scoring: types.HandicapScoring{Enabled: true, Handicaps: invalid},
+		}, h.SetHandicapScoring, &resp)
+		assert.Equal(t, http.StatusBadRequest, code)
+	}
+	// Test valid request
+	t.Log("Testing valid request.")
+	resp = types.SetHandicapScoringResponse{}
+	code = jsonTestRequest(t, http.MethodPost, "/handicaps/set", variables.knownValues["write"], request, h.SetHandicapScoring, &resp)
+	if assert.Equal(t, http.StatusOK, code) {
+		assert.True(t, resp.Scoring.Enabled)
+		assert.Equal(t, request.Scoring.Handicaps, resp.Scoring.Handicaps)
+	}
+	scoring, err := database.GetHandicapScoring(variables.eventYears["event1"]["2021"].Identifier)
+	if assert.NoError(t, err) && assert.NotNil(t, scoring) {
+		assert.Equal(t, resp.Scoring, *scoring)
+	}
+}
+
+func TestHandicapResults(t *testing.T) {
+	variables, finalize := setupTests(t)
+	defer finalize(t)
+	h := Handler{}
+	h.Setup()
+	year := "2021"
+	request := types.GetResultsRequest{
+		Slug: variables.events["event1"].Slug,
+		Year: &year,
+	}
+	findBib := func(resp types.GetResultsResponse, bib string) *types.Result {
+		for _, results := range resp.Results {
+			for _, res := range results {
+				if res.Bib == bib {
+					return &res
+				}
+			}
+		}
+		return nil
+	}
+	// Without handicap scoring nothing is set.
+	t.Log("Testing without handicap scoring.")
+	var resp types.GetResultsResponse
+	code := jsonTestRequest(t, http.MethodPost, "/results/finish", variables.knownValues["write"], request, h.GetFinishResults, &resp)
+	if assert.Equal(t, http.StatusOK, code) {
+		if res := findBib(resp, "0"); assert.NotNil(t, res) {
+			assert.Equal(t, 0, res.HandicapRanking)
+			assert.Equal(t, 0, res.ActualRanking)
+		}
+	}
+	// Bibs 0, 1 and 2 finish the Marathon five seconds apart, so bib 1 has the best actual time.
+	_, err := database.SetHandicapScoring(variables.eventYears["event1"]["2021"].Identifier, types.HandicapScoring{
+		Enabled: true,
+		Handicaps: []types.Handicap{
+			{Bib: "0", Seconds: 20},
+			{Bib: "1", Seconds: 30},
+		},
+	})
+	assert.NoError(t, err)
+	t.Log("Testing finish results.")
+	resp = types.GetResultsResponse{}
+	code = jsonTestRequest(t, http.MethodPost, "/results/finish", variables.knownValues["write"], request, h.GetFinishResults, &resp)
+	if assert.Equal(t, http.StatusOK, code) {
+		if res := findBib(resp, "0"); assert.NotNil(t, res) {
+			assert.Equal(t, 20, res.HandicapSeconds)
+			assert.Equal(t, 1, res.HandicapRanking)
+			assert.Equal(t, res.Seconds-20, res.ActualSeconds)
+			assert.Equal(t, 2, res.ActualRanking)
+		}
+		if res := findBib(resp, "1"); assert.NotNil(t, res) {
+			assert.Equal(t, 2, res.HandicapRanking)
+			assert.Equal(t, 1, res.ActualRanking)
+		}
+		if res := findBib(resp, "2"); assert.NotNil(t, res) {
+			assert.Equal(t, 0, res.HandicapSeconds)
+			assert.Equal(t, 3, res.HandicapRanking)
+			assert.Equal(t, res.Seconds, res.ActualSeconds)
+			assert.Equal(t, 3, res.ActualRanking)
+		}
+	}
+	// A single page is still ranked against every finish.
+	t.Log("Testing a single page.")
+	limit := 1
+	page := 2
+	resp = types.GetResultsResponse{}
+	code = jsonTestRequest(t, http.MethodPost, "/results/finish", variables.knownValues["write"], types.GetResultsRequest{
+		Slug:  request.Slug,
+		Year:  &year,
+		Limit: &limit,
+		Page:  &page,
+	}, h.GetFinishResults, &resp)
+	if assert.Equal(t, http.StatusOK, code) {
+		for _, results := range resp.Results {
+			for _, res := range results {
+				assert.NotEqual(t, 0, res.HandicapRanking)
+				assert.NotEqual(t, 0, res.ActualRanking)
+			}
+		}
+	}
+	// Exports include the handicap columns.
+	t.Log("Testing export.")
+	body, err := json.Marshal(types.ExportResultsRequest{
+		Slug: request.Slug,
+		Year: &year,
+	})
+	if err != nil {
+		t.Fatalf("Error encoding request body into json object: %v", err)
+	}
+	req := httptest.NewRequest(http.MethodPost, "/results/export", strings.NewReader(string(body)))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	req.Header.Set(echo.HeaderAuthorization, "Bearer "+variables.knownValues["write"])
+	response := httptest.NewRecorder()
+	c := echo.New().NewContext(req, response)
+	if assert.NoError(t, h.ExportResults(c)) && assert.Equal(t, http.StatusOK, response.Code) {
+		rows, err := csv.NewReader(response.Body).ReadAll()
+		if assert.NoError(t, err) && assert.NotEqual(t, 0, len(rows)) {
+			header := rows[0]
+			bibCol := slices.Index(header, "Bib")
+			finishCol := slices.Index(header, "Finish")
+			rankingCol := slices.Index(header, "Actual Ranking")
+			if assert.NotEqual(t, -1, slices.Index(header, "Handicap")) &&
+				assert.NotEqual(t, -1, slices.Index(header, "Handicap Ranking")) &&
+				assert.NotEqual(t, -1, slices.Index(header, "Actual Time")) &&
+				assert.NotEqual(t, -1, rankingCol) {
+				found := false
+				for _, row := range rows[1:] {
+					if row[bibCol] == "1" && row[finishCol] == "true" {
+						found = true
+						assert.Equal(t, "1", row[rankingCol])
+					}
+				}
+				assert.True(t, found)
+			}
+		}
+	}
+}
+
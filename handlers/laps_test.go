@@ -0,0 +1,160 @@
+/*
+Chronokeep Desktop - Race Scoring Software
+Copyright (C) 2026 James Sentinella
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package handlers
+
+import (
+	"chronokeep/results/types"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetLapStandings(t *testing.T) {
+	// POST, /results/laps
+	variables, finalize := setupTests(t)
+	defer finalize(t)
+	h := Handler{}
+	h.Setup()
+	event, err := database.AddEvent(types.Event{
+		AccountIdentifier: variables.accounts[0].Identifier,
+		Name:              "Six Hour",
+		Slug:              "six-hour",
+		ContactEmail:      "sixhour@test.com",
+		Type:              "time",
+	})
+	if err != nil {
+		t.Fatalf("Error adding event: %v", err)
+	}
+	eventYear, err := database.AddEventYear(types.EventYear{
+		EventIdentifier: event.Identifier,
+		Year:            "2021",
+		DateTime:        time.Date(2021, 10, 06, 9, 0, 0, 0, time.Local),
+		DaysAllowed:     3,
+		RankingType:     "gun",
+	})
+	if err != nil {
+		t.Fatalf("Error adding event year: %v", err)
+	}
+	_, err = database.AddDistances(eventYear.Identifier, []types.Distance{
+		{
+			Name:          "6 Hour",
+			Certification: "None",
+			DistanceValue: 400,
+			DistanceUnit:  "meters",
+		},
+	})
+	if err != nil {
+		t.Fatalf("Error adding distances: %v", err)
+	}
+	laps := make([]types.Result, 0)
+	for _, runner := range []struct {
+		bib        string
+		laps       int
+		lapSeconds int
+	}{
+		{bib: "1", laps: 4, lapSeconds: 120},
+		{bib: "2", laps: 5, lapSeconds: 130},
+		{bib: "3", laps: 4, lapSeconds: 110},
+	} {
+		for lap := 1; lap <= runner.laps; lap++ {
+			laps = append(laps, types.Result{
+				PersonId:  runner.bib,
+				Bib:       runner.bib,
+				First:     "Runner",
+				Last:      runner.bib,
+				Gender:    "Woman",
+				AgeGroup:  "30-39",
+				Distance:  "6 Hour",
+				Seconds:   lap * runner.lapSeconds,
+				Location:  "Start/Finish",
+				Occurence: lap,
+			})
+		}
+	}
+	if _, err := database.AddResults(eventYear.Identifier, laps, nil); err != nil {
+		t.Fatalf("Error adding results: %v", err)
+	}
+	year := "2021"
+	request := types.GetResultsRequest{
+		Slug: "six-hour",
+		Year: &year,
+	}
+	var resp types.GetLapStandingsResponse
+	// Test no key
+	t.Log("Testing no key given.")
+	code := jsonTestRequest(t, http.MethodPost, "/results/laps", "", request, h.GetLapStandings, &resp)
+	assert.Equal(t, http.StatusUnauthorized, code)
+	// Test expired key
+	t.Log("Testing expired key.")
+	code = jsonTestRequest(t, http.MethodPost, "/results/laps", variables.knownValues["expired"], request, h.GetLapStandings, &resp)
+	assert.Equal(t, http.StatusUnauthorized, code)
+	// Test invalid host
+	t.Log("Testing invalid host.")
+	code = jsonTestRequest(t, http.MethodPost, "/results/laps", variables.knownValues["delete"], request, h.GetLapStandings, &resp)
+	assert.Equal(t, http.StatusUnauthorized, code)
+	// Test event year not found
+	t.Log("Testing event year not found.")
+	notFound := request
+	notFound.Slug = "not-an-event"
+	code = jsonTestRequest(t, http.MethodPost, "/results/laps", variables.knownValues["read"], notFound, h.GetLapStandings, &resp)
+	assert.Equal(t, http.StatusNotFound, code)
+	// Test restricted event
+	t.Log("Testing restricted event.")
+	restricted := request
+	restricted.Slug = "event2"
+	code = jsonTestRequest(t, http.MethodPost, "/results/laps", variables.knownValues["write"], restricted, h.GetLapStandings, &resp)
+	assert.Equal(t, http.StatusUnauthorized, code)
+	// Test event that isn't a timed event
+	t.Log("Testing event that isn't a timed event.")
+	distanceEvent := request
+	distanceEvent.Slug = "event1"
+	code = jsonTestRequest(t, http.MethodPost, "/results/laps", variables.knownValues["read"], distanceEvent, h.GetLapStandings, &resp)
+	assert.Equal(t, http.StatusBadRequest, code)
+	// Test valid request
+	t.Log("Testing valid request.")
+	code = jsonTestRequest(t, http.MethodPost, "/results/laps", variables.knownValues["read"], request, h.GetLapStandings, &resp)
+	if assert.Equal(t, http.StatusOK, code) && assert.Equal(t, 3, len(resp.Standings["6 Hour"])) {
+		standings := resp.Standings["6 Hour"]
+		assert.Equal(t, "2", standings[0].Bib)
+		assert.Equal(t, 1, standings[0].Ranking)
+		assert.Equal(t, 5, standings[0].Laps)
+		assert.Equal(t, 2000.0, standings[0].DistanceValue)
+		assert.Equal(t, "meters", standings[0].DistanceUnit)
+		assert.Equal(t, 650, standings[0].Seconds)
+		assert.Equal(t, 5, len(standings[0].LapTimes))
+		assert.Equal(t, "3", standings[1].Bib)
+		assert.Equal(t, 2, standings[1].Ranking)
+		assert.Equal(t, 110, standings[1].BestLapSeconds)
+		assert.Equal(t, "1", standings[2].Bib)
+		assert.Equal(t, 3, standings[2].Ranking)
+		assert.Equal(t, 120, standings[2].AverageLapSeconds)
+	}
+	// Test distance
+	t.Log("Testing distance.")
+	resp = types.GetLapStandingsResponse{}
+	distance := "Not A Distance"
+	request.Distance = &distance
+	code = jsonTestRequest(t, http.MethodPost, "/results/laps", variables.knownValues["read"], request, h.GetLapStandings, &resp)
+	if assert.Equal(t, http.StatusOK, code) {
+		assert.Equal(t, 0, len(resp.Standings))
+	}
+}
+
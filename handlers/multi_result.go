@@ -19,6 +19,7 @@ along with this program.  If not, see <https://www.gnu.org/licenses/>.
 package handlers
 
 import (
+	db "chronokeep/results/database"
 	"chronokeep/results/types"
 	"net/http"
 
@@ -69,6 +70,18 @@ func (h Handler) GetMultiResults(c *echo.Context) error {
 	if event.AccessRestricted && mkey.Account.Identifier != event.AccountIdentifier {
 		return getAPIError(c, http.StatusUnauthorized, "Restricted Event", nil)
 	}
+	var records []types.Record
+	if keepsRecords(event) {
+		records, err = database.GetRecords(event.Identifier)
+		if err != nil {
+			return getAPIError(c, http.StatusInternalServerError, "Error Retrieving Records", err)
+		}
+		records, err = publishedRecords(event, mkey.Account, records)
+		if err != nil {
+			return getAPIError(c, http.StatusInternalServerError, "Error Retrieving Publish Settings", err)
+		}
+		records = db.BestRecords(records)
+	}
 	outRes := make(map[string]map[string][]types.Result)
 	for _, year := range request.Years {
 		eYear, err := database.GetEventYear(event.Slug, year)
@@ -83,6 +96,19 @@ func (h Handler) GetMultiResults(c *echo.Context) error {
 			if err != nil {
 				return getAPIError(c, http.StatusInternalServerError, "Error Retrieving Results", err)
 			}
+			results, err = publishedResults(event, eYear, mkey.Account, results)
+			if err != nil {
+				return getAPIError(c, http.StatusInternalServerError, "Error Retrieving Publish Settings", err)
+			}
+			db.FlagRecords(results, *eYear, records)
+			err = ageGradeResults(event, eYear, results)
+			if err != nil {
+				return getAPIError(c, http.StatusInternalServerError, "Error Retrieving Distances", err)
+			}
+			_, err = handicapResults(event, eYear, mkey.Account, results)
+			if err != nil {
+				return getAPIError(c, http.StatusInternalServerError, "Error Retrieving Handicaps", err)
+			}
 			for _, res := range results {
 				if _, ok := outRes[year][res.Distance]; !ok {
 					outRes[year][res.Distance] = make([]types.Result, 0, 1)
@@ -20,6 +20,7 @@ package handlers
 
 import (
 	"chronokeep/results/types"
+	"chronokeep/results/util"
 	"errors"
 	"net/http"
 	"time"
@@ -148,18 +149,48 @@ func (h Handler) AddParticipants(c *echo.Context) error {
 	if mkey.Account.Identifier != multi.Event.AccountIdentifier {
 		return getAPIError(c, http.StatusUnauthorized, "Restricted Event", nil)
 	}
+	// Official results can't be changed until an admin unlocks the event year.
+	if multi.EventYear.Locked() {
+		return getAPIError(c, http.StatusForbidden, "Event Year Is Locked", nil)
+	}
+	existing, err := database.GetParticipants(multi.EventYear.Identifier, 0, 0, nil)
+	if err != nil {
+		return getAPIError(c, http.StatusInternalServerError, "Error Retrieving Participants", err)
+	}
+	known := make(map[string]bool)
+	for _, part := range existing {
+		known[part.AlternateId] = true
+	}
 	// validate participants
 	var partToAdd []types.Participant
+	outcomes := make([]types.ItemOutcome, len(request.Participants))
+	rejected := false
 	updatedAt := time.Now().UTC().Unix()
-	for _, part := range request.Participants {
+	for ix, part := range request.Participants {
 		// Validate all results, only add the results that pass validation.
-		if err := part.Validate(h.validate); err == nil {
-			part.UpdatedAt = updatedAt
-			partToAdd = append(partToAdd, part)
+		if err := part.Validate(h.validate); err != nil {
+			outcomes[ix] = rejectedOutcome(ix, part, err)
+			rejected = true
+			continue
 		}
-	}
-	if len(partToAdd) < 1 {
-		return getAPIError(c, http.StatusBadRequest, "No Valid Participants", nil)
+		outcomes[ix] = types.ItemOutcome{
+			Index:  ix,
+			Status: util.ITEM_STATUS_INSERTED,
+		}
+		if known[part.AlternateId] {
+			outcomes[ix].Status = util.ITEM_STATUS_UPDATED
+		}
+		known[part.AlternateId] = true
+		part.UpdatedAt = updatedAt
+		partToAdd = append(partToAdd, part)
+	}
+	if len(partToAdd) < 1 || (request.AllOrNothing && rejected) {
+		skipOutcomes(outcomes)
+		return c.JSON(http.StatusBadRequest, types.AddParticipantsResponse{
+			Count:    0,
+			Updated:  make([]types.Participant, 0),
+			Outcomes: outcomes,
+		})
 	}
 	participants, err := database.AddParticipants(multi.EventYear.Identifier, partToAdd)
 	if err != nil {
@@ -173,8 +204,9 @@ func (h Handler) AddParticipants(c *echo.Context) error {
 		}
 	}
 	return c.JSON(http.StatusOK, types.AddParticipantsResponse{
-		Count:   len(participants),
-		Updated: updated,
+		Count:    len(participants),
+		Updated:  updated,
+		Outcomes: outcomes,
 	})
 }
 
@@ -225,6 +257,10 @@ func (h Handler) DeleteParticipants(c *echo.Context) error {
 	if mkey.Account.Identifier != mult.Event.AccountIdentifier {
 		return getAPIError(c, http.StatusUnauthorized, "Restricted Event", nil)
 	}
+	// Official results can't be changed until an admin unlocks the event year.
+	if mult.EventYear.Locked() {
+		return getAPIError(c, http.StatusForbidden, "Event Year Is Locked", nil)
+	}
 	count, err := database.DeleteParticipants(mult.EventYear.Identifier, request.Identifiers)
 	if err != nil {
 		return getAPIError(c, http.StatusInternalServerError, "Error Deleting Participants", err)
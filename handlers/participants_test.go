@@ -20,6 +20,7 @@ package handlers
 
 import (
 	"chronokeep/results/types"
+	"chronokeep/results/util"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
@@ -812,6 +813,93 @@ func TestAddParticipants(t *testing.T) {
 	if assert.NoError(t, h.AddParticipants(c)) {
 		assert.Equal(t, http.StatusBadRequest, response.Code)
 	}
+	// outcomes for each participant
+	existing := types.Participant{
+		AlternateId: "1011111",
+		Bib:         "10",
+		Birthdate:   "1/1/2004",
+		AgeGroup:    "10-20",
+		First:       "John",
+		Last:        "Jacob",
+		Distance:    "1 Mile",
+		Gender:      "Man",
+	}
+	added := existing
+	added.AlternateId = "1011112"
+	added.Bib = "11"
+	invalid := existing
+	invalid.AlternateId = "1011113"
+	invalid.Birthdate = "1/1/3050"
+	body, err = json.Marshal(types.AddParticipantsRequest{
+		Slug:         variables.events["event2"].Slug,
+		Year:         year.Year,
+		Participants: []types.Participant{existing, added, invalid},
+	})
+	if err != nil {
+		t.Fatalf("Error encoding request body into json object: %v", err)
+	}
+	t.Log("Testing participant outcomes.")
+	request = httptest.NewRequest(http.MethodPost, "/participants/add", strings.NewReader(string(body)))
+	request.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	request.Header.Set(echo.HeaderAuthorization, "Bearer "+variables.knownValues["delete2"])
+	response = httptest.NewRecorder()
+	c = e.NewContext(request, response)
+	if assert.NoError(t, h.AddParticipants(c)) {
+		assert.Equal(t, http.StatusOK, response.Code)
+		var resp types.AddParticipantsResponse
+		if assert.NoError(t, json.Unmarshal(response.Body.Bytes(), &resp)) {
+			assert.Equal(t, 2, resp.Count)
+			if assert.Equal(t, 3, len(resp.Outcomes)) {
+				assert.Equal(t, util.ITEM_STATUS_UPDATED, resp.Outcomes[0].Status)
+				assert.Equal(t, util.ITEM_STATUS_INSERTED, resp.Outcomes[1].Status)
+				assert.Equal(t, 2, resp.Outcomes[2].Index)
+				assert.Equal(t, util.ITEM_STATUS_REJECTED, resp.Outcomes[2].Status)
+				assert.Equal(t, "birthdate", resp.Outcomes[2].Field)
+				assert.Equal(t, "invalid birthdate", resp.Outcomes[2].Reason)
+			}
+		}
+	}
+	// all or nothing
+	added.AlternateId = "1011114"
+	invalid.Birthdate = "1/1/2004"
+	invalid.Distance = ""
+	body, err = json.Marshal(types.AddParticipantsRequest{
+		Slug:         variables.events["event2"].Slug,
+		Year:         year.Year,
+		Participants: []types.Participant{added, invalid},
+		AllOrNothing: true,
+	})
+	if err != nil {
+		t.Fatalf("Error encoding request body into json object: %v", err)
+	}
+	t.Log("Testing all or nothing.")
+	request = httptest.NewRequest(http.MethodPost, "/participants/add", strings.NewReader(string(body)))
+	request.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	request.Header.Set(echo.HeaderAuthorization, "Bearer "+variables.knownValues["delete2"])
+	response = httptest.NewRecorder()
+	c = e.NewContext(request, response)
+	if assert.NoError(t, h.AddParticipants(c)) {
+		assert.Equal(t, http.StatusBadRequest, response.Code)
+		var resp types.AddParticipantsResponse
+		if assert.NoError(t, json.Unmarshal(response.Body.Bytes(), &resp)) {
+			assert.Equal(t, 0, resp.Count)
+			if assert.Equal(t, 2, len(resp.Outcomes)) {
+				assert.Equal(t, util.ITEM_STATUS_SKIPPED, resp.Outcomes[0].Status)
+				assert.Equal(t, util.ITEM_STATUS_REJECTED, resp.Outcomes[1].Status)
+				assert.Equal(t, "distance", resp.Outcomes[1].Field)
+				assert.Equal(t, "distance is required", resp.Outcomes[1].Reason)
+			}
+		}
+		multi, err := database.GetEventAndYear(variables.events["event2"].Slug, year.Year)
+		if assert.NoError(t, err) && assert.NotNil(t, multi) {
+			parts, err := database.GetParticipants(multi.EventYear.Identifier, 0, 0, nil)
+			if assert.NoError(t, err) {
+				for _, part := range parts {
+					assert.NotEqual(t, "1011114", part.AlternateId)
+				}
+			}
+		}
+	}
 }
 
 func TestDeleteParticipants(t *testing.T) {
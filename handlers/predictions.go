@@ -0,0 +1,126 @@
+/*
+Chronokeep Desktop - Race Scoring Software
+Copyright (C) 2026 James Sentinella
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package handlers
+
+import (
+	db "chronokeep/results/database"
+	"chronokeep/results/types"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v5"
+)
+
+// Number of upcoming arrivals returned when no limit is given.  Overdue arrivals aren't limited.
+const defaultPredictionLimit = 20
+
+func (h Handler) GetPredictions(c *echo.Context) error {
+	// Get Key from Authorization Header
+	k, err := retrieveKey(c.Request())
+	if err != nil {
+		return getAPIError(c, http.StatusUnauthorized, "Error Getting Key From Authorization Header", err)
+	}
+	if k == nil {
+		return getAPIError(c, http.StatusUnauthorized, "Key Not Provided in Authorization Header", nil)
+	}
+	var request types.GetPredictionsRequest
+	if err := c.Bind(&request); err != nil {
+		return getAPIError(c, http.StatusBadRequest, "Invalid Request Body", err)
+	}
+	// Get Key
+	mkey, err := database.GetKeyAndAccount(*k)
+	if err != nil {
+		return getAPIError(c, http.StatusInternalServerError, "Error Retrieving Key/Account", err)
+	}
+	if mkey == nil || mkey.Key == nil || mkey.Account == nil {
+		return getAPIError(c, http.StatusUnauthorized, "Key/Account Not Found", nil)
+	}
+	// Check for expired key
+	if mkey.Key.Expired() {
+		return getAPIError(c, http.StatusUnauthorized, "Expired Key", nil)
+	}
+	// Check for host being allowed.
+	if !mkey.Key.IsAllowed(c.Request().Referer()) {
+		return getAPIError(c, http.StatusUnauthorized, "Host Not Allowed", nil)
+	}
+	// And Event for verification of whether or not we can allow access to this key
+	year := ""
+	if request.Year != nil {
+		year = *request.Year
+	}
+	mult, err := database.GetEventAndYear(request.Slug, year)
+	if err != nil {
+		return getAPIError(c, http.StatusInternalServerError, "Error Retrieving Event/Year", err)
+	}
+	if mult == nil || mult.Event == nil || mult.EventYear == nil {
+		return getAPIError(c, http.StatusNotFound, "Event/Year Not Found", nil)
+	}
+	if mult.Event.AccessRestricted && mkey.Account.Identifier != mult.Event.AccountIdentifier {
+		return getAPIError(c, http.StatusUnauthorized, "Restricted Event", nil)
+	}
+	segment := ""
+	if request.Segment != nil {
+		segment = *request.Segment
+	}
+	limit := defaultPredictionLimit
+	if request.Limit != nil && *request.Limit > 0 {
+		limit = *request.Limit
+	}
+	results, err := database.GetResults(mult.EventYear.Identifier, 0, 0)
+	if err != nil {
+		return getAPIError(c, http.StatusInternalServerError, "Error Retrieving Results", err)
+	}
+	results, err = publishedResults(mult.Event, mult.EventYear, mkey.Account, results)
+	if err != nil {
+		return getAPIError(c, http.StatusInternalServerError, "Error Retrieving Publish Settings", err)
+	}
+	segments, err := database.GetSegments(mult.EventYear.Identifier)
+	if err != nil {
+		return getAPIError(c, http.StatusInternalServerError, "Error Fetching Segments", err)
+	}
+	distances, err := database.GetDistances(mult.EventYear.Identifier)
+	if err != nil {
+		return getAPIError(c, http.StatusInternalServerError, "Error Fetching Distances", err)
+	}
+	waves, err := database.GetWaves(mult.EventYear.Identifier)
+	if err != nil {
+		return getAPIError(c, http.StatusInternalServerError, "Error Retrieving Waves", err)
+	}
+	predictions, overdue := db.PredictArrivals(
+		results,
+		segments,
+		distances,
+		waves,
+		segment,
+		mult.EventYear.DateTime,
+		time.Now(),
+	)
+	// The limit is only for upcoming arrivals.
+	if len(predictions) > limit {
+		predictions = predictions[:limit]
+	}
+	return c.JSON(http.StatusOK, types.GetPredictionsResponse{
+		Event:       *mult.Event,
+		EventYear:   *mult.EventYear,
+		Segment:     segment,
+		Predictions: predictions,
+		Overdue:     overdue,
+	})
+}
+
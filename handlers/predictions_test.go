@@ -0,0 +1,162 @@
+/*
+Chronokeep Desktop - Race Scoring Software
+Copyright (C) 2026 James Sentinella
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package handlers
+
+import (
+	"chronokeep/results/types"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetPredictions(t *testing.T) {
+	// POST, /results/predictions
+	variables, finalize := setupTests(t)
+	defer finalize(t)
+	h := Handler{}
+	h.Setup()
+	eventYear := variables.eventYears["event1"]["2021"]
+	eventYear.DateTime = time.Now().Add(-time.Hour).Truncate(time.Second)
+	if err := database.UpdateEventYear(eventYear); err != nil {
+		t.Fatalf("Error updating event year: %v", err)
+	}
+	split := func(bib, location string, seconds int) types.Result {
+		return types.Result{
+			PersonId:  bib,
+			Bib:       bib,
+			First:     "Runner",
+			Last:      bib,
+			Gender:    "Woman",
+			AgeGroup:  "20-29",
+			Distance:  "Marathon",
+			Seconds:   seconds,
+			Location:  location,
+			Occurence: 1,
+		}
+	}
+	_, err := database.AddResults(eventYear.Identifier, []types.Result{
+		split("P1", "7 Mile", 3000),
+		// Expected at 21 miles half an hour ago.
+		split("P2", "7 Mile", 600),
+		split("P3", "Half Marathon", 5000),
+	}, nil)
+	if err != nil {
+		t.Fatalf("Error adding results: %v", err)
+	}
+	year := "2021"
+	segment := "21 Miles"
+	request := types.GetPredictionsRequest{
+		Slug:    "event1",
+		Year:    &year,
+		Segment: &segment,
+	}
+	var resp types.GetPredictionsResponse
+	// Test no key
+	t.Log("Testing no key given.")
+	code := jsonTestRequest(t, http.MethodPost, "/results/predictions", "", request, h.GetPredictions, &resp)
+	assert.Equal(t, http.StatusUnauthorized, code)
+	// Test expired key
+	t.Log("Testing expired key.")
+	code = jsonTestRequest(t, http.MethodPost, "/results/predictions", variables.knownValues["expired"], request, h.GetPredictions, &resp)
+	assert.Equal(t, http.StatusUnauthorized, code)
+	// Test invalid host
+	t.Log("Testing invalid host.")
+	code = jsonTestRequest(t, http.MethodPost, "/results/predictions", variables.knownValues["delete"], request, h.GetPredictions, &resp)
+	assert.Equal(t, http.StatusUnauthorized, code)
+	// Test event year not found
+	t.Log("Testing event year not found.")
+	notFound := request
+	notFound.Slug = "not-an-event"
+	code = jsonTestRequest(t, http.MethodPost, "/results/predictions", variables.knownValues["read"], notFound, h.GetPredictions, &resp)
+	assert.Equal(t, http.StatusNotFound, code)
+	// Test restricted event
+	t.Log("Testing restricted event.")
+	restricted := request
+	restricted.Slug = "event2"
+	code = jsonTestRequest(t, http.MethodPost, "/results/predictions", variables.knownValues["write"], restricted, h.GetPredictions, &resp)
+	assert.Equal(t, http.StatusUnauthorized, code)
+	// Test valid request
+	t.Log("Testing valid request.")
+	code = jsonTestRequest(t, http.MethodPost, "/results/predictions", variables.knownValues["read"], request, h.GetPredictions, &resp)
+	if assert.Equal(t, http.StatusOK, code) {
+		assert.Equal(t, segment, resp.Segment)
+		if assert.Equal(t, 1, len(resp.Overdue)) {
+			assert.Equal(t, "P2", resp.Overdue[0].Bib)
+			assert.True(t, resp.Overdue[0].Overdue)
+		}
+		if assert.Equal(t, 2, len(resp.Predictions)) {
+			assert.Equal(t, "P3", resp.Predictions[0].Bib)
+			assert.Equal(t, "13.1 Miles", resp.Predictions[0].LastSeen)
+			assert.False(t, resp.Predictions[0].Overdue)
+			assert.Equal(t, "P1", resp.Predictions[1].Bib)
+			assert.Equal(t, 9000, resp.Predictions[1].Seconds)
+			assert.Equal(t, "miles", resp.Predictions[1].PaceUnit)
+			assert.False(t, resp.Predictions[1].Overdue)
+		}
+	}
+	// Test a wave start
+	t.Log("Testing wave start.")
+	mult, err := database.GetEventAndYear("event1", year)
+	if err != nil || mult == nil || mult.EventYear == nil {
+		t.Fatalf("Error retrieving event year: %v", err)
+	}
+	_, err = database.SetWaves(eventYear.Identifier, []types.Wave{
+		{
+			Distance:  "Marathon",
+			Name:      "Wave 2",
+			StartTime: mult.EventYear.DateTime.Add(time.Minute * 10),
+			Bibs:      []string{"P1"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Error setting waves: %v", err)
+	}
+	resp = types.GetPredictionsResponse{}
+	code = jsonTestRequest(t, http.MethodPost, "/results/predictions", variables.knownValues["read"], request, h.GetPredictions, &resp)
+	if assert.Equal(t, http.StatusOK, code) && assert.Equal(t, 2, len(resp.Predictions)) {
+		assert.Equal(t, "P1", resp.Predictions[0].Bib)
+		assert.Equal(t, 7800, resp.Predictions[0].Seconds)
+	}
+	if _, err = database.SetWaves(eventYear.Identifier, nil); err != nil {
+		t.Fatalf("Error removing waves: %v", err)
+	}
+	// Test limit
+	t.Log("Testing limit.")
+	resp = types.GetPredictionsResponse{}
+	limit := 1
+	request.Limit = &limit
+	code = jsonTestRequest(t, http.MethodPost, "/results/predictions", variables.knownValues["read"], request, h.GetPredictions, &resp)
+	if assert.Equal(t, http.StatusOK, code) && assert.Equal(t, 1, len(resp.Predictions)) {
+		// Overdue arrivals don't take up the limit.
+		assert.Equal(t, "P3", resp.Predictions[0].Bib)
+		assert.Equal(t, 1, len(resp.Overdue))
+	}
+	// Test finish of a distance without a length
+	t.Log("Testing finish of a distance without a length.")
+	resp = types.GetPredictionsResponse{}
+	request.Segment = nil
+	code = jsonTestRequest(t, http.MethodPost, "/results/predictions", variables.knownValues["read"], request, h.GetPredictions, &resp)
+	if assert.Equal(t, http.StatusOK, code) {
+		assert.Equal(t, 0, len(resp.Predictions))
+		assert.Equal(t, 0, len(resp.Overdue))
+	}
+}
+
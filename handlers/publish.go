@@ -0,0 +1,246 @@
+/*
+Chronokeep Desktop - Race Scoring Software
+Copyright (C) 2026 James Sentinella
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package handlers
+
+import (
+	"chronokeep/results/types"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v5"
+)
+
+// publishSettings Returns the publish settings limiting which results of an event year the account
+// can see, or nil when they can see all of them.  The owner of the event can always see every result
+// so they can check them before they're published.
+func publishSettings(ownerID, eventYearID int64, account *types.Account) (*types.PublishSettings, error) {
+	if ownerID == account.Identifier {
+		return nil, nil
+	}
+	return database.GetPublishSettings(eventYearID)
+}
+
+// publishedResults Removes the results the account isn't allowed to see yet.
+func publishedResults(event *types.Event, year *types.EventYear, account *types.Account, results []types.Result) ([]types.Result, error) {
+	return publishedYearResults(event.AccountIdentifier, year.Identifier, account, results)
+}
+
+// publishedYearResults Removes the results of an event year owned by the account with the owner
+// id given that the account isn't allowed to see yet.
+func publishedYearResults(ownerID, eventYearID int64, account *types.Account, results []types.Result) ([]types.Result, error) {
+	settings, err := publishSettings(ownerID, eventYearID, account)
+	if err != nil {
+		return nil, err
+	}
+	if settings == nil {
+		return results, nil
+	}
+	return settings.Visible(results, time.Now()), nil
+}
+
+// publishedParticipants Removes the participants in distances the account isn't allowed to see
+// the results of yet, or every participant if none of the results can be seen.
+func publishedParticipants(event *types.Event, year *types.EventYear, account *types.Account, participants []types.Participant) ([]types.Participant, error) {
+	settings, err := publishSettings(event.AccountIdentifier, year.Identifier, account)
+	if err != nil {
+		return nil, err
+	}
+	if settings == nil {
+		return participants, nil
+	}
+	output := make([]types.Participant, 0, len(participants))
+	if !settings.Published(time.Now()) {
+		return output, nil
+	}
+	for _, part := range participants {
+		if !settings.DistanceHidden(part.Distance) {
+			output = append(output, part)
+		}
+	}
+	return output, nil
+}
+
+// resultsEmbargoed Returns true if the account can't see any of the results of the event year yet.
+func resultsEmbargoed(event *types.Event, year *types.EventYear, account *types.Account) (bool, error) {
+	settings, err := publishSettings(event.AccountIdentifier, year.Identifier, account)
+	if err != nil {
+		return false, err
+	}
+	return settings != nil && !settings.Published(time.Now()), nil
+}
+
+// yearPublishSettings Returns the publish settings of each year of an event that limit what the
+// account can see, keyed by the year.  Years without settings aren't included.
+func yearPublishSettings(event *types.Event, account *types.Account) (map[string]*types.PublishSettings, error) {
+	output := make(map[string]*types.PublishSettings)
+	if event.AccountIdentifier == account.Identifier {
+		return output, nil
+	}
+	years, err := database.GetEventYears(event.Slug)
+	if err != nil {
+		return nil, err
+	}
+	for _, year := range years {
+		settings, err := database.GetPublishSettings(year.Identifier)
+		if err != nil {
+			return nil, err
+		}
+		if settings != nil {
+			output[year.Year] = settings
+		}
+	}
+	return output, nil
+}
+
+// publishedRecords Removes the records set in event years or distances the account isn't allowed
+// to see the results of yet.
+func publishedRecords(event *types.Event, account *types.Account, records []types.Record) ([]types.Record, error) {
+	if len(records) < 1 {
+		return records, nil
+	}
+	settings, err := yearPublishSettings(event, account)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	output := make([]types.Record, 0, len(records))
+	for _, rec := range records {
+		if set, ok := settings[rec.Year]; !ok || (set.Published(now) && !set.DistanceHidden(rec.Distance)) {
+			output = append(output, rec)
+		}
+	}
+	return output, nil
+}
+
+func (h Handler) GetPublishSettings(c *echo.Context) error {
+	// Get Key from Authorization Header
+	k, err := retrieveKey(c.Request())
+	if err != nil {
+		return getAPIError(c, http.StatusUnauthorized, "Error Getting Key From Authorization Header", err)
+	}
+	if k == nil {
+		return getAPIError(c, http.StatusUnauthorized, "Key Not Provided in Authorization Header", nil)
+	}
+	var request types.GetPublishSettingsRequest
+	if err := c.Bind(&request); err != nil {
+		return getAPIError(c, http.StatusBadRequest, "Invalid Request Body", err)
+	}
+	// Get Key
+	mkey, err := database.GetKeyAndAccount(*k)
+	if err != nil {
+		return getAPIError(c, http.StatusInternalServerError, "Error Retrieving Key/Account", err)
+	}
+	if mkey == nil || mkey.Key == nil || mkey.Account == nil {
+		return getAPIError(c, http.StatusUnauthorized, "Key/Account Not Found", nil)
+	}
+	// Check for expired key
+	if mkey.Key.Expired() {
+		return getAPIError(c, http.StatusUnauthorized, "Expired Key", nil)
+	}
+	// Check for host being allowed.
+	if !mkey.Key.IsAllowed(c.Request().Referer()) {
+		return getAPIError(c, http.StatusUnauthorized, "Host Not Allowed", nil)
+	}
+	year := ""
+	if request.Year != nil {
+		year = *request.Year
+	}
+	mult, err := database.GetEventAndYear(request.Slug, year)
+	if err != nil {
+		return getAPIError(c, http.StatusInternalServerError, "Error Retrieving Event/Year", err)
+	}
+	if mult == nil || mult.Event == nil || mult.EventYear == nil {
+		return getAPIError(c, http.StatusNotFound, "Event/Year Not Found", nil)
+	}
+	// Check if they own this event.
+	if mult.Event.AccountIdentifier != mkey.Account.Identifier {
+		return getAPIError(c, http.StatusUnauthorized, "Ownership Error", nil)
+	}
+	settings, err := database.GetPublishSettings(mult.EventYear.Identifier)
+	if err != nil {
+		return getAPIError(c, http.StatusInternalServerError, "Error Retrieving Publish Settings", err)
+	}
+	if settings == nil {
+		settings = &types.PublishSettings{
+			HiddenDistances: make([]string, 0),
+		}
+	}
+	return c.JSON(http.StatusOK, types.PublishSettingsResponse{
+		Settings: *settings,
+	})
+}
+
+func (h Handler) SetPublishSettings(c *echo.Context) error {
+	// Get Key from Authorization Header
+	k, err := retrieveKey(c.Request())
+	if err != nil {
+		return getAPIError(c, http.StatusUnauthorized, "Error Getting Key From Authorization Header", err)
+	}
+	if k == nil {
+		return getAPIError(c, http.StatusUnauthorized, "Key Not Provided in Authorization Header", nil)
+	}
+	var request types.SetPublishSettingsRequest
+	if err := c.Bind(&request); err != nil {
+		return getAPIError(c, http.StatusBadRequest, "Invalid Request Body", err)
+	}
+	if err := h.validate.Struct(request.Settings); err != nil {
+		return getAPIError(c, http.StatusBadRequest, "Invalid Publish Settings", err)
+	}
+	// Get Key
+	mkey, err := database.GetKeyAndAccount(*k)
+	if err != nil {
+		return getAPIError(c, http.StatusInternalServerError, "Error Retrieving Key/Account", err)
+	}
+	if mkey == nil || mkey.Key == nil || mkey.Account == nil {
+		return getAPIError(c, http.StatusUnauthorized, "Key/Account Not Found", nil)
+	}
+	// Check for expired key
+	if mkey.Key.Expired() {
+		return getAPIError(c, http.StatusUnauthorized, "Expired Key", nil)
+	}
+	// Check for host being allowed.
+	if !mkey.Key.IsAllowed(c.Request().Referer()) {
+		return getAPIError(c, http.StatusUnauthorized, "Host Not Allowed", nil)
+	}
+	if mkey.Key.Type == "read" {
+		return getAPIError(c, http.StatusUnauthorized, "Key is ReadOnly", nil)
+	}
+	// And Event for verification of whether or not we can allow access to this key
+	mult, err := database.GetEventAndYear(request.Slug, request.Year)
+	if err != nil {
+		return getAPIError(c, http.StatusInternalServerError, "Error Retrieving Event/Year", err)
+	}
+	if mult == nil || mult.Event == nil || mult.EventYear == nil {
+		return getAPIError(c, http.StatusNotFound, "Event/Year Not Found", nil)
+	}
+	// Check if they own this event.
+	if mult.Event.AccountIdentifier != mkey.Account.Identifier {
+		return getAPIError(c, http.StatusUnauthorized, "Ownership Error", nil)
+	}
+	settings, err := database.SetPublishSettings(mult.EventYear.Identifier, request.Settings)
+	if err != nil {
+		return getAPIError(c, http.StatusInternalServerError, "Error Setting Publish Settings", err)
+	}
+	// Anyone watching the results gets the ones they can now see.
+	publishReset(mult.EventYear.Identifier)
+	return c.JSON(http.StatusOK, types.PublishSettingsResponse{
+		Settings: *settings,
+	})
+}
+
@@ -0,0 +1,503 @@
+/*
+Chronokeep Desktop - Race Scoring Software
+Copyright (C) 2026 James Sentinella
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package handlers
+
+import (
+	"chronokeep/results/types"
+	"chronokeep/results/util"
+	"encoding/csv"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetPublishSettings(t *testing.T) {
+	// POST, /publish
+	variables, finalize := setupTests(t)
+	defer finalize(t)
+	h := Handler{}
+	h.Setup()
+	year := "2021"
+	request := types.GetPublishSettingsRequest{
+		Slug: variables.events["event1"].Slug,
+		Year: &year,
+	}
+	var resp types.PublishSettingsResponse
+	// Test no key
+	t.Log("Testing no key given.")
+	code := jsonTestRequest(t, http.MethodPost, "/publish", "", request, h.GetPublishSettings, &resp)
+	assert.Equal(t, http.StatusUnauthorized, code)
+	// Test expired key
+	t.Log("Testing expired key.")
+	code = jsonTestRequest(t, http.MethodPost, "/publish", variables.knownValues["expired"], request, h.GetPublishSettings, &resp)
+	assert.Equal(t, http.StatusUnauthorized, code)
+	// Test wrong account
+	t.Log("Testing wrong account.")
+	code = jsonTestRequest(t, http.MethodPost, "/publish", variables.knownValues["read"], request, h.GetPublishSettings, &resp)
+	assert.Equal(t, http.StatusUnauthorized, code)
+	// Test invalid event
+	t.Log("Testing event not found.")
+	code = jsonTestRequest(t, http.MethodPost, "/publish", variables.knownValues["write"], types.GetPublishSettingsRequest{Slug: "invalid-event", Year: &year}, h.GetPublishSettings, &resp)
+	assert.Equal(t, http.StatusNotFound, code)
+	// Test no settings
+	t.Log("Testing no settings.")
+	code = jsonTestRequest(t, http.MethodPost, "/publish", variables.knownValues["write"], request, h.GetPublishSettings, &resp)
+	if assert.Equal(t, http.StatusOK, code) {
+		assert.Equal(t, int64(0), resp.Settings.PublishAt)
+		assert.False(t, resp.Settings.OwnerOnly)
+		assert.Equal(t, 0, len(resp.Settings.HiddenDistances))
+	}
+	// Test stored settings
+	t.Log("Testing stored settings.")
+	_, err := database.SetPublishSettings(variables.eventYears["event1"]["2021"].Identifier, types.PublishSettings{
+		PublishAt:       1700000000,
+		HiddenDistances: []string{"1 Mile"},
+	})
+	assert.NoError(t, err)
+	code = jsonTestRequest(t, http.MethodPost, "/publish", variables.knownValues["write"], request, h.GetPublishSettings, &resp)
+	if assert.Equal(t, http.StatusOK, code) {
+		assert.Equal(t, int64(1700000000), resp.Settings.PublishAt)
+		assert.False(t, resp.Settings.OwnerOnly)
+		assert.Equal(t, []string{"1 Mile"}, resp.Settings.HiddenDistances)
+	}
+}
+
+func TestSetPublishSettings(t *testing.T) {
+	// POST, /publish/set
+	variables, finalize := setupTests(t)
+	defer finalize(t)
+	h := Handler{}
+	h.Setup()
+	request := types.SetPublishSettingsRequest{
+		Slug: variables.events["event1"].Slug,
+		Year: "2021",
+		Settings: types.PublishSettings{
+			OwnerOnly:       true,
+			HiddenDistances: []string{"1 Mile"},
+		},
+	}
+	var resp types.PublishSettingsResponse
+	// Test no key
+	t.Log("Testing no key given.")
+	code := jsonTestRequest(t, http.MethodPost, "/publish/set", "", request, h.SetPublishSettings, &resp)
+	assert.Equal(t, http.StatusUnauthorized, code)
+	// Test expired key
+	t.Log("Testing expired key.")
+	code = jsonTestRequest(t, http.MethodPost, "/publish/set", variables.knownValues["expired"], request, h.SetPublishSettings, &resp)
+	assert.Equal(t, http.StatusUnauthorized, code)
+	// Test read key
+	t.Log("Testing read key.")
+	code = jsonTestRequest(t, http.MethodPost, "/publish/set", variables.knownValues["read"], request, h.SetPublishSettings, &resp)
+	assert.Equal(t, http.StatusUnauthorized, code)
+	// Test wrong account
+	t.Log("Testing wrong account.")
+	code = jsonTestRequest(t, http.MethodPost, "/publish/set", variables.knownValues["write2"], request, h.SetPublishSettings, &resp)
+	assert.Equal(t, http.StatusUnauthorized, code)
+	// Test invalid event
+	t.Log("Testing event not found.")
+	code = jsonTestRequest(t, http.MethodPost, "/publish/set", variables.knownValues["write"], types.SetPublishSettingsRequest{Slug: "invalid-event", Year: "2021"}, h.SetPublishSettings, &resp)
+	assert.Equal(t, http.StatusNotFound, code)
+	// Test invalid settings
+	t.Log("Testing invalid settings.")
+	code = jsonTestRequest(t, http.MethodPost, "/publish/set", variables.knownValues["write"], types.SetPublishSettingsRequest{
+		Slug:     request.Slug,
+		Year:     request.Year,
+		Settings: types.PublishSettings{PublishAt: -1},
+	}, h.SetPublishSettings, &resp)
+	assert.Equal(t, http.StatusBadRequest, code)
+	// Test valid request
+	t.Log("Testing valid request.")
+	code = jsonTestRequest(t, http.MethodPost, "/publish/set", variables.knownValues["write"], request, h.SetPublishSettings, &resp)
+	if assert.Equal(t, http.StatusOK, code) {
+		assert.Equal(t, request.Settings, resp.Settings)
+	}
+	stored, err := database.GetPublishSettings(variables.eventYears["event1"]["2021"].Identifier)
+	if assert.NoError(t, err) && assert.NotNil(t, stored) {
+		assert.Equal(t, request.Settings, *stored)
+	}
+}
+
+func TestPublishedResults(t *testing.T) {
+	variables, finalize := setupTests(t)
+	defer finalize(t)
+	h := Handler{}
+	h.Setup()
+	year := "2021"
+	eventYear := variables.eventYears["event1"]["2021"]
+	resultsRequest := types.GetResultsRequest{
+		Slug: variables.events["event1"].Slug,
+		Year: &year,
+	}
+	bib := variables.results["event1"]["2021"][0].Bib
+	bibRequest := types.GetBibResultsRequest{
+		Slug: variables.events["event1"].Slug,
+		Year: year,
+		Bib:  bib,
+	}
+	multiRequest := types.GetMultiResultsRequest{
+		Slug:  variables.events["event1"].Slug,
+		Years: []string{year},
+	}
+	// count Returns the number of results each of the public result handlers gives the key.
+	count := func(key string) []int {
+		counts := make([]int, 0)
+		var resp types.GetResultsResponse
+		code := jsonTestRequest(t, http.MethodPost, "/results", key, resultsRequest, h.GetResults, &resp)
+		assert.Equal(t, http.StatusOK, code)
+		counts = append(counts, resp.Count)
+		resp = types.GetResultsResponse{}
+		code = jsonTestRequest(t, http.MethodPost, "/results/all", key, resultsRequest, h.GetAllResults, &resp)
+		assert.Equal(t, http.StatusOK, code)
+		counts = append(counts, resp.Count)
+		resp = types.GetResultsResponse{}
+		code = jsonTestRequest(t, http.MethodPost, "/results/finish", key, resultsRequest, h.GetFinishResults, &resp)
+		assert.Equal(t, http.StatusOK, code)
+		counts = append(counts, resp.Count)
+		var bibResp types.GetBibResultsResponse
+		code = jsonTestRequest(t, http.MethodPost, "/results/bib", key, bibRequest, h.GetBibResults, &bibResp)
+		assert.Equal(t, http.StatusOK, code)
+		counts = append(counts, len(bibResp.Results))
+		var multiResp types.GetMultiResultsResponse
+		code = jsonTestRequest(t, http.MethodPost, "/results/multi", key, multiRequest, h.GetMultiResults, &multiResp)
+		assert.Equal(t, http.StatusOK, code)
+		total := 0
+		for _, res := range multiResp.Results[year] {
+			total += len(res)
+		}
+		counts = append(counts, total)
+		return counts
+	}
+	all := count(variables.knownValues["write"])
+	for _, c := range all {
+		assert.NotEqual(t, 0, c)
+	}
+	assert.Equal(t, all, count(variables.knownValues["read"]))
+	none := []int{0, 0, 0, 0, 0}
+	// Test owner only
+	t.Log("Testing owner only.")
+	_, err := database.SetPublishSettings(eventYear.Identifier, types.PublishSettings{OwnerOnly: true})
+	assert.NoError(t, err)
+	assert.Equal(t, none, count(variables.knownValues["read"]))
+	assert.Equal(t, all, count(variables.knownValues["write"]))
+	// Test embargoed until a later time
+	t.Log("Testing publish time.")
+	_, err = database.SetPublishSettings(eventYear.Identifier, types.PublishSettings{PublishAt: time.Now().Add(time.Hour).Unix()})
+	assert.NoError(t, err)
+	assert.Equal(t, none, count(variables.knownValues["read"]))
+	assert.Equal(t, all, count(variables.knownValues["write"]))
+	_, err = database.SetPublishSettings(eventYear.Identifier, types.PublishSettings{PublishAt: time.Now().Add(-time.Hour).Unix()})
+	assert.NoError(t, err)
+	assert.Equal(t, all, count(variables.knownValues["read"]))
+	// Test hidden distances
+	t.Log("Testing hidden distances.")
+	distance := variables.results["event1"]["2021"][0].Distance
+	_, err = database.SetPublishSettings(eventYear.Identifier, types.PublishSettings{HiddenDistances: []string{distance}})
+	assert.NoError(t, err)
+	var resp types.GetResultsResponse
+	code := jsonTestRequest(t, http.MethodPost, "/results", variables.knownValues["read"], resultsRequest, h.GetResults, &resp)
+	if assert.Equal(t, http.StatusOK, code) {
+		assert.NotContains(t, resp.Results, distance)
+	}
+	hidden := count(variables.knownValues["read"])
+	assert.Equal(t, 0, hidden[3])
+	for ix := range all {
+		assert.Less(t, hidden[ix], all[ix])
+	}
+	assert.Equal(t, all, count(variables.knownValues["write"]))
+}
+
+
+// addEmbargoTestEvent Adds an event owned by the first account with a single result in its 2021 year.
+func addEmbargoTestEvent(t *testing.T, variables *SetupVariables, slug, eventType string, result types.Result) types.EventYear {
+	event, err := database.AddEvent(types.Event{
+		AccountIdentifier: variables.accounts[0].Identifier,
+		Name:              slug,
+		Slug:              slug,
+		ContactEmail:      slug + "@test.com",
+		Type:              eventType,
+	})
+	if err != nil {
+		t.Fatalf("Error adding event: %v", err)
+	}
+	eventYear, err := database.AddEventYear(types.EventYear{
+		EventIdentifier: event.Identifier,
+		Year:            "2021",
+		DateTime:        time.Date(2021, 10, 06, 9, 0, 0, 0, time.Local),
+		DaysAllowed:     3,
+		RankingType:     "gun",
+	})
+	if err != nil {
+		t.Fatalf("Error adding event year: %v", err)
+	}
+	if _, err := database.AddResults(eventYear.Identifier, []types.Result{result}, nil); err != nil {
+		t.Fatalf("Error adding results: %v", err)
+	}
+	return *eventYear
+}
+
+func TestEmbargoedEndpoints(t *testing.T) {
+	variables, finalize := setupTests(t)
+	defer finalize(t)
+	e := echo.New()
+	h := Handler{}
+	h.Setup()
+	year := "2021"
+	event := variables.events["event1"]
+	eventYear := variables.eventYears["event1"]["2021"]
+	series := setupTestSeries()
+	series.AccountIdentifier = variables.accounts[0].Identifier
+	for ix := range series.Events {
+		series.Events[ix].EventYearIdentifier = variables.eventYears["event1"][series.Events[ix].Year].Identifier
+	}
+	if _, err := database.AddSeries(series); err != nil {
+		t.Fatalf("Error adding series: %v", err)
+	}
+	setupTestAthlete(t, &variables)
+	bob := seriesTestResult("Bob", "Ray", "Man", 1, 1)
+	bob.ChipSeconds = bob.Seconds
+	cat := seriesTestResult("Cat", "Kim", "Woman", 2, 1)
+	cat.ChipSeconds = cat.Seconds
+	_, err := database.AddResults(eventYear.Identifier, []types.Result{
+		bob,
+		cat,
+		{
+			PersonId:  "P1",
+			Bib:       "P1",
+			First:     "Runner",
+			Last:      "P1",
+			Gender:    "Woman",
+			AgeGroup:  "20-29",
+			Distance:  "Marathon",
+			Seconds:   3000,
+			Location:  "7 Mile",
+			Occurence: 1,
+		},
+	}, nil)
+	if err != nil {
+		t.Fatalf("Error adding results: %v", err)
+	}
+	_, err = database.AddDistances(eventYear.Identifier, []types.Distance{
+		{
+			Name:          "5K",
+			Certification: "None",
+			DistanceValue: 3.1,
+			DistanceUnit:  util.DISTANCE_TYPE_MILE,
+		},
+	})
+	if err != nil {
+		t.Fatalf("Error adding distances: %v", err)
+	}
+	_, err = database.AddTeams(eventYear.Identifier, []types.Team{
+		{Name: "Delta", Distance: "5K", Members: []types.TeamMember{{Bib: "Bob"}, {Bib: "Cat"}, {Bib: "A1"}}},
+	})
+	if err != nil {
+		t.Fatalf("Error adding teams: %v", err)
+	}
+	if err := updateRecords(&event, &eventYear); err != nil {
+		t.Fatalf("Error updating records: %v", err)
+	}
+	runner := types.Result{
+		PersonId:  "1",
+		Bib:       "1",
+		First:     "Runner",
+		Last:      "1",
+		Gender:    "Woman",
+		AgeGroup:  "30-39",
+		Seconds:   3000,
+		Location:  "Start/Finish",
+		Occurence: 1,
+	}
+	runner.Distance = "Backyard"
+	backyard := addEmbargoTestEvent(t, &variables, "backyard", "backyardultra", runner)
+	runner.Distance = "6 Hour"
+	timed := addEmbargoTestEvent(t, &variables, "six-hour", "time", runner)
+	resultsRequest := types.GetResultsRequest{
+		Slug: "event1",
+		Year: &year,
+	}
+	segment := "21 Miles"
+	// count Returns the number of results from the event years under embargo each endpoint gives the key.
+	count := func(key string) map[string]int {
+		counts := make(map[string]int)
+		var locationResp types.GetLocationResultsResponse
+		code := jsonTestRequest(t, http.MethodPost, "/results/location", key, types.GetLocationResultsRequest{
+			Slug:     "event1",
+			Year:     &year,
+			Location: "7 Mile",
+		}, h.GetLocationResults, &locationResp)
+		assert.Equal(t, http.StatusOK, code)
+		counts["location"] = len(locationResp.Results)
+		var ageResp types.GetResultsResponse
+		code = jsonTestRequest(t, http.MethodPost, "/results/age-graded", key, resultsRequest, h.GetAgeGradedResults, &ageResp)
+		assert.Equal(t, http.StatusOK, code)
+		counts["age graded"] = ageResp.Count
+		var teamResp types.GetTeamResultsResponse
+		code = jsonTestRequest(t, http.MethodPost, "/results/teams", key, types.GetTeamResultsRequest{
+			Slug: "event1",
+			Year: &year,
+		}, h.GetTeamResults, &teamResp)
+		assert.Equal(t, http.StatusOK, code)
+		for _, teams := range teamResp.Results {
+			for _, team := range teams {
+				for _, member := range team.Members {
+					if member.Place > 0 {
+						counts["teams"]++
+					}
+				}
+			}
+		}
+		var predictionResp types.GetPredictionsResponse
+		code = jsonTestRequest(t, http.MethodPost, "/results/predictions", key, types.GetPredictionsRequest{
+			Slug:    "event1",
+			Year:    &year,
+			Segment: &segment,
+		}, h.GetPredictions, &predictionResp)
+		assert.Equal(t, http.StatusOK, code)
+		counts["predictions"] = len(predictionResp.Predictions) + len(predictionResp.Overdue)
+		var backyardResp types.GetBackyardStandingsResponse
+		code = jsonTestRequest(t, http.MethodPost, "/results/backyard", key, types.GetResultsRequest{
+			Slug: "backyard",
+			Year: &year,
+		}, h.GetBackyardStandings, &backyardResp)
+		assert.Equal(t, http.StatusOK, code)
+		counts["backyard"] = len(backyardResp.Standings)
+		var lapResp types.GetLapStandingsResponse
+		code = jsonTestRequest(t, http.MethodPost, "/results/laps", key, types.GetResultsRequest{
+			Slug: "six-hour",
+			Year: &year,
+		}, h.GetLapStandings, &lapResp)
+		assert.Equal(t, http.StatusOK, code)
+		counts["laps"] = len(lapResp.Standings)
+		var recordResp types.GetRecordsResponse
+		code = jsonTestRequest(t, http.MethodPost, "/records", key, types.GetRecordsRequest{
+			Slug: "event1",
+		}, h.GetRecords, &recordResp)
+		assert.Equal(t, http.StatusOK, code)
+		counts["records"] = len(recordResp.Records)
+		var statResp types.GetStatisticsResponse
+		code = jsonTestRequest(t, http.MethodPost, "/statistics", key, types.GetStatisticsRequest{
+			Slug: "event1",
+			Year: &year,
+		}, h.GetStatistics, &statResp)
+		assert.Equal(t, http.StatusOK, code)
+		counts["statistics"] = len(statResp.Distances)
+		for _, stats := range statResp.Years {
+			counts["year statistics"] += stats.Starters + stats.Finishers
+		}
+		var athleteResp types.GetAthleteResponse
+		code = jsonTestRequest(t, http.MethodPost, "/athlete", key, types.GetAthleteRequest{
+			Slug: "ann-lee",
+		}, h.GetAthlete, &athleteResp)
+		assert.Equal(t, http.StatusOK, code)
+		for _, entry := range athleteResp.Events {
+			if entry.Slug == "event1" {
+				counts["athlete"]++
+			}
+		}
+		var seriesResp types.GetSeriesStandingsResponse
+		code = jsonTestRequest(t, http.MethodPost, "/series/standings", key, types.GetSeriesStandingsRequest{
+			Slug: series.Slug,
+		}, h.GetSeriesStandings, &seriesResp)
+		assert.Equal(t, http.StatusOK, code)
+		for _, standings := range seriesResp.Standings {
+			counts["series"] += len(standings)
+		}
+		var eventResp types.GetEventResponse
+		code = jsonTestRequest(t, http.MethodPost, "/event", key, types.GetEventRequest{
+			Slug: "event1",
+		}, h.GetEvent, &eventResp)
+		assert.Equal(t, http.StatusOK, code)
+		for _, results := range eventResp.Results {
+			counts["event"] += len(results)
+		}
+		body, err := json.Marshal(types.ExportResultsRequest{
+			Slug: "event1",
+			Year: &year,
+		})
+		if err != nil {
+			t.Fatalf("Error encoding request body into json object: %v", err)
+		}
+		request := httptest.NewRequest(http.MethodPost, "/results/export", strings.NewReader(string(body)))
+		request.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		request.Header.Set(echo.HeaderAuthorization, "Bearer "+key)
+		response := httptest.NewRecorder()
+		if assert.NoError(t, h.ExportResults(e.NewContext(request, response))) && assert.Equal(t, http.StatusOK, response.Code) {
+			rows, err := csv.NewReader(response.Body).ReadAll()
+			if assert.NoError(t, err) {
+				counts["export"] = len(rows) - 1
+			}
+		}
+		return counts
+	}
+	all := count(variables.knownValues["write"])
+	for endpoint, c := range all {
+		assert.NotEqual(t, 0, c, endpoint)
+	}
+	assert.Equal(t, all, count(variables.knownValues["read"]))
+	// Test owner only
+	t.Log("Testing owner only.")
+	for _, id := range []int64{
+		eventYear.Identifier,
+		variables.eventYears["event1"]["2020"].Identifier,
+		backyard.Identifier,
+		timed.Identifier,
+	} {
+		_, err = database.SetPublishSettings(id, types.PublishSettings{OwnerOnly: true})
+		assert.NoError(t, err)
+	}
+	for endpoint, c := range count(variables.knownValues["read"]) {
+		assert.Equal(t, 0, c, endpoint)
+	}
+	assert.Equal(t, all, count(variables.knownValues["write"]))
+	// Test streams only get what the key can see
+	t.Log("Testing streams.")
+	e.GET("/results/stream", h.StreamResults)
+	server := httptest.NewServer(e)
+	defer server.Close()
+	ownerStream, ownerReader := openResultStream(t, server, variables.knownValues["write"], "event1", year, "")
+	defer ownerStream.Body.Close()
+	readStream, readReader := openResultStream(t, server, variables.knownValues["read"], "event1", year, "")
+	defer readStream.Body.Close()
+	assert.NotEqual(t, 0, len(readStreamEvent(t, ownerReader).event.Results))
+	assert.Equal(t, 0, len(readStreamEvent(t, readReader).event.Results))
+	results := variables.results["event1"]["2021"]
+	results[0].Seconds = results[0].Seconds + 7
+	uploadTestResults(t, h, variables.knownValues["write"], year, results[0:1])
+	assert.NotEqual(t, 0, len(readStreamEvent(t, ownerReader).event.Results))
+	pushed := readStreamEvent(t, readReader)
+	assert.False(t, pushed.event.Reset)
+	assert.Equal(t, 0, len(pushed.event.Results))
+	// Publishing sends everything to the keys that couldn't see it.
+	t.Log("Testing publishing.")
+	code := jsonTestRequest(t, http.MethodPost, "/publish/set", variables.knownValues["write"], types.SetPublishSettingsRequest{
+		Slug: "event1",
+		Year: year,
+	}, h.SetPublishSettings, nil)
+	assert.Equal(t, http.StatusOK, code)
+	published := readStreamEvent(t, readReader)
+	assert.True(t, published.event.Reset)
+	assert.NotEqual(t, 0, len(published.event.Results))
+}
+
@@ -102,6 +102,7 @@ func (h Handler) RGetAllEventYears(c *echo.Context) error {
 					Live:        year.Live,
 					DaysAllowed: year.DaysAllowed,
 					RankingType: year.RankingType,
+					Status:      year.GetStatus(),
 				})
 			}
 		}
@@ -142,13 +143,15 @@ func (h Handler) RAddEventYear(c *echo.Context) error {
 	if account.Identifier != event.AccountIdentifier && account.Type != "admin" {
 		return getAPIError(c, http.StatusUnauthorized, "Unauthorized", errors.New("ownership error"))
 	}
+	status := request.EventYear.GetStatus("")
 	eventYear, err := database.AddEventYear(types.EventYear{
 		EventIdentifier: event.Identifier,
 		Year:            request.EventYear.Year,
 		DateTime:        request.EventYear.GetDateTime(),
-		Live:            request.EventYear.Live,
+		Live:            status == types.EventYearStatusLive,
 		DaysAllowed:     request.EventYear.DaysAllowed,
 		RankingType:     request.EventYear.RankingType,
+		Status:          status,
 	})
 	if err != nil {
 		return getAPIError(c, http.StatusInternalServerError, "Error Adding Event Year (Duplicate Year Likely)", err)
@@ -190,15 +193,27 @@ func (h Handler) RUpdateEventYear(c *echo.Context) error {
 	if account.Identifier != mult.Event.AccountIdentifier && account.Type != "admin" {
 		return getAPIError(c, http.StatusUnauthorized, "Unauthorized", errors.New("ownership error"))
 	}
-	err = database.UpdateEventYear(types.EventYear{
+	status := request.EventYear.GetStatus(mult.EventYear.GetStatus())
+	updated := types.EventYear{
 		EventIdentifier: mult.EventYear.EventIdentifier,
 		Identifier:      mult.EventYear.Identifier,
 		Year:            mult.EventYear.Year,
 		DateTime:        request.EventYear.GetDateTime(),
-		Live:            request.EventYear.Live,
+		Live:            status == types.EventYearStatusLive,
 		DaysAllowed:     request.EventYear.DaysAllowed,
 		RankingType:     request.EventYear.RankingType,
-	})
+		Status:          status,
+	}
+	// Only an admin can unlock an official or archived year, and what changes the results of a
+	// locked year can only be changed while unlocking it.
+	unlocking := mult.EventYear.Locked() && !updated.Locked()
+	if unlocking && account.Type != "admin" {
+		return getAPIError(c, http.StatusForbidden, "Event Year Is Locked", nil)
+	}
+	if mult.EventYear.Locked() && !unlocking && mult.EventYear.ResultsChanged(&updated) {
+		return getAPIError(c, http.StatusForbidden, "Event Year Is Locked", nil)
+	}
+	err = database.UpdateEventYear(updated)
 	if err != nil {
 		return getAPIError(c, http.StatusInternalServerError, "Error Updating Event", err)
 	}
@@ -134,6 +134,10 @@ func (h Handler) RAddParticipant(c *echo.Context) error {
 	if account.Type != "admin" && account.Identifier != multi.Event.AccountIdentifier && !is_linked {
 		return getAPIError(c, http.StatusUnauthorized, "Unauthorized", errors.New("ownership error"))
 	}
+	// Official results can't be changed until an admin unlocks the event year.
+	if multi.EventYear.Locked() {
+		return getAPIError(c, http.StatusForbidden, "Event Year Is Locked", nil)
+	}
 	// validate participants
 	var partToAdd []types.Participant
 	// Validate, only add if it passes validation.
@@ -197,6 +201,10 @@ func (h Handler) RDeleteParticipants(c *echo.Context) error {
 	if account.Type != "admin" && account.Identifier != multi.Event.AccountIdentifier {
 		return getAPIError(c, http.StatusUnauthorized, "Unauthorized", errors.New("ownership error"))
 	}
+	// Official results can't be changed until an admin unlocks the event year.
+	if multi.EventYear.Locked() {
+		return getAPIError(c, http.StatusForbidden, "Event Year Is Locked", nil)
+	}
 	count, err := database.DeleteParticipants(multi.EventYear.Identifier, request.Identifiers)
 	if err != nil {
 		return getAPIError(c, http.StatusInternalServerError, "Error Deleting Participants", err)
@@ -246,6 +254,10 @@ func (h Handler) RUpdateParticipant(c *echo.Context) error {
 	if account.Type != "admin" && account.Identifier != multi.Event.AccountIdentifier && !is_linked {
 		return getAPIError(c, http.StatusUnauthorized, "Unauthorized", errors.New("ownership error"))
 	}
+	// Official results can't be changed until an admin unlocks the event year.
+	if multi.EventYear.Locked() {
+		return getAPIError(c, http.StatusForbidden, "Event Year Is Locked", nil)
+	}
 	// set the updated at field on the participant
 	request.Participant.UpdatedAt = time.Now().UTC().Unix()
 	part, err := database.UpdateParticipant(multi.EventYear.Identifier, request.Participant)
@@ -305,6 +317,10 @@ func (h Handler) RUpdateManyParticipants(c *echo.Context) error {
 	if account.Type != "admin" && account.Identifier != multi.Event.AccountIdentifier && !is_linked {
 		return getAPIError(c, http.StatusUnauthorized, "Unauthorized", errors.New("ownership error"))
 	}
+	// Official results can't be changed until an admin unlocks the event year.
+	if multi.EventYear.Locked() {
+		return getAPIError(c, http.StatusForbidden, "Event Year Is Locked", nil)
+	}
 	// validate participants
 	var partsToAdd []types.Participant
 	// Validate, only add if it passes validation.
@@ -375,6 +391,10 @@ func (h Handler) RAddManyParticipants(c *echo.Context) error {
 	if account.Type != "admin" && account.Identifier != multi.Event.AccountIdentifier && !is_linked {
 		return getAPIError(c, http.StatusUnauthorized, "Unauthorized", errors.New("ownership error"))
 	}
+	// Official results can't be changed until an admin unlocks the event year.
+	if multi.EventYear.Locked() {
+		return getAPIError(c, http.StatusForbidden, "Event Year Is Locked", nil)
+	}
 	// validate participants
 	var partsToAdd []types.Participant
 	// Validate, only add if it passes validation.
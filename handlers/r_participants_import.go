@@ -0,0 +1,307 @@
+/*
+Chronokeep Desktop - Race Scoring Software
+Copyright (C) 2026 James Sentinella
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package handlers
+
+import (
+	"chronokeep/results/types"
+	"chronokeep/results/util"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v5"
+)
+
+// importField Describes how to set a participant field from a csv value.
+type importField struct {
+	name string
+	set  func(p *types.Participant, value string) error
+}
+
+var importFields = []importField{
+	{"id", func(p *types.Participant, v string) error { p.AlternateId = v; return nil }},
+	{"bib", func(p *types.Participant, v string) error { p.Bib = v; return nil }},
+	{"first", func(p *types.Participant, v string) error { p.First = v; return nil }},
+	{"last", func(p *types.Participant, v string) error { p.Last = v; return nil }},
+	{"birthdate", func(p *types.Participant, v string) error { p.Birthdate = v; return nil }},
+	{"gender", func(p *types.Participant, v string) error { p.Gender = v; return nil }},
+	{"age_group", func(p *types.Participant, v string) error { p.AgeGroup = v; return nil }},
+	{"distance", func(p *types.Participant, v string) error { p.Distance = v; return nil }},
+	{"anonymous", func(p *types.Participant, v string) (err error) {
+		p.Anonymous, err = parseImportBool("anonymous", v)
+		return
+	}},
+	{"sms_enabled", func(p *types.Participant, v string) (err error) {
+		p.SMSEnabled, err = parseImportBool("sms_enabled", v)
+		return
+	}},
+	{"mobile", func(p *types.Participant, v string) error { p.Mobile = v; return nil }},
+	{"apparel", func(p *types.Participant, v string) error { p.Apparel = v; return nil }},
+}
+
+// parseImportBool Accepts the usual ways registration exports write true and false.
+func parseImportBool(field, value string) (bool, error) {
+	switch strings.ToLower(strings.TrimSpace(value)) {
+	case "", "0", "false", "f", "no", "n":
+		return false, nil
+	case "1", "true", "t", "yes", "y", "x":
+		return true, nil
+	}
+	return false, fmt.Errorf("invalid value for %s: %s", field, value)
+}
+
+// importColumns Finds the column index for each participant field.  Fields that aren't mapped
+// use a header with the same name.  Mapping to a header that doesn't exist is an error.
+func importColumns(header []string, mapping map[string]string) (map[string]int, error) {
+	known := make(map[string]bool)
+	for _, field := range importFields {
+		known[field.name] = true
+	}
+	for field := range mapping {
+		if !known[field] {
+			return nil, fmt.Errorf("unknown participant field: %s", field)
+		}
+	}
+	headers := make(map[string]int)
+	for ix, name := range header {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if _, ok := headers[name]; !ok {
+			headers[name] = ix
+		}
+	}
+	columns := make(map[string]int)
+	for _, field := range importFields {
+		name, mapped := mapping[field.name]
+		if !mapped {
+			name = field.name
+		}
+		ix, ok := headers[strings.ToLower(strings.TrimSpace(name))]
+		if ok {
+			columns[field.name] = ix
+		} else if mapped {
+			return nil, fmt.Errorf("column not found: %s", name)
+		}
+	}
+	if _, ok := columns["distance"]; !ok {
+		return nil, errors.New("no distance column")
+	}
+	return columns, nil
+}
+
+// importParticipant Creates a participant from a csv row.
+func importParticipant(row []string, columns map[string]int) (types.Participant, error) {
+	var part types.Participant
+	for _, field := range importFields {
+		ix, ok := columns[field.name]
+		if !ok {
+			continue
+		}
+		if ix >= len(row) {
+			return part, fmt.Errorf("missing value for %s", field.name)
+		}
+		if err := field.set(&part, strings.TrimSpace(row[ix])); err != nil {
+			return part, err
+		}
+	}
+	return part, nil
+}
+
+// bindImportRequest Reads an import request sent either as json with the csv in data, or as a
+// multipart form with the csv uploaded as file and the mapping as a json object.
+func bindImportRequest(c *echo.Context) (types.ImportParticipantsRequest, error) {
+	var request types.ImportParticipantsRequest
+	if !strings.HasPrefix(c.Request().Header.Get(echo.HeaderContentType), echo.MIMEMultipartForm) {
+		err := c.Bind(&request)
+		return request, err
+	}
+	request.Slug = c.FormValue("slug")
+	request.Year = c.FormValue("year")
+	if mapping := c.FormValue("mapping"); mapping != "" {
+		if err := json.Unmarshal([]byte(mapping), &request.Mapping); err != nil {
+			return request, err
+		}
+	}
+	upload, err := c.FormFile("file")
+	if err != nil {
+		return request, err
+	}
+	file, err := upload.Open()
+	if err != nil {
+		return request, err
+	}
+	defer file.Close()
+	data, err := io.ReadAll(file)
+	if err != nil {
+		return request, err
+	}
+	request.Data = string(data)
+	return request, nil
+}
+
+func (h Handler) RImportParticipants(c *echo.Context) error {
+	request, err := bindImportRequest(c)
+	if err != nil {
+		return getAPIError(c, http.StatusBadRequest, "Invalid Request Body", err)
+	}
+	account, err := verifyToken(c.Request())
+	if err != nil {
+		return getAPIError(c, http.StatusUnauthorized, "Unauthorized Token", err)
+	}
+	if account == nil {
+		return getAPIError(c, http.StatusNotFound, "Account Not Found", nil)
+	}
+	if account.Locked {
+		return getAPIError(c, http.StatusUnauthorized, "Unauthorized", errors.New("account locked"))
+	}
+	if len(request.Slug) < 1 || len(request.Year) < 1 {
+		return getAPIError(c, http.StatusBadRequest, "Bad Request", errors.New("no slug/year specified"))
+	}
+	multi, err := database.GetAccountEventAndYear(request.Slug, request.Year)
+	if err != nil {
+		return getAPIError(c, http.StatusInternalServerError, "Error Retrieving Event/Year", nil)
+	}
+	if multi == nil || multi.Event == nil || multi.EventYear == nil {
+		return getAPIError(c, http.StatusNotFound, "Event/Year Not Found", nil)
+	}
+	linked, err := database.GetLinkedAccounts(multi.Account.Email)
+	if err != nil {
+		return getAPIError(c, http.StatusInternalServerError, "Error Retrieving Linked Accounts", nil)
+	}
+	is_linked := false
+	for _, acc := range linked {
+		if acc.Identifier == account.Identifier {
+			is_linked = true
+			break
+		}
+	}
+	// Verify they're allowed to pull these identifiers
+	if account.Type != "admin" && account.Identifier != multi.Event.AccountIdentifier && !is_linked {
+		return getAPIError(c, http.StatusUnauthorized, "Unauthorized", errors.New("ownership error"))
+	}
+	// Official results can't be changed until an admin unlocks the event year.
+	if multi.EventYear.Locked() {
+		return getAPIError(c, http.StatusForbidden, "Event Year Is Locked", nil)
+	}
+	// Spreadsheet programs often start their csv exports with a byte order mark.
+	reader := csv.NewReader(strings.NewReader(strings.TrimPrefix(request.Data, "\ufeff")))
+	// Let rows with missing trailing columns through so they can be reported on.
+	reader.FieldsPerRecord = -1
+	header, err := reader.Read()
+	if err != nil {
+		return getAPIError(c, http.StatusBadRequest, "Invalid CSV", err)
+	}
+	columns, err := importColumns(header, request.Mapping)
+	if err != nil {
+		return getAPIError(c, http.StatusBadRequest, "Invalid Column Mapping", err)
+	}
+	existing, err := database.GetParticipants(multi.EventYear.Identifier, 0, 0, nil)
+	if err != nil {
+		return getAPIError(c, http.StatusInternalServerError, "Error Retrieving Participants", err)
+	}
+	known := make(map[string]bool)
+	for _, part := range existing {
+		known[part.AlternateId] = true
+	}
+	// Validate, only add if it passes validation.
+	var partsToAdd []types.Participant
+	rows := make([]types.ImportParticipantRow, 0)
+	seen := make(map[string]int)
+	updatedAt := time.Now().UTC().Unix()
+	rowNum := 1
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		rowNum++
+		if err != nil {
+			var parseErr *csv.ParseError
+			if !errors.As(err, &parseErr) {
+				return getAPIError(c, http.StatusBadRequest, "Invalid CSV", err)
+			}
+			rows = append(rows, types.ImportParticipantRow{
+				Row:    rowNum,
+				Status: util.IMPORT_STATUS_REJECTED,
+				Reason: parseErr.Err.Error(),
+			})
+			continue
+		}
+		part, err := importParticipant(record, columns)
+		row := types.ImportParticipantRow{
+			Row:         rowNum,
+			AlternateId: part.AlternateId,
+			Bib:         part.Bib,
+		}
+		if err == nil {
+			err = part.Validate(h.validate)
+		}
+		if err != nil {
+			row.Status = util.IMPORT_STATUS_REJECTED
+			row.Reason = err.Error()
+			rows = append(rows, row)
+			continue
+		}
+		// create random alternate id if none is set
+		if len(part.AlternateId) < 1 || part.AlternateId == "-1" || part.AlternateId == "0" {
+			part.AlternateId = fmt.Sprintf("new%s%s", part.First, part.Last)
+			row.AlternateId = part.AlternateId
+		}
+		if prev, ok := seen[part.AlternateId]; ok {
+			row.Status = util.IMPORT_STATUS_REJECTED
+			row.Reason = fmt.Sprintf("duplicate of row %d", prev)
+			rows = append(rows, row)
+			continue
+		}
+		seen[part.AlternateId] = rowNum
+		if known[part.AlternateId] {
+			row.Status = util.IMPORT_STATUS_UPDATED
+		} else {
+			row.Status = util.IMPORT_STATUS_ACCEPTED
+		}
+		part.UpdatedAt = updatedAt
+		partsToAdd = append(partsToAdd, part)
+		rows = append(rows, row)
+	}
+	if len(partsToAdd) > 0 {
+		_, err = database.AddParticipants(multi.EventYear.Identifier, partsToAdd)
+		if err != nil {
+			return getAPIError(c, http.StatusInternalServerError, "Error Adding Participants", err)
+		}
+	}
+	out := types.ImportParticipantsResponse{
+		Rows: rows,
+	}
+	for _, row := range rows {
+		switch row.Status {
+		case util.IMPORT_STATUS_ACCEPTED:
+			out.Accepted++
+		case util.IMPORT_STATUS_UPDATED:
+			out.Updated++
+		default:
+			out.Rejected++
+		}
+	}
+	return c.JSON(http.StatusOK, out)
+}
+
@@ -0,0 +1,126 @@
+/*
+Chronokeep Desktop - Race Scoring Software
+Copyright (C) 2026 James Sentinella
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package handlers
+
+import (
+	db "chronokeep/results/database"
+	"chronokeep/results/types"
+	"chronokeep/results/util"
+	"net/http"
+
+	"github.com/labstack/echo/v5"
+)
+
+// keepsRecords Returns true if records are kept for the event.  Time based events don't
+// finish on a time so they don't have records.
+func keepsRecords(event *types.Event) bool {
+	return event.Type != util.EVENT_TYPE_TIME && event.Type != util.EVENT_TYPE_BACKYARDULTRA
+}
+
+// updateRecords Recalculates the best finishes of an event year.
+func updateRecords(event *types.Event, year *types.EventYear) error {
+	if !keepsRecords(event) {
+		return nil
+	}
+	results, err := database.GetResults(year.Identifier, 0, 0)
+	if err != nil {
+		return err
+	}
+	_, err = database.SetRecords(year.Identifier, db.CalculateRecords(results, year.Year, year.RankingType))
+	return err
+}
+
+// flagRecords Marks the results from an event year that hold a record for the event out of the
+// records the account can see.
+func flagRecords(event *types.Event, year *types.EventYear, account *types.Account, results []types.Result) error {
+	if !keepsRecords(event) || len(results) < 1 {
+		return nil
+	}
+	records, err := database.GetRecords(event.Identifier)
+	if err != nil {
+		return err
+	}
+	records, err = publishedRecords(event, account, records)
+	if err != nil {
+		return err
+	}
+	db.FlagRecords(results, *year, db.BestRecords(records))
+	return nil
+}
+
+func (h Handler) GetRecords(c *echo.Context) error {
+	// Get Key from Authorization Header
+	k, err := retrieveKey(c.Request())
+	if err != nil {
+		return getAPIError(c, http.StatusUnauthorized, "Error Getting Key From Authorization Header", err)
+	}
+	if k == nil {
+		return getAPIError(c, http.StatusUnauthorized, "Key Not Provided in Authorization Header", nil)
+	}
+	var request types.GetRecordsRequest
+	if err := c.Bind(&request); err != nil {
+		return getAPIError(c, http.StatusBadRequest, "Invalid Request Body", err)
+	}
+	// Get Key
+	mkey, err := database.GetKeyAndAccount(*k)
+	if err != nil {
+		return getAPIError(c, http.StatusInternalServerError, "Error Retrieving Key/Account", err)
+	}
+	if mkey == nil || mkey.Key == nil || mkey.Account == nil {
+		return getAPIError(c, http.StatusUnauthorized, "Key/Account Not Found", nil)
+	}
+	// Check for expired key
+	if mkey.Key.Expired() {
+		return getAPIError(c, http.StatusUnauthorized, "Expired Key", nil)
+	}
+	// Check for host being allowed.
+	if !mkey.Key.IsAllowed(c.Request().Referer()) {
+		return getAPIError(c, http.StatusUnauthorized, "Host Not Allowed", nil)
+	}
+	// Verify key is allowed to access the event.
+	event, err := database.GetEvent(request.Slug)
+	if err != nil {
+		return getAPIError(c, http.StatusInternalServerError, "Error Retrieving Event", err)
+	}
+	if event == nil {
+		return getAPIError(c, http.StatusNotFound, "Event Not Found", nil)
+	}
+	if event.AccessRestricted && mkey.Account.Identifier != event.AccountIdentifier {
+		return getAPIError(c, http.StatusUnauthorized, "Restricted Event", nil)
+	}
+	records, err := database.GetRecords(event.Identifier)
+	if err != nil {
+		return getAPIError(c, http.StatusInternalServerError, "Error Retrieving Records", err)
+	}
+	records, err = publishedRecords(event, mkey.Account, records)
+	if err != nil {
+		return getAPIError(c, http.StatusInternalServerError, "Error Retrieving Publish Settings", err)
+	}
+	outRecords := make([]types.Record, 0)
+	for _, rec := range db.BestRecords(records) {
+		if request.Distance == nil || *request.Distance == rec.Distance {
+			outRecords = append(outRecords, rec)
+		}
+	}
+	return c.JSON(http.StatusOK, types.GetRecordsResponse{
+		Event:   *event,
+		Records: outRecords,
+	})
+}
+
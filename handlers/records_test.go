@@ -0,0 +1,233 @@
+/*
+Chronokeep Desktop - Race Scoring Software
+Copyright (C) 2026 James Sentinella
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package handlers
+
+import (
+	db "chronokeep/results/database"
+	"chronokeep/results/types"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+func getTestRecords(t *testing.T, h Handler, key string, request types.GetRecordsRequest) (int, types.GetRecordsResponse) {
+	var out types.GetRecordsResponse
+	body, err := json.Marshal(request)
+	if err != nil {
+		t.Fatalf("Error encoding request body into json object: %v", err)
+	}
+	req := httptest.NewRequest(http.MethodPost, "/records", strings.NewReader(string(body)))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	if key != "" {
+		req.Header.Set(echo.HeaderAuthorization, "Bearer "+key)
+	}
+	response := httptest.NewRecorder()
+	c := echo.New().NewContext(req, response)
+	if assert.NoError(t, h.GetRecords(c)) && response.Code == http.StatusOK {
+		assert.NoError(t, json.Unmarshal(response.Body.Bytes(), &out))
+	}
+	return response.Code, out
+}
+
+func uploadTestResults(t *testing.T, h Handler, key, year string, results []types.Result) {
+	body, err := json.Marshal(types.AddResultsRequest{
+		Slug:    "event1",
+		Year:    year,
+		Results: results,
+	})
+	if err != nil {
+		t.Fatalf("Error encoding request body into json object: %v", err)
+	}
+	request := httptest.NewRequest(http.MethodPost, "/results/add", strings.NewReader(string(body)))
+	request.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	request.Header.Set(echo.HeaderAuthorization, "Bearer "+key)
+	response := httptest.NewRecorder()
+	c := echo.New().NewContext(request, response)
+	if assert.NoError(t, h.AddResults(c)) {
+		assert.Equal(t, http.StatusOK, response.Code)
+	}
+}
+
+func TestGetRecords(t *testing.T) {
+	// POST, /records
+	variables, finalize := setupTests(t)
+	defer finalize(t)
+	h := Handler{}
+	h.Setup()
+	request := types.GetRecordsRequest{
+		Slug: variables.events["event2"].Slug,
+	}
+	// Test no key
+	t.Log("Testing no key given.")
+	code, _ := getTestRecords(t, h, "", request)
+	assert.Equal(t, http.StatusUnauthorized, code)
+	// Test expired key
+	t.Log("Testing expired key.")
+	code, _ = getTestRecords(t, h, variables.knownValues["expired"], request)
+	assert.Equal(t, http.StatusUnauthorized, code)
+	// Test invalid key
+	t.Log("Testing invalid key.")
+	code, _ = getTestRecords(t, h, "not-a-valid-key", request)
+	assert.Equal(t, http.StatusUnauthorized, code)
+	// Test invalid host
+	t.Log("Testing invalid host.")
+	code, _ = getTestRecords(t, h, variables.knownValues["delete"], request)
+	assert.Equal(t, http.StatusUnauthorized, code)
+	// Test restricted event
+	t.Log("Testing restricted event but unauthorized key.")
+	code, _ = getTestRecords(t, h, variables.knownValues["write"], request)
+	assert.Equal(t, http.StatusUnauthorized, code)
+	// Test invalid event
+	t.Log("Testing event not found.")
+	code, _ = getTestRecords(t, h, variables.knownValues["read"], types.GetRecordsRequest{Slug: "invalid-event"})
+	assert.Equal(t, http.StatusNotFound, code)
+	// Test no records
+	t.Log("Testing no records.")
+	request.Slug = variables.events["event1"].Slug
+	code, resp := getTestRecords(t, h, variables.knownValues["read"], request)
+	if assert.Equal(t, http.StatusOK, code) {
+		assert.Equal(t, variables.events["event1"].Slug, resp.Event.Slug)
+		assert.Equal(t, 0, len(resp.Records))
+	}
+	// Records are calculated when results are uploaded.
+	t.Log("Testing records after upload.")
+	eventYear := variables.eventYears["event1"]["2021"]
+	results := variables.results["event1"]["2021"]
+	for ix := range results {
+		results[ix].ChipSeconds = results[ix].Seconds
+	}
+	uploadTestResults(t, h, variables.knownValues["write"], "2021", results)
+	uploaded, err := database.GetResults(eventYear.Identifier, 0, 0)
+	if err != nil {
+		t.Fatalf("Error getting results: %v", err)
+	}
+	expected := db.CalculateRecords(uploaded, eventYear.Year, eventYear.RankingType)
+	var course *types.Record
+	code, resp = getTestRecords(t, h, variables.knownValues["read"], request)
+	if assert.Equal(t, http.StatusOK, code) && assert.Equal(t, len(expected), len(resp.Records)) {
+		for _, outer := range expected {
+			found := false
+			for _, inner := range resp.Records {
+				if outer.Equals(&inner) {
+					found = true
+				}
+			}
+			assert.True(t, found)
+			if outer.Distance == "Marathon" && outer.Category == types.RecordCourse {
+				course = &outer
+			}
+		}
+	}
+	if course == nil {
+		t.Fatalf("Expected a course record for the Marathon.")
+	}
+	// Results holding a record are flagged.
+	t.Log("Testing results are flagged.")
+	year := eventYear.Year
+	distance := "Marathon"
+	body, err := json.Marshal(types.GetResultsRequest{
+		Slug:     request.Slug,
+		Year:     &year,
+		Distance: &distance,
+	})
+	if err != nil {
+		t.Fatalf("Error encoding request body into json object: %v", err)
+	}
+	getFlagged := func() map[string]string {
+		flagged := make(map[string]string)
+		req := httptest.NewRequest(http.MethodPost, "/results", strings.NewReader(string(body)))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		req.Header.Set(echo.HeaderAuthorization, "Bearer "+variables.knownValues["read"])
+		response := httptest.NewRecorder()
+		c := echo.New().NewContext(req, response)
+		if assert.NoError(t, h.GetResults(c)) && assert.Equal(t, http.StatusOK, response.Code) {
+			var resp types.GetResultsResponse
+			if assert.NoError(t, json.Unmarshal(response.Body.Bytes(), &resp)) {
+				for _, res := range resp.Results[distance] {
+					if res.Record != "" {
+						flagged[res.Bib] = res.Record
+					}
+				}
+			}
+		}
+		return flagged
+	}
+	flagged := getFlagged()
+	assert.Equal(t, types.RecordCourse, flagged[course.Bib])
+	// A faster time in another year takes the record.
+	t.Log("Testing a new record from another year.")
+	uploadTestResults(t, h, variables.knownValues["write"], "2020", []types.Result{
+		{
+			PersonId:    "900",
+			Bib:         "900",
+			First:       "Fast",
+			Last:        "Runner",
+			Age:         24,
+			Gender:      "Man",
+			AgeGroup:    "20-29",
+			Distance:    "Marathon",
+			Seconds:     course.Seconds - 10,
+			ChipSeconds: course.Seconds - 10,
+			Location:    "Start/Finish",
+			Occurence:   1,
+			Finish:      true,
+		},
+	})
+	code, resp = getTestRecords(t, h, variables.knownValues["read"], types.GetRecordsRequest{
+		Slug:     request.Slug,
+		Distance: &distance,
+	})
+	if assert.Equal(t, http.StatusOK, code) && assert.True(t, len(resp.Records) > 0) {
+		for _, rec := range resp.Records {
+			assert.Equal(t, distance, rec.Distance)
+			if rec.Category == types.RecordCourse {
+				assert.Equal(t, "2020", rec.Year)
+				assert.Equal(t, "900", rec.Bib)
+			}
+		}
+	}
+	flagged = getFlagged()
+	assert.Equal(t, "", flagged[course.Bib])
+	// Deleting the results of a year removes its records.
+	t.Log("Testing records after delete.")
+	deleteYear := "2020"
+	delBody, err := json.Marshal(types.GetResultsRequest{
+		Slug: request.Slug,
+		Year: &deleteYear,
+	})
+	if err != nil {
+		t.Fatalf("Error encoding request body into json object: %v", err)
+	}
+	req := httptest.NewRequest(http.MethodDelete, "/results/delete", strings.NewReader(string(delBody)))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	req.Header.Set(echo.HeaderAuthorization, "Bearer "+variables.knownValues["delete3"])
+	response := httptest.NewRecorder()
+	c := echo.New().NewContext(req, response)
+	if assert.NoError(t, h.DeleteResults(c)) {
+		assert.Equal(t, http.StatusOK, response.Code)
+	}
+	flagged = getFlagged()
+	assert.Equal(t, types.RecordCourse, flagged[course.Bib])
+}
+
@@ -19,12 +19,26 @@ along with this program.  If not, see <https://www.gnu.org/licenses/>.
 package handlers
 
 import (
+	db "chronokeep/results/database"
 	"chronokeep/results/types"
+	"chronokeep/results/util"
 	"net/http"
+	"time"
 
 	"github.com/labstack/echo/v5"
 )
 
+// resultKey Identifies a result within an event year.
+type resultKey struct {
+	bib       string
+	location  string
+	occurence int
+}
+
+func keyOf(res types.Result) resultKey {
+	return resultKey{bib: res.Bib, location: res.Location, occurence: res.Occurence}
+}
+
 func (h Handler) GetResults(c *echo.Context) error {
 	// Get Key from Authorization Header
 	k, err := retrieveKey(c.Request())
@@ -92,6 +106,10 @@ func (h Handler) GetResults(c *echo.Context) error {
 	if err != nil {
 		return getAPIError(c, http.StatusInternalServerError, "Error Retrieving Results", err)
 	}
+	results, err = publishedResults(mult.Event, mult.EventYear, mkey.Account, results)
+	if err != nil {
+		return getAPIError(c, http.StatusInternalServerError, "Error Retrieving Publish Settings", err)
+	}
 	parts, err := database.GetParticipants(mult.EventYear.Identifier, 0, 0, nil)
 	if err != nil {
 		return getAPIError(c, http.StatusInternalServerError, "Error Retrieving Participants", err)
@@ -115,6 +133,18 @@ func (h Handler) GetResults(c *echo.Context) error {
 			Count:   len(results),
 		})
 	}
+	err = flagRecords(mult.Event, mult.EventYear, mkey.Account, results)
+	if err != nil {
+		return getAPIError(c, http.StatusInternalServerError, "Error Retrieving Records", err)
+	}
+	err = ageGradeResults(mult.Event, mult.EventYear, results)
+	if err != nil {
+		return getAPIError(c, http.StatusInternalServerError, "Error Retrieving Distances", err)
+	}
+	_, err = handicapResults(mult.Event, mult.EventYear, mkey.Account, results)
+	if err != nil {
+		return getAPIError(c, http.StatusInternalServerError, "Error Retrieving Handicaps", err)
+	}
 	outRes := make(map[string][]types.Result)
 	for _, result := range results {
 		if _, ok := outRes[result.Distance]; !ok {
@@ -213,6 +243,10 @@ func (h Handler) GetFinishResults(c *echo.Context) error {
 	if err != nil {
 		return getAPIError(c, http.StatusInternalServerError, "Error Retrieving Results", err)
 	}
+	results, err = publishedResults(mult.Event, mult.EventYear, mkey.Account, results)
+	if err != nil {
+		return getAPIError(c, http.StatusInternalServerError, "Error Retrieving Publish Settings", err)
+	}
 	if request.Version != nil && *request.Version == 1 {
 		outRes := make(map[string][]types.ResultVers1)
 		for _, result := range results {
@@ -232,6 +266,18 @@ func (h Handler) GetFinishResults(c *echo.Context) error {
 			Count:   len(results),
 		})
 	}
+	err = flagRecords(mult.Event, mult.EventYear, mkey.Account, results)
+	if err != nil {
+		return getAPIError(c, http.StatusInternalServerError, "Error Retrieving Records", err)
+	}
+	err = ageGradeResults(mult.Event, mult.EventYear, results)
+	if err != nil {
+		return getAPIError(c, http.StatusInternalServerError, "Error Retrieving Distances", err)
+	}
+	_, err = handicapResults(mult.Event, mult.EventYear, mkey.Account, results)
+	if err != nil {
+		return getAPIError(c, http.StatusInternalServerError, "Error Retrieving Handicaps", err)
+	}
 	outRes := make(map[string][]types.Result)
 	for _, result := range results {
 		if _, ok := outRes[result.Distance]; !ok {
@@ -311,9 +357,34 @@ func (h Handler) GetAllResults(c *echo.Context) error {
 			page--
 		}
 	}
-	results, err := database.GetAllDistanceResults(mult.EventYear.Identifier, distance, limit, page)
+	// Take the time before we look so anything changed while we're looking isn't missed
+	// when this is used as updated_after for the next request.
+	updatedAt := time.Now().Unix()
+	var results, deleted []types.Result
+	if request.UpdatedAfter != nil && *request.UpdatedAfter >= 0 {
+		results, err = database.GetUpdatedResults(mult.EventYear.Identifier, distance, *request.UpdatedAfter)
+		if err != nil {
+			return getAPIError(c, http.StatusInternalServerError, "Error Retrieving Results", err)
+		}
+		deleted, err = database.GetDeletedResults(mult.EventYear.Identifier, distance, *request.UpdatedAfter)
+		if err != nil {
+			return getAPIError(c, http.StatusInternalServerError, "Error Retrieving Deleted Results", err)
+		}
+	} else {
+		results, err = database.GetAllDistanceResults(mult.EventYear.Identifier, distance, limit, page)
+		if err != nil {
+			return getAPIError(c, http.StatusInternalServerError, "Error Retrieving Results", err)
+		}
+	}
+	results, err = publishedResults(mult.Event, mult.EventYear, mkey.Account, results)
 	if err != nil {
-		return getAPIError(c, http.StatusInternalServerError, "Error Retrieving Results", err)
+		return getAPIError(c, http.StatusInternalServerError, "Error Retrieving Publish Settings", err)
+	}
+	if deleted != nil {
+		deleted, err = publishedResults(mult.Event, mult.EventYear, mkey.Account, deleted)
+		if err != nil {
+			return getAPIError(c, http.StatusInternalServerError, "Error Retrieving Publish Settings", err)
+		}
 	}
 	if request.Version != nil && *request.Version == 1 {
 		outRes := make(map[string][]types.ResultVers1)
@@ -334,6 +405,18 @@ func (h Handler) GetAllResults(c *echo.Context) error {
 			Count:   len(results),
 		})
 	}
+	err = flagRecords(mult.Event, mult.EventYear, mkey.Account, results)
+	if err != nil {
+		return getAPIError(c, http.StatusInternalServerError, "Error Retrieving Records", err)
+	}
+	err = ageGradeResults(mult.Event, mult.EventYear, results)
+	if err != nil {
+		return getAPIError(c, http.StatusInternalServerError, "Error Retrieving Distances", err)
+	}
+	_, err = handicapResults(mult.Event, mult.EventYear, mkey.Account, results)
+	if err != nil {
+		return getAPIError(c, http.StatusInternalServerError, "Error Retrieving Handicaps", err)
+	}
 	outRes := make(map[string][]types.Result)
 	for _, result := range results {
 		if _, ok := outRes[result.Distance]; !ok {
@@ -347,6 +430,8 @@ func (h Handler) GetAllResults(c *echo.Context) error {
 		Years:     years,
 		Results:   outRes,
 		Count:     len(results),
+		Deleted:   deleted,
+		UpdatedAt: updatedAt,
 	})
 }
 
@@ -394,6 +479,10 @@ func (h Handler) GetBibResults(c *echo.Context) error {
 	if err != nil {
 		return getAPIError(c, http.StatusInternalServerError, "Error Retrieving Results", err)
 	}
+	results, err = publishedResults(mult.Event, mult.EventYear, mkey.Account, results)
+	if err != nil {
+		return getAPIError(c, http.StatusInternalServerError, "Error Retrieving Publish Settings", err)
+	}
 	person, err := database.GetPerson(request.Slug, request.Year, request.Bib)
 	if err != nil {
 		return getAPIError(c, http.StatusInternalServerError, "Error Retrieving Person", err)
@@ -409,6 +498,26 @@ func (h Handler) GetBibResults(c *echo.Context) error {
 	if err != nil {
 		return getAPIError(c, http.StatusInternalServerError, "Error Fetching Distance", nil)
 	}
+	err = flagRecords(mult.Event, mult.EventYear, mkey.Account, results)
+	if err != nil {
+		return getAPIError(c, http.StatusInternalServerError, "Error Retrieving Records", err)
+	}
+	err = ageGradeResults(mult.Event, mult.EventYear, results)
+	if err != nil {
+		return getAPIError(c, http.StatusInternalServerError, "Error Retrieving Distances", err)
+	}
+	_, err = handicapResults(mult.Event, mult.EventYear, mkey.Account, results)
+	if err != nil {
+		return getAPIError(c, http.StatusInternalServerError, "Error Retrieving Handicaps", err)
+	}
+	splits := make([]types.Split, 0)
+	if len(results) > 0 {
+		field, err := database.GetAllDistanceResults(mult.EventYear.Identifier, person.Distance, 0, 0)
+		if err != nil {
+			return getAPIError(c, http.StatusInternalServerError, "Error Retrieving Results", err)
+		}
+		splits = db.CalculateSplits(results, field, segments, distance, mult.EventYear.RankingType)
+	}
 	return c.JSON(http.StatusOK, types.GetBibResultsResponse{
 		Event:          *mult.Event,
 		EventYear:      *mult.EventYear,
@@ -417,6 +526,181 @@ func (h Handler) GetBibResults(c *echo.Context) error {
 		SingleDistance: *mult.DistanceCount == 1,
 		Segments:       segments,
 		Distance:       distance,
+		Splits:         splits,
+	})
+}
+
+func (h Handler) SearchResults(c *echo.Context) error {
+	// Get Key from Authorization Header
+	k, err := retrieveKey(c.Request())
+	if err != nil {
+		return getAPIError(c, http.StatusUnauthorized, "Error Getting Key From Authorization Header", err)
+	}
+	if k == nil {
+		return getAPIError(c, http.StatusUnauthorized, "Key Not Provided in Authorization Header", nil)
+	}
+	var request types.SearchResultsRequest
+	if err := c.Bind(&request); err != nil {
+		return getAPIError(c, http.StatusBadRequest, "Invalid Request Body", err)
+	}
+	if request.Search.Empty() {
+		return getAPIError(c, http.StatusBadRequest, "Search Not Provided", nil)
+	}
+	// Get Key
+	mkey, err := database.GetKeyAndAccount(*k)
+	if err != nil {
+		return getAPIError(c, http.StatusInternalServerError, "Error Retrieving Key/Account", err)
+	}
+	if mkey == nil || mkey.Key == nil || mkey.Account == nil {
+		return getAPIError(c, http.StatusUnauthorized, "Key/Account Not Found", nil)
+	}
+	// Check for expired key
+	if mkey.Key.Expired() {
+		return getAPIError(c, http.StatusUnauthorized, "Expired Key", nil)
+	}
+	// Check for host being allowed.
+	if !mkey.Key.IsAllowed(c.Request().Referer()) {
+		return getAPIError(c, http.StatusUnauthorized, "Host Not Allowed", nil)
+	}
+	// And Event for verification of whether or not we can allow access to this key
+	year := ""
+	if request.Year != nil {
+		year = *request.Year
+	}
+	mult, err := database.GetEventAndYear(request.Slug, year)
+	if err != nil {
+		return getAPIError(c, http.StatusInternalServerError, "Error Retrieving Event/Year", err)
+	}
+	if mult == nil || mult.Event == nil || mult.EventYear == nil {
+		return getAPIError(c, http.StatusNotFound, "Event/Year Not Found", nil)
+	}
+	if mult.Event.AccessRestricted && mkey.Account.Identifier != mult.Event.AccountIdentifier {
+		return getAPIError(c, http.StatusUnauthorized, "Restricted Event", nil)
+	}
+	// Names are matched outside of the database so accents can be ignored the same way everywhere,
+	// which means paging has to happen after the search.
+	results, err := database.GetDistanceResults(mult.EventYear.Identifier, "", 0, 0)
+	if err != nil {
+		return getAPIError(c, http.StatusInternalServerError, "Error Retrieving Results", err)
+	}
+	results, err = publishedResults(mult.Event, mult.EventYear, mkey.Account, results)
+	if err != nil {
+		return getAPIError(c, http.StatusInternalServerError, "Error Retrieving Publish Settings", err)
+	}
+	results = db.SearchResults(results, request.Search)
+	count := len(results)
+	if request.Limit != nil && *request.Limit > 0 {
+		limit := *request.Limit
+		page := 0
+		if request.Page != nil && *request.Page > 0 {
+			page = *request.Page - 1
+		}
+		start := min(page*limit, count)
+		results = results[start:min(start+limit, count)]
+	}
+	return c.JSON(http.StatusOK, types.SearchResultsResponse{
+		Event:     *mult.Event,
+		EventYear: *mult.EventYear,
+		Count:     count,
+		Results:   results,
+	})
+}
+
+func (h Handler) GetLocationResults(c *echo.Context) error {
+	// Get Key from Authorization Header
+	k, err := retrieveKey(c.Request())
+	if err != nil {
+		return getAPIError(c, http.StatusUnauthorized, "Error Getting Key From Authorization Header", err)
+	}
+	if k == nil {
+		return getAPIError(c, http.StatusUnauthorized, "Key Not Provided in Authorization Header", nil)
+	}
+	var request types.GetLocationResultsRequest
+	if err := c.Bind(&request); err != nil {
+		return getAPIError(c, http.StatusBadRequest, "Invalid Request Body", err)
+	}
+	if request.Location == "" && request.Segment == "" {
+		return getAPIError(c, http.StatusBadRequest, "Location Not Provided", nil)
+	}
+	// Get Key
+	mkey, err := database.GetKeyAndAccount(*k)
+	if err != nil {
+		return getAPIError(c, http.StatusInternalServerError, "Error Retrieving Key/Account", err)
+	}
+	if mkey == nil || mkey.Key == nil || mkey.Account == nil {
+		return getAPIError(c, http.StatusUnauthorized, "Key/Account Not Found", nil)
+	}
+	// Check for expired key
+	if mkey.Key.Expired() {
+		return getAPIError(c, http.StatusUnauthorized, "Expired Key", nil)
+	}
+	// Check for host being allowed.
+	if !mkey.Key.IsAllowed(c.Request().Referer()) {
+		return getAPIError(c, http.StatusUnauthorized, "Host Not Allowed", nil)
+	}
+	// And Event for verification of whether or not we can allow access to this key
+	year := ""
+	if request.Year != nil {
+		year = *request.Year
+	}
+	mult, err := database.GetEventAndYear(request.Slug, year)
+	if err != nil {
+		return getAPIError(c, http.StatusInternalServerError, "Error Retrieving Event/Year", err)
+	}
+	if mult == nil || mult.Event == nil || mult.EventYear == nil {
+		return getAPIError(c, http.StatusNotFound, "Event/Year Not Found", nil)
+	}
+	if mult.Event.AccessRestricted && mkey.Account.Identifier != mult.Event.AccountIdentifier {
+		return getAPIError(c, http.StatusUnauthorized, "Restricted Event", nil)
+	}
+	distance := ""
+	if request.Distance != nil {
+		distance = *request.Distance
+	}
+	// Segment names take priority over the location.
+	location, occurence := request.Location, 1
+	if request.Segment != "" {
+		location, occurence = "", 0
+	} else if request.Occurence != nil {
+		occurence = *request.Occurence
+	}
+	results, err := database.GetLocationResults(mult.EventYear.Identifier, distance, location, request.Segment, occurence)
+	if err != nil {
+		return getAPIError(c, http.StatusInternalServerError, "Error Retrieving Results", err)
+	}
+	results, err = publishedResults(mult.Event, mult.EventYear, mkey.Account, results)
+	if err != nil {
+		return getAPIError(c, http.StatusInternalServerError, "Error Retrieving Publish Settings", err)
+	}
+	var last []types.Result
+	if distance != "" {
+		last, err = database.GetDistanceResults(mult.EventYear.Identifier, distance, 0, 0)
+	} else {
+		last, err = database.GetLastResults(mult.EventYear.Identifier, 0, 0)
+	}
+	if err != nil {
+		return getAPIError(c, http.StatusInternalServerError, "Error Retrieving Results", err)
+	}
+	last, err = publishedResults(mult.Event, mult.EventYear, mkey.Account, last)
+	if err != nil {
+		return getAPIError(c, http.StatusInternalServerError, "Error Retrieving Publish Settings", err)
+	}
+	participants, err := database.GetParticipants(mult.EventYear.Identifier, 0, 0, nil)
+	if err != nil {
+		return getAPIError(c, http.StatusInternalServerError, "Error Retrieving Participants", err)
+	}
+	participants, err = publishedParticipants(mult.Event, mult.EventYear, mkey.Account, participants)
+	if err != nil {
+		return getAPIError(c, http.StatusInternalServerError, "Error Retrieving Publish Settings", err)
+	}
+	return c.JSON(http.StatusOK, types.GetLocationResultsResponse{
+		Event:     *mult.Event,
+		EventYear: *mult.EventYear,
+		Location:  location,
+		Segment:   request.Segment,
+		Occurence: occurence,
+		Results:   db.RankArrivals(results),
+		Expected:  db.ExpectedArrivals(participants, results, last, distance),
 	})
 }
 
@@ -434,15 +718,19 @@ func (h Handler) AddResults(c *echo.Context) error {
 		return getAPIError(c, http.StatusBadRequest, "Invalid Request Body", err)
 	}
 	var resToAdd []types.Result
-	for _, res := range request.Results {
+	outcomes := make([]types.ItemOutcome, len(request.Results))
+	rejected := false
+	for ix, res := range request.Results {
 		// Validate all results, only add the results that pass validation.
-		if err := res.Validate(h.validate); err == nil {
-			// we want seconds to be high if the type is DNF
-			if res.Type == 3 || res.Type == 30 {
-				res.Seconds = 1000000
-			}
-			resToAdd = append(resToAdd, res)
+		if err := res.Validate(h.validate); err != nil {
+			outcomes[ix] = rejectedOutcome(ix, res, err)
+			rejected = true
+			continue
 		}
+		// Older versions of Chronokeep Desktop only send a type.
+		res.NormalizeStatus()
+		outcomes[ix] = types.ItemOutcome{Index: ix}
+		resToAdd = append(resToAdd, res)
 	}
 	// Get Key
 	mkey, err := database.GetKeyAndAccount(*k)
@@ -475,12 +763,59 @@ func (h Handler) AddResults(c *echo.Context) error {
 	if mult.Event.AccountIdentifier != mkey.Account.Identifier {
 		return getAPIError(c, http.StatusUnauthorized, "Ownership Error", nil)
 	}
-	results, err := database.AddResults(mult.EventYear.Identifier, resToAdd)
+	// Official results can't be changed until an admin unlocks the event year.
+	if mult.EventYear.Locked() {
+		return getAPIError(c, http.StatusForbidden, "Event Year Is Locked", nil)
+	}
+	if request.AllOrNothing && rejected {
+		skipOutcomes(outcomes)
+		return c.JSON(http.StatusBadRequest, types.AddResultsResponse{
+			Count:    0,
+			Outcomes: outcomes,
+		})
+	}
+	existing, err := database.GetResults(mult.EventYear.Identifier, 0, 0)
+	if err != nil {
+		return getAPIError(c, http.StatusInternalServerError, "Error Retrieving Results", err)
+	}
+	known := make(map[resultKey]bool)
+	for _, res := range existing {
+		known[keyOf(res)] = true
+	}
+	for ix, res := range request.Results {
+		if outcomes[ix].Status == util.ITEM_STATUS_REJECTED {
+			continue
+		}
+		if known[keyOf(res)] {
+			outcomes[ix].Status = util.ITEM_STATUS_UPDATED
+		} else {
+			outcomes[ix].Status = util.ITEM_STATUS_INSERTED
+		}
+		known[keyOf(res)] = true
+	}
+	// Chip times of results in a wave are worked out from when the wave started.
+	waves, err := database.GetWaves(mult.EventYear.Identifier)
+	if err != nil {
+		return getAPIError(c, http.StatusInternalServerError, "Error Retrieving Waves", err)
+	}
+	db.ApplyWaves(resToAdd, waves, mult.EventYear.DateTime)
+	results, err := database.AddResults(mult.EventYear.Identifier, resToAdd, auditActor(mkey))
 	if err != nil {
 		return getAPIError(c, http.StatusInternalServerError, "Error Adding Results", err)
 	}
+	// Rankings are calculated by us so partial or stale uploads don't leave incorrect places.
+	_, err = database.UpdateRankings(mult.EventYear.Identifier)
+	if err != nil {
+		return getAPIError(c, http.StatusInternalServerError, "Error Updating Rankings", err)
+	}
+	err = updateRecords(mult.Event, mult.EventYear)
+	if err != nil {
+		return getAPIError(c, http.StatusInternalServerError, "Error Updating Records", err)
+	}
+	publishResults(mult.EventYear.Identifier, existing, results)
 	return c.JSON(http.StatusOK, types.AddResultsResponse{
-		Count: len(results),
+		Count:    len(results),
+		Outcomes: outcomes,
 	})
 }
 
@@ -533,18 +868,31 @@ func (h Handler) DeleteResults(c *echo.Context) error {
 	if mult.Event.AccountIdentifier != mkey.Account.Identifier {
 		return getAPIError(c, http.StatusUnauthorized, "Ownership Error", nil)
 	}
+	// Official results can't be changed until an admin unlocks the event year.
+	if mult.EventYear.Locked() {
+		return getAPIError(c, http.StatusForbidden, "Event Year Is Locked", nil)
+	}
 	var count int64
 	if request.Distance != nil && len(*request.Distance) > 0 {
-		count, err = database.DeleteDistanceResults(mult.EventYear.Identifier, *request.Distance)
+		count, err = database.DeleteDistanceResults(mult.EventYear.Identifier, *request.Distance, auditActor(mkey))
 		if err != nil {
 			return getAPIError(c, http.StatusInternalServerError, "Error Deleting Results", err)
 		}
 	} else {
-		count, err = database.DeleteEventResults(mult.EventYear.Identifier)
+		count, err = database.DeleteEventResults(mult.EventYear.Identifier, auditActor(mkey))
 		if err != nil {
 			return getAPIError(c, http.StatusInternalServerError, "Error Deleting Results", err)
 		}
 	}
+	_, err = database.UpdateRankings(mult.EventYear.Identifier)
+	if err != nil {
+		return getAPIError(c, http.StatusInternalServerError, "Error Updating Rankings", err)
+	}
+	err = updateRecords(mult.Event, mult.EventYear)
+	if err != nil {
+		return getAPIError(c, http.StatusInternalServerError, "Error Updating Records", err)
+	}
+	publishReset(mult.EventYear.Identifier)
 	return c.JSON(http.StatusOK, types.AddResultsResponse{
 		Count: int(count),
 	})
@@ -0,0 +1,345 @@
+/*
+Chronokeep Desktop - Race Scoring Software
+Copyright (C) 2026 James Sentinella
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package handlers
+
+import (
+	"archive/zip"
+	"chronokeep/results/types"
+	"chronokeep/results/util"
+	"encoding/csv"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v5"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	mimeCSV  = "text/csv; charset=utf-8"
+	mimeXLSX = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+)
+
+// exportColumn is a single column in an exported file.
+type exportColumn[T any] struct {
+	name    string
+	numeric bool
+	value   func(r *T) string
+}
+
+var exportColumns = []exportColumn[types.Result]{
+	{name: "Bib", value: func(r *types.Result) string { return r.Bib }},
+	{name: "First", value: func(r *types.Result) string { return r.First }},
+	{name: "Last", value: func(r *types.Result) string { return r.Last }},
+	{name: "Age", numeric: true, value: func(r *types.Result) string { return strconv.Itoa(r.Age) }},
+	{name: "Gender", value: func(r *types.Result) string { return r.Gender }},
+	{name: "Age Group", value: func(r *types.Result) string { return r.AgeGroup }},
+	{name: "Division", value: func(r *types.Result) string { return r.Division }},
+	{name: "Distance", value: func(r *types.Result) string { return r.Distance }},
+	{name: "Segment", value: func(r *types.Result) string { return r.Segment }},
+	{name: "Location", value: func(r *types.Result) string { return r.Location }},
+	{name: "Occurence", numeric: true, value: func(r *types.Result) string { return strconv.Itoa(r.Occurence) }},
+	{name: "Gun Time", value: func(r *types.Result) string { return r.GunTime() }},
+	{name: "Chip Time", value: func(r *types.Result) string { return r.ChipTime() }},
+	{name: "Seconds", numeric: true, value: func(r *types.Result) string { return strconv.Itoa(r.Seconds) }},
+	{name: "Milliseconds", numeric: true, value: func(r *types.Result) string { return strconv.Itoa(r.Milliseconds) }},
+	{name: "Chip Seconds", numeric: true, value: func(r *types.Result) string { return strconv.Itoa(r.ChipSeconds) }},
+	{name: "Chip Milliseconds", numeric: true, value: func(r *types.Result) string { return strconv.Itoa(r.ChipMilliseconds) }},
+	{name: "Ranking", numeric: true, value: func(r *types.Result) string { return strconv.Itoa(r.Ranking) }},
+	{name: "Gender Ranking", numeric: true, value: func(r *types.Result) string { return strconv.Itoa(r.GenderRanking) }},
+	{name: "Age Ranking", numeric: true, value: func(r *types.Result) string { return strconv.Itoa(r.AgeRanking) }},
+	{name: "Division Ranking", numeric: true, value: func(r *types.Result) string { return strconv.Itoa(r.DivisionRanking) }},
+	{name: "Finish", value: func(r *types.Result) string { return strconv.FormatBool(r.Finish) }},
+	{name: "Type", numeric: true, value: func(r *types.Result) string { return strconv.Itoa(r.Type) }},
+	{name: "Anonymous", value: func(r *types.Result) string { return strconv.FormatBool(r.Anonymous) }},
+	{name: "Local Time", value: func(r *types.Result) string { return r.LocalTime }},
+	{name: "Status", value: func(r *types.Result) string { return r.ResultStatus() }},
+	{name: "Status Reason", value: func(r *types.Result) string { return r.StatusReason }},
+}
+
+// handicapExportColumns are added to the exported results of event years with handicap scoring.
+var handicapExportColumns = []exportColumn[types.Result]{
+	{name: "Handicap", value: func(r *types.Result) string { return r.HandicapTime() }},
+	{name: "Handicap Ranking", numeric: true, value: func(r *types.Result) string { return strconv.Itoa(r.HandicapRanking) }},
+	{name: "Actual Time", value: func(r *types.Result) string { return r.ActualTime() }},
+	{name: "Actual Ranking", numeric: true, value: func(r *types.Result) string { return strconv.Itoa(r.ActualRanking) }},
+}
+
+// exportResult Returns a copy of the result that is safe to export.  Anonymous results
+// don't include the name of the person.
+func exportResult(r types.Result) types.Result {
+	if r.Anonymous {
+		r.First = ""
+		r.Last = ""
+	}
+	return r
+}
+
+// csvText Returns a text value that a spreadsheet won't run as a formula when the CSV file is
+// opened.  Values starting with a formula character are prefixed with a single quote.
+func csvText(value string) string {
+	if value != "" && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
+
+// writeCSV Writes the rows to w as a CSV file with a header row.  Text columns are written with
+// csvText so free text such as names can't be used as formulas.
+func writeCSV[T any](w io.Writer, columns []exportColumn[T], rows []T) error {
+	writer := csv.NewWriter(w)
+	row := make([]string, len(columns))
+	for ix, col := range columns {
+		row[ix] = col.name
+	}
+	if err := writer.Write(row); err != nil {
+		return err
+	}
+	for _, r := range rows {
+		for ix, col := range columns {
+			row[ix] = col.value(&r)
+			if !col.numeric {
+				row[ix] = csvText(row[ix])
+			}
+		}
+		if err := writer.Write(row); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// xlsxFiles are the parts of an XLSX file that don't change based on the rows.
+var xlsxFiles = []struct {
+	name    string
+	content string
+}{
+	{
+		name: "[Content_Types].xml",
+		content: xml.Header +
+			`<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+			`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+			`<Default Extension="xml" ContentType="application/xml"/>` +
+			`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+			`<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>` +
+			`</Types>`,
+	},
+	{
+		name: "_rels/.rels",
+		content: xml.Header +
+			`<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+			`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+			`</Relationships>`,
+	},
+	{
+		name: "xl/workbook.xml",
+		content: xml.Header +
+			`<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+			`<sheets><sheet name="Results" sheetId="1" r:id="rId1"/></sheets>` +
+			`</workbook>`,
+	},
+	{
+		name: "xl/_rels/workbook.xml.rels",
+		content: xml.Header +
+			`<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+			`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>` +
+			`</Relationships>`,
+	},
+}
+
+// writeXLSXCell Writes a single worksheet cell.  Numeric cells are stored as numbers so
+// they can be sorted and used in formulas, everything else is an inline string.
+func writeXLSXCell(w io.Writer, value string, numeric bool) error {
+	if numeric {
+		_, err := fmt.Fprintf(w, "<c><v>%s</v></c>", value)
+		return err
+	}
+	if _, err := io.WriteString(w, `<c t="inlineStr"><is><t xml:space="preserve">`); err != nil {
+		return err
+	}
+	if err := xml.EscapeText(w, []byte(value)); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "</t></is></c>")
+	return err
+}
+
+// writeXLSX Writes the rows to w as an XLSX workbook with a single sheet.
+func writeXLSX[T any](w io.Writer, columns []exportColumn[T], rows []T) error {
+	archive := zip.NewWriter(w)
+	for _, file := range xlsxFiles {
+		f, err := archive.Create(file.name)
+		if err != nil {
+			return err
+		}
+		if _, err := io.WriteString(f, file.content); err != nil {
+			return err
+		}
+	}
+	sheet, err := archive.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(sheet, xml.Header+`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData><row>`)
+	if err != nil {
+		return err
+	}
+	for _, col := range columns {
+		if err := writeXLSXCell(sheet, col.name, false); err != nil {
+			return err
+		}
+	}
+	if _, err := io.WriteString(sheet, "</row>"); err != nil {
+		return err
+	}
+	for _, r := range rows {
+		if _, err := io.WriteString(sheet, "<row>"); err != nil {
+			return err
+		}
+		for _, col := range columns {
+			if err := writeXLSXCell(sheet, col.value(&r), col.numeric); err != nil {
+				return err
+			}
+		}
+		if _, err := io.WriteString(sheet, "</row>"); err != nil {
+			return err
+		}
+	}
+	if _, err := io.WriteString(sheet, "</sheetData></worksheet>"); err != nil {
+		return err
+	}
+	return archive.Close()
+}
+
+// exportFileName Returns a file name for the export that only contains safe characters.
+func exportFileName(parts ...string) string {
+	name := strings.Join(parts, "-")
+	return strings.Map(func(r rune) rune {
+		if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') || r == '-' || r == '_' {
+			return r
+		}
+		return '_'
+	}, name)
+}
+
+func (h Handler) ExportResults(c *echo.Context) error {
+	// Get Key from Authorization Header
+	k, err := retrieveKey(c.Request())
+	if err != nil {
+		return getAPIError(c, http.StatusUnauthorized, "Error Getting Key From Authorization Header", err)
+	}
+	if k == nil {
+		return getAPIError(c, http.StatusUnauthorized, "Key Not Provided in Authorization Header", nil)
+	}
+	var request types.ExportResultsRequest
+	if err := c.Bind(&request); err != nil {
+		return getAPIError(c, http.StatusBadRequest, "Invalid Request Body", err)
+	}
+	format := strings.ToLower(request.Format)
+	if format == "" {
+		format = util.EXPORT_FORMAT_CSV
+	}
+	if format != util.EXPORT_FORMAT_CSV && format != util.EXPORT_FORMAT_XLSX {
+		return getAPIError(c, http.StatusBadRequest, "Invalid Export Format", nil)
+	}
+	// Get Key
+	mkey, err := database.GetKeyAndAccount(*k)
+	if err != nil {
+		return getAPIError(c, http.StatusInternalServerError, "Error Retrieving Key/Account", err)
+	}
+	if mkey == nil || mkey.Key == nil || mkey.Account == nil {
+		return getAPIError(c, http.StatusUnauthorized, "Key/Account Not Found", nil)
+	}
+	// Check for expired key
+	if mkey.Key.Expired() {
+		return getAPIError(c, http.StatusUnauthorized, "Expired Key", nil)
+	}
+	// Check for host being allowed.
+	if !mkey.Key.IsAllowed(c.Request().Referer()) {
+		return getAPIError(c, http.StatusUnauthorized, "Host Not Allowed", nil)
+	}
+	// And Event for verification of whether or not we can allow access to this key
+	year := ""
+	if request.Year != nil {
+		year = *request.Year
+	}
+	mult, err := database.GetEventAndYear(request.Slug, year)
+	if err != nil {
+		return getAPIError(c, http.StatusInternalServerError, "Error Retrieving Event/Year", err)
+	}
+	if mult == nil || mult.Event == nil || mult.EventYear == nil {
+		return getAPIError(c, http.StatusNotFound, "Event/Year Not Found", nil)
+	}
+	if mult.Event.AccessRestricted && mkey.Account.Identifier != mult.Event.AccountIdentifier {
+		return getAPIError(c, http.StatusUnauthorized, "Restricted Event", nil)
+	}
+	distance := ""
+	if request.Distance != nil {
+		distance = *request.Distance
+	}
+	results, err := database.GetAllDistanceResults(mult.EventYear.Identifier, distance, 0, 0)
+	if err != nil {
+		return getAPIError(c, http.StatusInternalServerError, "Error Retrieving Results", err)
+	}
+	results, err = publishedResults(mult.Event, mult.EventYear, mkey.Account, results)
+	if err != nil {
+		return getAPIError(c, http.StatusInternalServerError, "Error Retrieving Publish Settings", err)
+	}
+	handicap, err := handicapResults(mult.Event, mult.EventYear, mkey.Account, results)
+	if err != nil {
+		return getAPIError(c, http.StatusInternalServerError, "Error Retrieving Handicaps", err)
+	}
+	columns := exportColumns
+	if handicap {
+		columns = append(append([]exportColumn[types.Result]{}, exportColumns...), handicapExportColumns...)
+	}
+	for ix := range results {
+		results[ix] = exportResult(results[ix])
+	}
+	nameParts := []string{mult.Event.Slug, mult.EventYear.Year}
+	if distance != "" {
+		nameParts = append(nameParts, distance)
+	}
+	fileName := exportFileName(nameParts...) + "." + format
+	c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", fileName))
+	if format == util.EXPORT_FORMAT_XLSX {
+		c.Response().Header().Set(echo.HeaderContentType, mimeXLSX)
+		c.Response().WriteHeader(http.StatusOK)
+		err = writeXLSX(c.Response(), columns, results)
+	} else {
+		c.Response().Header().Set(echo.HeaderContentType, mimeCSV)
+		c.Response().WriteHeader(http.StatusOK)
+		err = writeCSV(c.Response(), columns, results)
+	}
+	if err != nil {
+		// Headers have already been sent so all we can do is note it.
+		log.WithFields(log.Fields{
+			"slug":   mult.Event.Slug,
+			"year":   mult.EventYear.Year,
+			"format": format,
+			"error":  err,
+		}).Error("Error writing results export.")
+	}
+	return nil
+}
+
@@ -0,0 +1,299 @@
+/*
+Chronokeep Desktop - Race Scoring Software
+Copyright (C) 2026 James Sentinella
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package handlers
+
+import (
+	"archive/zip"
+	"bytes"
+	"chronokeep/results/types"
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExportResults(t *testing.T) {
+	// POST, /results/export
+	variables, finalize := setupTests(t)
+	defer finalize(t)
+	e := echo.New()
+	h := Handler{}
+	year := "2021"
+	body, err := json.Marshal(types.ExportResultsRequest{
+		Slug: variables.events["event2"].Slug,
+		Year: &year,
+	})
+	if err != nil {
+		t.Fatalf("Error encoding request body into json object: %v", err)
+	}
+	// Test no key
+	t.Log("Testing no key given.")
+	request := httptest.NewRequest(http.MethodPost, "/results/export", strings.NewReader(string(body)))
+	request.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	response := httptest.NewRecorder()
+	c := e.NewContext(request, response)
+	if assert.NoError(t, h.ExportResults(c)) {
+		assert.Equal(t, http.StatusUnauthorized, response.Code)
+	}
+	// Test expired key
+	t.Log("Testing expired key.")
+	request = httptest.NewRequest(http.MethodPost, "/results/export", strings.NewReader(string(body)))
+	request.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	request.Header.Set(echo.HeaderAuthorization, "Bearer "+variables.knownValues["expired"])
+	response = httptest.NewRecorder()
+	c = e.NewContext(request, response)
+	if assert.NoError(t, h.ExportResults(c)) {
+		assert.Equal(t, http.StatusUnauthorized, response.Code)
+	}
+	// Test invalid key
+	t.Log("Testing invalid key.")
+	request = httptest.NewRequest(http.MethodPost, "/results/export", strings.NewReader(string(body)))
+	request.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	request.Header.Set(echo.HeaderAuthorization, "Bearer not-a-valid-key")
+	response = httptest.NewRecorder()
+	c = e.NewContext(request, response)
+	if assert.NoError(t, h.ExportResults(c)) {
+		assert.Equal(t, http.StatusUnauthorized, response.Code)
+	}
+	// Test invalid host
+	t.Log("Testing invalid host.")
+	request = httptest.NewRequest(http.MethodPost, "/results/export", strings.NewReader(string(body)))
+	request.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	request.Header.Set(echo.HeaderAuthorization, "Bearer "+variables.knownValues["delete"])
+	response = httptest.NewRecorder()
+	c = e.NewContext(request, response)
+	if assert.NoError(t, h.ExportResults(c)) {
+		assert.Equal(t, http.StatusUnauthorized, response.Code)
+	}
+	// Test restricted event
+	t.Log("Testing restricted event but unauthorized key.")
+	request = httptest.NewRequest(http.MethodPost, "/results/export", strings.NewReader(string(body)))
+	request.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	request.Header.Set(echo.HeaderAuthorization, "Bearer "+variables.knownValues["write"])
+	response = httptest.NewRecorder()
+	c = e.NewContext(request, response)
+	if assert.NoError(t, h.ExportResults(c)) {
+		assert.Equal(t, http.StatusUnauthorized, response.Code)
+	}
+	// Test invalid format
+	t.Log("Testing invalid format.")
+	body, err = json.Marshal(types.ExportResultsRequest{
+		Slug:   variables.events["event2"].Slug,
+		Year:   &year,
+		Format: "pdf",
+	})
+	if err != nil {
+		t.Fatalf("Error encoding request body into json object: %v", err)
+	}
+	request = httptest.NewRequest(http.MethodPost, "/results/export", strings.NewReader(string(body)))
+	request.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	request.Header.Set(echo.HeaderAuthorization, "Bearer "+variables.knownValues["read"])
+	response = httptest.NewRecorder()
+	c = e.NewContext(request, response)
+	if assert.NoError(t, h.ExportResults(c)) {
+		assert.Equal(t, http.StatusBadRequest, response.Code)
+	}
+	// Test invalid event
+	t.Log("Testing event not found.")
+	body, err = json.Marshal(types.ExportResultsRequest{
+		Slug: "invalid event",
+	})
+	if err != nil {
+		t.Fatalf("Error encoding request body into json object: %v", err)
+	}
+	request = httptest.NewRequest(http.MethodPost, "/results/export", strings.NewReader(string(body)))
+	request.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	request.Header.Set(echo.HeaderAuthorization, "Bearer "+variables.knownValues["read"])
+	response = httptest.NewRecorder()
+	c = e.NewContext(request, response)
+	if assert.NoError(t, h.ExportResults(c)) {
+		assert.Equal(t, http.StatusNotFound, response.Code)
+	}
+	// Make someone anonymous so we can check they aren't named.
+	eventYear := variables.eventYears["event1"]["2021"]
+	anonymous := variables.results["event1"]["2021"][0]
+	anonymous.Anonymous = true
+	_, err = database.AddResults(eventYear.Identifier, []types.Result{anonymous}, nil)
+	if err != nil {
+		t.Fatalf("Error updating result: %v", err)
+	}
+	// Test csv
+	t.Log("Testing csv export.")
+	body, err = json.Marshal(types.ExportResultsRequest{
+		Slug: variables.events["event1"].Slug,
+		Year: &year,
+	})
+	if err != nil {
+		t.Fatalf("Error encoding request body into json object: %v", err)
+	}
+	request = httptest.NewRequest(http.MethodPost, "/results/export", strings.NewReader(string(body)))
+	request.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	request.Header.Set(echo.HeaderAuthorization, "Bearer "+variables.knownValues["read"])
+	response = httptest.NewRecorder()
+	c = e.NewContext(request, response)
+	if assert.NoError(t, h.ExportResults(c)) {
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.Equal(t, mimeCSV, response.Header().Get(echo.HeaderContentType))
+		assert.Equal(t, `attachment; filename="event1-2021.csv"`, response.Header().Get(echo.HeaderContentDisposition))
+		rows, err := csv.NewReader(response.Body).ReadAll()
+		if assert.NoError(t, err) {
+			assert.Equal(t, len(variables.results["event1"]["2021"])+1, len(rows))
+			assert.Equal(t, "Bib", rows[0][0])
+			assert.Equal(t, "Gun Time", rows[0][11])
+			for _, row := range rows[1:] {
+				if row[0] == anonymous.Bib && row[9] == anonymous.Location && row[10] == strconv.Itoa(anonymous.Occurence) {
+					assert.Equal(t, "", row[1])
+					assert.Equal(t, "", row[2])
+					assert.Equal(t, anonymous.GunTime(), row[11])
+					assert.Equal(t, "true", row[23])
+				}
+			}
+		}
+	}
+	// Test csv for a single distance
+	t.Log("Testing csv export of a distance.")
+	distance := "2 Mile"
+	body, err = json.Marshal(types.ExportResultsRequest{
+		Slug:     variables.events["event1"].Slug,
+		Year:     &year,
+		Distance: &distance,
+		Format:   "CSV",
+	})
+	if err != nil {
+		t.Fatalf("Error encoding request body into json object: %v", err)
+	}
+	request = httptest.NewRequest(http.MethodPost, "/results/export", strings.NewReader(string(body)))
+	request.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	request.Header.Set(echo.HeaderAuthorization, "Bearer "+variables.knownValues["read"])
+	response = httptest.NewRecorder()
+	c = e.NewContext(request, response)
+	if assert.NoError(t, h.ExportResults(c)) {
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.Equal(t, `attachment; filename="event1-2021-2_Mile.csv"`, response.Header().Get(echo.HeaderContentDisposition))
+		rows, err := csv.NewReader(response.Body).ReadAll()
+		if assert.NoError(t, err) {
+			count := 0
+			for _, res := range variables.results["event1"]["2021"] {
+				if res.Distance == distance {
+					count++
+				}
+			}
+			assert.Equal(t, count+1, len(rows))
+			for _, row := range rows[1:] {
+				assert.Equal(t, distance, row[7])
+			}
+		}
+	}
+	// Test xlsx
+	t.Log("Testing xlsx export.")
+	body, err = json.Marshal(types.ExportResultsRequest{
+		Slug:   variables.events["event1"].Slug,
+		Year:   &year,
+		Format: "xlsx",
+	})
+	if err != nil {
+		t.Fatalf("Error encoding request body into json object: %v", err)
+	}
+	request = httptest.NewRequest(http.MethodPost, "/results/export", strings.NewReader(string(body)))
+	request.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	request.Header.Set(echo.HeaderAuthorization, "Bearer "+variables.knownValues["read"])
+	response = httptest.NewRecorder()
+	c = e.NewContext(request, response)
+	if assert.NoError(t, h.ExportResults(c)) {
+		assert.Equal(t, http.StatusOK, response.Code)
+		assert.Equal(t, mimeXLSX, response.Header().Get(echo.HeaderContentType))
+		data := response.Body.Bytes()
+		archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+		if assert.NoError(t, err) {
+			names := make(map[string]*zip.File)
+			for _, f := range archive.File {
+				names[f.Name] = f
+			}
+			for _, file := range xlsxFiles {
+				assert.Contains(t, names, file.name)
+			}
+			if assert.Contains(t, names, "xl/worksheets/sheet1.xml") {
+				f, err := names["xl/worksheets/sheet1.xml"].Open()
+				if assert.NoError(t, err) {
+					content, err := io.ReadAll(f)
+					f.Close()
+					assert.NoError(t, err)
+					var sheet struct {
+						Rows []struct {
+							Cells []struct {
+								Type   string `xml:"t,attr"`
+								Value  string `xml:"v"`
+								Inline string `xml:"is>t"`
+							} `xml:"c"`
+						} `xml:"sheetData>row"`
+					}
+					if assert.NoError(t, xml.Unmarshal(content, &sheet)) {
+						assert.Equal(t, len(variables.results["event1"]["2021"])+1, len(sheet.Rows))
+						assert.Equal(t, "Bib", sheet.Rows[0].Cells[0].Inline)
+						assert.Equal(t, len(exportColumns), len(sheet.Rows[1].Cells))
+						// Age is a number.
+						assert.Equal(t, "", sheet.Rows[1].Cells[3].Type)
+						assert.NotEqual(t, "", sheet.Rows[1].Cells[3].Value)
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestWriteCSV(t *testing.T) {
+	results := []types.Result{
+		{Bib: "1", First: "=HYPERLINK(\"http://example.com\",\"Click\")", Last: "@SUM(A1:A2)", Age: 30, Gender: "Woman", Seconds: 300},
+		{Bib: "2", First: "+1", Last: "-1", Age: 41, Gender: "\tMan", StatusReason: "\r=1+1"},
+		{Bib: "3", First: "Jane", Last: "O'Neil", Age: 25, Gender: "Woman"},
+	}
+	var buf bytes.Buffer
+	if assert.NoError(t, writeCSV(&buf, exportColumns, results)) {
+		rows, err := csv.NewReader(&buf).ReadAll()
+		if assert.NoError(t, err) && assert.Equal(t, 4, len(rows)) {
+			// Values that would be formulas are kept as text.
+			assert.Equal(t, "'=HYPERLINK(\"http://example.com\",\"Click\")", rows[1][1])
+			assert.Equal(t, "'@SUM(A1:A2)", rows[1][2])
+			assert.Equal(t, "300", rows[1][13])
+			assert.Equal(t, "'+1", rows[2][1])
+			assert.Equal(t, "'-1", rows[2][2])
+			assert.Equal(t, "'\tMan", rows[2][4])
+			assert.Equal(t, "'\r=1+1", rows[2][26])
+			// Everything else is left alone.
+			assert.Equal(t, "Jane", rows[3][1])
+			assert.Equal(t, "O'Neil", rows[3][2])
+			assert.Equal(t, "25", rows[3][3])
+		}
+	}
+}
+
+func TestFormatTime(t *testing.T) {
+	assert.Equal(t, "0:00:00.000", types.FormatTime(0, 0))
+	assert.Equal(t, "0:06:17.050", types.FormatTime(377, 50))
+	assert.Equal(t, "2:03:04.999", types.FormatTime(7384, 999))
+}
+
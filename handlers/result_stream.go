@@ -0,0 +1,401 @@
+/*
+Chronokeep Desktop - Race Scoring Software
+Copyright (C) 2026 James Sentinella
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package handlers
+
+import (
+	db "chronokeep/results/database"
+	"chronokeep/results/types"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/labstack/echo/v5"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// Number of events kept per event year so reconnecting clients can catch up.
+	streamHistorySize = 200
+	// Number of events a subscriber can fall behind before it is disconnected.
+	streamSubscriberBuffer = 32
+	streamKeepAlive        = time.Second * 30
+)
+
+// streamEvent is a single set of results sent to everyone watching an event year.  Resets
+// don't carry any results, the current results are fetched when they're sent.
+type streamEvent struct {
+	id    int64
+	reset bool
+	data  []types.Result
+}
+
+type resultStream struct {
+	// Events after this one can be resumed from.  This is the id the stream started with
+	// or the last event dropped from its history.
+	firstID     int64
+	lastID      int64
+	history     []streamEvent
+	subscribers map[chan streamEvent]struct{}
+}
+
+// resultBroker keeps track of everyone watching the results of an event year and the
+// recent events sent to them.  Event ids are shared by every event year so a stream
+// that is removed and started again never reuses the ids of the old one.
+type resultBroker struct {
+	lock       sync.Mutex
+	generation int64
+	lastID     int64
+	streams    map[int64]*resultStream
+}
+
+var broker = newResultBroker()
+
+func newResultBroker() *resultBroker {
+	return &resultBroker{
+		generation: time.Now().UnixNano(),
+		streams:    make(map[int64]*resultStream),
+	}
+}
+
+// token Creates a resume token for an event.  The generation is included so tokens
+// from before a restart are never mistaken for current ones.
+func (b *resultBroker) token(id int64) string {
+	return fmt.Sprintf("%d-%d", b.generation, id)
+}
+
+// parseToken Returns the event id a token refers to and if it is valid for this broker.
+func (b *resultBroker) parseToken(token string) (int64, bool) {
+	parts := strings.Split(token, "-")
+	if len(parts) != 2 {
+		return 0, false
+	}
+	gen, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil || gen != b.generation {
+		return 0, false
+	}
+	id, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return id, true
+}
+
+// subscribe Registers a new subscriber for an event year.  If the resume token can be
+// satisfied from history the missed events are returned and ok is true.  Otherwise the
+// caller must send the full set of results.  The last event id is returned to use as
+// the token for that full set.
+func (b *resultBroker) subscribe(eventYearID int64, resume string) (chan streamEvent, []streamEvent, int64, bool) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	stream, found := b.streams[eventYearID]
+	if !found {
+		b.lastID++
+		stream = &resultStream{
+			firstID:     b.lastID,
+			lastID:      b.lastID,
+			subscribers: make(map[chan streamEvent]struct{}),
+		}
+		b.streams[eventYearID] = stream
+	}
+	ch := make(chan streamEvent, streamSubscriberBuffer)
+	stream.subscribers[ch] = struct{}{}
+	lastID := stream.lastID
+	if resume == "" {
+		return ch, nil, lastID, false
+	}
+	// Anything before the first id we have is either too old or from a stream that
+	// was removed while nobody was watching.
+	id, valid := b.parseToken(resume)
+	if !valid || id < stream.firstID || id > lastID {
+		return ch, nil, lastID, false
+	}
+	var missed []streamEvent
+	for _, ev := range stream.history {
+		if ev.id > id {
+			missed = append(missed, ev)
+		}
+	}
+	return ch, missed, lastID, true
+}
+
+// unsubscribe Removes a subscriber from an event year.  The event year stops being
+// watched once its last subscriber leaves.
+func (b *resultBroker) unsubscribe(eventYearID int64, ch chan streamEvent) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	if stream, ok := b.streams[eventYearID]; ok {
+		if _, ok := stream.subscribers[ch]; ok {
+			delete(stream.subscribers, ch)
+			close(ch)
+		}
+		if len(stream.subscribers) == 0 {
+			delete(b.streams, eventYearID)
+		}
+	}
+}
+
+// watched Returns true if anyone is subscribed to an event year.
+func (b *resultBroker) watched(eventYearID int64) bool {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	_, ok := b.streams[eventYearID]
+	return ok
+}
+
+// publish Sends results to all subscribers of an event year and records them for
+// clients that reconnect.  Subscribers that have fallen too far behind are
+// disconnected so they can resume once they've caught up.
+func (b *resultBroker) publish(eventYearID int64, results []types.Result, reset bool) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	stream, ok := b.streams[eventYearID]
+	if !ok {
+		return
+	}
+	b.lastID++
+	stream.lastID = b.lastID
+	ev := streamEvent{
+		id:    b.lastID,
+		reset: reset,
+	}
+	if !reset {
+		ev.data = results
+	}
+	stream.history = append(stream.history, ev)
+	if len(stream.history) > streamHistorySize {
+		dropped := len(stream.history) - streamHistorySize
+		stream.firstID = stream.history[dropped-1].id
+		stream.history = stream.history[dropped:]
+	}
+	for ch := range stream.subscribers {
+		select {
+		case ch <- ev:
+		default:
+			delete(stream.subscribers, ch)
+			close(ch)
+		}
+	}
+}
+
+// publishResults Sends the current version of the uploaded results to anyone watching
+// the event year.  Results are fetched again so recalculated rankings are included, and
+// any other result whose rankings changed from before the upload is sent along with them.
+func publishResults(eventYearID int64, before []types.Result, uploaded []types.Result) {
+	if !broker.watched(eventYearID) {
+		return
+	}
+	all, err := database.GetResults(eventYearID, 0, 0)
+	if err != nil {
+		log.WithFields(log.Fields{
+			"event_year_id": eventYearID,
+			"error":         err,
+		}).Error("Unable to retrieve results to publish.")
+		return
+	}
+	keys := make(map[resultKey]bool)
+	for _, res := range uploaded {
+		keys[keyOf(res)] = true
+	}
+	previous := make(map[resultKey]types.Result)
+	for _, res := range before {
+		previous[keyOf(res)] = res
+	}
+	changed := make([]types.Result, 0, len(uploaded))
+	for _, res := range all {
+		old, known := previous[keyOf(res)]
+		if keys[keyOf(res)] || !known || db.RankingsChanged(&old, &res) {
+			changed = append(changed, res)
+		}
+	}
+	broker.publish(eventYearID, changed, false)
+}
+
+// publishReset Tells anyone watching the event year to replace everything they have
+// with the current set of results.
+func publishReset(eventYearID int64) {
+	broker.publish(eventYearID, nil, true)
+}
+
+func writeStreamEvent(c *echo.Context, id string, reset bool, results []types.Result) error {
+	if results == nil {
+		results = make([]types.Result, 0)
+	}
+	data, err := json.Marshal(types.ResultsStreamEvent{
+		Reset:   reset,
+		Results: results,
+	})
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(c.Response(), "id: %s\nevent: results\ndata: %s\n\n", id, data)
+	if err != nil {
+		return err
+	}
+	return http.NewResponseController(c.Response()).Flush()
+}
+
+// writeVisibleStreamEvent Writes the results of a stream event the account is allowed to see.
+func writeVisibleStreamEvent(c *echo.Context, event *types.Event, year *types.EventYear, account *types.Account, ev streamEvent) error {
+	results := ev.data
+	if ev.reset {
+		var err error
+		results, err = database.GetResults(year.Identifier, 0, 0)
+		if err != nil {
+			return err
+		}
+	}
+	results, err := publishedResults(event, year, account, results)
+	if err != nil {
+		return err
+	}
+	return writeStreamEvent(c, broker.token(ev.id), ev.reset, results)
+}
+
+func (h Handler) StreamResults(c *echo.Context) error {
+	// Get Key from Authorization Header
+	k, err := retrieveKey(c.Request())
+	if err != nil {
+		return getAPIError(c, http.StatusUnauthorized, "Error Getting Key From Authorization Header", err)
+	}
+	if k == nil {
+		return getAPIError(c, http.StatusUnauthorized, "Key Not Provided in Authorization Header", nil)
+	}
+	var request types.GetResultsStreamRequest
+	if err := c.Bind(&request); err != nil {
+		return getAPIError(c, http.StatusBadRequest, "Invalid Request", err)
+	}
+	// Get Key
+	mkey, err := database.GetKeyAndAccount(*k)
+	if err != nil {
+		return getAPIError(c, http.StatusInternalServerError, "Error Retrieving Key/Account", err)
+	}
+	if mkey == nil || mkey.Key == nil || mkey.Account == nil {
+		return getAPIError(c, http.StatusUnauthorized, "Key/Account Not Found", nil)
+	}
+	// Check for expired key
+	if mkey.Key.Expired() {
+		return getAPIError(c, http.StatusUnauthorized, "Expired Key", nil)
+	}
+	// Check for host being allowed.
+	if !mkey.Key.IsAllowed(c.Request().Referer()) {
+		return getAPIError(c, http.StatusUnauthorized, "Host Not Allowed", nil)
+	}
+	// And Event for verification of whether or not we can allow access to this key
+	mult, err := database.GetEventAndYear(request.Slug, request.Year)
+	if err != nil {
+		return getAPIError(c, http.StatusInternalServerError, "Error Retrieving Event/Year", err)
+	}
+	if mult == nil || mult.Event == nil || mult.EventYear == nil {
+		return getAPIError(c, http.StatusNotFound, "Event/Year Not Found", nil)
+	}
+	if mult.Event.AccessRestricted && mkey.Account.Identifier != mult.Event.AccountIdentifier {
+		return getAPIError(c, http.StatusUnauthorized, "Restricted Event", nil)
+	}
+	// EventSource sends the last id it saw when it reconnects.
+	resume := c.Request().Header.Get("Last-Event-ID")
+	if resume == "" {
+		resume = request.Resume
+	}
+	ch, missed, lastID, resumed := broker.subscribe(mult.EventYear.Identifier, resume)
+	defer broker.unsubscribe(mult.EventYear.Identifier, ch)
+	var current []types.Result
+	if !resumed {
+		current, err = database.GetResults(mult.EventYear.Identifier, 0, 0)
+		if err != nil {
+			return getAPIError(c, http.StatusInternalServerError, "Error Retrieving Results", err)
+		}
+		current, err = publishedResults(mult.Event, mult.EventYear, mkey.Account, current)
+		if err != nil {
+			return getAPIError(c, http.StatusInternalServerError, "Error Retrieving Publish Settings", err)
+		}
+	}
+	// Keys that can't see the results yet are sent all of them once they're published.
+	embargoed, err := resultsEmbargoed(mult.Event, mult.EventYear, mkey.Account)
+	if err != nil {
+		return getAPIError(c, http.StatusInternalServerError, "Error Retrieving Publish Settings", err)
+	}
+	c.Response().Header().Set(echo.HeaderContentType, "text/event-stream")
+	c.Response().Header().Set(echo.HeaderCacheControl, "no-cache")
+	c.Response().Header().Set(echo.HeaderConnection, "keep-alive")
+	c.Response().WriteHeader(http.StatusOK)
+	if !resumed {
+		if err := writeStreamEvent(c, broker.token(lastID), true, current); err != nil {
+			return nil
+		}
+	}
+	for _, ev := range missed {
+		if err := writeVisibleStreamEvent(c, mult.Event, mult.EventYear, mkey.Account, ev); err != nil {
+			return nil
+		}
+		lastID = ev.id
+	}
+	keepAlive := time.NewTicker(streamKeepAlive)
+	defer keepAlive.Stop()
+	for {
+		select {
+		case <-c.Request().Context().Done():
+			return nil
+		case ev, ok := <-ch:
+			if !ok {
+				// We fell behind, the client will resume with the last id it received.
+				return nil
+			}
+			if err := writeVisibleStreamEvent(c, mult.Event, mult.EventYear, mkey.Account, ev); err != nil {
+				return nil
+			}
+			lastID = ev.id
+			// Resets are sent when the publish settings change.
+			if ev.reset {
+				if embargoed, err = resultsEmbargoed(mult.Event, mult.EventYear, mkey.Account); err != nil {
+					return nil
+				}
+			}
+		case <-keepAlive.C:
+			if embargoed {
+				if embargoed, err = resultsEmbargoed(mult.Event, mult.EventYear, mkey.Account); err != nil {
+					return nil
+				}
+				if !embargoed {
+					current, err = database.GetResults(mult.EventYear.Identifier, 0, 0)
+					if err != nil {
+						return nil
+					}
+					current, err = publishedResults(mult.Event, mult.EventYear, mkey.Account, current)
+					if err != nil {
+						return nil
+					}
+					if err := writeStreamEvent(c, broker.token(lastID), true, current); err != nil {
+						return nil
+					}
+				}
+			}
+			if _, err := fmt.Fprint(c.Response(), ": keep-alive\n\n"); err != nil {
+				return nil
+			}
+			if err := http.NewResponseController(c.Response()).Flush(); err != nil {
+				return nil
+			}
+		}
+	}
+}
+
@@ -0,0 +1,313 @@
+/*
+Chronokeep Desktop - Race Scoring Software
+Copyright (C) 2026 James Sentinella
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package handlers
+
+import (
+	"bufio"
+	db "chronokeep/results/database"
+	"chronokeep/results/types"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+type testStreamEvent struct {
+	id    string
+	event types.ResultsStreamEvent
+}
+
+// readStreamEvent Reads the next results event from a stream, skipping keep alives.
+func readStreamEvent(t *testing.T, reader *bufio.Reader) testStreamEvent {
+	var out testStreamEvent
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			t.Fatalf("Error reading from stream: %v", err)
+		}
+		line = strings.TrimRight(line, "\n")
+		if line == "" {
+			return out
+		}
+		if id, ok := strings.CutPrefix(line, "id: "); ok {
+			out.id = id
+		} else if data, ok := strings.CutPrefix(line, "data: "); ok {
+			if err := json.Unmarshal([]byte(data), &out.event); err != nil {
+				t.Fatalf("Error decoding stream event: %v", err)
+			}
+		}
+	}
+}
+
+func openResultStream(t *testing.T, server *httptest.Server, key, slug, year, resume string) (*http.Response, *bufio.Reader) {
+	query := url.Values{}
+	query.Set("slug", slug)
+	query.Set("year", year)
+	request, err := http.NewRequest(http.MethodGet, server.URL+"/results/stream?"+query.Encode(), nil)
+	if err != nil {
+		t.Fatalf("Error creating stream request: %v", err)
+	}
+	request.Header.Set(echo.HeaderAuthorization, "Bearer "+key)
+	if resume != "" {
+		request.Header.Set("Last-Event-ID", resume)
+	}
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		t.Fatalf("Error opening stream: %v", err)
+	}
+	return response, bufio.NewReader(response.Body)
+}
+
+// findStreamResult Returns the result sent for the same bib, location and occurence.
+func findStreamResult(results []types.Result, res types.Result) (types.Result, bool) {
+	for _, sent := range results {
+		if keyOf(sent) == keyOf(res) {
+			return sent, true
+		}
+	}
+	return types.Result{}, false
+}
+
+func uploadStreamResults(t *testing.T, h Handler, key string, results []types.Result) {
+	body, err := json.Marshal(types.AddResultsRequest{
+		Slug:    "event2",
+		Year:    "2021",
+		Results: results,
+	})
+	if err != nil {
+		t.Fatalf("Error encoding request body into json object: %v", err)
+	}
+	request := httptest.NewRequest(http.MethodPost, "/results/add", strings.NewReader(string(body)))
+	request.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	request.Header.Set(echo.HeaderAuthorization, "Bearer "+key)
+	response := httptest.NewRecorder()
+	c := echo.New().NewContext(request, response)
+	if assert.NoError(t, h.AddResults(c)) {
+		assert.Equal(t, http.StatusOK, response.Code)
+	}
+}
+
+func TestStreamResults(t *testing.T) {
+	// GET, /results/stream
+	variables, finalize := setupTests(t)
+	defer finalize(t)
+	e := echo.New()
+	h := Handler{}
+	h.Setup()
+	target := "/results/stream?slug=event2&year=2021"
+	// Test no key
+	t.Log("Testing no key given.")
+	request := httptest.NewRequest(http.MethodGet, target, nil)
+	response := httptest.NewRecorder()
+	c := e.NewContext(request, response)
+	if assert.NoError(t, h.StreamResults(c)) {
+		assert.Equal(t, http.StatusUnauthorized, response.Code)
+	}
+	// Test expired key
+	t.Log("Testing expired key.")
+	request = httptest.NewRequest(http.MethodGet, target, nil)
+	request.Header.Set(echo.HeaderAuthorization, "Bearer "+variables.knownValues["expired"])
+	response = httptest.NewRecorder()
+	c = e.NewContext(request, response)
+	if assert.NoError(t, h.StreamResults(c)) {
+		assert.Equal(t, http.StatusUnauthorized, response.Code)
+	}
+	// Test invalid key
+	t.Log("Testing invalid key.")
+	request = httptest.NewRequest(http.MethodGet, target, nil)
+	request.Header.Set(echo.HeaderAuthorization, "Bearer not-a-valid-key")
+	response = httptest.NewRecorder()
+	c = e.NewContext(request, response)
+	if assert.NoError(t, h.StreamResults(c)) {
+		assert.Equal(t, http.StatusUnauthorized, response.Code)
+	}
+	// Test invalid host
+	t.Log("Testing invalid host.")
+	request = httptest.NewRequest(http.MethodGet, target, nil)
+	request.Header.Set(echo.HeaderAuthorization, "Bearer "+variables.knownValues["delete"])
+	response = httptest.NewRecorder()
+	c = e.NewContext(request, response)
+	if assert.NoError(t, h.StreamResults(c)) {
+		assert.Equal(t, http.StatusUnauthorized, response.Code)
+	}
+	// Test restricted event
+	t.Log("Testing restricted event.")
+	request = httptest.NewRequest(http.MethodGet, target, nil)
+	request.Header.Set(echo.HeaderAuthorization, "Bearer "+variables.knownValues["write"])
+	response = httptest.NewRecorder()
+	c = e.NewContext(request, response)
+	if assert.NoError(t, h.StreamResults(c)) {
+		assert.Equal(t, http.StatusUnauthorized, response.Code)
+	}
+	// Test invalid year
+	t.Log("Testing invalid year.")
+	request = httptest.NewRequest(http.MethodGet, "/results/stream?slug=event2&year=invalid-year", nil)
+	request.Header.Set(echo.HeaderAuthorization, "Bearer "+variables.knownValues["read"])
+	response = httptest.NewRecorder()
+	c = e.NewContext(request, response)
+	if assert.NoError(t, h.StreamResults(c)) {
+		assert.Equal(t, http.StatusNotFound, response.Code)
+	}
+	// Test a valid stream
+	t.Log("Testing valid stream.")
+	e.GET("/results/stream", h.StreamResults)
+	server := httptest.NewServer(e)
+	defer server.Close()
+	stream, reader := openResultStream(t, server, variables.knownValues["read"], "event2", "2021", "")
+	assert.Equal(t, http.StatusOK, stream.StatusCode)
+	assert.Equal(t, "text/event-stream", stream.Header.Get(echo.HeaderContentType))
+	first := readStreamEvent(t, reader)
+	assert.True(t, first.event.Reset)
+	assert.Equal(t, len(variables.results["event2"]["2021"]), len(first.event.Results))
+	// Upload a changed result and make sure it is pushed.
+	results := variables.results["event2"]["2021"]
+	results[0].Seconds = results[0].Seconds + 7
+	uploadStreamResults(t, h, variables.knownValues["write2"], results[0:1])
+	pushed := readStreamEvent(t, reader)
+	assert.False(t, pushed.event.Reset)
+	if found, ok := findStreamResult(pushed.event.Results, results[0]); assert.True(t, ok) {
+		assert.Equal(t, results[0].Seconds, found.Seconds)
+	}
+	assert.NotEqual(t, first.id, pushed.id)
+	// Everything else sent had its places changed by the new time.
+	current, err := database.GetResults(variables.eventYears["event2"]["2021"].Identifier, 0, 0)
+	if err != nil {
+		t.Fatalf("Error retrieving results: %v", err)
+	}
+	for _, res := range current {
+		before, _ := findStreamResult(first.event.Results, res)
+		after, sent := findStreamResult(pushed.event.Results, res)
+		if keyOf(res) == keyOf(results[0]) {
+			continue
+		}
+		assert.Equal(t, db.RankingsChanged(&before, &res), sent, "bib %s", res.Bib)
+		if sent {
+			assert.Equal(t, res.Ranking, after.Ranking)
+			assert.Equal(t, res.GenderRanking, after.GenderRanking)
+			assert.Equal(t, res.AgeRanking, after.AgeRanking)
+		}
+	}
+	// Someone else keeps watching so there is something to resume.
+	watcher, watcherReader := openResultStream(t, server, variables.knownValues["read"], "event2", "2021", "")
+	readStreamEvent(t, watcherReader)
+	stream.Body.Close()
+	// Upload while disconnected then resume.
+	t.Log("Testing resume.")
+	results[1].Seconds = results[1].Seconds + 9
+	uploadStreamResults(t, h, variables.knownValues["write2"], results[1:2])
+	stream, reader = openResultStream(t, server, variables.knownValues["read"], "event2", "2021", pushed.id)
+	missed := readStreamEvent(t, reader)
+	assert.False(t, missed.event.Reset)
+	if found, ok := findStreamResult(missed.event.Results, results[1]); assert.True(t, ok) {
+		assert.Equal(t, results[1].Seconds, found.Seconds)
+	}
+	stream.Body.Close()
+	// An unknown resume token gets everything again.
+	t.Log("Testing invalid resume token.")
+	stream, reader = openResultStream(t, server, variables.knownValues["read"], "event2", "2021", "1-1")
+	reset := readStreamEvent(t, reader)
+	assert.True(t, reset.event.Reset)
+	assert.Equal(t, len(variables.results["event2"]["2021"]), len(reset.event.Results))
+	stream.Body.Close()
+	// Once nobody is watching the event year resuming gets everything again.
+	t.Log("Testing resume after everyone leaves.")
+	watcher.Body.Close()
+	eventYearID := variables.eventYears["event2"]["2021"].Identifier
+	assert.Eventually(t, func() bool { return !broker.watched(eventYearID) }, time.Second*5, time.Millisecond*10)
+	stream, reader = openResultStream(t, server, variables.knownValues["read"], "event2", "2021", missed.id)
+	reset = readStreamEvent(t, reader)
+	assert.True(t, reset.event.Reset)
+	assert.Equal(t, len(variables.results["event2"]["2021"]), len(reset.event.Results))
+	stream.Body.Close()
+}
+
+func TestResultBroker(t *testing.T) {
+	b := newResultBroker()
+	// Nothing is recorded for event years nobody is watching.
+	assert.False(t, b.watched(1))
+	b.publish(1, []types.Result{{Bib: "1"}}, false)
+	watcher, missed, lastID, resumed := b.subscribe(1, "")
+	assert.False(t, resumed)
+	assert.Nil(t, missed)
+	assert.True(t, b.watched(1))
+	ch, _, _, _ := b.subscribe(1, "")
+	b.publish(1, []types.Result{{Bib: "2"}}, false)
+	var first int64
+	select {
+	case ev := <-ch:
+		first = ev.id
+		assert.Greater(t, first, lastID)
+		assert.Equal(t, "2", ev.data[0].Bib)
+	case <-time.After(time.Second):
+		t.Fatalf("Expected event to be published.")
+	}
+	b.publish(1, []types.Result{{Bib: "3"}}, false)
+	b.unsubscribe(1, ch)
+	// Resume from the first event.
+	ch, missed, _, resumed = b.subscribe(1, b.token(first))
+	assert.True(t, resumed)
+	if assert.Equal(t, 1, len(missed)) {
+		assert.Equal(t, "3", missed[0].data[0].Bib)
+	}
+	b.unsubscribe(1, ch)
+	// Resets are kept without their results.
+	b.publish(1, []types.Result{{Bib: "4"}}, true)
+	ch, missed, _, resumed = b.subscribe(1, b.token(first))
+	assert.True(t, resumed)
+	if assert.Equal(t, 2, len(missed)) {
+		assert.True(t, missed[1].reset)
+		assert.Nil(t, missed[1].data)
+	}
+	b.unsubscribe(1, ch)
+	// Tokens from another generation or the future aren't valid.
+	for _, token := range []string{"12-1", b.token(100), "not-a-token"} {
+		ch, _, _, resumed = b.subscribe(1, token)
+		assert.False(t, resumed, token)
+		b.unsubscribe(1, ch)
+	}
+	// The event year is no longer watched once everyone leaves, and its events can't be resumed.
+	b.unsubscribe(1, watcher)
+	assert.False(t, b.watched(1))
+	assert.Equal(t, 0, len(b.streams))
+	ch, _, _, resumed = b.subscribe(1, b.token(first))
+	assert.False(t, resumed)
+	b.unsubscribe(1, ch)
+	// Tokens older than our history aren't valid.
+	ch, _, _, _ = b.subscribe(2, "")
+	for i := 0; i < streamHistorySize+5; i++ {
+		b.publish(2, nil, false)
+	}
+	oldest, ok := <-ch
+	assert.True(t, ok)
+	ch2, _, _, resumed := b.subscribe(2, b.token(oldest.id))
+	assert.False(t, resumed)
+	b.unsubscribe(2, ch2)
+	// Subscribers that fall too far behind are disconnected.
+	for ok {
+		_, ok = <-ch
+	}
+	b.unsubscribe(2, ch)
+}
+
@@ -19,7 +19,9 @@ along with this program.  If not, see <https://www.gnu.org/licenses/>.
 package handlers
 
 import (
+	db "chronokeep/results/database"
 	"chronokeep/results/types"
+	"chronokeep/results/util"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
@@ -1540,6 +1542,76 @@ func TestGetAllResults(t *testing.T) {
 	if assert.NoError(t, h.GetAllResults(c)) {
 		assert.Equal(t, http.StatusUnauthorized, response.Code)
 	}
+	// Test updated after
+	t.Log("Testing updated after.")
+	year = "2021"
+	updatedAfter := int64(0)
+	body, err = json.Marshal(types.GetResultsRequest{
+		Slug:         variables.events["event1"].Slug,
+		Year:         &year,
+		UpdatedAfter: &updatedAfter,
+	})
+	if err != nil {
+		t.Fatalf("Error encoding request body into json object: %v", err)
+	}
+	request = httptest.NewRequest(http.MethodPost, "/results/all", strings.NewReader(string(body)))
+	request.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	request.Header.Set(echo.HeaderAuthorization, "Bearer "+variables.knownValues["read"])
+	response = httptest.NewRecorder()
+	c = e.NewContext(request, response)
+	if assert.NoError(t, h.GetAllResults(c)) {
+		assert.Equal(t, http.StatusOK, response.Code)
+		var resp types.GetResultsResponse
+		if assert.NoError(t, json.Unmarshal(response.Body.Bytes(), &resp)) {
+			assert.Equal(t, len(variables.results["event1"]["2021"]), resp.Count)
+			assert.Equal(t, 0, len(resp.Deleted))
+			assert.NotEqual(t, int64(0), resp.UpdatedAt)
+		}
+	}
+	// Timestamps are stored to the second so wait for the next one.
+	updatedAfter = time.Now().Unix() + 1
+	time.Sleep(time.Until(time.Unix(updatedAfter, 0)))
+	eventYear := variables.eventYears["event1"]["2021"]
+	changed := variables.results["event1"]["2021"][0]
+	changed.Seconds = changed.Seconds + 5
+	_, err = database.AddResults(eventYear.Identifier, []types.Result{changed}, nil)
+	if err != nil {
+		t.Fatalf("Error updating result: %v", err)
+	}
+	removed := variables.results["event1"]["2021"][1]
+	_, err = database.DeleteResults(eventYear.Identifier, []types.Result{removed}, nil)
+	if err != nil {
+		t.Fatalf("Error deleting result: %v", err)
+	}
+	body, err = json.Marshal(types.GetResultsRequest{
+		Slug:         variables.events["event1"].Slug,
+		Year:         &year,
+		UpdatedAfter: &updatedAfter,
+	})
+	if err != nil {
+		t.Fatalf("Error encoding request body into json object: %v", err)
+	}
+	request = httptest.NewRequest(http.MethodPost, "/results/all", strings.NewReader(string(body)))
+	request.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	request.Header.Set(echo.HeaderAuthorization, "Bearer "+variables.knownValues["read"])
+	response = httptest.NewRecorder()
+	c = e.NewContext(request, response)
+	if assert.NoError(t, h.GetAllResults(c)) {
+		assert.Equal(t, http.StatusOK, response.Code)
+		var resp types.GetResultsResponse
+		if assert.NoError(t, json.Unmarshal(response.Body.Bytes(), &resp)) {
+			assert.Equal(t, 1, resp.Count)
+			if assert.Equal(t, 1, len(resp.Results[changed.Distance])) {
+				assert.True(t, changed.Equals(&resp.Results[changed.Distance][0]))
+			}
+			if assert.Equal(t, 1, len(resp.Deleted)) {
+				assert.Equal(t, removed.Bib, resp.Deleted[0].Bib)
+				assert.Equal(t, removed.Location, resp.Deleted[0].Location)
+				assert.Equal(t, removed.Occurence, resp.Deleted[0].Occurence)
+			}
+			assert.GreaterOrEqual(t, resp.UpdatedAt, updatedAfter)
+		}
+	}
 }
 
 func TestGetFinishResults(t *testing.T) {
@@ -2380,6 +2452,13 @@ func TestGetBibResults(t *testing.T) {
 			assert.NotNil(t, resp.Distance)
 			assert.Equal(t, variables.distances["event1"]["2021"][0].Name, resp.Distance.Name)
 			assert.Equal(t, variables.distances["event1"]["2021"][0].Certification, resp.Distance.Certification)
+			// splits
+			if assert.Equal(t, 1, len(resp.Splits)) {
+				assert.True(t, resp.Splits[0].Finish)
+				assert.Equal(t, "Start/Finish", resp.Splits[0].Location)
+				assert.Equal(t, 1, resp.Splits[0].Ranking)
+				assert.Equal(t, 0, resp.Splits[0].BehindSeconds)
+			}
 		}
 	}
 	// Test a valid request
@@ -2508,6 +2587,221 @@ func TestGetBibResults(t *testing.T) {
 	}
 }
 
+func TestSearchResults(t *testing.T) {
+	// POST, /results/search
+	variables, finalize := setupTests(t)
+	defer finalize(t)
+	h := Handler{}
+	year := "2021"
+	request := types.SearchResultsRequest{
+		Slug:   variables.events["event2"].Slug,
+		Year:   &year,
+		Search: types.ResultSearch{Name: "SMITH"},
+	}
+	var resp types.SearchResultsResponse
+	// Test no key
+	t.Log("Testing no key given.")
+	code := jsonTestRequest(t, http.MethodPost, "/results/search", "", request, h.SearchResults, &resp)
+	assert.Equal(t, http.StatusUnauthorized, code)
+	// Test expired key
+	t.Log("Testing expired key.")
+	code = jsonTestRequest(t, http.MethodPost, "/results/search", variables.knownValues["expired"], request, h.SearchResults, &resp)
+	assert.Equal(t, http.StatusUnauthorized, code)
+	// Test empty search
+	t.Log("Testing empty search.")
+	code = jsonTestRequest(t, http.MethodPost, "/results/search", variables.knownValues["read"], types.SearchResultsRequest{
+		Slug: request.Slug,
+		Year: &year,
+	}, h.SearchResults, &resp)
+	assert.Equal(t, http.StatusBadRequest, code)
+	// Test invalid event
+	t.Log("Testing event not found.")
+	code = jsonTestRequest(t, http.MethodPost, "/results/search", variables.knownValues["read"], types.SearchResultsRequest{
+		Slug:   "invalid-event",
+		Search: request.Search,
+	}, h.SearchResults, &resp)
+	assert.Equal(t, http.StatusNotFound, code)
+	// Test restricted event
+	t.Log("Testing restricted event but unauthorized key.")
+	code = jsonTestRequest(t, http.MethodPost, "/results/search", variables.knownValues["write"], request, h.SearchResults, &resp)
+	assert.Equal(t, http.StatusUnauthorized, code)
+	// Test valid request
+	t.Log("Testing valid request.")
+	results, err := database.GetDistanceResults(variables.eventYears["event2"]["2021"].Identifier, "", 0, 0)
+	if err != nil {
+		t.Fatalf("Error getting results: %v", err)
+	}
+	expected := db.SearchResults(results, request.Search)
+	assert.NotEqual(t, 0, len(expected))
+	resp = types.SearchResultsResponse{}
+	code = jsonTestRequest(t, http.MethodPost, "/results/search", variables.knownValues["read"], request, h.SearchResults, &resp)
+	if assert.Equal(t, http.StatusOK, code) {
+		assert.Equal(t, variables.events["event2"].Slug, resp.Event.Slug)
+		assert.Equal(t, len(expected), resp.Count)
+		assert.Equal(t, len(expected), len(resp.Results))
+		for _, res := range resp.Results {
+			assert.False(t, res.Anonymous)
+			assert.Contains(t, strings.ToLower(res.Last), "smith")
+		}
+	}
+	// Test partial bib
+	t.Log("Testing partial bib.")
+	bib := expected[0].Bib[:1]
+	resp = types.SearchResultsResponse{}
+	code = jsonTestRequest(t, http.MethodPost, "/results/search", variables.knownValues["read"], types.SearchResultsRequest{
+		Slug:   request.Slug,
+		Year:   &year,
+		Search: types.ResultSearch{Bib: bib},
+	}, h.SearchResults, &resp)
+	if assert.Equal(t, http.StatusOK, code) && assert.NotEqual(t, 0, len(resp.Results)) {
+		for _, res := range resp.Results {
+			assert.Contains(t, res.Bib, bib)
+		}
+	}
+	// Test pagination
+	t.Log("Testing pagination.")
+	limit := 1
+	page := 2
+	request.Limit = &limit
+	request.Page = &page
+	resp = types.SearchResultsResponse{}
+	code = jsonTestRequest(t, http.MethodPost, "/results/search", variables.knownValues["read"], request, h.SearchResults, &resp)
+	if assert.Equal(t, http.StatusOK, code) {
+		assert.Equal(t, len(expected), resp.Count)
+		if len(expected) > 1 && assert.Equal(t, 1, len(resp.Results)) {
+			assert.Equal(t, expected[1].Bib, resp.Results[0].Bib)
+		}
+	}
+	page = 1000
+	resp = types.SearchResultsResponse{}
+	code = jsonTestRequest(t, http.MethodPost, "/results/search", variables.knownValues["read"], request, h.SearchResults, &resp)
+	if assert.Equal(t, http.StatusOK, code) {
+		assert.Equal(t, len(expected), resp.Count)
+		assert.Equal(t, 0, len(resp.Results))
+	}
+}
+
+func TestGetLocationResults(t *testing.T) {
+	// POST, /results/location
+	variables, finalize := setupTests(t)
+	defer finalize(t)
+	h := Handler{}
+	h.Setup()
+	eventYearID := variables.eventYears["event1"]["2021"].Identifier
+	_, err := database.AddParticipants(eventYearID, []types.Participant{
+		{AlternateId: "A1", Bib: "A1", First: "Ann", Last: "Lee", Gender: "Woman", AgeGroup: "20-29", Distance: "Marathon"},
+		{AlternateId: "A2", Bib: "A2", First: "Bob", Last: "Ray", Gender: "Man", AgeGroup: "20-29", Distance: "Marathon"},
+		{AlternateId: "A3", Bib: "A3", First: "Cat", Last: "Kim", Gender: "Woman", AgeGroup: "20-29", Distance: "Marathon"},
+	})
+	if err != nil {
+		t.Fatalf("Error adding participants: %v", err)
+	}
+	aid := types.Result{
+		PersonId:  "A1",
+		Bib:       "A1",
+		First:     "Ann",
+		Last:      "Lee",
+		Gender:    "Woman",
+		AgeGroup:  "20-29",
+		Distance:  "Marathon",
+		Seconds:   3600,
+		Location:  "Aid 1",
+		Occurence: 1,
+	}
+	start := aid
+	start.PersonId, start.Bib, start.First, start.Last = "A2", "A2", "Bob", "Ray"
+	start.Location, start.Seconds, start.Occurence = "Start/Finish", 0, 0
+	early := aid
+	early.PersonId, early.Bib, early.First, early.Last = "0", "0", "John0", "Smith"
+	early.Seconds = 300
+	_, err = database.AddResults(eventYearID, []types.Result{aid, start, early}, nil)
+	if err != nil {
+		t.Fatalf("Error adding results: %v", err)
+	}
+	year := "2021"
+	distance := "Marathon"
+	request := types.GetLocationResultsRequest{
+		Slug:     "event1",
+		Year:     &year,
+		Distance: &distance,
+		Location: "Aid 1",
+	}
+	var resp types.GetLocationResultsResponse
+	// Test no key
+	t.Log("Testing no key given.")
+	code := jsonTestRequest(t, http.MethodPost, "/results/location", "", request, h.GetLocationResults, &resp)
+	assert.Equal(t, http.StatusUnauthorized, code)
+	// Test expired key
+	t.Log("Testing expired key.")
+	code = jsonTestRequest(t, http.MethodPost, "/results/location", variables.knownValues["expired"], request, h.GetLocationResults, &resp)
+	assert.Equal(t, http.StatusUnauthorized, code)
+	// Test invalid host
+	t.Log("Testing invalid host.")
+	code = jsonTestRequest(t, http.MethodPost, "/results/location", variables.knownValues["delete"], request, h.GetLocationResults, &resp)
+	assert.Equal(t, http.StatusUnauthorized, code)
+	// Test no location
+	t.Log("Testing no location.")
+	noLocation := request
+	noLocation.Location = ""
+	code = jsonTestRequest(t, http.MethodPost, "/results/location", variables.knownValues["read"], noLocation, h.GetLocationResults, &resp)
+	assert.Equal(t, http.StatusBadRequest, code)
+	// Test event year not found
+	t.Log("Testing event year not found.")
+	notFound := request
+	notFound.Slug = "not-an-event"
+	code = jsonTestRequest(t, http.MethodPost, "/results/location", variables.knownValues["read"], notFound, h.GetLocationResults, &resp)
+	assert.Equal(t, http.StatusNotFound, code)
+	// Test restricted event
+	t.Log("Testing restricted event.")
+	restricted := request
+	restricted.Slug = "event2"
+	code = jsonTestRequest(t, http.MethodPost, "/results/location", variables.knownValues["write"], restricted, h.GetLocationResults, &resp)
+	assert.Equal(t, http.StatusUnauthorized, code)
+	// Test valid request
+	t.Log("Testing valid request.")
+	code = jsonTestRequest(t, http.MethodPost, "/results/location", variables.knownValues["read"], request, h.GetLocationResults, &resp)
+	if assert.Equal(t, http.StatusOK, code) {
+		assert.Equal(t, "Aid 1", resp.Location)
+		assert.Equal(t, 1, resp.Occurence)
+		if assert.Equal(t, 2, len(resp.Results)) {
+			assert.Equal(t, "0", resp.Results[0].Bib)
+			assert.Equal(t, 1, resp.Results[0].Arrival)
+			assert.Equal(t, "A1", resp.Results[1].Bib)
+			assert.Equal(t, 2, resp.Results[1].Arrival)
+			assert.Equal(t, 3300, resp.Results[1].BehindSeconds)
+		}
+		// Everyone else in the marathon has finished.
+		assert.Equal(t, 2, resp.Expected)
+	}
+	// Test location nobody has reached
+	t.Log("Testing location nobody has reached.")
+	resp = types.GetLocationResultsResponse{}
+	request.Location = "Aid 2"
+	code = jsonTestRequest(t, http.MethodPost, "/results/location", variables.knownValues["read"], request, h.GetLocationResults, &resp)
+	if assert.Equal(t, http.StatusOK, code) {
+		assert.Equal(t, 0, len(resp.Results))
+		assert.Equal(t, 3, resp.Expected)
+	}
+	// Test hidden distance
+	t.Log("Testing hidden distance.")
+	_, err = database.SetPublishSettings(eventYearID, types.PublishSettings{HiddenDistances: []string{"Marathon"}})
+	if err != nil {
+		t.Fatalf("Error setting publish settings: %v", err)
+	}
+	request.Distance = nil
+	resp = types.GetLocationResultsResponse{}
+	code = jsonTestRequest(t, http.MethodPost, "/results/location", variables.knownValues["read"], request, h.GetLocationResults, &resp)
+	if assert.Equal(t, http.StatusOK, code) {
+		assert.Equal(t, 0, len(resp.Results))
+		assert.Equal(t, 0, resp.Expected)
+	}
+	resp = types.GetLocationResultsResponse{}
+	code = jsonTestRequest(t, http.MethodPost, "/results/location", variables.knownValues["write"], request, h.GetLocationResults, &resp)
+	if assert.Equal(t, http.StatusOK, code) {
+		assert.Equal(t, 3, resp.Expected)
+	}
+}
+
 func TestAddResults(t *testing.T) {
 	// POST, /results/add
 	variables, finalize := setupTests(t)
@@ -2641,9 +2935,15 @@ func TestAddResults(t *testing.T) {
 	t.Log("Verifying information was added.")
 	uploaded, err := database.GetResults(eventYear.Identifier, 0, 0)
 	if assert.NoError(t, err) {
+		// rankings are calculated on upload
+		ranked := db.CalculateRankings(results, eventYear.RankingType)
+		// statuses are set on upload
+		for ix := range ranked {
+			ranked[ix].NormalizeStatus()
+		}
 		found := 0
 		for _, res := range uploaded {
-			for _, inner := range results {
+			for _, inner := range ranked {
 				if res == inner {
 					found++
 				}
@@ -2679,9 +2979,15 @@ func TestAddResults(t *testing.T) {
 	t.Log("Verifying information was updated.")
 	uploaded, err = database.GetResults(eventYear.Identifier, 0, 0)
 	if assert.NoError(t, err) {
+		// rankings are calculated on upload
+		ranked := db.CalculateRankings(results, eventYear.RankingType)
+		// statuses are set on upload
+		for ix := range ranked {
+			ranked[ix].NormalizeStatus()
+		}
 		found := 0
 		for _, res := range uploaded {
-			for _, inner := range results {
+			for _, inner := range ranked {
 				if res == inner {
 					found++
 				}
@@ -2973,6 +3279,131 @@ func TestAddResults(t *testing.T) {
 			assert.Equal(t, len(results)-1, resp.Count)
 		}
 	}
+	// Test outcomes for each result
+	t.Log("Testing result outcomes.")
+	added := results[1]
+	added.Location = "Outcome Test"
+	invalid := results[1]
+	invalid.Bib = ""
+	body, err = json.Marshal(types.AddResultsRequest{
+		Slug:    variables.events["event2"].Slug,
+		Year:    "2023",
+		Results: []types.Result{results[1], added, invalid},
+	})
+	if err != nil {
+		t.Fatalf("Error encoding request body into json object: %v", err)
+	}
+	request = httptest.NewRequest(http.MethodPost, "/results/add", strings.NewReader(string(body)))
+	request.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	request.Header.Set(echo.HeaderAuthorization, "Bearer "+variables.knownValues["delete2"])
+	response = httptest.NewRecorder()
+	c = e.NewContext(request, response)
+	if assert.NoError(t, h.AddResults(c)) {
+		assert.Equal(t, http.StatusOK, response.Code)
+		var resp types.AddResultsResponse
+		if assert.NoError(t, json.Unmarshal(response.Body.Bytes(), &resp)) {
+			assert.Equal(t, 2, resp.Count)
+			if assert.Equal(t, 3, len(resp.Outcomes)) {
+				assert.Equal(t, 0, resp.Outcomes[0].Index)
+				assert.Equal(t, util.ITEM_STATUS_UPDATED, resp.Outcomes[0].Status)
+				assert.Equal(t, util.ITEM_STATUS_INSERTED, resp.Outcomes[1].Status)
+				assert.Equal(t, 2, resp.Outcomes[2].Index)
+				assert.Equal(t, util.ITEM_STATUS_REJECTED, resp.Outcomes[2].Status)
+				assert.Equal(t, "bib", resp.Outcomes[2].Field)
+				assert.Equal(t, "bib is required", resp.Outcomes[2].Reason)
+			}
+		}
+	}
+	// Test all or nothing
+	t.Log("Testing all or nothing.")
+	added.Location = "All Or Nothing"
+	body, err = json.Marshal(types.AddResultsRequest{
+		Slug:         variables.events["event2"].Slug,
+		Year:         "2023",
+		Results:      []types.Result{added, invalid},
+		AllOrNothing: true,
+	})
+	if err != nil {
+		t.Fatalf("Error encoding request body into json object: %v", err)
+	}
+	request = httptest.NewRequest(http.MethodPost, "/results/add", strings.NewReader(string(body)))
+	request.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	request.Header.Set(echo.HeaderAuthorization, "Bearer "+variables.knownValues["delete2"])
+	response = httptest.NewRecorder()
+	c = e.NewContext(request, response)
+	if assert.NoError(t, h.AddResults(c)) {
+		assert.Equal(t, http.StatusBadRequest, response.Code)
+		var resp types.AddResultsResponse
+		if assert.NoError(t, json.Unmarshal(response.Body.Bytes(), &resp)) {
+			assert.Equal(t, 0, resp.Count)
+			if assert.Equal(t, 2, len(resp.Outcomes)) {
+				assert.Equal(t, util.ITEM_STATUS_SKIPPED, resp.Outcomes[0].Status)
+				assert.Equal(t, util.ITEM_STATUS_REJECTED, resp.Outcomes[1].Status)
+			}
+		}
+		uploaded, err := database.GetResults(eventYear.Identifier, 0, 0)
+		if assert.NoError(t, err) {
+			for _, res := range uploaded {
+				assert.NotEqual(t, "All Or Nothing", res.Location)
+			}
+		}
+	}
+	// Test statuses
+	t.Log("Testing result statuses.")
+	dnf := results[1]
+	dnf.Location = "Status Test"
+	dnf.Type = types.ResultTypeDNF
+	dnf.Seconds = 0
+	dq := results[2]
+	dq.Location = "Status Test"
+	dq.Status = types.ResultStatusDQ
+	dq.StatusReason = "Course cut"
+	badStatus := results[3]
+	badStatus.Status = "lost"
+	body, err = json.Marshal(types.AddResultsRequest{
+		Slug:    variables.events["event2"].Slug,
+		Year:    "2023",
+		Results: []types.Result{dnf, dq, badStatus},
+	})
+	if err != nil {
+		t.Fatalf("Error encoding request body into json object: %v", err)
+	}
+	request = httptest.NewRequest(http.MethodPost, "/results/add", strings.NewReader(string(body)))
+	request.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	request.Header.Set(echo.HeaderAuthorization, "Bearer "+variables.knownValues["delete2"])
+	response = httptest.NewRecorder()
+	c = e.NewContext(request, response)
+	if assert.NoError(t, h.AddResults(c)) {
+		assert.Equal(t, http.StatusOK, response.Code)
+		var resp types.AddResultsResponse
+		if assert.NoError(t, json.Unmarshal(response.Body.Bytes(), &resp)) {
+			assert.Equal(t, 2, resp.Count)
+			if assert.Equal(t, 3, len(resp.Outcomes)) {
+				assert.Equal(t, util.ITEM_STATUS_REJECTED, resp.Outcomes[2].Status)
+				assert.Equal(t, "status", resp.Outcomes[2].Field)
+			}
+		}
+		uploaded, err := database.GetResults(eventYear.Identifier, 0, 0)
+		if assert.NoError(t, err) {
+			found := 0
+			for _, res := range uploaded {
+				if res.Location != "Status Test" {
+					continue
+				}
+				found++
+				if res.Bib == dnf.Bib {
+					assert.Equal(t, types.ResultStatusDNF, res.Status)
+					assert.Equal(t, 0, res.Seconds)
+					assert.Equal(t, db.Unranked, res.Ranking)
+				} else if res.Bib == dq.Bib {
+					assert.Equal(t, types.ResultStatusDQ, res.Status)
+					assert.Equal(t, "Course cut", res.StatusReason)
+					assert.Equal(t, db.Unranked, res.Ranking)
+				}
+			}
+			assert.Equal(t, 2, found)
+		}
+	}
 }
 
 func TestDeleteResults(t *testing.T) {
@@ -128,6 +128,10 @@ func (h Handler) AddSegments(c *echo.Context) error {
 	if mult.Event.AccountIdentifier != mkey.Account.Identifier {
 		return getAPIError(c, http.StatusUnauthorized, "Ownership Error", nil)
 	}
+	// Official results can't be changed until an admin unlocks the event year.
+	if mult.EventYear.Locked() {
+		return getAPIError(c, http.StatusForbidden, "Event Year Is Locked", nil)
+	}
 	segments, err := database.AddSegments(mult.EventYear.Identifier, segToAdd)
 	if err != nil {
 		return getAPIError(c, http.StatusInternalServerError, "Error Adding Segments", err)
@@ -182,6 +186,10 @@ func (h Handler) DeleteSegments(c *echo.Context) error {
 	if mult.Event.AccountIdentifier != mkey.Account.Identifier {
 		return getAPIError(c, http.StatusUnauthorized, "Ownership Error", nil)
 	}
+	// Official results can't be changed until an admin unlocks the event year.
+	if mult.EventYear.Locked() {
+		return getAPIError(c, http.StatusForbidden, "Event Year Is Locked", nil)
+	}
 	count, err := database.DeleteSegments(mult.EventYear.Identifier)
 	if err != nil {
 		return getAPIError(c, http.StatusInternalServerError, "Error Deleting Segments", nil)
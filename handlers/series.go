@@ -0,0 +1,274 @@
+/*
+Chronokeep Desktop - Race Scoring Software
+Copyright (C) 2026 James Sentinella
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package handlers
+
+import (
+	db "chronokeep/results/database"
+	"chronokeep/results/types"
+	"net/http"
+
+	"github.com/labstack/echo/v5"
+)
+
+func (h Handler) GetSeries(c *echo.Context) error {
+	// Get Key from Authorization Header
+	k, err := retrieveKey(c.Request())
+	if err != nil {
+		return getAPIError(c, http.StatusUnauthorized, "Error Getting Key From Authorization Header", err)
+	}
+	if k == nil {
+		return getAPIError(c, http.StatusUnauthorized, "Key Not Provided in Authorization Header", nil)
+	}
+	var request types.GetSeriesRequest
+	if err := c.Bind(&request); err != nil {
+		return getAPIError(c, http.StatusBadRequest, "Invalid Request Body", err)
+	}
+	// Get Key
+	mkey, err := database.GetKeyAndAccount(*k)
+	if err != nil {
+		return getAPIError(c, http.StatusInternalServerError, "Error Retrieving Key/Account", err)
+	}
+	if mkey == nil || mkey.Key == nil || mkey.Account == nil {
+		return getAPIError(c, http.StatusUnauthorized, "Key/Account Not Found", nil)
+	}
+	// Check for expired key
+	if mkey.Key.Expired() {
+		return getAPIError(c, http.StatusUnauthorized, "Expired Key", nil)
+	}
+	// Check for host being allowed.
+	if !mkey.Key.IsAllowed(c.Request().Referer()) {
+		return getAPIError(c, http.StatusUnauthorized, "Host Not Allowed", nil)
+	}
+	series, err := database.GetSeries(request.Slug)
+	if err != nil {
+		return getAPIError(c, http.StatusInternalServerError, "Error Retrieving Series", err)
+	}
+	if series == nil {
+		return getAPIError(c, http.StatusNotFound, "Series Not Found", nil)
+	}
+	return c.JSON(http.StatusOK, types.GetSeriesResponse{
+		Series: *series,
+	})
+}
+
+func (h Handler) GetSeriesStandings(c *echo.Context) error {
+	// Get Key from Authorization Header
+	k, err := retrieveKey(c.Request())
+	if err != nil {
+		return getAPIError(c, http.StatusUnauthorized, "Error Getting Key From Authorization Header", err)
+	}
+	if k == nil {
+		return getAPIError(c, http.StatusUnauthorized, "Key Not Provided in Authorization Header", nil)
+	}
+	var request types.GetSeriesStandingsRequest
+	if err := c.Bind(&request); err != nil {
+		return getAPIError(c, http.StatusBadRequest, "Invalid Request Body", err)
+	}
+	category := types.SeriesCategoryOverall
+	if request.Category != nil {
+		category = *request.Category
+	}
+	if category != types.SeriesCategoryOverall && category != types.SeriesCategoryGender && category != types.SeriesCategoryAgeGroup {
+		return getAPIError(c, http.StatusBadRequest, "Invalid Category", nil)
+	}
+	// Get Key
+	mkey, err := database.GetKeyAndAccount(*k)
+	if err != nil {
+		return getAPIError(c, http.StatusInternalServerError, "Error Retrieving Key/Account", err)
+	}
+	if mkey == nil || mkey.Key == nil || mkey.Account == nil {
+		return getAPIError(c, http.StatusUnauthorized, "Key/Account Not Found", nil)
+	}
+	// Check for expired key
+	if mkey.Key.Expired() {
+		return getAPIError(c, http.StatusUnauthorized, "Expired Key", nil)
+	}
+	// Check for host being allowed.
+	if !mkey.Key.IsAllowed(c.Request().Referer()) {
+		return getAPIError(c, http.StatusUnauthorized, "Host Not Allowed", nil)
+	}
+	series, err := database.GetSeries(request.Slug)
+	if err != nil {
+		return getAPIError(c, http.StatusInternalServerError, "Error Retrieving Series", err)
+	}
+	if series == nil {
+		return getAPIError(c, http.StatusNotFound, "Series Not Found", nil)
+	}
+	races := make([]db.SeriesRace, 0, len(series.Events))
+	for _, event := range series.Events {
+		mult, err := database.GetEventAndYear(event.Slug, event.Year)
+		if err != nil {
+			return getAPIError(c, http.StatusInternalServerError, "Error Retrieving Event/Year", err)
+		}
+		// Event years that have been deleted no longer count towards the series.
+		if mult == nil || mult.Event == nil || mult.EventYear == nil {
+			continue
+		}
+		if mult.Event.AccessRestricted && mkey.Account.Identifier != mult.Event.AccountIdentifier {
+			return getAPIError(c, http.StatusUnauthorized, "Restricted Event", nil)
+		}
+		results, err := database.GetFinishResults(mult.EventYear.Identifier, event.Distance, 0, 0)
+		if err != nil {
+			return getAPIError(c, http.StatusInternalServerError, "Error Retrieving Results", err)
+		}
+		results, err = publishedResults(mult.Event, mult.EventYear, mkey.Account, results)
+		if err != nil {
+			return getAPIError(c, http.StatusInternalServerError, "Error Retrieving Publish Settings", err)
+		}
+		races = append(races, db.SeriesRace{
+			Event:       event,
+			RankingType: mult.EventYear.RankingType,
+			Results:     results,
+		})
+	}
+	return c.JSON(http.StatusOK, types.GetSeriesStandingsResponse{
+		Series:    *series,
+		Category:  category,
+		Standings: db.CalculateSeriesStandings(*series, races, category),
+	})
+}
+
+func (h Handler) AddSeries(c *echo.Context) error {
+	// Get Key from Authorization Header
+	k, err := retrieveKey(c.Request())
+	if err != nil {
+		return getAPIError(c, http.StatusUnauthorized, "Error Getting Key From Authorization Header", err)
+	}
+	if k == nil {
+		return getAPIError(c, http.StatusUnauthorized, "Key Not Provided in Authorization Header", nil)
+	}
+	var request types.AddSeriesRequest
+	if err := c.Bind(&request); err != nil {
+		return getAPIError(c, http.StatusBadRequest, "Invalid Request Body", err)
+	}
+	// Validate the Series
+	if err := request.Series.Validate(h.validate); err != nil {
+		return getAPIError(c, http.StatusBadRequest, "Validation Error", err)
+	}
+	// Get Key
+	mkey, err := database.GetKeyAndAccount(*k)
+	if err != nil {
+		return getAPIError(c, http.StatusInternalServerError, "Error Retrieving Key/Account", err)
+	}
+	if mkey == nil || mkey.Key == nil || mkey.Account == nil {
+		return getAPIError(c, http.StatusUnauthorized, "Key/Account Not Found", nil)
+	}
+	// Check for expired key
+	if mkey.Key.Expired() {
+		return getAPIError(c, http.StatusUnauthorized, "Expired Key", nil)
+	}
+	// Check for host being allowed.
+	if !mkey.Key.IsAllowed(c.Request().Referer()) {
+		return getAPIError(c, http.StatusUnauthorized, "Host Not Allowed", nil)
+	}
+	// Verify key access level.  Readonly cannot write or modify values.
+	if mkey.Key.Type == "read" {
+		return getAPIError(c, http.StatusUnauthorized, "Key is ReadOnly", nil)
+	}
+	existing, err := database.GetSeries(request.Series.Slug)
+	if err != nil {
+		return getAPIError(c, http.StatusInternalServerError, "Error Retrieving Series", err)
+	}
+	if existing != nil && existing.AccountIdentifier != mkey.Account.Identifier {
+		return getAPIError(c, http.StatusUnauthorized, "Ownership Error", nil)
+	}
+	// Only event years owned by the account can be added to a series.
+	series := request.Series
+	series.AccountIdentifier = mkey.Account.Identifier
+	found := make(map[int64]bool)
+	for ix, event := range series.Events {
+		mult, err := database.GetEventAndYear(event.Slug, event.Year)
+		if err != nil {
+			return getAPIError(c, http.StatusInternalServerError, "Error Retrieving Event/Year", err)
+		}
+		if mult == nil || mult.Event == nil || mult.EventYear == nil {
+			return getAPIError(c, http.StatusNotFound, "Event/Year Not Found", nil)
+		}
+		if mult.Event.AccountIdentifier != mkey.Account.Identifier {
+			return getAPIError(c, http.StatusUnauthorized, "Ownership Error", nil)
+		}
+		if found[mult.EventYear.Identifier] {
+			return getAPIError(c, http.StatusBadRequest, "Duplicate Series Event", nil)
+		}
+		found[mult.EventYear.Identifier] = true
+		series.Events[ix].EventYearIdentifier = mult.EventYear.Identifier
+	}
+	_, err = database.AddSeries(series)
+	if err != nil {
+		return getAPIError(c, http.StatusInternalServerError, "Error Adding Series", err)
+	}
+	out, err := database.GetSeries(series.Slug)
+	if err != nil {
+		return getAPIError(c, http.StatusInternalServerError, "Error Retrieving Series", err)
+	}
+	return c.JSON(http.StatusOK, types.ModifySeriesResponse{
+		Series: *out,
+	})
+}
+
+func (h Handler) DeleteSeries(c *echo.Context) error {
+	// Get Key from Authorization Header
+	k, err := retrieveKey(c.Request())
+	if err != nil {
+		return getAPIError(c, http.StatusUnauthorized, "Error Getting Key From Authorization Header", err)
+	}
+	if k == nil {
+		return getAPIError(c, http.StatusUnauthorized, "Key Not Provided in Authorization Header", nil)
+	}
+	var request types.DeleteSeriesRequest
+	if err := c.Bind(&request); err != nil {
+		return getAPIError(c, http.StatusBadRequest, "Invalid Request Body", err)
+	}
+	// Get Key
+	mkey, err := database.GetKeyAndAccount(*k)
+	if err != nil {
+		return getAPIError(c, http.StatusInternalServerError, "Error Retrieving Key/Account", err)
+	}
+	if mkey == nil || mkey.Key == nil || mkey.Account == nil {
+		return getAPIError(c, http.StatusUnauthorized, "Key/Account Not Found", nil)
+	}
+	// Check for expired key
+	if mkey.Key.Expired() {
+		return getAPIError(c, http.StatusUnauthorized, "Expired Key", nil)
+	}
+	// Check for host being allowed.
+	if !mkey.Key.IsAllowed(c.Request().Referer()) {
+		return getAPIError(c, http.StatusUnauthorized, "Host Not Allowed", nil)
+	}
+	// Verify access level. Delete is the only level that can delete values.
+	if mkey.Key.Type != "delete" {
+		return getAPIError(c, http.StatusUnauthorized, "Key is ReadOnly/Write", nil)
+	}
+	series, err := database.GetSeries(request.Slug)
+	if err != nil {
+		return getAPIError(c, http.StatusInternalServerError, "Error Retrieving Series", err)
+	}
+	if series == nil {
+		return getAPIError(c, http.StatusNotFound, "Series Not Found", nil)
+	}
+	if series.AccountIdentifier != mkey.Account.Identifier {
+		return getAPIError(c, http.StatusUnauthorized, "Ownership Error", nil)
+	}
+	err = database.DeleteSeries(*series)
+	if err != nil {
+		return getAPIError(c, http.StatusInternalServerError, "Error Deleting Series", err)
+	}
+	return c.NoContent(http.StatusOK)
+}
+
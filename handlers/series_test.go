@@ -0,0 +1,351 @@
+/*
+Chronokeep Desktop - Race Scoring Software
+Copyright (C) 2026 James Sentinella
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package handlers
+
+import (
+	"chronokeep/results/types"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func setupTestSeries() types.Series {
+	return types.Series{
+		Slug:        "fall-series",
+		Name:        "Fall Series",
+		Points:      []int{10, 8},
+		TieBreakers: []string{types.SeriesTieMostRecent},
+		Identity:    types.SeriesIdentityName,
+		Events: []types.SeriesEvent{
+			{Slug: "event1", Year: "2021", Distance: "5K"},
+			{Slug: "event1", Year: "2020", Distance: "5K"},
+		},
+	}
+}
+
+func seriesTestResult(first, last, gender string, ranking, genderRanking int) types.Result {
+	return types.Result{
+		PersonId:      first,
+		Bib:           first,
+		First:         first,
+		Last:          last,
+		Age:           30,
+		Gender:        gender,
+		AgeGroup:      "30-39",
+		Distance:      "5K",
+		Seconds:       1000 + ranking*10,
+		Location:      "Start/Finish",
+		Occurence:     1,
+		Ranking:       ranking,
+		GenderRanking: genderRanking,
+		AgeRanking:    genderRanking,
+		Finish:        true,
+	}
+}
+
+func TestGetSeries(t *testing.T) {
+	// POST, /series
+	variables, finalize := setupTests(t)
+	defer finalize(t)
+	h := Handler{}
+	h.Setup()
+	request := types.GetSeriesRequest{
+		Slug: "fall-series",
+	}
+	var resp types.GetSeriesResponse
+	// Test no key
+	t.Log("Testing no key given.")
+	code := jsonTestRequest(t, http.MethodPost, "/series", "", request, h.GetSeries, &resp)
+	assert.Equal(t, http.StatusUnauthorized, code)
+	// Test expired key
+	t.Log("Testing expired key.")
+	code = jsonTestRequest(t, http.MethodPost, "/series", variables.knownValues["expired"], request, h.GetSeries, &resp)
+	assert.Equal(t, http.StatusUnauthorized, code)
+	// Test invalid key
+	t.Log("Testing invalid key.")
+	code = jsonTestRequest(t, http.MethodPost, "/series", "not-a-valid-key", request, h.GetSeries, &resp)
+	assert.Equal(t, http.StatusUnauthorized, code)
+	// Test invalid host
+	t.Log("Testing invalid host.")
+	code = jsonTestRequest(t, http.MethodPost, "/series", variables.knownValues["delete"], request, h.GetSeries, &resp)
+	assert.Equal(t, http.StatusUnauthorized, code)
+	// Test series not found
+	t.Log("Testing series not found.")
+	code = jsonTestRequest(t, http.MethodPost, "/series", variables.knownValues["read"], request, h.GetSeries, &resp)
+	assert.Equal(t, http.StatusNotFound, code)
+	// Test valid request
+	t.Log("Testing valid request.")
+	series := setupTestSeries()
+	series.AccountIdentifier = variables.accounts[0].Identifier
+	for ix := range series.Events {
+		series.Events[ix].EventYearIdentifier = variables.eventYears["event1"][series.Events[ix].Year].Identifier
+	}
+	if _, err := database.AddSeries(series); err != nil {
+		t.Fatalf("Error adding series: %v", err)
+	}
+	code = jsonTestRequest(t, http.MethodPost, "/series", variables.knownValues["read"], request, h.GetSeries, &resp)
+	if assert.Equal(t, http.StatusOK, code) {
+		assert.Equal(t, series.Name, resp.Series.Name)
+		assert.Equal(t, series.Points, resp.Series.Points)
+		// Events are ordered by date.
+		if assert.Equal(t, 2, len(resp.Series.Events)) {
+			assert.Equal(t, "2020", resp.Series.Events[0].Year)
+			assert.Equal(t, "2021", resp.Series.Events[1].Year)
+		}
+	}
+}
+
+func TestAddSeries(t *testing.T) {
+	// POST, /series/add
+	variables, finalize := setupTests(t)
+	defer finalize(t)
+	h := Handler{}
+	h.Setup()
+	request := types.AddSeriesRequest{
+		Series: setupTestSeries(),
+	}
+	var resp types.ModifySeriesResponse
+	// Test no key
+	t.Log("Testing no key given.")
+	code := jsonTestRequest(t, http.MethodPost, "/series/add", "", request, h.AddSeries, &resp)
+	assert.Equal(t, http.StatusUnauthorized, code)
+	// Test expired key
+	t.Log("Testing expired key.")
+	code = jsonTestRequest(t, http.MethodPost, "/series/add", variables.knownValues["expired"], request, h.AddSeries, &resp)
+	assert.Equal(t, http.StatusUnauthorized, code)
+	// Test invalid key
+	t.Log("Testing invalid key.")
+	code = jsonTestRequest(t, http.MethodPost, "/series/add", "not-a-valid-key", request, h.AddSeries, &resp)
+	assert.Equal(t, http.StatusUnauthorized, code)
+	// Test read key
+	t.Log("Testing read key.")
+	code = jsonTestRequest(t, http.MethodPost, "/series/add", variables.knownValues["read"], request, h.AddSeries, &resp)
+	assert.Equal(t, http.StatusUnauthorized, code)
+	// Test events owned by another account
+	t.Log("Testing events owned by another account.")
+	code = jsonTestRequest(t, http.MethodPost, "/series/add", variables.knownValues["write2"], request, h.AddSeries, &resp)
+	assert.Equal(t, http.StatusUnauthorized, code)
+	// Test invalid series
+	t.Log("Testing invalid series.")
+	for _, invalid := range []func(s *types.Series){
+		func(s *types.Series) { s.Slug = "bad slug" },
+		func(s *types.Series) { s.Name = "" },
+		func(s *types.Series) { s.Points = []int{} },
+		func(s *types.Series) { s.Points = []int{10, -1} },
+		func(s *types.Series) { s.BestOf = -1 },
+		func(s *types.Series) { s.TieBreakers = []string{"coin_flip"} },
+		func(s *types.Series) { s.Identity = "bib" },
+		func(s *types.Series) { s.Events[0].Distance = "" },
+	} {
+		series := setupTestSeries()
+		invalid(&series)
+		code = jsonTestRequest(t, http.MethodPost, "/series/add", variables.knownValues["write"], types.AddSeriesRequest{Series: series}, h.AddSeries, &resp)
+		assert.Equal(t, http.StatusBadRequest, code)
+	}
+	// Test duplicate events
+	t.Log("Testing duplicate events.")
+	series := setupTestSeries()
+	series.Events = append(series.Events, series.Events[0])
+	code = jsonTestRequest(t, http.MethodPost, "/series/add", variables.knownValues["write"], types.AddSeriesRequest{Series: series}, h.AddSeries, &resp)
+	assert.Equal(t, http.StatusBadRequest, code)
+	// Test event not found
+	t.Log("Testing event not found.")
+	series = setupTestSeries()
+	series.Events[0].Year = "1999"
+	code = jsonTestRequest(t, http.MethodPost, "/series/add", variables.knownValues["write"], types.AddSeriesRequest{Series: series}, h.AddSeries, &resp)
+	assert.Equal(t, http.StatusNotFound, code)
+	// Test valid request
+	t.Log("Testing valid request.")
+	code = jsonTestRequest(t, http.MethodPost, "/series/add", variables.knownValues["write"], request, h.AddSeries, &resp)
+	if assert.Equal(t, http.StatusOK, code) {
+		assert.Equal(t, request.Series.Slug, resp.Series.Slug)
+		assert.Equal(t, request.Series.Points, resp.Series.Points)
+		assert.Equal(t, 2, len(resp.Series.Events))
+	}
+	// Test updating the series
+	t.Log("Testing update.")
+	request.Series.Name = "Updated Series"
+	request.Series.Events = request.Series.Events[:1]
+	code = jsonTestRequest(t, http.MethodPost, "/series/add", variables.knownValues["write"], request, h.AddSeries, &resp)
+	if assert.Equal(t, http.StatusOK, code) {
+		assert.Equal(t, "Updated Series", resp.Series.Name)
+		assert.Equal(t, 1, len(resp.Series.Events))
+	}
+	// Test updating a series owned by another account
+	t.Log("Testing series owned by another account.")
+	request.Series.Events = []types.SeriesEvent{{Slug: "event2", Year: "2021", Distance: "5K"}}
+	code = jsonTestRequest(t, http.MethodPost, "/series/add", variables.knownValues["write2"], request, h.AddSeries, &resp)
+	assert.Equal(t, http.StatusUnauthorized, code)
+}
+
+func TestDeleteSeries(t *testing.T) {
+	// DELETE, /series/delete
+	variables, finalize := setupTests(t)
+	defer finalize(t)
+	h := Handler{}
+	h.Setup()
+	series := setupTestSeries()
+	series.AccountIdentifier = variables.accounts[0].Identifier
+	for ix := range series.Events {
+		series.Events[ix].EventYearIdentifier = variables.eventYears["event1"][series.Events[ix].Year].Identifier
+	}
+	if _, err := database.AddSeries(series); err != nil {
+		t.Fatalf("Error adding series: %v", err)
+	}
+	request := types.DeleteSeriesRequest{
+		Slug: series.Slug,
+	}
+	// Test no key
+	t.Log("Testing no key given.")
+	code := jsonTestRequest(t, http.MethodDelete, "/series/delete", "", request, h.DeleteSeries, nil)
+	assert.Equal(t, http.StatusUnauthorized, code)
+	// Test expired key
+	t.Log("Testing expired key.")
+	code = jsonTestRequest(t, http.MethodDelete, "/series/delete", variables.knownValues["expired"], request, h.DeleteSeries, nil)
+	assert.Equal(t, http.StatusUnauthorized, code)
+	// Test write key
+	t.Log("Testing write key.")
+	code = jsonTestRequest(t, http.MethodDelete, "/series/delete", variables.knownValues["write"], request, h.DeleteSeries, nil)
+	assert.Equal(t, http.StatusUnauthorized, code)
+	// Test wrong account
+	t.Log("Testing wrong account.")
+	code = jsonTestRequest(t, http.MethodDelete, "/series/delete", variables.knownValues["delete2"], request, h.DeleteSeries, nil)
+	assert.Equal(t, http.StatusUnauthorized, code)
+	// Test series not found
+	t.Log("Testing series not found.")
+	code = jsonTestRequest(t, http.MethodDelete, "/series/delete", variables.knownValues["delete3"], types.DeleteSeriesRequest{Slug: "not-a-series"}, h.DeleteSeries, nil)
+	assert.Equal(t, http.StatusNotFound, code)
+	// Test valid request
+	t.Log("Testing valid request.")
+	code = jsonTestRequest(t, http.MethodDelete, "/series/delete", variables.knownValues["delete3"], request, h.DeleteSeries, nil)
+	assert.Equal(t, http.StatusOK, code)
+	out, err := database.GetSeries(series.Slug)
+	if assert.NoError(t, err) {
+		assert.Nil(t, out)
+	}
+}
+
+func TestGetSeriesStandings(t *testing.T) {
+	// POST, /series/standings
+	variables, finalize := setupTests(t)
+	defer finalize(t)
+	h := Handler{}
+	h.Setup()
+	series := setupTestSeries()
+	series.AccountIdentifier = variables.accounts[0].Identifier
+	for ix := range series.Events {
+		series.Events[ix].EventYearIdentifier = variables.eventYears["event1"][series.Events[ix].Year].Identifier
+	}
+	if _, err := database.AddSeries(series); err != nil {
+		t.Fatalf("Error adding series: %v", err)
+	}
+	_, err := database.AddResults(variables.eventYears["event1"]["2020"].Identifier, []types.Result{
+		seriesTestResult("Ann", "Lee", "Woman", 1, 1),
+		seriesTestResult("Bob", "Ray", "Man", 2, 1),
+	}, nil)
+	if err != nil {
+		t.Fatalf("Error adding results: %v", err)
+	}
+	_, err = database.AddResults(variables.eventYears["event1"]["2021"].Identifier, []types.Result{
+		seriesTestResult("Bob", "Ray", "Man", 1, 1),
+		seriesTestResult("Ann", "Lee", "Woman", 2, 1),
+		seriesTestResult("Cat", "Kim", "Woman", 3, 2),
+	}, nil)
+	if err != nil {
+		t.Fatalf("Error adding results: %v", err)
+	}
+	request := types.GetSeriesStandingsRequest{
+		Slug: series.Slug,
+	}
+	var resp types.GetSeriesStandingsResponse
+	// Test no key
+	t.Log("Testing no key given.")
+	code := jsonTestRequest(t, http.MethodPost, "/series/standings", "", request, h.GetSeriesStandings, &resp)
+	assert.Equal(t, http.StatusUnauthorized, code)
+	// Test expired key
+	t.Log("Testing expired key.")
+	code = jsonTestRequest(t, http.MethodPost, "/series/standings", variables.knownValues["expired"], request, h.GetSeriesStandings, &resp)
+	assert.Equal(t, http.StatusUnauthorized, code)
+	// Test invalid host
+	t.Log("Testing invalid host.")
+	code = jsonTestRequest(t, http.MethodPost, "/series/standings", variables.knownValues["delete"], request, h.GetSeriesStandings, &resp)
+	assert.Equal(t, http.StatusUnauthorized, code)
+	// Test invalid category
+	t.Log("Testing invalid category.")
+	category := "division"
+	code = jsonTestRequest(t, http.MethodPost, "/series/standings", variables.knownValues["read"], types.GetSeriesStandingsRequest{Slug: series.Slug, Category: &category}, h.GetSeriesStandings, &resp)
+	assert.Equal(t, http.StatusBadRequest, code)
+	// Test series not found
+	t.Log("Testing series not found.")
+	code = jsonTestRequest(t, http.MethodPost, "/series/standings", variables.knownValues["read"], types.GetSeriesStandingsRequest{Slug: "not-a-series"}, h.GetSeriesStandings, &resp)
+	assert.Equal(t, http.StatusNotFound, code)
+	// Test overall standings
+	t.Log("Testing overall standings.")
+	code = jsonTestRequest(t, http.MethodPost, "/series/standings", variables.knownValues["read"], request, h.GetSeriesStandings, &resp)
+	if assert.Equal(t, http.StatusOK, code) {
+		assert.Equal(t, types.SeriesCategoryOverall, resp.Category)
+		overall := resp.Standings["Overall"]
+		if assert.Equal(t, 3, len(overall)) {
+			// Bob wins the tie by doing better in the most recent race.
+			assert.Equal(t, "Bob", overall[0].First)
+			assert.Equal(t, 1, overall[0].Ranking)
+			assert.Equal(t, 18, overall[0].Points)
+			assert.Equal(t, 2, overall[0].Races)
+			assert.Equal(t, "Ann", overall[1].First)
+			assert.Equal(t, 2, overall[1].Ranking)
+			assert.Equal(t, "Cat", overall[2].First)
+			assert.Equal(t, 0, overall[2].Points)
+			if assert.Equal(t, 2, len(overall[1].Results)) {
+				assert.Equal(t, "2020", overall[1].Results[0].Year)
+				assert.Equal(t, 10, overall[1].Results[0].Points)
+			}
+		}
+	}
+	// Test gender standings
+	t.Log("Testing gender standings.")
+	category = types.SeriesCategoryGender
+	request.Category = &category
+	resp = types.GetSeriesStandingsResponse{}
+	code = jsonTestRequest(t, http.MethodPost, "/series/standings", variables.knownValues["read"], request, h.GetSeriesStandings, &resp)
+	if assert.Equal(t, http.StatusOK, code) && assert.Equal(t, 2, len(resp.Standings)) {
+		if assert.Equal(t, 2, len(resp.Standings["Woman"])) {
+			assert.Equal(t, "Ann", resp.Standings["Woman"][0].First)
+			assert.Equal(t, 20, resp.Standings["Woman"][0].Points)
+			assert.Equal(t, 8, resp.Standings["Woman"][1].Points)
+		}
+		assert.Equal(t, 1, len(resp.Standings["Man"]))
+	}
+	// Test restricted event
+	t.Log("Testing restricted event but unauthorized key.")
+	restricted := setupTestSeries()
+	restricted.Slug = "restricted-series"
+	restricted.AccountIdentifier = variables.accounts[1].Identifier
+	restricted.Events = []types.SeriesEvent{
+		{Slug: "event2", Year: "2021", Distance: "5K", EventYearIdentifier: variables.eventYears["event2"]["2021"].Identifier},
+	}
+	if _, err := database.AddSeries(restricted); err != nil {
+		t.Fatalf("Error adding series: %v", err)
+	}
+	code = jsonTestRequest(t, http.MethodPost, "/series/standings", variables.knownValues["write"], types.GetSeriesStandingsRequest{Slug: restricted.Slug}, h.GetSeriesStandings, &resp)
+	assert.Equal(t, http.StatusUnauthorized, code)
+	code = jsonTestRequest(t, http.MethodPost, "/series/standings", variables.knownValues["read"], types.GetSeriesStandingsRequest{Slug: restricted.Slug}, h.GetSeriesStandings, &resp)
+	assert.Equal(t, http.StatusOK, code)
+}
+
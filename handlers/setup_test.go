@@ -466,7 +466,7 @@ func setupTests(t *testing.T) (SetupVariables, func(t *testing.T)) {
 			Chip: "chip" + tmpStr,
 		})
 	}
-	_, err = database.AddResults(output.eventYears["event1"]["2021"].Identifier, res)
+	_, err = database.AddResults(output.eventYears["event1"]["2021"].Identifier, res, nil)
 	if err != nil {
 		t.Fatalf("Unexpected error adding 500 results to database: %v", err)
 	}
@@ -584,7 +584,7 @@ func setupTests(t *testing.T) (SetupVariables, func(t *testing.T)) {
 			Division:        "Nother",
 			DivisionRanking: 1,
 		},
-	})
+	}, nil)
 	if err != nil {
 		t.Fatalf("Unexpected error adding small number of results to database: %v", err)
 	}
@@ -781,7 +781,7 @@ func setupTests(t *testing.T) (SetupVariables, func(t *testing.T)) {
 				Division:        "Nother",
 				DivisionRanking: 1,
 			},
-		})
+		}, nil)
 		if err != nil {
 			t.Fatalf("Unexpected error adding small number of results to database: %v", err)
 		}
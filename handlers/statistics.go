@@ -0,0 +1,125 @@
+/*
+Chronokeep Desktop - Race Scoring Software
+Copyright (C) 2026 James Sentinella
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package handlers
+
+import (
+	db "chronokeep/results/database"
+	"chronokeep/results/types"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v5"
+)
+
+func (h Handler) GetStatistics(c *echo.Context) error {
+	// Get Key from Authorization Header
+	k, err := retrieveKey(c.Request())
+	if err != nil {
+		return getAPIError(c, http.StatusUnauthorized, "Error Getting Key From Authorization Header", err)
+	}
+	if k == nil {
+		return getAPIError(c, http.StatusUnauthorized, "Key Not Provided in Authorization Header", nil)
+	}
+	var request types.GetStatisticsRequest
+	if err := c.Bind(&request); err != nil {
+		return getAPIError(c, http.StatusBadRequest, "Invalid Request Body", err)
+	}
+	bucketSeconds := db.DefaultHistogramBucket
+	if request.BucketSeconds != nil {
+		bucketSeconds = *request.BucketSeconds
+	}
+	if bucketSeconds < 1 {
+		return getAPIError(c, http.StatusBadRequest, "Invalid Bucket Size", nil)
+	}
+	// Get Key
+	mkey, err := database.GetKeyAndAccount(*k)
+	if err != nil {
+		return getAPIError(c, http.StatusInternalServerError, "Error Retrieving Key/Account", err)
+	}
+	if mkey == nil || mkey.Key == nil || mkey.Account == nil {
+		return getAPIError(c, http.StatusUnauthorized, "Key/Account Not Found", nil)
+	}
+	// Check for expired key
+	if mkey.Key.Expired() {
+		return getAPIError(c, http.StatusUnauthorized, "Expired Key", nil)
+	}
+	// Check for host being allowed.
+	if !mkey.Key.IsAllowed(c.Request().Referer()) {
+		return getAPIError(c, http.StatusUnauthorized, "Host Not Allowed", nil)
+	}
+	// And Event for verification of whether or not we can allow access to this key
+	year := ""
+	if request.Year != nil {
+		year = *request.Year
+	}
+	mult, err := database.GetEventAndYear(request.Slug, year)
+	if err != nil {
+		return getAPIError(c, http.StatusInternalServerError, "Error Retrieving Event/Year", err)
+	}
+	if mult == nil || mult.Event == nil || mult.EventYear == nil {
+		return getAPIError(c, http.StatusNotFound, "Event/Year Not Found", nil)
+	}
+	if mult.Event.AccessRestricted && mkey.Account.Identifier != mult.Event.AccountIdentifier {
+		return getAPIError(c, http.StatusUnauthorized, "Restricted Event", nil)
+	}
+	distance := ""
+	if request.Distance != nil {
+		distance = *request.Distance
+	}
+	stats, err := database.GetDistanceStatistics(mult.EventYear.Identifier, distance, mult.EventYear.RankingType, bucketSeconds)
+	if err != nil {
+		return getAPIError(c, http.StatusInternalServerError, "Error Retrieving Statistics", err)
+	}
+	// Statistics give away results so they follow the same publish settings.
+	settings, err := publishSettings(mult.Event.AccountIdentifier, mult.EventYear.Identifier, mkey.Account)
+	if err != nil {
+		return getAPIError(c, http.StatusInternalServerError, "Error Retrieving Publish Settings", err)
+	}
+	if settings != nil {
+		visible := make([]types.DistanceStatistics, 0, len(stats))
+		for _, dist := range stats {
+			if settings.Published(time.Now()) && !settings.DistanceHidden(dist.Distance) {
+				visible = append(visible, dist)
+			}
+		}
+		stats = visible
+	}
+	years, err := database.GetYearStatistics(mult.Event.Identifier)
+	if err != nil {
+		return getAPIError(c, http.StatusInternalServerError, "Error Retrieving Year Statistics", err)
+	}
+	// Year totals include every distance so they're left out for years with any results hidden.
+	yearSettings, err := yearPublishSettings(mult.Event, mkey.Account)
+	if err != nil {
+		return getAPIError(c, http.StatusInternalServerError, "Error Retrieving Publish Settings", err)
+	}
+	for ix := range years {
+		if set, ok := yearSettings[years[ix].Year]; ok && (!set.Published(time.Now()) || len(set.HiddenDistances) > 0) {
+			years[ix].Starters = 0
+			years[ix].Finishers = 0
+		}
+	}
+	return c.JSON(http.StatusOK, types.GetStatisticsResponse{
+		Event:     *mult.Event,
+		EventYear: *mult.EventYear,
+		Distances: stats,
+		Years:     years,
+	})
+}
+
@@ -0,0 +1,123 @@
+/*
+Chronokeep Desktop - Race Scoring Software
+Copyright (C) 2026 James Sentinella
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package handlers
+
+import (
+	"chronokeep/results/types"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetStatistics(t *testing.T) {
+	// POST, /statistics
+	variables, finalize := setupTests(t)
+	defer finalize(t)
+	h := Handler{}
+	h.Setup()
+	year := "2021"
+	request := types.GetStatisticsRequest{
+		Slug: variables.events["event2"].Slug,
+		Year: &year,
+	}
+	var resp types.GetStatisticsResponse
+	// Test no key
+	t.Log("Testing no key given.")
+	code := jsonTestRequest(t, http.MethodPost, "/statistics", "", request, h.GetStatistics, &resp)
+	assert.Equal(t, http.StatusUnauthorized, code)
+	// Test expired key
+	t.Log("Testing expired key.")
+	code = jsonTestRequest(t, http.MethodPost, "/statistics", variables.knownValues["expired"], request, h.GetStatistics, &resp)
+	assert.Equal(t, http.StatusUnauthorized, code)
+	// Test restricted event
+	t.Log("Testing restricted event but unauthorized key.")
+	code = jsonTestRequest(t, http.MethodPost, "/statistics", variables.knownValues["write"], request, h.GetStatistics, &resp)
+	assert.Equal(t, http.StatusUnauthorized, code)
+	// Test invalid bucket size
+	t.Log("Testing invalid bucket size.")
+	bucket := 0
+	code = jsonTestRequest(t, http.MethodPost, "/statistics", variables.knownValues["read"], types.GetStatisticsRequest{
+		Slug:          request.Slug,
+		Year:          &year,
+		BucketSeconds: &bucket,
+	}, h.GetStatistics, &resp)
+	assert.Equal(t, http.StatusBadRequest, code)
+	// Test invalid event
+	t.Log("Testing event not found.")
+	code = jsonTestRequest(t, http.MethodPost, "/statistics", variables.knownValues["read"], types.GetStatisticsRequest{Slug: "invalid-event"}, h.GetStatistics, &resp)
+	assert.Equal(t, http.StatusNotFound, code)
+	// Test valid request
+	t.Log("Testing valid request.")
+	results, err := database.GetFinishResults(variables.eventYears["event2"]["2021"].Identifier, "", 0, 0)
+	if err != nil {
+		t.Fatalf("Error getting results: %v", err)
+	}
+	finishers := make(map[string]int)
+	for _, res := range results {
+		if res.ResultStatus() == types.ResultStatusFinished {
+			finishers[res.Distance]++
+		}
+	}
+	resp = types.GetStatisticsResponse{}
+	code = jsonTestRequest(t, http.MethodPost, "/statistics", variables.knownValues["read"], request, h.GetStatistics, &resp)
+	if assert.Equal(t, http.StatusOK, code) {
+		assert.Equal(t, variables.events["event2"].Slug, resp.Event.Slug)
+		assert.Equal(t, year, resp.EventYear.Year)
+		if assert.Equal(t, len(finishers), len(resp.Distances)) {
+			for _, dist := range resp.Distances {
+				assert.Equal(t, finishers[dist.Distance], dist.Finishers)
+				count := 0
+				for _, bucket := range dist.Histogram {
+					count += bucket.Count
+				}
+				assert.Equal(t, dist.Finishers, count)
+			}
+		}
+		assert.Equal(t, len(variables.eventYears["event2"]), len(resp.Years))
+	}
+	// Test a single distance
+	t.Log("Testing a single distance.")
+	distance := resp.Distances[0].Distance
+	resp = types.GetStatisticsResponse{}
+	code = jsonTestRequest(t, http.MethodPost, "/statistics", variables.knownValues["read"], types.GetStatisticsRequest{
+		Slug:     request.Slug,
+		Year:     &year,
+		Distance: &distance,
+	}, h.GetStatistics, &resp)
+	if assert.Equal(t, http.StatusOK, code) && assert.Equal(t, 1, len(resp.Distances)) {
+		assert.Equal(t, distance, resp.Distances[0].Distance)
+	}
+	// Test publish settings
+	t.Log("Testing publish settings.")
+	_, err = database.SetPublishSettings(variables.eventYears["event1"]["2021"].Identifier, types.PublishSettings{OwnerOnly: true})
+	assert.NoError(t, err)
+	request.Slug = variables.events["event1"].Slug
+	resp = types.GetStatisticsResponse{}
+	code = jsonTestRequest(t, http.MethodPost, "/statistics", variables.knownValues["read"], request, h.GetStatistics, &resp)
+	if assert.Equal(t, http.StatusOK, code) {
+		assert.Equal(t, 0, len(resp.Distances))
+	}
+	resp = types.GetStatisticsResponse{}
+	code = jsonTestRequest(t, http.MethodPost, "/statistics", variables.knownValues["write"], request, h.GetStatistics, &resp)
+	if assert.Equal(t, http.StatusOK, code) {
+		assert.NotEqual(t, 0, len(resp.Distances))
+	}
+}
+
@@ -0,0 +1,421 @@
+/*
+Chronokeep Desktop - Race Scoring Software
+Copyright (C) 2026 James Sentinella
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package handlers
+
+import (
+	"chronokeep/results/types"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+// jsonTestRequest Sends a request with a json body to a handler and decodes the response into out
+// if the request succeeds.
+func jsonTestRequest(t *testing.T, method, target, key string, request any, handle func(*echo.Context) error, out any) int {
+	body, err := json.Marshal(request)
+	if err != nil {
+		t.Fatalf("Error encoding request body into json object: %v", err)
+	}
+	req := httptest.NewRequest(method, target, strings.NewReader(string(body)))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	if key != "" {
+		req.Header.Set(echo.HeaderAuthorization, "Bearer "+key)
+	}
+	response := httptest.NewRecorder()
+	c := echo.New().NewContext(req, response)
+	if assert.NoError(t, handle(c)) && response.Code == http.StatusOK && out != nil {
+		assert.NoError(t, json.Unmarshal(response.Body.Bytes(), out))
+	}
+	return response.Code
+}
+
+func setupTestTeams() []types.Team {
+	teams := []types.Team{
+		{Name: "Alpha", Distance: "Marathon"},
+		{Name: "Beta", Distance: "Marathon"},
+		{Name: "Gamma", Distance: "Marathon", Members: []types.TeamMember{{Bib: "11"}}},
+	}
+	for i := 0; i < 7; i++ {
+		teams[0].Members = append(teams[0].Members, types.TeamMember{Bib: strconv.Itoa(i * 2)})
+	}
+	for i := 0; i < 5; i++ {
+		teams[1].Members = append(teams[1].Members, types.TeamMember{Bib: strconv.Itoa(i*2 + 1)})
+	}
+	return teams
+}
+
+func TestGetTeams(t *testing.T) {
+	// POST, /teams
+	variables, finalize := setupTests(t)
+	defer finalize(t)
+	h := Handler{}
+	h.Setup()
+	request := types.GetTeamsRequest{
+		Slug: variables.events["event2"].Slug,
+	}
+	var resp types.GetTeamsResponse
+	// Test no key
+	t.Log("Testing no key given.")
+	code := jsonTestRequest(t, http.MethodPost, "/teams", "", request, h.GetTeams, &resp)
+	assert.Equal(t, http.StatusUnauthorized, code)
+	// Test expired key
+	t.Log("Testing expired key.")
+	code = jsonTestRequest(t, http.MethodPost, "/teams", variables.knownValues["expired"], request, h.GetTeams, &resp)
+	assert.Equal(t, http.StatusUnauthorized, code)
+	// Test invalid key
+	t.Log("Testing invalid key.")
+	code = jsonTestRequest(t, http.MethodPost, "/teams", "not-a-valid-key", request, h.GetTeams, &resp)
+	assert.Equal(t, http.StatusUnauthorized, code)
+	// Test invalid host
+	t.Log("Testing invalid host.")
+	code = jsonTestRequest(t, http.MethodPost, "/teams", variables.knownValues["delete"], request, h.GetTeams, &resp)
+	assert.Equal(t, http.StatusUnauthorized, code)
+	// Test restricted event
+	t.Log("Testing restricted event but unauthorized key.")
+	code = jsonTestRequest(t, http.MethodPost, "/teams", variables.knownValues["write"], request, h.GetTeams, &resp)
+	assert.Equal(t, http.StatusUnauthorized, code)
+	// Test invalid event
+	t.Log("Testing event not found.")
+	code = jsonTestRequest(t, http.MethodPost, "/teams", variables.knownValues["read"], types.GetTeamsRequest{Slug: "invalid-event"}, h.GetTeams, &resp)
+	assert.Equal(t, http.StatusNotFound, code)
+	// Test no teams
+	t.Log("Testing no teams.")
+	request.Slug = variables.events["event1"].Slug
+	code = jsonTestRequest(t, http.MethodPost, "/teams", variables.knownValues["read"], request, h.GetTeams, &resp)
+	if assert.Equal(t, http.StatusOK, code) {
+		assert.Equal(t, 0, len(resp.Teams))
+		assert.Equal(t, 0, len(resp.Scoring))
+	}
+	// Test teams
+	t.Log("Testing teams.")
+	eventYear := variables.eventYears["event1"]["2021"]
+	teams := setupTestTeams()
+	if _, err := database.AddTeams(eventYear.Identifier, teams); err != nil {
+		t.Fatalf("Error adding teams: %v", err)
+	}
+	scoring := []types.TeamScoring{{Distance: "Marathon", Scorers: 3, Displacers: 1, Method: types.TeamScoringTimes}}
+	if _, err := database.SetTeamScoring(eventYear.Identifier, scoring); err != nil {
+		t.Fatalf("Error setting team scoring: %v", err)
+	}
+	code = jsonTestRequest(t, http.MethodPost, "/teams", variables.knownValues["read"], request, h.GetTeams, &resp)
+	if assert.Equal(t, http.StatusOK, code) && assert.Equal(t, len(teams), len(resp.Teams)) {
+		for ix := range teams {
+			assert.True(t, teams[ix].Equals(resp.Teams[ix]))
+		}
+		assert.Equal(t, scoring, resp.Scoring)
+	}
+	// Test other year
+	t.Log("Testing other year.")
+	year := "2020"
+	request.Year = &year
+	code = jsonTestRequest(t, http.MethodPost, "/teams", variables.knownValues["read"], request, h.GetTeams, &resp)
+	if assert.Equal(t, http.StatusOK, code) {
+		assert.Equal(t, 0, len(resp.Teams))
+	}
+}
+
+func TestAddTeams(t *testing.T) {
+	// POST, /teams/add
+	variables, finalize := setupTests(t)
+	defer finalize(t)
+	h := Handler{}
+	h.Setup()
+	teams := setupTestTeams()
+	request := types.AddTeamsRequest{
+		Slug:  variables.events["event1"].Slug,
+		Year:  "2021",
+		Teams: teams,
+	}
+	var resp types.AddTeamsResponse
+	// Test no key
+	t.Log("Testing no key given.")
+	code := jsonTestRequest(t, http.MethodPost, "/teams/add", "", request, h.AddTeams, &resp)
+	assert.Equal(t, http.StatusUnauthorized, code)
+	// Test expired key
+	t.Log("Testing expired key.")
+	code = jsonTestRequest(t, http.MethodPost, "/teams/add", variables.knownValues["expired"], request, h.AddTeams, &resp)
+	assert.Equal(t, http.StatusUnauthorized, code)
+	// Test invalid key
+	t.Log("Testing invalid key.")
+	code = jsonTestRequest(t, http.MethodPost, "/teams/add", "not-a-valid-key", request, h.AddTeams, &resp)
+	assert.Equal(t, http.StatusUnauthorized, code)
+	// Test invalid host
+	t.Log("Testing invalid host.")
+	code = jsonTestRequest(t, http.MethodPost, "/teams/add", variables.knownValues["delete"], request, h.AddTeams, &resp)
+	assert.Equal(t, http.StatusUnauthorized, code)
+	// Test read key
+	t.Log("Testing read key.")
+	code = jsonTestRequest(t, http.MethodPost, "/teams/add", variables.knownValues["read"], request, h.AddTeams, &resp)
+	assert.Equal(t, http.StatusUnauthorized, code)
+	// Test wrong account
+	t.Log("Testing wrong account.")
+	code = jsonTestRequest(t, http.MethodPost, "/teams/add", variables.knownValues["write2"], request, h.AddTeams, &resp)
+	assert.Equal(t, http.StatusUnauthorized, code)
+	// Test invalid event
+	t.Log("Testing event not found.")
+	code = jsonTestRequest(t, http.MethodPost, "/teams/add", variables.knownValues["write"], types.AddTeamsRequest{Slug: "invalid-event", Year: "2021", Teams: teams}, h.AddTeams, &resp)
+	assert.Equal(t, http.StatusNotFound, code)
+	// Test valid request
+	t.Log("Testing valid request.")
+	code = jsonTestRequest(t, http.MethodPost, "/teams/add", variables.knownValues["write"], request, h.AddTeams, &resp)
+	if assert.Equal(t, http.StatusOK, code) && assert.Equal(t, len(teams), len(resp.Teams)) {
+		for ix := range teams {
+			assert.True(t, teams[ix].Equals(resp.Teams[ix]))
+		}
+	}
+	// Test updating a team and skipping invalid teams
+	t.Log("Testing update and invalid teams.")
+	request.Teams = []types.Team{
+		{Name: "Gamma", Distance: "Marathon", Members: []types.TeamMember{{Bib: "11"}, {PersonId: "13"}}},
+		{Name: "", Distance: "Marathon"},
+		{Name: "Delta", Distance: ""},
+		{Name: "Epsilon", Distance: "Marathon", Members: []types.TeamMember{{}}},
+	}
+	code = jsonTestRequest(t, http.MethodPost, "/teams/add", variables.knownValues["write"], request, h.AddTeams, &resp)
+	if assert.Equal(t, http.StatusOK, code) && assert.Equal(t, 1, len(resp.Teams)) {
+		assert.True(t, request.Teams[0].Equals(resp.Teams[0]))
+	}
+	stored, err := database.GetTeams(variables.eventYears["event1"]["2021"].Identifier)
+	if assert.NoError(t, err) && assert.Equal(t, len(teams), len(stored)) {
+		assert.True(t, request.Teams[0].Equals(stored[2]))
+	}
+}
+
+func TestDeleteTeams(t *testing.T) {
+	// DELETE, /teams/delete
+	variables, finalize := setupTests(t)
+	defer finalize(t)
+	h := Handler{}
+	h.Setup()
+	eventYear := variables.eventYears["event1"]["2021"]
+	teams := setupTestTeams()
+	if _, err := database.AddTeams(eventYear.Identifier, teams); err != nil {
+		t.Fatalf("Error adding teams: %v", err)
+	}
+	request := types.DeleteTeamsRequest{
+		Slug:  variables.events["event1"].Slug,
+		Year:  "2021",
+		Names: []string{"Alpha"},
+	}
+	var resp types.DeleteTeamsResponse
+	// Test no key
+	t.Log("Testing no key given.")
+	code := jsonTestRequest(t, http.MethodDelete, "/teams/delete", "", request, h.DeleteTeams, &resp)
+	assert.Equal(t, http.StatusUnauthorized, code)
+	// Test expired key
+	t.Log("Testing expired key.")
+	code = jsonTestRequest(t, http.MethodDelete, "/teams/delete", variables.knownValues["expired"], request, h.DeleteTeams, &resp)
+	assert.Equal(t, http.StatusUnauthorized, code)
+	// Test invalid key
+	t.Log("Testing invalid key.")
+	code = jsonTestRequest(t, http.MethodDelete, "/teams/delete", "not-a-valid-key", request, h.DeleteTeams, &resp)
+	assert.Equal(t, http.StatusUnauthorized, code)
+	// Test read key
+	t.Log("Testing read key.")
+	code = jsonTestRequest(t, http.MethodDelete, "/teams/delete", variables.knownValues["read"], request, h.DeleteTeams, &resp)
+	assert.Equal(t, http.StatusUnauthorized, code)
+	// Test wrong account
+	t.Log("Testing wrong account.")
+	code = jsonTestRequest(t, http.MethodDelete, "/teams/delete", variables.knownValues["write2"], request, h.DeleteTeams, &resp)
+	assert.Equal(t, http.StatusUnauthorized, code)
+	// Test invalid event
+	t.Log("Testing event not found.")
+	code = jsonTestRequest(t, http.MethodDelete, "/teams/delete", variables.knownValues["write"], types.DeleteTeamsRequest{Slug: "invalid-event", Year: "2021"}, h.DeleteTeams, &resp)
+	assert.Equal(t, http.StatusNotFound, code)
+	// Test deleting a single team
+	t.Log("Testing deleting a single team.")
+	code = jsonTestRequest(t, http.MethodDelete, "/teams/delete", variables.knownValues["write"], request, h.DeleteTeams, &resp)
+	if assert.Equal(t, http.StatusOK, code) {
+		assert.Equal(t, int64(1), resp.Count)
+	}
+	stored, err := database.GetTeams(eventYear.Identifier)
+	if assert.NoError(t, err) && assert.Equal(t, 2, len(stored)) {
+		assert.Equal(t, "Beta", stored[0].Name)
+		assert.Equal(t, "Gamma", stored[1].Name)
+	}
+	// Test deleting all teams
+	t.Log("Testing deleting all teams.")
+	request.Names = nil
+	code = jsonTestRequest(t, http.MethodDelete, "/teams/delete", variables.knownValues["write"], request, h.DeleteTeams, &resp)
+	if assert.Equal(t, http.StatusOK, code) {
+		assert.Equal(t, int64(2), resp.Count)
+	}
+	stored, err = database.GetTeams(eventYear.Identifier)
+	if assert.NoError(t, err) {
+		assert.Equal(t, 0, len(stored))
+	}
+}
+
+func TestSetTeamScoring(t *testing.T) {
+	// POST, /teams/scoring
+	variables, finalize := setupTests(t)
+	defer finalize(t)
+	h := Handler{}
+	h.Setup()
+	request := types.SetTeamScoringRequest{
+		Slug: variables.events["event1"].Slug,
+		Year: "2021",
+		Scoring: []types.TeamScoring{
+			{Distance: "Marathon", Scorers: 4, Displacers: 0, Method: types.TeamScoringPlaces},
+		},
+	}
+	var resp types.SetTeamScoringResponse
+	// Test no key
+	t.Log("Testing no key given.")
+	code := jsonTestRequest(t, http.MethodPost, "/teams/scoring", "", request, h.SetTeamScoring, &resp)
+	assert.Equal(t, http.StatusUnauthorized, code)
+	// Test expired key
+	t.Log("Testing expired key.")
+	code = jsonTestRequest(t, http.MethodPost, "/teams/scoring", variables.knownValues["expired"], request, h.SetTeamScoring, &resp)
+	assert.Equal(t, http.StatusUnauthorized, code)
+	// Test read key
+	t.Log("Testing read key.")
+	code = jsonTestRequest(t, http.MethodPost, "/teams/scoring", variables.knownValues["read"], request, h.SetTeamScoring, &resp)
+	assert.Equal(t, http.StatusUnauthorized, code)
+	// Test wrong account
+	t.Log("Testing wrong account.")
+	code = jsonTestRequest(t, http.MethodPost, "/teams/scoring", variables.knownValues["write2"], request, h.SetTeamScoring, &resp)
+	assert.Equal(t, http.StatusUnauthorized, code)
+	// Test invalid event
+	t.Log("Testing event not found.")
+	code = jsonTestRequest(t, http.MethodPost, "/teams/scoring", variables.knownValues["write"], types.SetTeamScoringRequest{Slug: "invalid-event", Year: "2021"}, h.SetTeamScoring, &resp)
+	assert.Equal(t, http.StatusNotFound, code)
+	// Test invalid scoring
+	t.Log("Testing invalid scoring.")
+	for _, invalid := range []types.TeamScoring{
+		{Distance: "", Scorers: 4, Method: types.TeamScoringPlaces},
+		{Distance: "Marathon", Scorers: 0, Method: types.TeamScoringPlaces},
+		{Distance: "Marathon", Scorers: 4, Displacers: -1, Method: types.TeamScoringPlaces},
+		{Distance: "Marathon", Scorers: 4, Method: "points"},
+	} {
+		code = jsonTestRequest(t, http.MethodPost, "/teams/scoring", variables.knownValues["write"], types.SetTeamScoringRequest{
+			Slug:    request.Slug,
+			Year:    request.Year,
+			Scoring: []types.TeamScoring{invalid},
+		}, h.SetTeamScoring, &resp)
+		assert.Equal(t, http.StatusBadRequest, code)
+	}
+	// Test valid request
+	t.Log("Testing valid request.")
+	code = jsonTestRequest(t, http.MethodPost, "/teams/scoring", variables.knownValues["write"], request, h.SetTeamScoring, &resp)
+	if assert.Equal(t, http.StatusOK, code) {
+		assert.Equal(t, request.Scoring, resp.Scoring)
+	}
+	// Test replacing the rules
+	t.Log("Testing replacing the rules.")
+	request.Scoring = []types.TeamScoring{
+		{Distance: "Half Marathon", Scorers: 3, Displacers: 2, Method: types.TeamScoringTimes},
+	}
+	code = jsonTestRequest(t, http.MethodPost, "/teams/scoring", variables.knownValues["write"], request, h.SetTeamScoring, &resp)
+	if assert.Equal(t, http.StatusOK, code) {
+		assert.Equal(t, request.Scoring, resp.Scoring)
+	}
+	stored, err := database.GetTeamScoring(variables.eventYears["event1"]["2021"].Identifier)
+	if assert.NoError(t, err) {
+		assert.Equal(t, request.Scoring, stored)
+	}
+}
+
+func TestGetTeamResults(t *testing.T) {
+	// POST, /results/teams
+	variables, finalize := setupTests(t)
+	defer finalize(t)
+	h := Handler{}
+	h.Setup()
+	year := "2021"
+	request := types.GetTeamResultsRequest{
+		Slug: variables.events["event2"].Slug,
+		Year: &year,
+	}
+	var resp types.GetTeamResultsResponse
+	// Test no key
+	t.Log("Testing no key given.")
+	code := jsonTestRequest(t, http.MethodPost, "/results/teams", "", request, h.GetTeamResults, &resp)
+	assert.Equal(t, http.StatusUnauthorized, code)
+	// Test expired key
+	t.Log("Testing expired key.")
+	code = jsonTestRequest(t, http.MethodPost, "/results/teams", variables.knownValues["expired"], request, h.GetTeamResults, &resp)
+	assert.Equal(t, http.StatusUnauthorized, code)
+	// Test invalid host
+	t.Log("Testing invalid host.")
+	code = jsonTestRequest(t, http.MethodPost, "/results/teams", variables.knownValues["delete"], request, h.GetTeamResults, &resp)
+	assert.Equal(t, http.StatusUnauthorized, code)
+	// Test restricted event
+	t.Log("Testing restricted event but unauthorized key.")
+	code = jsonTestRequest(t, http.MethodPost, "/results/teams", variables.knownValues["write"], request, h.GetTeamResults, &resp)
+	assert.Equal(t, http.StatusUnauthorized, code)
+	// Test invalid event
+	t.Log("Testing event not found.")
+	code = jsonTestRequest(t, http.MethodPost, "/results/teams", variables.knownValues["read"], types.GetTeamResultsRequest{Slug: "invalid-event"}, h.GetTeamResults, &resp)
+	assert.Equal(t, http.StatusNotFound, code)
+	// Test no teams
+	t.Log("Testing no teams.")
+	request.Slug = variables.events["event1"].Slug
+	code = jsonTestRequest(t, http.MethodPost, "/results/teams", variables.knownValues["read"], request, h.GetTeamResults, &resp)
+	if assert.Equal(t, http.StatusOK, code) {
+		assert.Equal(t, variables.events["event1"].Slug, resp.Event.Slug)
+		assert.Equal(t, 0, len(resp.Results))
+	}
+	// Test team results
+	t.Log("Testing team results.")
+	eventYear := variables.eventYears["event1"]["2021"]
+	if _, err := database.AddTeams(eventYear.Identifier, setupTestTeams()); err != nil {
+		t.Fatalf("Error adding teams: %v", err)
+	}
+	results := variables.results["event1"]["2021"]
+	for ix := range results {
+		results[ix].ChipSeconds = results[ix].Seconds
+	}
+	uploadTestResults(t, h, variables.knownValues["write"], "2021", results)
+	code = jsonTestRequest(t, http.MethodPost, "/results/teams", variables.knownValues["read"], request, h.GetTeamResults, &resp)
+	if assert.Equal(t, http.StatusOK, code) && assert.Equal(t, 1, len(resp.Results)) {
+		marathon := resp.Results["Marathon"]
+		if assert.Equal(t, 3, len(marathon)) {
+			assert.Equal(t, "Alpha", marathon[0].Name)
+			assert.Equal(t, 1, marathon[0].Ranking)
+			assert.Equal(t, 1+3+5+7+9, marathon[0].Score)
+			if assert.Equal(t, 7, len(marathon[0].Members)) {
+				assert.Equal(t, 11, marathon[0].Members[5].Place)
+				assert.Equal(t, 12, marathon[0].Members[6].Place)
+				assert.False(t, marathon[0].Members[5].Scorer)
+			}
+			assert.Equal(t, "Beta", marathon[1].Name)
+			assert.Equal(t, 2, marathon[1].Ranking)
+			assert.Equal(t, 2+4+6+8+10, marathon[1].Score)
+			assert.Equal(t, "Gamma", marathon[2].Name)
+			assert.Equal(t, -1, marathon[2].Ranking)
+		}
+	}
+	// Test distance filter
+	t.Log("Testing distance filter.")
+	distance := "Half Marathon"
+	request.Distance = &distance
+	resp = types.GetTeamResultsResponse{}
+	code = jsonTestRequest(t, http.MethodPost, "/results/teams", variables.knownValues["read"], request, h.GetTeamResults, &resp)
+	if assert.Equal(t, http.StatusOK, code) {
+		assert.Equal(t, 0, len(resp.Results))
+	}
+}
+
@@ -20,12 +20,15 @@ package handlers
 
 import (
 	"chronokeep/results/types"
+	"chronokeep/results/util"
 	"errors"
 	"fmt"
 	"net/http"
+	"reflect"
 	"strings"
 	"time"
 
+	"github.com/go-playground/validator/v10"
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/labstack/echo/v5"
 	log "github.com/sirupsen/logrus"
@@ -45,6 +48,71 @@ func getAPIError(c *echo.Context, code int, message string, err error) error {
 	return c.JSON(code, APIError{Message: message})
 }
 
+// jsonFieldName Returns the name clients send a field of item as, or the field name itself if
+// it isn't one of the fields of item.
+func jsonFieldName(item any, field string) string {
+	t := reflect.TypeOf(item)
+	for t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return field
+	}
+	if sf, ok := t.FieldByName(field); ok {
+		name := strings.SplitN(sf.Tag.Get("json"), ",", 2)[0]
+		if name != "" && name != "-" {
+			return name
+		}
+	}
+	return field
+}
+
+// rejectedOutcome Creates the outcome for an item that failed validation, naming the field at fault
+// by the name clients send it as when we know it.
+func rejectedOutcome(index int, item any, err error) types.ItemOutcome {
+	out := types.ItemOutcome{
+		Index:  index,
+		Status: util.ITEM_STATUS_REJECTED,
+		Reason: err.Error(),
+	}
+	var valErrs validator.ValidationErrors
+	var fieldErr *types.FieldError
+	if errors.As(err, &valErrs) && len(valErrs) > 0 {
+		out.Field = jsonFieldName(item, valErrs[0].StructField())
+		switch valErrs[0].Tag() {
+		case "required":
+			out.Reason = fmt.Sprintf("%s is required", out.Field)
+		case "gte":
+			out.Reason = fmt.Sprintf("%s must be at least %s", out.Field, valErrs[0].Param())
+		case "lte":
+			out.Reason = fmt.Sprintf("%s must be at most %s", out.Field, valErrs[0].Param())
+		default:
+			out.Reason = fmt.Sprintf("%s is invalid", out.Field)
+		}
+	} else if errors.As(err, &fieldErr) {
+		out.Field = fieldErr.Field
+	}
+	return out
+}
+
+// skipOutcomes Marks every item that wasn't rejected as skipped.  Used when a batch is all or nothing.
+func skipOutcomes(outcomes []types.ItemOutcome) {
+	for ix := range outcomes {
+		if outcomes[ix].Status != util.ITEM_STATUS_REJECTED {
+			outcomes[ix].Status = util.ITEM_STATUS_SKIPPED
+		}
+	}
+}
+
+// auditActor Identifies who is making a change so it can be recorded in the result history.
+func auditActor(mkey *types.MultiKey) *types.AuditActor {
+	return &types.AuditActor{
+		Account: mkey.Account.Email,
+		KeyName: mkey.Key.Name,
+		KeyType: mkey.Key.Type,
+	}
+}
+
 func retrieveKey(r *http.Request) (*string, error) {
 	bearToken := r.Header.Get("Authorization")
 	strArr := strings.Split(bearToken, " ")
@@ -0,0 +1,187 @@
+/*
+Chronokeep Desktop - Race Scoring Software
+Copyright (C) 2026 James Sentinella
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package handlers
+
+import (
+	db "chronokeep/results/database"
+	"chronokeep/results/types"
+	"net/http"
+
+	"github.com/labstack/echo/v5"
+)
+
+// waveKey Identifies a wave within an event year.
+type waveKey struct {
+	distance string
+	name     string
+}
+
+// applyWaves Recalculates the chip time of every result in the event year from the start of its
+// wave and saves the results that changed.  Returns the number of results that were updated.
+func applyWaves(mult *types.MultiGet, waves []types.Wave, actor *types.AuditActor) (int, error) {
+	results, err := database.GetResults(mult.EventYear.Identifier, 0, 0)
+	if err != nil {
+		return 0, err
+	}
+	changed := db.ApplyWaves(results, waves, mult.EventYear.DateTime)
+	if len(changed) == 0 {
+		return 0, nil
+	}
+	changed, err = database.AddResults(mult.EventYear.Identifier, changed, actor)
+	if err != nil {
+		return 0, err
+	}
+	if _, err = database.UpdateRankings(mult.EventYear.Identifier); err != nil {
+		return 0, err
+	}
+	if err = updateRecords(mult.Event, mult.EventYear); err != nil {
+		return 0, err
+	}
+	publishResults(mult.EventYear.Identifier, results, changed)
+	return len(changed), nil
+}
+
+func (h Handler) GetWaves(c *echo.Context) error {
+	// Get Key from Authorization Header
+	k, err := retrieveKey(c.Request())
+	if err != nil {
+		return getAPIError(c, http.StatusUnauthorized, "Error Getting Key From Authorization Header", err)
+	}
+	if k == nil {
+		return getAPIError(c, http.StatusUnauthorized, "Key Not Provided in Authorization Header", nil)
+	}
+	var request types.GetWavesRequest
+	if err := c.Bind(&request); err != nil {
+		return getAPIError(c, http.StatusBadRequest, "Invalid Request Body", err)
+	}
+	// Get Key
+	mkey, err := database.GetKeyAndAccount(*k)
+	if err != nil {
+		return getAPIError(c, http.StatusInternalServerError, "Error Retrieving Key/Account", err)
+	}
+	if mkey == nil || mkey.Key == nil || mkey.Account == nil {
+		return getAPIError(c, http.StatusUnauthorized, "Key/Account Not Found", nil)
+	}
+	// Check for expired key
+	if mkey.Key.Expired() {
+		return getAPIError(c, http.StatusUnauthorized, "Expired Key", nil)
+	}
+	// Check for host being allowed.
+	if !mkey.Key.IsAllowed(c.Request().Referer()) {
+		return getAPIError(c, http.StatusUnauthorized, "Host Not Allowed", nil)
+	}
+	// And Event for verification of whether or not we can allow access to this key
+	year := ""
+	if request.Year != nil {
+		year = *request.Year
+	}
+	mult, err := database.GetEventAndYear(request.Slug, year)
+	if err != nil {
+		return getAPIError(c, http.StatusInternalServerError, "Error Retrieving Event/Year", err)
+	}
+	if mult == nil || mult.Event == nil || mult.EventYear == nil {
+		return getAPIError(c, http.StatusNotFound, "Event/Year Not Found", nil)
+	}
+	if mult.Event.AccessRestricted && mkey.Account.Identifier != mult.Event.AccountIdentifier {
+		return getAPIError(c, http.StatusUnauthorized, "Restricted Event", nil)
+	}
+	waves, err := database.GetWaves(mult.EventYear.Identifier)
+	if err != nil {
+		return getAPIError(c, http.StatusInternalServerError, "Error Retrieving Waves", err)
+	}
+	return c.JSON(http.StatusOK, types.GetWavesResponse{
+		Event:     *mult.Event,
+		EventYear: *mult.EventYear,
+		Waves:     waves,
+	})
+}
+
+func (h Handler) SetWaves(c *echo.Context) error {
+	// Get Key from Authorization Header
+	k, err := retrieveKey(c.Request())
+	if err != nil {
+		return getAPIError(c, http.StatusUnauthorized, "Error Getting Key From Authorization Header", err)
+	}
+	if k == nil {
+		return getAPIError(c, http.StatusUnauthorized, "Key Not Provided in Authorization Header", nil)
+	}
+	var request types.SetWavesRequest
+	if err := c.Bind(&request); err != nil {
+		return getAPIError(c, http.StatusBadRequest, "Invalid Request Body", err)
+	}
+	seen := make(map[waveKey]bool)
+	for _, wave := range request.Waves {
+		if err := h.validate.Struct(wave); err != nil {
+			return getAPIError(c, http.StatusBadRequest, "Invalid Wave", err)
+		}
+		key := waveKey{distance: wave.Distance, name: wave.Name}
+		if seen[key] {
+			return getAPIError(c, http.StatusBadRequest, "Duplicate Wave", nil)
+		}
+		seen[key] = true
+	}
+	// Get Key
+	mkey, err := database.GetKeyAndAccount(*k)
+	if err != nil {
+		return getAPIError(c, http.StatusInternalServerError, "Error Retrieving Key/Account", err)
+	}
+	if mkey == nil || mkey.Key == nil || mkey.Account == nil {
+		return getAPIError(c, http.StatusUnauthorized, "Key/Account Not Found", nil)
+	}
+	// Check for expired key
+	if mkey.Key.Expired() {
+		return getAPIError(c, http.StatusUnauthorized, "Expired Key", nil)
+	}
+	// Check for host being allowed.
+	if !mkey.Key.IsAllowed(c.Request().Referer()) {
+		return getAPIError(c, http.StatusUnauthorized, "Host Not Allowed", nil)
+	}
+	if mkey.Key.Type == "read" {
+		return getAPIError(c, http.StatusUnauthorized, "Key is ReadOnly", nil)
+	}
+	// And Event for verification of whether or not we can allow access to this key
+	mult, err := database.GetEventAndYear(request.Slug, request.Year)
+	if err != nil {
+		return getAPIError(c, http.StatusInternalServerError, "Error Retrieving Event/Year", err)
+	}
+	if mult == nil || mult.Event == nil || mult.EventYear == nil {
+		return getAPIError(c, http.StatusNotFound, "Event/Year Not Found", nil)
+	}
+	// Check if they own this event.
+	if mult.Event.AccountIdentifier != mkey.Account.Identifier {
+		return getAPIError(c, http.StatusUnauthorized, "Ownership Error", nil)
+	}
+	// Changing a wave changes the results in it, which can't happen until an admin unlocks the event year.
+	if mult.EventYear.Locked() {
+		return getAPIError(c, http.StatusForbidden, "Event Year Is Locked", nil)
+	}
+	waves, err := database.SetWaves(mult.EventYear.Identifier, request.Waves)
+	if err != nil {
+		return getAPIError(c, http.StatusInternalServerError, "Error Setting Waves", err)
+	}
+	updated, err := applyWaves(mult, waves, auditActor(mkey))
+	if err != nil {
+		return getAPIError(c, http.StatusInternalServerError, "Error Updating Results", err)
+	}
+	return c.JSON(http.StatusOK, types.SetWavesResponse{
+		Waves:   waves,
+		Updated: updated,
+	})
+}
+
@@ -0,0 +1,216 @@
+/*
+Chronokeep Desktop - Race Scoring Software
+Copyright (C) 2026 James Sentinella
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package handlers
+
+import (
+	"chronokeep/results/types"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetWaves(t *testing.T) {
+	// POST, /waves
+	variables, finalize := setupTests(t)
+	defer finalize(t)
+	h := Handler{}
+	h.Setup()
+	year := "2021"
+	request := types.GetWavesRequest{
+		Slug: variables.events["event2"].Slug,
+		Year: &year,
+	}
+	var resp types.GetWavesResponse
+	// Test no key
+	t.Log("Testing no key given.")
+	code := jsonTestRequest(t, http.MethodPost, "/waves", "", request, h.GetWaves, &resp)
+	assert.Equal(t, http.StatusUnauthorized, code)
+	// Test expired key
+	t.Log("Testing expired key.")
+	code = jsonTestRequest(t, http.MethodPost, "/waves", variables.knownValues["expired"], request, h.GetWaves, &resp)
+	assert.Equal(t, http.StatusUnauthorized, code)
+	// Test restricted event
+	t.Log("Testing restricted event but unauthorized key.")
+	code = jsonTestRequest(t, http.MethodPost, "/waves", variables.knownValues["write"], request, h.GetWaves, &resp)
+	assert.Equal(t, http.StatusUnauthorized, code)
+	// Test invalid event
+	t.Log("Testing event not found.")
+	code = jsonTestRequest(t, http.MethodPost, "/waves", variables.knownValues["read"], types.GetWavesRequest{Slug: "invalid-event"}, h.GetWaves, &resp)
+	assert.Equal(t, http.StatusNotFound, code)
+	// Test no waves
+	t.Log("Testing no waves.")
+	code = jsonTestRequest(t, http.MethodPost, "/waves", variables.knownValues["read"], request, h.GetWaves, &resp)
+	if assert.Equal(t, http.StatusOK, code) {
+		assert.Equal(t, variables.events["event2"].Slug, resp.Event.Slug)
+		assert.Equal(t, 0, len(resp.Waves))
+	}
+	// Test waves
+	t.Log("Testing waves.")
+	waves := []types.Wave{
+		{
+			Distance:  "2 Mile",
+			Name:      "Wave 1",
+			StartTime: time.Date(2021, 4, 5, 11, 0, 0, 0, time.UTC),
+			BibRanges: []types.BibRange{{First: 0, Last: 99}},
+			Bibs:      []string{},
+		},
+	}
+	_, err := database.SetWaves(variables.eventYears["event2"]["2021"].Identifier, waves)
+	assert.NoError(t, err)
+	resp = types.GetWavesResponse{}
+	code = jsonTestRequest(t, http.MethodPost, "/waves", variables.knownValues["read"], request, h.GetWaves, &resp)
+	if assert.Equal(t, http.StatusOK, code) && assert.Equal(t, 1, len(resp.Waves)) {
+		assert.Equal(t, waves[0].Name, resp.Waves[0].Name)
+		assert.True(t, waves[0].StartTime.Equal(resp.Waves[0].StartTime))
+		assert.Equal(t, waves[0].BibRanges, resp.Waves[0].BibRanges)
+	}
+}
+
+func TestSetWaves(t *testing.T) {
+	// POST, /waves/set
+	variables, finalize := setupTests(t)
+	defer finalize(t)
+	h := Handler{}
+	h.Setup()
+	mult, err := database.GetEventAndYear(variables.events["event1"].Slug, "2021")
+	if err != nil || mult == nil || mult.EventYear == nil {
+		t.Fatalf("Error getting event year: %v", err)
+	}
+	start := mult.EventYear.DateTime.Add(time.Minute)
+	request := types.SetWavesRequest{
+		Slug: variables.events["event1"].Slug,
+		Year: "2021",
+		Waves: []types.Wave{
+			{
+				Distance:  "Marathon",
+				Name:      "Wave 1",
+				StartTime: start,
+				BibRanges: []types.BibRange{{First: 0, Last: 999}},
+			},
+		},
+	}
+	var resp types.SetWavesResponse
+	// Test no key
+	t.Log("Testing no key given.")
+	code := jsonTestRequest(t, http.MethodPost, "/waves/set", "", request, h.SetWaves, &resp)
+	assert.Equal(t, http.StatusUnauthorized, code)
+	// Test expired key
+	t.Log("Testing expired key.")
+	code = jsonTestRequest(t, http.MethodPost, "/waves/set", variables.knownValues["expired"], request, h.SetWaves, &resp)
+	assert.Equal(t, http.StatusUnauthorized, code)
+	// Test read key
+	t.Log("Testing read key.")
+	code = jsonTestRequest(t, http.MethodPost, "/waves/set", variables.knownValues["read"], request, h.SetWaves, &resp)
+	assert.Equal(t, http.StatusUnauthorized, code)
+	// Test wrong account
+	t.Log("Testing wrong account.")
+	code = jsonTestRequest(t, http.MethodPost, "/waves/set", variables.knownValues["write2"], request, h.SetWaves, &resp)
+	assert.Equal(t, http.StatusUnauthorized, code)
+	// Test invalid event
+	t.Log("Testing event not found.")
+	code = jsonTestRequest(t, http.MethodPost, "/waves/set", variables.knownValues["write"], types.SetWavesRequest{Slug: "invalid-event", Year: "2021"}, h.SetWaves, &resp)
+	assert.Equal(t, http.StatusNotFound, code)
+	// Test invalid waves
+	t.Log("Testing invalid waves.")
+	for _, invalid := range [][]types.Wave{
+		{{Name: "Wave 1", StartTime: start}},
+		{{Distance: "Marathon", StartTime: start}},
+		{{Distance: "Marathon", Name: "Wave 1"}},
+		{{Distance: "Marathon", Name: "Wave 1", StartTime: start, BibRanges: []types.BibRange{{First: 10, Last: 5}}}},
+		{{Distance: "Marathon", Name: "Wave 1", StartTime: start, Bibs: []string{"1,2"}}},
+		{{Distance: "Marathon", Name: "Wave 1", StartTime: start}, {Distance: "Marathon", Name: "Wave 1", StartTime: start}},
+	} {
+		code = jsonTestRequest(t, http.MethodPost, "/waves/set", variables.knownValues["write"], types.SetWavesRequest{
+			Slug:  request.Slug,
+			Year:  request.Year,
+			Waves: invalid,
+		}, h.SetWaves, &resp)
+		assert.Equal(t, http.StatusBadRequest, code)
+	}
+	// Test valid request
+	t.Log("Testing valid request.")
+	resp = types.SetWavesResponse{}
+	code = jsonTestRequest(t, http.MethodPost, "/waves/set", variables.knownValues["write"], request, h.SetWaves, &resp)
+	if assert.Equal(t, http.StatusOK, code) {
+		assert.Equal(t, 1, len(resp.Waves))
+		assert.NotEqual(t, 0, resp.Updated)
+	}
+	finish := func(bib string) *types.Result {
+		results, err := database.GetBibResults(mult.EventYear.Identifier, bib)
+		assert.NoError(t, err)
+		for _, res := range results {
+			if res.Finish {
+				return &res
+			}
+		}
+		return nil
+	}
+	checkChip := func(bib string, offset int) {
+		if res := finish(bib); assert.NotNil(t, res) {
+			assert.Equal(t, res.Seconds-offset, res.ChipSeconds)
+		}
+	}
+	checkChip("0", 60)
+	checkChip("299", 60)
+	// Test correcting the start time of a wave
+	t.Log("Testing corrected start time.")
+	request.Waves[0].StartTime = start.Add(time.Minute)
+	resp = types.SetWavesResponse{}
+	code = jsonTestRequest(t, http.MethodPost, "/waves/set", variables.knownValues["write"], request, h.SetWaves, &resp)
+	if assert.Equal(t, http.StatusOK, code) {
+		assert.NotEqual(t, 0, resp.Updated)
+	}
+	checkChip("0", 120)
+	checkChip("299", 120)
+	// Setting the same waves again doesn't change anything.
+	resp = types.SetWavesResponse{}
+	code = jsonTestRequest(t, http.MethodPost, "/waves/set", variables.knownValues["write"], request, h.SetWaves, &resp)
+	if assert.Equal(t, http.StatusOK, code) {
+		assert.Equal(t, 0, resp.Updated)
+	}
+	// Test uploaded results in a wave
+	t.Log("Testing uploaded results.")
+	var addResp types.AddResultsResponse
+	code = jsonTestRequest(t, http.MethodPost, "/results/add", variables.knownValues["write"], types.AddResultsRequest{
+		Slug: request.Slug,
+		Year: request.Year,
+		Results: []types.Result{
+			{
+				PersonId:    "5",
+				Bib:         "5",
+				Distance:    "Marathon",
+				Seconds:     1000,
+				ChipSeconds: 1000,
+				Location:    "Start/Finish",
+				Occurence:   1,
+				Finish:      true,
+			},
+		},
+	}, h.AddResults, &addResp)
+	if assert.Equal(t, http.StatusOK, code) {
+		assert.Equal(t, 1, addResp.Count)
+	}
+	if res := finish("5"); assert.NotNil(t, res) {
+		assert.Equal(t, 1000, res.Seconds)
+		assert.Equal(t, 880, res.ChipSeconds)
+	}
+}
+
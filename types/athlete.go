@@ -0,0 +1,94 @@
+/*
+Chronokeep Desktop - Race Scoring Software
+Copyright (C) 2026 James Sentinella
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package types
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// Athlete is a person whose results are linked across events and years.  Participants with the same
+// name and birthdate as the athlete are linked automatically, while others can be linked to the
+// athlete by claiming their bib.  The birthdate is only used for matching and is never shown on
+// the public profile of the athlete.
+type Athlete struct {
+	Identifier        int64  `json:"-"`
+	AccountIdentifier int64  `json:"-"`
+	Slug              string `json:"slug" validate:"required"`
+	First             string `json:"first" validate:"required"`
+	Last              string `json:"last" validate:"required"`
+	Birthdate         string `json:"birthdate,omitempty"`
+}
+
+// AthleteEntry is an event year a participant linked to an athlete took part in.  Claimed is true
+// if the entry was linked explicitly instead of by name and birthdate.
+type AthleteEntry struct {
+	EventYearIdentifier int64     `json:"-"`
+	AccountIdentifier   int64     `json:"-"`
+	AccessRestricted    bool      `json:"-"`
+	Birthdate           string    `json:"-"`
+	Anonymous           bool      `json:"-"`
+	Claimed             bool      `json:"-"`
+	Slug                string    `json:"slug"`
+	Name                string    `json:"name"`
+	Year                string    `json:"year"`
+	DateTime            time.Time `json:"date_time"`
+	Bib                 string    `json:"bib"`
+}
+
+// AthleteEvent holds the results of an athlete in one event year.
+type AthleteEvent struct {
+	AthleteEntry
+	Results []Result `json:"results"`
+}
+
+// Validate Ensures valid information in the structure.
+func (a *Athlete) Validate(validate *validator.Validate) error {
+	a.Slug = strings.ToLower(a.Slug)
+	if !validSlug(a.Slug) {
+		return errors.New("invalid slug (only letters, numbers, and - character allowed)")
+	}
+	if a.Birthdate != "" && NormalizeBirthdate(a.Birthdate) == "" {
+		return &FieldError{Field: "birthdate", Message: "invalid birthdate"}
+	}
+	return validate.Struct(a)
+}
+
+// Equals Returns true if all fields other than the Identifier fields are equal.
+func (a *Athlete) Equals(other *Athlete) bool {
+	return a.Slug == other.Slug &&
+		a.First == other.First &&
+		a.Last == other.Last &&
+		a.Birthdate == other.Birthdate
+}
+
+// NormalizeBirthdate Returns a birthdate in either of the formats participants use as YYYY-MM-DD
+// so they can be compared, or an empty string if it can't be parsed.
+func NormalizeBirthdate(birthdate string) string {
+	for _, layout := range []string{"1/2/2006", "2006/1/2", "2006-01-02"} {
+		if t, err := time.Parse(layout, strings.TrimSpace(birthdate)); err == nil {
+			return t.Format("2006-01-02")
+		}
+	}
+	return ""
+}
+
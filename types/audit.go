@@ -0,0 +1,54 @@
+/*
+Chronokeep Desktop - Race Scoring Software
+Copyright (C) 2026 James Sentinella
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package types
+
+// Audit entry types and actions.  Person entries are changes to the person a result belongs to
+// and have no location.
+const (
+	AuditTypeResult   = "result"
+	AuditTypePerson   = "person"
+	AuditActionInsert = "insert"
+	AuditActionUpdate = "update"
+	AuditActionDelete = "delete"
+)
+
+// AuditActor is the account and key that made a change.
+type AuditActor struct {
+	Account string
+	KeyName string
+	KeyType string
+}
+
+// AuditEntry is a single change to a result or person.  Before is nil for inserts and After is
+// nil for deletes.  Rankings and other values calculated by the server aren't kept.
+type AuditEntry struct {
+	Identifier int64   `json:"id"`
+	Type       string  `json:"type"`
+	Action     string  `json:"action"`
+	Bib        string  `json:"bib"`
+	Location   string  `json:"location"`
+	Occurence  int     `json:"occurence"`
+	Account    string  `json:"account"`
+	KeyName    string  `json:"key_name"`
+	KeyType    string  `json:"key_type"`
+	Before     *Result `json:"before"`
+	After      *Result `json:"after"`
+	ChangedAt  int64   `json:"changed_at"`
+}
+
@@ -0,0 +1,86 @@
+/*
+Chronokeep Desktop - Race Scoring Software
+Copyright (C) 2026 James Sentinella
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package types
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Award categories.  Overall awards go to the top finishers of each gender, masters awards to
+// the top finishers of each gender at or over a masters age, age group awards to the top
+// finishers of each gender and age group and division awards to the top finishers of each division.
+const (
+	AwardOverall  = "overall"
+	AwardMasters  = "masters"
+	AwardAgeGroup = "age_group"
+	AwardDivision = "division"
+)
+
+// AwardRules hold how many awards are given in each category for every distance of an event
+// year.  MastersAges are the minimum ages of each masters category, so 40 and 50 give awards
+// to the top masters and grand masters of each gender.  Finishers only win a single award
+// unless DoubleDip is set.
+type AwardRules struct {
+	OverallPlaces  int   `json:"overall_places" validate:"gte=0"`
+	AgeGroupPlaces int   `json:"age_group_places" validate:"gte=0"`
+	DivisionPlaces int   `json:"division_places" validate:"gte=0"`
+	MastersAges    []int `json:"masters_ages" validate:"dive,gt=0"`
+	MastersPlaces  int   `json:"masters_places" validate:"gte=0"`
+	DoubleDip      bool  `json:"double_dip"`
+}
+
+// Award is a single award won by a finisher.  Group is the name of the group the award is for,
+// such as the gender for overall awards or the gender and age group for age group awards.
+// Place is the place in that group, which can be better than the ranking of the result when
+// finishers ahead of them already won another award.
+type Award struct {
+	Distance string `json:"distance"`
+	Category string `json:"category"`
+	Group    string `json:"group"`
+	Place    int    `json:"place"`
+	Result   Result `json:"result"`
+}
+
+// EncodeMastersAges Returns the masters ages as a comma separated list for storage.
+func (r *AwardRules) EncodeMastersAges() string {
+	ages := make([]string, len(r.MastersAges))
+	for ix, age := range r.MastersAges {
+		ages[ix] = strconv.Itoa(age)
+	}
+	return strings.Join(ages, ",")
+}
+
+// DecodeMastersAges Sets the masters ages from the list returned by EncodeMastersAges.
+func (r *AwardRules) DecodeMastersAges(ages string) error {
+	r.MastersAges = make([]int, 0)
+	if ages == "" {
+		return nil
+	}
+	for _, a := range strings.Split(ages, ",") {
+		val, err := strconv.Atoi(a)
+		if err != nil {
+			return fmt.Errorf("invalid masters age %s: %v", a, err)
+		}
+		r.MastersAges = append(r.MastersAges, val)
+	}
+	return nil
+}
+
@@ -0,0 +1,54 @@
+/*
+Chronokeep Desktop - Race Scoring Software
+Copyright (C) 2026 James Sentinella
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package types
+
+// Backyard ultra statuses.  Runners are running until they miss a cutoff or stop, which drops
+// them.  The winner is the last runner standing and the assist the last runner to drop.
+const (
+	BackyardStatusRunning = "running"
+	BackyardStatusDropped = "dropped"
+	BackyardStatusWinner  = "winner"
+	BackyardStatusAssist  = "assist"
+)
+
+// BackyardStanding is the standing of a runner in a backyard ultra.  DroppedIn is the yard the runner
+// missed the cutoff or stopped in and is 0 while they're still running or if they won.
+type BackyardStanding struct {
+	Ranking        int            `json:"ranking"`
+	Bib            string         `json:"bib"`
+	First          string         `json:"first"`
+	Last           string         `json:"last"`
+	Gender         string         `json:"gender"`
+	AgeGroup       string         `json:"age_group"`
+	Distance       string         `json:"distance"`
+	YardsCompleted int            `json:"yards_completed"`
+	DroppedIn      int            `json:"dropped_in"`
+	Status         string         `json:"status"`
+	Yards          []BackyardYard `json:"yards"`
+}
+
+// BackyardYard is the time a runner took for a yard, counted from the start of the yard.  Completed
+// is false when the yard was finished after its cutoff.
+type BackyardYard struct {
+	Yard         int  `json:"yard"`
+	Seconds      int  `json:"seconds"`
+	Milliseconds int  `json:"milliseconds"`
+	Completed    bool `json:"completed"`
+}
+
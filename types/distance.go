@@ -21,11 +21,14 @@ package types
 import "github.com/go-playground/validator/v10"
 
 // Event is a structure holding the information regarding an event that can span
-// multiple years
+// multiple years.  DistanceValue and DistanceUnit give the certified length of the
+// distance and are used for age grading.
 type Distance struct {
-	Identifier    int64  `json:"-"`
-	Name          string `json:"name" validate:"required"`
-	Certification string `json:"certification" validate:"required"`
+	Identifier    int64   `json:"-"`
+	Name          string  `json:"name" validate:"required"`
+	Certification string  `json:"certification" validate:"required"`
+	DistanceValue float64 `json:"distance_value" validate:"gte=0"`
+	DistanceUnit  string  `json:"distance_unit" validate:"required_with=DistanceValue,omitempty,oneof=miles meters kilometers yards feet"`
 }
 
 func (d *Distance) Validate(validate *validator.Validate) error {
@@ -34,6 +37,8 @@ func (d *Distance) Validate(validate *validator.Validate) error {
 
 func (d Distance) Equals(other Distance) bool {
 	return d.Name == other.Name &&
-		d.Certification == other.Certification
+		d.Certification == other.Certification &&
+		d.DistanceValue == other.DistanceValue &&
+		d.DistanceUnit == other.DistanceUnit
 }
 
@@ -25,8 +25,18 @@ import (
 	"github.com/go-playground/validator/v10"
 )
 
+// Event year lifecycle states.  Official and archived years are locked so their results can't
+// be changed until an admin moves them back to another state.
+const (
+	EventYearStatusDraft      = "draft"
+	EventYearStatusLive       = "live"
+	EventYearStatusUnofficial = "unofficial"
+	EventYearStatusOfficial   = "official"
+	EventYearStatusArchived   = "archived"
+)
+
 // EventYear is a structure holding information about a specific year related to an
-// Event
+// Event.  Live is set when the status is live.
 type EventYear struct {
 	Identifier      int64     `json:"-"`
 	EventIdentifier int64     `json:"-"`
@@ -35,6 +45,7 @@ type EventYear struct {
 	Live            bool      `json:"live"`
 	DaysAllowed     int       `json:"days_allowed"`
 	RankingType     string    `json:"ranking_type"`
+	Status          string    `json:"status" validate:"omitempty,oneof=draft live unofficial official archived"`
 }
 
 type EventYearVers1 struct {
@@ -42,12 +53,15 @@ type EventYearVers1 struct {
 	DateTime time.Time `json:"date_time"`
 }
 
+// RequestYear is an event year as sent by clients.  Clients that don't know about Status only
+// send Live, and the status is worked out from it.
 type RequestYear struct {
 	Year        string `json:"year" validate:"required"`
 	DateTime    string `json:"date_time"`
 	Live        bool   `json:"live"`
 	DaysAllowed int    `json:"days_allowed"`
 	RankingType string `json:"ranking_type"`
+	Status      string `json:"status" validate:"omitempty,oneof=draft live unofficial official archived"`
 }
 
 type AllEventYear struct {
@@ -58,6 +72,7 @@ type AllEventYear struct {
 	Live        bool      `json:"live"`
 	DaysAllowed int       `json:"days_allowed"`
 	RankingType string    `json:"ranking_type"`
+	Status      string    `json:"status"`
 }
 
 func (e *EventYear) ConvertToVers1() EventYearVers1 {
@@ -72,7 +87,8 @@ func (e *EventYear) Equals(other *EventYear) bool {
 		e.DateTime.Equal(other.DateTime) &&
 		e.Live == other.Live &&
 		e.DaysAllowed == other.DaysAllowed &&
-		e.RankingType == other.RankingType
+		e.RankingType == other.RankingType &&
+		e.GetStatus() == other.GetStatus()
 }
 
 func (e *EventYear) EqualsAll(other *AllEventYear) bool {
@@ -80,7 +96,32 @@ func (e *EventYear) EqualsAll(other *AllEventYear) bool {
 		e.DateTime.Equal(other.DateTime) &&
 		e.Live == other.Live &&
 		e.DaysAllowed == other.DaysAllowed &&
-		e.RankingType == other.RankingType
+		e.RankingType == other.RankingType &&
+		e.GetStatus() == other.Status
+}
+
+// GetStatus Returns the lifecycle state of the event year.  Years without one are live or a
+// draft depending on the Live flag.
+func (e *EventYear) GetStatus() string {
+	if e.Status != "" {
+		return e.Status
+	}
+	if e.Live {
+		return EventYearStatusLive
+	}
+	return EventYearStatusDraft
+}
+
+// Locked Returns true if the event year is official or archived.
+func (e *EventYear) Locked() bool {
+	status := e.GetStatus()
+	return status == EventYearStatusOfficial || status == EventYearStatusArchived
+}
+
+// ResultsChanged Returns true if the other event year has a different start time or ranking type,
+// either of which changes the results.
+func (e *EventYear) ResultsChanged(other *EventYear) bool {
+	return !e.DateTime.Equal(other.DateTime) || e.RankingType != other.RankingType
 }
 
 // Validate Ensures valid data in the structure.
@@ -99,13 +140,34 @@ func (e *RequestYear) Validate(validate *validator.Validate) error {
 	return validate.Struct(e)
 }
 
+// GetStatus Returns the lifecycle state requested for an event year given its current state.
+// Without a status a year being made live is live, a live year that's no longer live is
+// unofficial, and any other year keeps its state.
+func (e RequestYear) GetStatus(current string) string {
+	if e.Status != "" {
+		return e.Status
+	}
+	if e.Live {
+		return EventYearStatusLive
+	}
+	if current == EventYearStatusLive {
+		return EventYearStatusUnofficial
+	}
+	if current == "" {
+		return EventYearStatusDraft
+	}
+	return current
+}
+
 // ToYear Turns a RequestYear into a year object.
 func (e RequestYear) ToYear() EventYear {
+	status := e.GetStatus("")
 	out := EventYear{
 		Year:        e.Year,
-		Live:        e.Live,
+		Live:        status == EventYearStatusLive,
 		DaysAllowed: e.DaysAllowed,
 		RankingType: e.RankingType,
+		Status:      status,
 	}
 	d, err := time.Parse(time.RFC3339, e.DateTime)
 	if err == nil {
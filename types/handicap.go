@@ -0,0 +1,35 @@
+/*
+Chronokeep Desktop - Race Scoring Software
+Copyright (C) 2026 James Sentinella
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package types
+
+// Handicap is how long after the gun a participant starts in a handicap race.
+type Handicap struct {
+	Bib          string `json:"bib" validate:"required"`
+	Seconds      int    `json:"seconds" validate:"gte=0"`
+	Milliseconds int    `json:"milliseconds" validate:"gte=0,lt=1000"`
+}
+
+// HandicapScoring is the handicap scoring of an event year.  When enabled, finishers are given a
+// handicap place in the order they finished and an actual time, which is their time without their
+// handicap, that they're also ranked by.  Participants without a handicap start with the gun.
+type HandicapScoring struct {
+	Enabled   bool       `json:"enabled"`
+	Handicaps []Handicap `json:"handicaps" validate:"dive"`
+}
+
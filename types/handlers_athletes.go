@@ -0,0 +1,59 @@
+/*
+Chronokeep Desktop - Race Scoring Software
+Copyright (C) 2026 James Sentinella
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package types
+
+/*
+	Responses
+*/
+
+// GetAthleteResponse Struct used for the public profile of an athlete.  Events are ordered from
+// most recent to oldest.
+type GetAthleteResponse struct {
+	Athlete Athlete        `json:"athlete"`
+	Events  []AthleteEvent `json:"events"`
+}
+
+type ModifyAthleteResponse struct {
+	Athlete Athlete `json:"athlete"`
+}
+
+/*
+	Requests
+*/
+
+type GetAthleteRequest struct {
+	Slug string `json:"slug"`
+}
+
+type AddAthleteRequest struct {
+	Athlete Athlete `json:"athlete"`
+}
+
+type DeleteAthleteRequest struct {
+	Slug string `json:"slug"`
+}
+
+// LinkAthleteRequest Struct used to claim or unclaim the bib of an event year for an athlete.
+type LinkAthleteRequest struct {
+	Athlete string `json:"athlete"`
+	Slug    string `json:"slug"`
+	Year    string `json:"year"`
+	Bib     string `json:"bib"`
+}
+
@@ -0,0 +1,64 @@
+/*
+Chronokeep Desktop - Race Scoring Software
+Copyright (C) 2026 James Sentinella
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package types
+
+/*
+	Responses
+*/
+
+// GetAwardsResponse Struct used for the response of a GetAwards request.  Awards are grouped
+// by distance.
+type GetAwardsResponse struct {
+	Event     Event              `json:"event"`
+	EventYear EventYear          `json:"event_year"`
+	Rules     AwardRules         `json:"rules"`
+	Awards    map[string][]Award `json:"awards"`
+}
+
+type SetAwardRulesResponse struct {
+	Rules AwardRules `json:"rules"`
+}
+
+/*
+	Requests
+*/
+
+type GetAwardsRequest struct {
+	Slug     string  `json:"slug"`
+	Year     *string `json:"year"`
+	Distance *string `json:"distance"`
+}
+
+// SetAwardRulesRequest Struct used to set the award rules of an event year.  Event years without
+// rules give awards to the top three overall and the top three in each age group.
+type SetAwardRulesRequest struct {
+	Slug  string     `json:"slug"`
+	Year  string     `json:"year"`
+	Rules AwardRules `json:"rules"`
+}
+
+// ExportAwardsRequest Struct used for the request to export the awards of an event year as a file.
+// Format is either csv or xlsx and defaults to csv.
+type ExportAwardsRequest struct {
+	Slug     string  `json:"slug"`
+	Year     *string `json:"year"`
+	Distance *string `json:"distance"`
+	Format   string  `json:"format"`
+}
+
@@ -0,0 +1,52 @@
+/*
+Chronokeep Desktop - Race Scoring Software
+Copyright (C) 2026 James Sentinella
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package types
+
+/*
+	Responses
+*/
+
+// GetHandicapScoringResponse Struct used for the response of a GetHandicapScoring request.
+type GetHandicapScoringResponse struct {
+	Event     Event           `json:"event"`
+	EventYear EventYear       `json:"event_year"`
+	Scoring   HandicapScoring `json:"scoring"`
+}
+
+type SetHandicapScoringResponse struct {
+	Scoring HandicapScoring `json:"scoring"`
+}
+
+/*
+	Requests
+*/
+
+type GetHandicapScoringRequest struct {
+	Slug string  `json:"slug"`
+	Year *string `json:"year"`
+}
+
+// SetHandicapScoringRequest Struct used to set the handicap scoring of an event year.  The handicaps
+// given replace all of the handicaps the event year had.
+type SetHandicapScoringRequest struct {
+	Slug    string          `json:"slug"`
+	Year    string          `json:"year"`
+	Scoring HandicapScoring `json:"scoring"`
+}
+
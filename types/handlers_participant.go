@@ -43,8 +43,27 @@ type UpdateParticipantsResponse struct {
 
 // AddResultsResponse Struct used for the response to an Add/Update/Delete Participants request.
 type AddParticipantsResponse struct {
-	Count   int           `json:"count"`
-	Updated []Participant `json:"updated_participants"`
+	Count    int           `json:"count"`
+	Updated  []Participant `json:"updated_participants"`
+	Outcomes []ItemOutcome `json:"outcomes,omitempty"`
+}
+
+// ImportParticipantsResponse Struct used for the response to an Import Participants request.
+type ImportParticipantsResponse struct {
+	Accepted int                    `json:"accepted"`
+	Updated  int                    `json:"updated"`
+	Rejected int                    `json:"rejected"`
+	Rows     []ImportParticipantRow `json:"rows"`
+}
+
+// ImportParticipantRow Describes what happened to a single row of an imported file.  Rows are
+// numbered from the header, so the first participant is row 2.
+type ImportParticipantRow struct {
+	Row         int    `json:"row"`
+	Status      string `json:"status"`
+	AlternateId string `json:"id"`
+	Bib         string `json:"bib"`
+	Reason      string `json:"reason,omitempty"`
 }
 
 /*
@@ -75,11 +94,13 @@ type GetParticipantsRequest struct {
 }
 
 // AddParticipantsRequest Struct used for the request to add/update participants for an event.
+// AllOrNothing is only used when adding participants with a key.
 type AddParticipantsRequest struct {
 	Slug         string        `json:"slug"`
 	Year         string        `json:"year"`
 	Participants []Participant `json:"participants"`
 	UpdatedAfter *int64        `json:"updated_after"`
+	AllOrNothing bool          `json:"all_or_nothing"`
 }
 
 // AddParticipantRequest Struct used for the request to add/update participants for an event.
@@ -97,3 +118,15 @@ type DeleteParticipantsRequest struct {
 	Identifiers []string `json:"identifiers"`
 }
 
+// ImportParticipantsRequest Struct used for the request to import participants from a csv file.
+// Mapping is keyed by participant field (bib, first, age_group, etc.) with the csv header to
+// read it from as the value.  Fields without a mapping are read from a header with the same name.
+// The file can also be uploaded as a multipart form with slug, year, mapping (as a json object)
+// and file fields.
+type ImportParticipantsRequest struct {
+	Slug    string            `json:"slug"`
+	Year    string            `json:"year"`
+	Data    string            `json:"data"`
+	Mapping map[string]string `json:"mapping"`
+}
+
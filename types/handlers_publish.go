@@ -0,0 +1,45 @@
+/*
+Chronokeep Desktop - Race Scoring Software
+Copyright (C) 2026 James Sentinella
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package types
+
+/*
+	Responses
+*/
+
+type PublishSettingsResponse struct {
+	Settings PublishSettings `json:"settings"`
+}
+
+/*
+	Requests
+*/
+
+type GetPublishSettingsRequest struct {
+	Slug string  `json:"slug"`
+	Year *string `json:"year"`
+}
+
+// SetPublishSettingsRequest Struct used to set the publish settings of an event year.  Results
+// are published as soon as they're uploaded for event years without settings.
+type SetPublishSettingsRequest struct {
+	Slug     string          `json:"slug"`
+	Year     string          `json:"year"`
+	Settings PublishSettings `json:"settings"`
+}
+
@@ -31,6 +31,8 @@ type GetResultsResponse struct {
 	Results      map[string][]Result `json:"results"`
 	Participants []ResultParticipant `json:"participants"`
 	Distances    []Distance          `json:"distances"`
+	Deleted      []Result            `json:"deleted,omitempty"`
+	UpdatedAt    int64               `json:"updated_at,omitempty"`
 }
 
 // GetResultsResponse Struct used for the response of a GetResults request.
@@ -48,7 +50,17 @@ type GetResultsResponseVers1 struct {
 
 // AddResultsResponse Struct used for the response to an Add/Update/Delete Results request.
 type AddResultsResponse struct {
-	Count int `json:"count"`
+	Count    int           `json:"count"`
+	Outcomes []ItemOutcome `json:"outcomes,omitempty"`
+}
+
+// ItemOutcome Describes what happened to a single item of an add request.  Index is the position of
+// the item in the request.  Field and Reason are only set when the item was rejected.
+type ItemOutcome struct {
+	Index  int    `json:"index"`
+	Status string `json:"status"`
+	Field  string `json:"field,omitempty"`
+	Reason string `json:"reason,omitempty"`
 }
 
 // GetBibResultsResponse Struct used for the response of a GetBibResults request.
@@ -60,6 +72,71 @@ type GetBibResultsResponse struct {
 	SingleDistance bool      `json:"single_distance"`
 	Segments       []Segment `json:"segments"`
 	Distance       *Distance `json:"distance"`
+	Splits         []Split   `json:"splits"`
+}
+
+// SearchResultsResponse Struct used for the response of a SearchResults request.  Count is the number
+// of results that matched the search, not just the ones on the page returned.
+type SearchResultsResponse struct {
+	Event     Event     `json:"event"`
+	EventYear EventYear `json:"event_year"`
+	Count     int       `json:"count"`
+	Results   []Result  `json:"results"`
+}
+
+// GetLocationResultsResponse Struct used for the response of a GetLocationResults request.  Expected is
+// the number of participants that haven't been seen at the location and are still on the course.
+type GetLocationResultsResponse struct {
+	Event     Event            `json:"event"`
+	EventYear EventYear        `json:"event_year"`
+	Location  string           `json:"location"`
+	Segment   string           `json:"segment"`
+	Occurence int              `json:"occurence"`
+	Results   []LocationResult `json:"results"`
+	Expected  int              `json:"expected"`
+}
+
+// GetPredictionsResponse Struct used for the response of a GetPredictions request.  Segment is empty
+// when the predictions are for the finish.  Predictions are the upcoming arrivals and Overdue the
+// people who should have already arrived.
+type GetPredictionsResponse struct {
+	Event       Event        `json:"event"`
+	EventYear   EventYear    `json:"event_year"`
+	Segment     string       `json:"segment"`
+	Predictions []Prediction `json:"predictions"`
+	Overdue     []Prediction `json:"overdue"`
+}
+
+// GetBackyardStandingsResponse Struct used for the response of a GetBackyardStandings request.
+// Standings are grouped by distance.
+type GetBackyardStandingsResponse struct {
+	Event     Event                         `json:"event"`
+	EventYear EventYear                     `json:"event_year"`
+	Standings map[string][]BackyardStanding `json:"standings"`
+}
+
+// GetLapStandingsResponse Struct used for the response of a GetLapStandings request.  Standings
+// are grouped by distance.
+type GetLapStandingsResponse struct {
+	Event     Event                    `json:"event"`
+	EventYear EventYear                `json:"event_year"`
+	Standings map[string][]LapStanding `json:"standings"`
+}
+
+// GetResultHistoryResponse Struct used for the response of a GetResultHistory request.  History is
+// in the order the changes were made.
+type GetResultHistoryResponse struct {
+	Event     Event        `json:"event"`
+	EventYear EventYear    `json:"event_year"`
+	Bib       string       `json:"bib"`
+	History   []AuditEntry `json:"history"`
+}
+
+// ResultsStreamEvent Struct used for each event sent on a live results stream.  When
+// Reset is true the results replace everything the client has for the event year.
+type ResultsStreamEvent struct {
+	Reset   bool     `json:"reset"`
+	Results []Result `json:"results"`
 }
 
 /*
@@ -67,13 +144,15 @@ type GetBibResultsResponse struct {
 */
 
 // GetResultsRequest Struct used for the request of Results for an EventYear. Also used for Delete.
+// UpdatedAfter is only used when getting all results and limits them to those changed since then.
 type GetResultsRequest struct {
-	Slug     string  `json:"slug"`
-	Year     *string `json:"year"`
-	Distance *string `json:"distance"`
-	Limit    *int    `json:"limit"`
-	Page     *int    `json:"page"`
-	Version  *int    `json:"version"`
+	Slug         string  `json:"slug"`
+	Year         *string `json:"year"`
+	Distance     *string `json:"distance"`
+	Limit        *int    `json:"limit"`
+	Page         *int    `json:"page"`
+	Version      *int    `json:"version"`
+	UpdatedAfter *int64  `json:"updated_after"`
 }
 
 // GetMultiResultsRequest Struct used for the request of results for many years for an Event.
@@ -83,10 +162,12 @@ type GetMultiResultsRequest struct {
 }
 
 // AddResultsRequest Struct used to add/update Results.
+// If AllOrNothing is set nothing is saved when any result is rejected.
 type AddResultsRequest struct {
-	Slug    string   `json:"slug"`
-	Year    string   `json:"year"`
-	Results []Result `json:"results"`
+	Slug         string   `json:"slug"`
+	Year         string   `json:"year"`
+	Results      []Result `json:"results"`
+	AllOrNothing bool     `json:"all_or_nothing"`
 }
 
 // GetBibResultsRequest Struct used for the request of results for a person with a bib for an event year.
@@ -96,3 +177,69 @@ type GetBibResultsRequest struct {
 	Year string `json:"year"`
 }
 
+// SearchResultsRequest Struct used for the request to search the results of an event year by name,
+// bib, distance, age group, gender or division.
+type SearchResultsRequest struct {
+	Slug   string       `json:"slug"`
+	Year   *string      `json:"year"`
+	Search ResultSearch `json:"search"`
+	Limit  *int         `json:"limit"`
+	Page   *int         `json:"page"`
+}
+
+// GetLocationResultsRequest Struct used for the request of the results at a single timing location of an
+// event year.  The location is picked by Segment if given and by Location and Occurence otherwise, with
+// Occurence defaulting to the first time the location is passed.
+type GetLocationResultsRequest struct {
+	Slug      string  `json:"slug"`
+	Year      *string `json:"year"`
+	Distance  *string `json:"distance"`
+	Location  string  `json:"location"`
+	Segment   string  `json:"segment"`
+	Occurence *int    `json:"occurence"`
+}
+
+// GetPredictionsRequest Struct used for the request of the next expected arrivals of an event year.
+// Segment is the name of the segment to predict arrivals at and the finish is used when it isn't given.
+// Limit is the number of upcoming arrivals returned.
+type GetPredictionsRequest struct {
+	Slug    string  `json:"slug"`
+	Year    *string `json:"year"`
+	Segment *string `json:"segment"`
+	Limit   *int    `json:"limit"`
+}
+
+// GetResultsStreamRequest Struct used for the request of a live results stream for an event year.
+type GetResultsStreamRequest struct {
+	Slug   string `query:"slug"`
+	Year   string `query:"year"`
+	Resume string `query:"resume"`
+}
+
+// ExportResultsRequest Struct used for the request to export the results of an event year as a file.
+// Format is either csv or xlsx and defaults to csv.
+type ExportResultsRequest struct {
+	Slug     string  `json:"slug"`
+	Year     *string `json:"year"`
+	Distance *string `json:"distance"`
+	Format   string  `json:"format"`
+}
+
+
+// GetResultHistoryRequest Struct used for the request of the change history of a bib for an event year.
+type GetResultHistoryRequest struct {
+	Slug string  `json:"slug"`
+	Year *string `json:"year"`
+	Bib  string  `json:"bib"`
+}
+
+// RevertResultsRequest Struct used to revert results to how they were after a change was made.
+// When Single is set only the person or result the change was for is reverted, otherwise the whole
+// event year is.  An AuditID of 0 reverts the event year to before any changes were recorded.
+type RevertResultsRequest struct {
+	Slug    string  `json:"slug"`
+	Year    *string `json:"year"`
+	AuditID int64   `json:"audit_id"`
+	Single  bool    `json:"single"`
+}
+
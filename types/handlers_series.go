@@ -0,0 +1,63 @@
+/*
+Chronokeep Desktop - Race Scoring Software
+Copyright (C) 2026 James Sentinella
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package types
+
+/*
+	Responses
+*/
+
+type GetSeriesResponse struct {
+	Series Series `json:"series"`
+}
+
+type ModifySeriesResponse struct {
+	Series Series `json:"series"`
+}
+
+// GetSeriesStandingsResponse Struct used for the response of a GetSeriesStandings request.  Standings
+// are grouped by overall, gender, or gender and age group depending on the category requested.
+type GetSeriesStandingsResponse struct {
+	Series    Series                      `json:"series"`
+	Category  string                      `json:"category"`
+	Standings map[string][]SeriesStanding `json:"standings"`
+}
+
+/*
+	Requests
+*/
+
+type GetSeriesRequest struct {
+	Slug string `json:"slug"`
+}
+
+// GetSeriesStandingsRequest Struct used to request the standings of a series.  Overall standings
+// are returned if no category is given.
+type GetSeriesStandingsRequest struct {
+	Slug     string  `json:"slug"`
+	Category *string `json:"category"`
+}
+
+type AddSeriesRequest struct {
+	Series Series `json:"series"`
+}
+
+type DeleteSeriesRequest struct {
+	Slug string `json:"slug"`
+}
+
@@ -0,0 +1,47 @@
+/*
+Chronokeep Desktop - Race Scoring Software
+Copyright (C) 2026 James Sentinella
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package types
+
+/*
+	Responses
+*/
+
+// GetStatisticsResponse Struct used for the response of a GetStatistics request.  Years holds
+// the participation numbers for every year of the event so trends can be shown.
+type GetStatisticsResponse struct {
+	Event     Event                `json:"event"`
+	EventYear EventYear            `json:"event_year"`
+	Distances []DistanceStatistics `json:"distances"`
+	Years     []YearStatistics     `json:"years"`
+}
+
+/*
+	Requests
+*/
+
+// GetStatisticsRequest Struct used for the request of the statistics of an event year.  All of
+// the distances are included if one isn't given.  BucketSeconds is the size of each finish
+// time histogram bucket and defaults to five minutes.
+type GetStatisticsRequest struct {
+	Slug          string  `json:"slug"`
+	Year          *string `json:"year"`
+	Distance      *string `json:"distance"`
+	BucketSeconds *int    `json:"bucket_seconds"`
+}
+
@@ -0,0 +1,86 @@
+/*
+Chronokeep Desktop - Race Scoring Software
+Copyright (C) 2026 James Sentinella
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+
+package types
+
+/*
+	Responses
+*/
+
+type GetTeamsResponse struct {
+	Teams   []Team        `json:"teams"`
+	Scoring []TeamScoring `json:"scoring"`
+}
+
+type AddTeamsResponse struct {
+	Teams []Team `json:"teams"`
+}
+
+type DeleteTeamsResponse struct {
+	Count int64 `json:"count"`
+}
+
+type SetTeamScoringResponse struct {
+	Scoring []TeamScoring `json:"scoring"`
+}
+
+// GetTeamResultsResponse Struct used for the response of a GetTeamResults request.  Results
+// are grouped by distance and ordered by ranking.
+type GetTeamResultsResponse struct {
+	Event     Event                   `json:"event"`
+	EventYear EventYear               `json:"event_year"`
+	Results   map[string][]TeamResult `json:"results"`
+}
+
+/*
+	Requests
+*/
+
+type GetTeamsRequest struct {
+	Slug string  `json:"slug"`
+	Year *string `json:"year"`
+}
+
+type AddTeamsRequest struct {
+	Slug  string `json:"slug"`
+	Year  string `json:"year"`
+	Teams []Team `json:"teams"`
+}
+
+// DeleteTeamsRequest Struct used to delete teams.  All of the teams are deleted if no names are given.
+type DeleteTeamsRequest struct {
+	Slug  string   `json:"slug"`
+	Year  string   `json:"year"`
+	Names []string `json:"names"`
+}
+
+// SetTeamScoringRequest Struct used to set the team scoring rules of an event year.  Distances
+// without rules are scored on the places of their top five finishers with two displacers.
+type SetTeamScoringRequest struct {
+	Slug    string        `json:"slug"`
+	Year    string        `json:"year"`
+	Scoring []TeamScoring `json:"scoring"`
+}
+
+type GetTeamResultsRequest struct {
+	Slug     string  `json:"slug"`
+	Year     *string `json:"year"`
+	Distance *string `json:"distance"`
+}
+
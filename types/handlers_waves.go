@@ -0,0 +1,55 @@
+/*
+Chronokeep Desktop - Race Scoring Software
+Copyright (C) 2026 James Sentinella
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package types
+
+/*
+	Responses
+*/
+
+// GetWavesResponse Struct used for the response of a GetWaves request.
+type GetWavesResponse struct {
+	Event     Event     `json:"event"`
+	EventYear EventYear `json:"event_year"`
+	Waves     []Wave    `json:"waves"`
+}
+
+// SetWavesResponse Struct used for the response of a SetWaves request.  Updated is the number of
+// results whose chip time was recalculated because of the waves.
+type SetWavesResponse struct {
+	Waves   []Wave `json:"waves"`
+	Updated int    `json:"updated"`
+}
+
+/*
+	Requests
+*/
+
+type GetWavesRequest struct {
+	Slug string  `json:"slug"`
+	Year *string `json:"year"`
+}
+
+// SetWavesRequest Struct used to set the waves of an event year.  The waves given replace all of
+// the waves the event year had.
+type SetWavesRequest struct {
+	Slug  string `json:"slug"`
+	Year  string `json:"year"`
+	Waves []Wave `json:"waves"`
+}
+
@@ -0,0 +1,61 @@
+/*
+Chronokeep Desktop - Race Scoring Software
+Copyright (C) 2026 James Sentinella
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package types
+
+// LapStanding is the standing of a runner in a timed event.  Seconds and Milliseconds are the time
+// the last lap was finished at and the lap times are the time taken for a single lap.  Distance
+// covered is given in DistanceUnit, the unit of the distance's lap length.
+type LapStanding struct {
+	Ranking                int     `json:"ranking"`
+	GenderRanking          int     `json:"gender_ranking"`
+	AgeRanking             int     `json:"age_ranking"`
+	DivisionRanking        int     `json:"division_ranking"`
+	Bib                    string  `json:"bib"`
+	First                  string  `json:"first"`
+	Last                   string  `json:"last"`
+	Gender                 string  `json:"gender"`
+	AgeGroup               string  `json:"age_group"`
+	Division               string  `json:"division"`
+	Distance               string  `json:"distance"`
+	Laps                   int     `json:"laps"`
+	DistanceValue          float64 `json:"distance_value"`
+	DistanceUnit           string  `json:"distance_unit"`
+	Seconds                int     `json:"seconds"`
+	Milliseconds           int     `json:"milliseconds"`
+	LastLapSeconds         int     `json:"last_lap_seconds"`
+	LastLapMilliseconds    int     `json:"last_lap_milliseconds"`
+	BestLapSeconds         int     `json:"best_lap_seconds"`
+	BestLapMilliseconds    int     `json:"best_lap_milliseconds"`
+	AverageLapSeconds      int     `json:"average_lap_seconds"`
+	AverageLapMilliseconds int     `json:"average_lap_milliseconds"`
+	LapTimes               []Lap   `json:"lap_times"`
+}
+
+// Lap is a single lap completed by a runner in a timed event.  Seconds and Milliseconds are the time
+// taken for the lap and the elapsed values the time it was finished at.
+type Lap struct {
+	Lap                 int     `json:"lap"`
+	Location            string  `json:"location"`
+	DistanceValue       float64 `json:"distance_value"`
+	Seconds             int     `json:"seconds"`
+	Milliseconds        int     `json:"milliseconds"`
+	ElapsedSeconds      int     `json:"elapsed_seconds"`
+	ElapsedMilliseconds int     `json:"elapsed_milliseconds"`
+}
+
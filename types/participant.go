@@ -19,7 +19,6 @@ along with this program.  If not, see <https://www.gnu.org/licenses/>.
 package types
 
 import (
-	"fmt"
 	"time"
 
 	"github.com/go-playground/validator/v10"
@@ -61,11 +60,11 @@ func (p *Participant) Validate(validate *validator.Validate) error {
 	if err != nil {
 		t, err := time.Parse(iso_layout, p.Birthdate)
 		if err != nil || t.After(time.Now()) {
-			return fmt.Errorf("invalid birthdate")
+			return &FieldError{Field: "birthdate", Message: "invalid birthdate"}
 		}
 	}
 	if t.After(time.Now()) {
-		return fmt.Errorf("invalid birthdate")
+		return &FieldError{Field: "birthdate", Message: "invalid birthdate"}
 	}
 	return validate.Struct(p)
 }
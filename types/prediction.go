@@ -0,0 +1,40 @@
+/*
+Chronokeep Desktop - Race Scoring Software
+Copyright (C) 2026 James Sentinella
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package types
+
+import "time"
+
+// Prediction is when a person is expected to arrive at a segment or the finish based on their pace
+// to the last segment they were seen at.  Seconds is the predicted gun time at arrival and DateTime
+// the predicted time of day.  Overdue is set when they should have already arrived.  The pace is in
+// seconds per PaceUnit.
+type Prediction struct {
+	Bib          string    `json:"bib"`
+	First        string    `json:"first"`
+	Last         string    `json:"last"`
+	Distance     string    `json:"distance"`
+	LastSeen     string    `json:"last_seen"`
+	Seconds      int       `json:"seconds"`
+	Milliseconds int       `json:"milliseconds"`
+	DateTime     time.Time `json:"date_time"`
+	Overdue      bool      `json:"overdue"`
+	PaceSeconds  int       `json:"pace_seconds"`
+	PaceUnit     string    `json:"pace_unit"`
+}
+
@@ -0,0 +1,61 @@
+/*
+Chronokeep Desktop - Race Scoring Software
+Copyright (C) 2026 James Sentinella
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package types
+
+import "time"
+
+// PublishSettings control who can see the results of an event year.  Results are hidden from
+// everyone but the owner of the event until PublishAt, a unix timestamp in seconds, or for as
+// long as OwnerOnly is set.  Results for the distances in HiddenDistances are never shown to
+// anyone but the owner.
+type PublishSettings struct {
+	PublishAt       int64    `json:"publish_at" validate:"gte=0"`
+	OwnerOnly       bool     `json:"owner_only"`
+	HiddenDistances []string `json:"hidden_distances"`
+}
+
+// Published Returns true if results can be shown to keys not belonging to the owner at the given time.
+func (p *PublishSettings) Published(now time.Time) bool {
+	return !p.OwnerOnly && now.Unix() >= p.PublishAt
+}
+
+// DistanceHidden Returns true if the results for the distance are hidden from everyone but the owner.
+func (p *PublishSettings) DistanceHidden(distance string) bool {
+	for _, hidden := range p.HiddenDistances {
+		if hidden == distance {
+			return true
+		}
+	}
+	return false
+}
+
+// Visible Returns the results that can be shown to keys not belonging to the owner at the given time.
+func (p *PublishSettings) Visible(results []Result, now time.Time) []Result {
+	if !p.Published(now) {
+		return make([]Result, 0)
+	}
+	output := make([]Result, 0, len(results))
+	for _, res := range results {
+		if !p.DistanceHidden(res.Distance) {
+			output = append(output, res)
+		}
+	}
+	return output
+}
+
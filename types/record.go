@@ -0,0 +1,61 @@
+/*
+Chronokeep Desktop - Race Scoring Software
+Copyright (C) 2026 James Sentinella
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package types
+
+// Record categories.  Course records are the best finish for a distance, gender records
+// the best for each gender and age group records the best for each gender and age group.
+const (
+	RecordCourse   = "course"
+	RecordGender   = "gender"
+	RecordAgeGroup = "age_group"
+)
+
+// Record is a structure holding the best finish in a record category for a distance in
+// a single event year.  Gender and AgeGroup are empty when the category doesn't use them.
+type Record struct {
+	Year         string `json:"year"`
+	Distance     string `json:"distance"`
+	Category     string `json:"category"`
+	Gender       string `json:"gender"`
+	AgeGroup     string `json:"age_group"`
+	Bib          string `json:"bib"`
+	First        string `json:"first"`
+	Last         string `json:"last"`
+	Seconds      int    `json:"seconds"`
+	Milliseconds int    `json:"milliseconds"`
+}
+
+// SameCategory Returns true if both records are for the same distance and record category.
+func (one *Record) SameCategory(two *Record) bool {
+	return one.Distance == two.Distance &&
+		one.Category == two.Category &&
+		one.Gender == two.Gender &&
+		one.AgeGroup == two.AgeGroup
+}
+
+func (one *Record) Equals(two *Record) bool {
+	return one.SameCategory(two) &&
+		one.Year == two.Year &&
+		one.Bib == two.Bib &&
+		one.First == two.First &&
+		one.Last == two.Last &&
+		one.Seconds == two.Seconds &&
+		one.Milliseconds == two.Milliseconds
+}
+
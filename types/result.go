@@ -19,11 +19,33 @@ along with this program.  If not, see <https://www.gnu.org/licenses/>.
 package types
 
 import (
+	"fmt"
+
 	"github.com/go-playground/validator/v10"
 )
 
+// Result type values sent by Chronokeep Desktop.  Early start variants
+// are the base value multiplied by ten.
+const (
+	ResultTypeDNS      = 2
+	ResultTypeDNF      = 3
+	ResultTypeEarlyDNS = 20
+	ResultTypeEarlyDNF = 30
+)
+
+// Result status values.  Results without a status have one derived from their type.
+const (
+	ResultStatusFinished   = "finished"
+	ResultStatusInProgress = "in_progress"
+	ResultStatusDNF        = "dnf"
+	ResultStatusDNS        = "dns"
+	ResultStatusDQ         = "dq"
+)
+
 // Result is a structure holding information about a specific time
-// result for a specific event.
+// result for a specific event.  Record is only set in responses and is
+// the broadest record category the result currently holds.  The age graded
+// and handicap values are also only set in responses.
 type Result struct {
 	PersonId         string `json:"person_id" validate:"required"`
 	Bib              string `json:"bib" validate:"required"`
@@ -49,6 +71,21 @@ type Result struct {
 	LocalTime        string `json:"local_time"`
 	Division         string `json:"division"`
 	DivisionRanking  int    `json:"division_ranking"`
+	Status           string `json:"status" validate:"omitempty,oneof=finished in_progress dnf dns dq"`
+	StatusReason     string `json:"status_reason"`
+	Record           string `json:"record,omitempty"`
+
+	AgeGradedSeconds      int     `json:"age_graded_seconds,omitempty"`
+	AgeGradedMilliseconds int     `json:"age_graded_milliseconds,omitempty"`
+	AgeGradedPercentage   float64 `json:"age_graded_percentage,omitempty"`
+	AgeGradedRanking      int     `json:"age_graded_ranking,omitempty"`
+
+	HandicapSeconds      int `json:"handicap_seconds,omitempty"`
+	HandicapMilliseconds int `json:"handicap_milliseconds,omitempty"`
+	HandicapRanking      int `json:"handicap_ranking,omitempty"`
+	ActualSeconds        int `json:"actual_seconds,omitempty"`
+	ActualMilliseconds   int `json:"actual_milliseconds,omitempty"`
+	ActualRanking        int `json:"actual_ranking,omitempty"`
 }
 
 type ResultVers1 struct {
@@ -127,7 +164,9 @@ func (one *Result) Equals(two *Result) bool {
 		one.Anonymous == two.Anonymous &&
 		one.PersonId == two.PersonId &&
 		one.Division == two.Division &&
-		one.DivisionRanking == two.DivisionRanking
+		one.DivisionRanking == two.DivisionRanking &&
+		one.Status == two.Status &&
+		one.StatusReason == two.StatusReason
 }
 
 func (one *Result) SamePerson(two *Result) bool {
@@ -142,6 +181,74 @@ func (one *Result) SamePerson(two *Result) bool {
 		one.Division == two.Division
 }
 
+// ResultStatus Returns the status of the result.  If one wasn't given it is derived from
+// the type and finish values sent by older versions of Chronokeep Desktop.
+func (r *Result) ResultStatus() string {
+	if r.Status != "" {
+		return r.Status
+	}
+	switch r.Type {
+	case ResultTypeDNF, ResultTypeEarlyDNF:
+		return ResultStatusDNF
+	case ResultTypeDNS, ResultTypeEarlyDNS:
+		return ResultStatusDNS
+	}
+	if r.Finish {
+		return ResultStatusFinished
+	}
+	return ResultStatusInProgress
+}
+
+// NormalizeStatus Sets the status of the result if it wasn't given.
+func (r *Result) NormalizeStatus() {
+	r.Status = r.ResultStatus()
+}
+
+// IsDNF Returns true if the result is marked as a did not finish.
+func (r *Result) IsDNF() bool {
+	return r.ResultStatus() == ResultStatusDNF
+}
+
+// IsDNS Returns true if the result is marked as a did not start.
+func (r *Result) IsDNS() bool {
+	return r.ResultStatus() == ResultStatusDNS
+}
+
+// IsDQ Returns true if the result has been disqualified.
+func (r *Result) IsDQ() bool {
+	return r.ResultStatus() == ResultStatusDQ
+}
+
+// IsRanked Returns false for results that shouldn't be given a place.
+func (r *Result) IsRanked() bool {
+	return !r.IsDNF() && !r.IsDNS() && !r.IsDQ()
+}
+
+// FormatTime Formats a time in seconds and milliseconds as H:MM:SS.mmm.
+func FormatTime(seconds, milliseconds int) string {
+	return fmt.Sprintf("%d:%02d:%02d.%03d", seconds/3600, (seconds%3600)/60, seconds%60, milliseconds)
+}
+
+// GunTime Returns the formatted gun time of the result.
+func (r *Result) GunTime() string {
+	return FormatTime(r.Seconds, r.Milliseconds)
+}
+
+// ChipTime Returns the formatted chip time of the result.
+func (r *Result) ChipTime() string {
+	return FormatTime(r.ChipSeconds, r.ChipMilliseconds)
+}
+
+// HandicapTime Returns the formatted handicap of the result.
+func (r *Result) HandicapTime() string {
+	return FormatTime(r.HandicapSeconds, r.HandicapMilliseconds)
+}
+
+// ActualTime Returns the formatted actual time of a handicap result.
+func (r *Result) ActualTime() string {
+	return FormatTime(r.ActualSeconds, r.ActualMilliseconds)
+}
+
 func (r *Result) AnonyInt() int {
 	if r.Anonymous {
 		return 1
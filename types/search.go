@@ -0,0 +1,36 @@
+/*
+Chronokeep Desktop - Race Scoring Software
+Copyright (C) 2026 James Sentinella
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package types
+
+// ResultSearch Criteria used to find results within an event year.  Name and Bib match partially
+// while the rest must match fully, and every criteria given has to match.
+type ResultSearch struct {
+	Name     string `json:"name"`
+	Bib      string `json:"bib"`
+	Distance string `json:"distance"`
+	AgeGroup string `json:"age_group"`
+	Gender   string `json:"gender"`
+	Division string `json:"division"`
+}
+
+// Empty Returns true if no criteria have been given.
+func (s ResultSearch) Empty() bool {
+	return s.Name == "" && s.Bib == "" && s.Distance == "" && s.AgeGroup == "" && s.Gender == "" && s.Division == ""
+}
+
@@ -0,0 +1,179 @@
+/*
+Chronokeep Desktop - Race Scoring Software
+Copyright (C) 2026 James Sentinella
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package types
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// Series standings categories.  Overall standings use the overall ranking of each race, gender
+// standings use the gender ranking and age group standings use the age group ranking.
+const (
+	SeriesCategoryOverall  = "overall"
+	SeriesCategoryGender   = "gender"
+	SeriesCategoryAgeGroup = "age_group"
+)
+
+// Rules used to decide which results across the events of a series belong to the same person.
+const (
+	SeriesIdentityPersonId   = "person_id"
+	SeriesIdentityName       = "name"
+	SeriesIdentityNameGender = "name_gender"
+)
+
+// Tie breakers for series standings.  Best finish favors the person with the best single place,
+// most races favors the person that raced more often and most recent favors the person that
+// scored more points in the latest race either of them scored in.
+const (
+	SeriesTieBestFinish = "best_finish"
+	SeriesTieMostRaces  = "most_races"
+	SeriesTieMostRecent = "most_recent"
+)
+
+// Series is a group of event years, possibly from different events, that are scored together.
+// Points holds the points given for each place, starting with first.  Only the best BestOf
+// races of each person count towards their total, or all of them if it is zero.  People with
+// fewer than MinimumRaces races are listed but left unranked.
+type Series struct {
+	Identifier        int64         `json:"-"`
+	AccountIdentifier int64         `json:"-"`
+	Slug              string        `json:"slug" validate:"required"`
+	Name              string        `json:"name" validate:"required"`
+	Points            []int         `json:"points" validate:"min=1,dive,gte=0"`
+	BestOf            int           `json:"best_of" validate:"gte=0"`
+	MinimumRaces      int           `json:"minimum_races" validate:"gte=0"`
+	TieBreakers       []string      `json:"tie_breakers" validate:"dive,oneof=best_finish most_races most_recent"`
+	Identity          string        `json:"identity" validate:"oneof=person_id name name_gender"`
+	Events            []SeriesEvent `json:"events" validate:"dive"`
+}
+
+// SeriesEvent is an event year that is part of a series along with the distance scored for it.
+type SeriesEvent struct {
+	EventYearIdentifier int64  `json:"-"`
+	Slug                string `json:"slug" validate:"required"`
+	Year                string `json:"year" validate:"required"`
+	Distance            string `json:"distance" validate:"required"`
+}
+
+// SeriesStanding is the standing of a person in a series.  Ranking is -1 for people that haven't
+// raced enough to qualify.
+type SeriesStanding struct {
+	Ranking  int                `json:"ranking"`
+	First    string             `json:"first"`
+	Last     string             `json:"last"`
+	Gender   string             `json:"gender"`
+	AgeGroup string             `json:"age_group"`
+	Points   int                `json:"points"`
+	Races    int                `json:"races"`
+	Results  []SeriesRaceResult `json:"results"`
+}
+
+// SeriesRaceResult is the result of a person in one event of a series.  Counted is false for
+// races that aren't one of the best races of the person.
+type SeriesRaceResult struct {
+	Slug         string `json:"slug"`
+	Year         string `json:"year"`
+	Bib          string `json:"bib"`
+	Seconds      int    `json:"seconds"`
+	Milliseconds int    `json:"milliseconds"`
+	Place        int    `json:"place"`
+	Points       int    `json:"points"`
+	Counted      bool   `json:"counted"`
+}
+
+// Validate Ensures valid information in the structure.
+func (s *Series) Validate(validate *validator.Validate) error {
+	s.Slug = strings.ToLower(s.Slug)
+	if !validSlug(s.Slug) {
+		return errors.New("invalid slug (only letters, numbers, and - character allowed)")
+	}
+	if s.Identity == "" {
+		s.Identity = SeriesIdentityName
+	}
+	for ix := range s.Events {
+		s.Events[ix].Slug = strings.ToLower(s.Events[ix].Slug)
+	}
+	return validate.Struct(s)
+}
+
+// Equals Returns true if all fields other than the Identifier fields are equal.
+func (s *Series) Equals(other *Series) bool {
+	if s.Slug != other.Slug ||
+		s.Name != other.Name ||
+		s.BestOf != other.BestOf ||
+		s.MinimumRaces != other.MinimumRaces ||
+		s.Identity != other.Identity ||
+		len(s.Points) != len(other.Points) ||
+		len(s.TieBreakers) != len(other.TieBreakers) ||
+		len(s.Events) != len(other.Events) {
+		return false
+	}
+	for ix := range s.Points {
+		if s.Points[ix] != other.Points[ix] {
+			return false
+		}
+	}
+	for ix := range s.TieBreakers {
+		if s.TieBreakers[ix] != other.TieBreakers[ix] {
+			return false
+		}
+	}
+	for ix := range s.Events {
+		if s.Events[ix].Slug != other.Events[ix].Slug ||
+			s.Events[ix].Year != other.Events[ix].Year ||
+			s.Events[ix].Distance != other.Events[ix].Distance {
+			return false
+		}
+	}
+	return true
+}
+
+// EncodeRules Returns the points table and tie breakers as comma separated lists for storage.
+func (s *Series) EncodeRules() (string, string) {
+	points := make([]string, len(s.Points))
+	for ix, p := range s.Points {
+		points[ix] = strconv.Itoa(p)
+	}
+	return strings.Join(points, ","), strings.Join(s.TieBreakers, ",")
+}
+
+// DecodeRules Sets the points table and tie breakers from the lists returned by EncodeRules.
+func (s *Series) DecodeRules(points, tieBreakers string) error {
+	s.Points = make([]int, 0)
+	s.TieBreakers = make([]string, 0)
+	if points != "" {
+		for _, p := range strings.Split(points, ",") {
+			val, err := strconv.Atoi(p)
+			if err != nil {
+				return fmt.Errorf("invalid series points value %s: %v", p, err)
+			}
+			s.Points = append(s.Points, val)
+		}
+	}
+	if tieBreakers != "" {
+		s.TieBreakers = strings.Split(tieBreakers, ",")
+	}
+	return nil
+}
+
@@ -0,0 +1,54 @@
+/*
+Chronokeep Desktop - Race Scoring Software
+Copyright (C) 2026 James Sentinella
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package types
+
+// Split is the time of a person at one of the segments of their distance, or at the finish when
+// it isn't a segment.  Times use chip time if the event year ranks by chip time and gun time
+// otherwise.  SplitSeconds is the time taken since the previous split and the paces are in
+// seconds per PaceUnit, which is empty when the distance of the split isn't known.  Ranking is
+// the place at the split and Behind is the time behind whoever is in first at the split.
+type Split struct {
+	Name               string  `json:"name"`
+	Location           string  `json:"location"`
+	Occurence          int     `json:"occurence"`
+	DistanceValue      float64 `json:"distance_value"`
+	DistanceUnit       string  `json:"distance_unit"`
+	Finish             bool    `json:"finish"`
+	Seconds            int     `json:"seconds"`
+	Milliseconds       int     `json:"milliseconds"`
+	SplitSeconds       int     `json:"split_seconds"`
+	SplitMilliseconds  int     `json:"split_milliseconds"`
+	PaceSeconds        int     `json:"pace_seconds"`
+	AveragePaceSeconds int     `json:"average_pace_seconds"`
+	PaceUnit           string  `json:"pace_unit"`
+	Ranking            int     `json:"ranking"`
+	BehindSeconds      int     `json:"behind_seconds"`
+	BehindMilliseconds int     `json:"behind_milliseconds"`
+}
+
+
+// LocationResult is a result at a single timing location.  Arrival is the order the person arrived
+// at the location in and Behind is the time since the first arrival, both using gun time.
+type LocationResult struct {
+	Result
+	Arrival            int `json:"arrival"`
+	BehindSeconds      int `json:"behind_seconds"`
+	BehindMilliseconds int `json:"behind_milliseconds"`
+}
+
@@ -0,0 +1,71 @@
+/*
+Chronokeep Desktop - Race Scoring Software
+Copyright (C) 2026 James Sentinella
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package types
+
+// StatTime is a time used in statistics split into seconds and milliseconds the same way
+// result times are.
+type StatTime struct {
+	Seconds      int `json:"seconds"`
+	Milliseconds int `json:"milliseconds"`
+}
+
+// NewStatTime Returns the StatTime for a time given in milliseconds.
+func NewStatTime(milliseconds int64) StatTime {
+	return StatTime{
+		Seconds:      int(milliseconds / 1000),
+		Milliseconds: int(milliseconds % 1000),
+	}
+}
+
+// HistogramBucket holds the number of finishers with a finish time from Start up to, but not
+// including, the start of the next bucket.  Start is in seconds.
+type HistogramBucket struct {
+	Start int `json:"start"`
+	Count int `json:"count"`
+}
+
+// DistanceStatistics are the numbers for a single distance of an event year.  Starters are
+// everyone with a finish line result that isn't a DNS.  Genders holds the number of finishers
+// of each gender.  Times are chip times when the event year is ranked on chip time and gun
+// times otherwise and only include finishers.
+type DistanceStatistics struct {
+	Distance  string            `json:"distance"`
+	Starters  int               `json:"starters"`
+	Finishers int               `json:"finishers"`
+	DNF       int               `json:"dnf"`
+	DNS       int               `json:"dns"`
+	DQ        int               `json:"dq"`
+	DNFRate   float64           `json:"dnf_rate"`
+	Genders   map[string]int    `json:"genders"`
+	Fastest   StatTime          `json:"fastest"`
+	Slowest   StatTime          `json:"slowest"`
+	Mean      StatTime          `json:"mean"`
+	Median    StatTime          `json:"median"`
+	Histogram []HistogramBucket `json:"histogram"`
+}
+
+// YearStatistics are the participation numbers for a single event year.  Registered is the
+// number of participants signed up for the year.
+type YearStatistics struct {
+	Year       string `json:"year"`
+	Registered int    `json:"registered"`
+	Starters   int    `json:"starters"`
+	Finishers  int    `json:"finishers"`
+}
+
@@ -0,0 +1,100 @@
+/*
+Chronokeep Desktop - Race Scoring Software
+Copyright (C) 2026 James Sentinella
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package types
+
+import "github.com/go-playground/validator/v10"
+
+// Team scoring methods.  Teams are either scored on the sum of the places their scorers
+// finished in or the sum of their times.
+const (
+	TeamScoringPlaces = "places"
+	TeamScoringTimes  = "times"
+)
+
+// Team is a group of participants in a distance of an event year that are scored together.
+type Team struct {
+	Identifier int64        `json:"-"`
+	Name       string       `json:"name" validate:"required"`
+	Distance   string       `json:"distance" validate:"required"`
+	Members    []TeamMember `json:"members" validate:"dive"`
+}
+
+// TeamMember is matched with results by bib or by the participant id given to results as
+// the person id.
+type TeamMember struct {
+	Bib      string `json:"bib" validate:"required_without=PersonId"`
+	PersonId string `json:"person_id" validate:"required_without=Bib"`
+}
+
+// TeamScoring holds the rules for scoring the teams of a distance.  Scorers is the number of
+// finishers whose places or times count towards the score of a team.  Displacers is the number
+// of finishers after them that don't count towards the score but still take places from the
+// finishers of other teams.
+type TeamScoring struct {
+	Distance   string `json:"distance" validate:"required"`
+	Scorers    int    `json:"scorers" validate:"gte=1"`
+	Displacers int    `json:"displacers" validate:"gte=0"`
+	Method     string `json:"method" validate:"oneof=places times"`
+}
+
+// TeamResult is the score of a team.  Score is the sum of the places of the scorers, or their
+// total time in milliseconds when scoring on times.  Seconds and Milliseconds are the total
+// time of the scorers.  Teams without enough finishers to score are left unranked.
+type TeamResult struct {
+	Name         string             `json:"name"`
+	Distance     string             `json:"distance"`
+	Ranking      int                `json:"ranking"`
+	Score        int                `json:"score"`
+	Seconds      int                `json:"seconds"`
+	Milliseconds int                `json:"milliseconds"`
+	Members      []TeamResultMember `json:"members"`
+}
+
+// TeamResultMember is a finisher on a team.  Place is their place amongst the finishers
+// that count for team scoring and is zero for those that don't.
+type TeamResultMember struct {
+	Bib          string `json:"bib"`
+	First        string `json:"first"`
+	Last         string `json:"last"`
+	Seconds      int    `json:"seconds"`
+	Milliseconds int    `json:"milliseconds"`
+	Place        int    `json:"place"`
+	Scorer       bool   `json:"scorer"`
+}
+
+func (t *Team) Validate(validate *validator.Validate) error {
+	return validate.Struct(t)
+}
+
+func (t Team) Equals(other Team) bool {
+	if t.Name != other.Name || t.Distance != other.Distance || len(t.Members) != len(other.Members) {
+		return false
+	}
+	for ix := range t.Members {
+		if t.Members[ix] != other.Members[ix] {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *TeamScoring) Validate(validate *validator.Validate) error {
+	return validate.Struct(s)
+}
+
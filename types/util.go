@@ -26,3 +26,13 @@ var (
 	validEventName = regexp.MustCompile(`^^[A-Za-z'0-9\s/&]+$`).MatchString
 )
 
+// FieldError Describes a validation problem with a single field.
+type FieldError struct {
+	Field   string
+	Message string
+}
+
+func (e *FieldError) Error() string {
+	return e.Message
+}
+
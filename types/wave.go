@@ -0,0 +1,113 @@
+/*
+Chronokeep Desktop - Race Scoring Software
+Copyright (C) 2026 James Sentinella
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <https://www.gnu.org/licenses/>.
+ */
+
+package types
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Wave is a group of participants in a distance that start at the same time.  A bib is in the
+// wave when it's one of the Bibs given or is a number in one of the BibRanges.
+type Wave struct {
+	Distance  string     `json:"distance" validate:"required"`
+	Name      string     `json:"name" validate:"required"`
+	StartTime time.Time  `json:"start_time" validate:"required"`
+	BibRanges []BibRange `json:"bib_ranges" validate:"dive"`
+	Bibs      []string   `json:"bibs" validate:"dive,required,excludesall=0x2C"`
+}
+
+// BibRange is an inclusive range of numeric bibs.
+type BibRange struct {
+	First int `json:"first" validate:"gte=0"`
+	Last  int `json:"last" validate:"gtefield=First"`
+}
+
+// Listed Returns true if the bib is one of the bibs explicitly given for the wave.
+func (w *Wave) Listed(bib string) bool {
+	for _, b := range w.Bibs {
+		if b == bib {
+			return true
+		}
+	}
+	return false
+}
+
+// InRange Returns true if the bib is a number within one of the bib ranges of the wave.
+func (w *Wave) InRange(bib string) bool {
+	val, err := strconv.Atoi(strings.TrimSpace(bib))
+	if err != nil {
+		return false
+	}
+	for _, r := range w.BibRanges {
+		if val >= r.First && val <= r.Last {
+			return true
+		}
+	}
+	return false
+}
+
+// EncodeBibRanges Returns the bib ranges as a comma separated list of first-last pairs for storage.
+func (w *Wave) EncodeBibRanges() string {
+	ranges := make([]string, len(w.BibRanges))
+	for ix, r := range w.BibRanges {
+		ranges[ix] = fmt.Sprintf("%d-%d", r.First, r.Last)
+	}
+	return strings.Join(ranges, ",")
+}
+
+// DecodeBibRanges Sets the bib ranges from the list returned by EncodeBibRanges.
+func (w *Wave) DecodeBibRanges(ranges string) error {
+	w.BibRanges = make([]BibRange, 0)
+	if ranges == "" {
+		return nil
+	}
+	for _, r := range strings.Split(ranges, ",") {
+		first, last, found := strings.Cut(r, "-")
+		if !found {
+			return fmt.Errorf("invalid bib range %s", r)
+		}
+		var br BibRange
+		var err error
+		if br.First, err = strconv.Atoi(first); err != nil {
+			return fmt.Errorf("invalid bib range %s: %v", r, err)
+		}
+		if br.Last, err = strconv.Atoi(last); err != nil {
+			return fmt.Errorf("invalid bib range %s: %v", r, err)
+		}
+		w.BibRanges = append(w.BibRanges, br)
+	}
+	return nil
+}
+
+// EncodeBibs Returns the bibs as a comma separated list for storage.
+func (w *Wave) EncodeBibs() string {
+	return strings.Join(w.Bibs, ",")
+}
+
+// DecodeBibs Sets the bibs from the list returned by EncodeBibs.
+func (w *Wave) DecodeBibs(bibs string) {
+	w.Bibs = make([]string, 0)
+	if bibs != "" {
+		w.Bibs = strings.Split(bibs, ",")
+	}
+}
+
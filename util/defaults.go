@@ -26,3 +26,32 @@ const (
 	DISTANCE_TYPE_FEET      = "feet"
 )
 
+const (
+	EVENT_TYPE_DISTANCE      = "distance"
+	EVENT_TYPE_TIME          = "time"
+	EVENT_TYPE_BACKYARDULTRA = "backyardultra"
+)
+
+const (
+	RANKING_TYPE_GUN  = "gun"
+	RANKING_TYPE_CHIP = "chip"
+)
+
+const (
+	EXPORT_FORMAT_CSV  = "csv"
+	EXPORT_FORMAT_XLSX = "xlsx"
+)
+
+const (
+	IMPORT_STATUS_ACCEPTED = "accepted"
+	IMPORT_STATUS_UPDATED  = "updated"
+	IMPORT_STATUS_REJECTED = "rejected"
+)
+
+const (
+	ITEM_STATUS_INSERTED = "inserted"
+	ITEM_STATUS_UPDATED  = "updated"
+	ITEM_STATUS_REJECTED = "rejected"
+	ITEM_STATUS_SKIPPED  = "skipped"
+)
+